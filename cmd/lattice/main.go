@@ -4,7 +4,7 @@
 // When you run `lattice` from any directory, this is what executes.
 //
 // Flow:
-// 1. Check if we're already inside a tmux session
+// 1. Check that tmux is installed, then whether we're already inside a session
 // 2. If not, start one and re-run ourselves inside it
 // 3. If yes, launch the TUI
 
@@ -28,6 +28,27 @@ func main() {
 	if handleValidateAgentCommand() {
 		return
 	}
+	if handleResetCommand() {
+		return
+	}
+	if handlePreviewPromptCommand() {
+		return
+	}
+	if handleVerifyCommand() {
+		return
+	}
+	if handleThroughputCommand() {
+		return
+	}
+	if handleStatusCommand() {
+		return
+	}
+	if handleSessionCommand() {
+		return
+	}
+	if handleRestampCommand() {
+		return
+	}
 	// Get the current working directory - this is the "project" we're working in
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -41,6 +62,13 @@ func main() {
 		defer sessionLogger.Close()
 	}
 
+	// The TUI runs agents in tmux windows, so fail early with install
+	// guidance instead of a confusing exec error further in
+	if err := tui.CheckTmux(exec.LookPath); err != nil {
+		logErrorf("%v\n", err)
+		os.Exit(1)
+	}
+
 	// Check if we're inside tmux already
 	// tmux sets TMUX env var when you're inside a session
 	if os.Getenv("TMUX") == "" {
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/modules"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+	"github.com/kingrea/The-Lattice/internal/workflow/engine"
+)
+
+func handlePreviewPromptCommand() bool {
+	if len(os.Args) < 2 || os.Args[1] != "preview-prompt" {
+		return false
+	}
+	fs := flag.NewFlagSet("preview-prompt", flag.ExitOnError)
+	sessionName := fs.String("session", "", "prepared session to render (number or name); defaults to all")
+	fromFile := fs.String("from-file", "", "render a synthetic session from a JSON file in the cycle tracker format")
+	cycle := fs.Int("cycle", 1, "agent cycle to render the prompt for")
+	workflowID := fs.String("workflow", "", "workflow whose work-process settings apply; defaults to the project's default workflow")
+	fs.Usage = func() {
+		logErrorf("Usage: lattice preview-prompt [--session <number|name> | --from-file session.json] [--cycle N] [--workflow ID]\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() > 0 || (*sessionName != "" && *fromFile != "") || *cycle < 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		logErrorf("Error getting working directory: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.NewConfig(cwd)
+	if err != nil {
+		logErrorf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	orch := orchestrator.New(cfg)
+	if err := configurePreview(cfg, orch, *workflowID); err != nil {
+		logErrorf("%v\n", err)
+		os.Exit(1)
+	}
+	sessions, err := previewSessions(orch, *sessionName, *fromFile)
+	if err != nil {
+		logErrorf("%v\n", err)
+		os.Exit(1)
+	}
+	for i, session := range sessions {
+		prompt, err := orch.PreviewAgentPrompt(session, *cycle)
+		if err != nil {
+			logErrorf("Render prompt for %s: %v\n", session.Name, err)
+			os.Exit(1)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("===== %s · %s (cycle %d) =====\n", session.Name, session.Agent.Name, *cycle)
+		fmt.Print(prompt)
+	}
+	return true
+}
+
+func previewSessions(orch *orchestrator.Orchestrator, name, fromFile string) ([]orchestrator.WorktreeSession, error) {
+	if fromFile != "" {
+		data, err := os.ReadFile(fromFile)
+		if err != nil {
+			return nil, fmt.Errorf("read session file: %w", err)
+		}
+		session, err := orch.SessionFromJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		return []orchestrator.WorktreeSession{session}, nil
+	}
+	sessions, err := orch.PreparedSessions()
+	if err != nil {
+		return nil, fmt.Errorf("load prepared sessions: %w", err)
+	}
+	if name == "" {
+		return sessions, nil
+	}
+	number, numErr := strconv.Atoi(name)
+	for _, session := range sessions {
+		if (numErr == nil && session.Number == number) || strings.EqualFold(session.Name, name) {
+			return []orchestrator.WorktreeSession{session}, nil
+		}
+	}
+	return nil, fmt.Errorf("no prepared session matches %q", name)
+}
+
+// configurePreview applies the work-process settings of the workflow's
+// persisted run to orch, parsed the same way the module parses them, so
+// options like repo_memory, max_prompt_beads and review_only shape the
+// preview. Without a run the defaults apply.
+func configurePreview(cfg *config.Config, orch *orchestrator.Orchestrator, workflowID string) error {
+	id := strings.TrimSpace(workflowID)
+	if id == "" {
+		id = cfg.DefaultWorkflow()
+	}
+	state, err := engine.NewWorkflowRepository(workflow.New(cfg.LatticeProjectDir), id).Load()
+	if errors.Is(err, engine.ErrStateNotFound) {
+		logErrorf("No run state for workflow %s; previewing with default cycle settings.\n", id)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load workflow state: %w", err)
+	}
+	reg := module.NewRegistry()
+	modules.RegisterBuiltins(reg)
+	for _, ref := range state.Definition.Modules {
+		if ref.ModuleID != "work-process" {
+			continue
+		}
+		mod, err := reg.Resolve(ref.ModuleID, module.Config(ref.Config))
+		if err != nil {
+			return fmt.Errorf("workflow %s module %s: %w", id, ref.InstanceID(), err)
+		}
+		configurer, ok := mod.(interface {
+			ConfigureOrchestrator(*orchestrator.Orchestrator) error
+		})
+		if !ok {
+			return fmt.Errorf("workflow %s module %s cannot configure the orchestrator", id, ref.InstanceID())
+		}
+		return configurer.ConfigureOrchestrator(orch)
+	}
+	return nil
+}
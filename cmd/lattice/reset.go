@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/workflow/reset"
+)
+
+func handleResetCommand() bool {
+	if len(os.Args) < 2 || os.Args[1] != "reset" {
+		return false
+	}
+	fs := flag.NewFlagSet("reset", flag.ExitOnError)
+	phaseName := fs.String("phase", "", "rewind to this phase ("+strings.Join(reset.PhaseNames(), ", ")+")")
+	all := fs.Bool("all", false, "clear all workflow state, including planning documents and worktrees")
+	worktrees := fs.Bool("worktrees", false, "also discard tracked worktree sessions")
+	force := fs.Bool("force", false, "reset even if the cycle tracker reports a running cycle")
+	yes := fs.Bool("yes", false, "skip the confirmation prompt")
+	fs.Usage = func() {
+		logErrorf("Usage: lattice reset (--phase <name> | --all) [--worktrees] [--force] [--yes]\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(os.Args[2:])
+	if (*phaseName == "") == !*all || fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	opts := reset.Options{All: *all, Worktrees: *worktrees, Force: *force}
+	if !*all {
+		phase, err := reset.ParsePhase(*phaseName)
+		if err != nil {
+			logErrorf("%v\n", err)
+			os.Exit(2)
+		}
+		opts.Phase = phase
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		logErrorf("Error getting working directory: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.NewConfig(cwd)
+	if err != nil {
+		logErrorf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	plan, err := reset.NewPlan(cfg, opts)
+	if err != nil {
+		if errors.Is(err, reset.ErrCycleActive) {
+			logErrorf("%v\nStop the cycle first or pass --force if it is wedged.\n", err)
+		} else {
+			logErrorf("%v\n", err)
+		}
+		os.Exit(1)
+	}
+	if plan.Empty() {
+		fmt.Println("Nothing to reset.")
+		return true
+	}
+	fmt.Printf("Resetting %s. This removes:\n", plan.Scope)
+	for _, path := range plan.Paths {
+		fmt.Printf("- %s\n", path)
+	}
+	if plan.Worktrees {
+		fmt.Println("- all tracked worktree sessions (branches are kept)")
+	}
+	if !*yes && !confirm("Proceed? [y/N] ") {
+		fmt.Println("Reset cancelled.")
+		return true
+	}
+	if err := plan.Apply(); err != nil {
+		logErrorf("Reset failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Reset complete.")
+	return true
+}
+
+func confirm(prompt string) bool {
+	fmt.Print(prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
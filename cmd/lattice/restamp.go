@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/modules"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+	"github.com/kingrea/The-Lattice/internal/workflow/engine"
+	"github.com/kingrea/The-Lattice/internal/workflow/provenance"
+	"github.com/kingrea/The-Lattice/plugins"
+)
+
+func handleRestampCommand() bool {
+	if len(os.Args) < 2 || os.Args[1] != "restamp" {
+		return false
+	}
+	fs := flag.NewFlagSet("restamp", flag.ExitOnError)
+	workflowID := fs.String("workflow", "", "workflow whose run state names the producing modules (defaults to the project default)")
+	yes := fs.Bool("yes", false, "skip the confirmation prompt")
+	fs.Usage = func() {
+		logErrorf("Usage: lattice restamp [--workflow <id>] [--yes]\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		logErrorf("Error getting working directory: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.NewConfig(cwd)
+	if err != nil {
+		logErrorf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	id := strings.TrimSpace(*workflowID)
+	if id == "" {
+		id = cfg.DefaultWorkflow()
+	}
+	wf := workflow.New(cfg.LatticeProjectDir)
+	state, err := engine.NewWorkflowRepository(wf, id).Load()
+	if err != nil {
+		if errors.Is(err, engine.ErrStateNotFound) {
+			logErrorf("No run state for workflow %s; run it before restamping.\n", id)
+		} else {
+			logErrorf("Error loading workflow state: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	reg := module.NewRegistry()
+	modules.RegisterBuiltins(reg)
+	if err := plugins.RegisterSkillPlugins(reg, cfg); err != nil {
+		logErrorf("Error loading plugins: %v\n", err)
+		os.Exit(1)
+	}
+	plan, err := provenance.NewPlan(wf, state, reg)
+	if err != nil {
+		logErrorf("%v\n", err)
+		os.Exit(1)
+	}
+	if plan.Empty() {
+		fmt.Println("Artifact provenance is intact.")
+		return true
+	}
+	fmt.Println("Restamping provenance on (content is left unchanged):")
+	for _, repair := range plan.Repairs {
+		fmt.Printf("- %s\n", repair)
+	}
+	if !*yes && !confirm("Proceed? [y/N] ") {
+		fmt.Println("Restamp cancelled.")
+		return true
+	}
+	if err := plan.Apply(); err != nil {
+		logErrorf("Restamp failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Restamped %d artifact(s).\n", len(plan.Repairs))
+	return true
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
+)
+
+func handleSessionCommand() bool {
+	if len(os.Args) < 2 || os.Args[1] != "session" {
+		return false
+	}
+	fs := flag.NewFlagSet("session", flag.ExitOnError)
+	reason := fs.String("reason", "", "note recorded with a suspension")
+	fs.Usage = func() {
+		logErrorf("Usage: lattice session suspend [--reason text] <worktree>\n       lattice session resume <worktree>\n")
+		fs.PrintDefaults()
+	}
+	if len(os.Args) < 3 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	action := os.Args[2]
+	_ = fs.Parse(os.Args[3:])
+	if fs.NArg() != 1 || (action != "suspend" && action != "resume") {
+		fs.Usage()
+		os.Exit(2)
+	}
+	name := fs.Arg(0)
+	cwd, err := os.Getwd()
+	if err != nil {
+		logErrorf("Error getting working directory: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.NewConfig(cwd)
+	if err != nil {
+		logErrorf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	orch := orchestrator.New(cfg)
+	if action == "suspend" {
+		err = orch.SuspendSession(name, *reason)
+	} else {
+		err = orch.ResumeSession(name)
+	}
+	if err != nil {
+		logErrorf("%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s: %sd\n", name, action)
+	return true
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
+)
+
+// statusReport is the `lattice status --json` output: the status board plus
+// the beads bd still lists as outstanding.
+type statusReport struct {
+	orchestrator.StatusBoard
+	Beads    *orchestrator.BeadCounts `json:"outstanding_beads"`
+	BeadsErr string                   `json:"outstanding_beads_error,omitempty"`
+}
+
+func handleStatusCommand() bool {
+	if len(os.Args) < 2 || os.Args[1] != "status" {
+		return false
+	}
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the status board as JSON")
+	fs.Usage = func() {
+		logErrorf("Usage: lattice status [--json]\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		logErrorf("Error getting working directory: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.NewConfig(cwd)
+	if err != nil {
+		logErrorf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	orch := orchestrator.New(cfg)
+	// Windows are looked up in the session startTmuxSession creates; without
+	// tmux they are left empty.
+	board, err := orch.StatusBoard("lattice")
+	if err != nil {
+		logErrorf("%v\n", err)
+		os.Exit(1)
+	}
+	report := statusReport{StatusBoard: board}
+	if counts, err := orch.OutstandingBeads(); err != nil {
+		report.BeadsErr = err.Error()
+	} else {
+		report.Beads = &counts
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			logErrorf("%v\n", err)
+			os.Exit(1)
+		}
+		return true
+	}
+	fmt.Printf("Phase: %s\n", board.PhaseName)
+	if board.HasCycle {
+		fmt.Printf("Cycle %d: %s, %d session(s)\n", board.Cycle.Cycle, board.Cycle.Status, board.Cycle.SessionCount)
+	} else {
+		fmt.Println("No cycle tracked.")
+	}
+	for _, row := range board.Sessions {
+		window := "idle"
+		if row.Window != "" {
+			window = "tmux " + row.Window
+		}
+		fmt.Printf("- %s · %s: %s/%s, cycle %d, %d bead(s), %d pt, %d/%d question(s) waiting, %s\n",
+			row.Agent, row.Worktree, row.Phase, row.State, row.Cycle, row.Beads, row.Points, row.Waiting, row.Questions, window)
+	}
+	if report.Beads != nil {
+		fmt.Printf("Outstanding beads: %d (%d open, %d in progress, %d blocked)\n", report.Beads.Total, report.Beads.Open, report.Beads.InProgress, report.Beads.Blocked)
+	} else {
+		fmt.Printf("Outstanding beads: unavailable (%s)\n", report.BeadsErr)
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
+)
+
+func handleThroughputCommand() bool {
+	if len(os.Args) < 2 || os.Args[1] != "throughput" {
+		return false
+	}
+	fs := flag.NewFlagSet("throughput", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	fs.Usage = func() {
+		logErrorf("Usage: lattice throughput [--json]\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		logErrorf("Error getting working directory: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.NewConfig(cwd)
+	if err != nil {
+		logErrorf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	report, err := orchestrator.New(cfg).Throughput()
+	if err != nil {
+		logErrorf("%v\n", err)
+		os.Exit(1)
+	}
+	if *asJSON {
+		out := struct {
+			Cycles          []orchestrator.CycleThroughput `json:"cycles"`
+			BeadsPerCycle   float64                        `json:"beads_per_cycle"`
+			PointsPerCycle  float64                        `json:"points_per_cycle"`
+			PointsPerAgent  float64                        `json:"points_per_agent"`
+			MeanDurationSec float64                        `json:"mean_duration_seconds"`
+		}{
+			Cycles:          report.Cycles,
+			BeadsPerCycle:   report.BeadsPerCycle(),
+			PointsPerCycle:  report.PointsPerCycle(),
+			PointsPerAgent:  report.PointsPerAgent(),
+			MeanDurationSec: report.MeanDuration().Seconds(),
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			logErrorf("%v\n", err)
+			os.Exit(1)
+		}
+		return true
+	}
+	if len(report.Cycles) == 0 {
+		fmt.Println("No completed cycles recorded yet.")
+		return true
+	}
+	for _, cycle := range report.Cycles {
+		if !cycle.Logged {
+			fmt.Printf("cycle %d: summary only, no work-log metrics\n", cycle.Cycle)
+			continue
+		}
+		duration := "unknown"
+		if cycle.Duration > 0 {
+			duration = cycle.Duration.String()
+		}
+		fmt.Printf("cycle %d: %d/%d beads, %d/%d points, %d agent(s), %s\n",
+			cycle.Cycle, cycle.CompletedBeads, cycle.AssignedBeads,
+			cycle.CompletedPoints, cycle.AssignedPoints, cycle.Agents, duration)
+	}
+	fmt.Printf("\nPer cycle: %.1f beads, %.1f points. Per agent: %.1f points.", report.BeadsPerCycle(), report.PointsPerCycle(), report.PointsPerAgent())
+	if mean := report.MeanDuration(); mean > 0 {
+		fmt.Printf(" Mean duration: %s.", mean.Round(time.Second))
+	}
+	fmt.Println()
+	return true
+}
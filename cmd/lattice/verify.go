@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kingrea/The-Lattice/internal/workflow/verify"
+)
+
+func handleVerifyCommand() bool {
+	if len(os.Args) < 2 || os.Args[1] != "verify" {
+		return false
+	}
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "repair issues that have an unambiguous fix")
+	fs.Usage = func() {
+		logErrorf("Usage: lattice verify [--fix]\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(os.Args[2:])
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		logErrorf("Error getting working directory: %v\n", err)
+		os.Exit(1)
+	}
+	report, err := verify.Check(cwd)
+	if err != nil {
+		logErrorf("%v\n", err)
+		os.Exit(1)
+	}
+	if report.OK() {
+		fmt.Println("OK: .lattice matches the expected layout.")
+		return true
+	}
+	remaining := report.Issues
+	if *fix {
+		remaining, err = report.Fix()
+		if fixed := len(report.Issues) - len(remaining); fixed > 0 {
+			fmt.Printf("Fixed %d issue(s).\n", fixed)
+		}
+		if err != nil {
+			logErrorf("%v\n", err)
+		}
+		if len(remaining) == 0 {
+			return true
+		}
+	}
+	fmt.Printf("Found %d issue(s):\n", len(remaining))
+	for _, issue := range remaining {
+		suffix := ""
+		if issue.Fixable && !*fix {
+			suffix = " (fixable with --fix)"
+		}
+		fmt.Printf("- %s%s\n", issue, suffix)
+	}
+	os.Exit(1)
+	return true
+}
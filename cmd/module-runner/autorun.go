@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow/engine"
+)
+
+// runAuto resumes the workflow's persisted run, or starts one, and runs every
+// module the engine can claim, in parallel up to the workflow's max_parallel,
+// until nothing more is runnable. Interrupting waits for running modules and
+// records their results before exiting.
+func runAuto(ctx *module.ModuleContext, reg *module.Registry, name string) error {
+	def, err := loadSimulationWorkflow(ctx.Config, name)
+	if err != nil {
+		return err
+	}
+	eng, err := engine.New(reg, engine.NewWorkflowRepository(ctx.Workflow, def.ID))
+	if err != nil {
+		return err
+	}
+	if _, err := eng.Resume(ctx, engine.ResumeRequest{Actor: "module-runner"}); errors.Is(err, engine.ErrStateNotFound) {
+		if _, err := eng.Start(ctx, engine.StartRequest{Definition: def, Actor: "module-runner"}); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	state, runErr := eng.AutoRun(runCtx, ctx, engine.AutoRunRequest{})
+	fmt.Printf("Workflow: %s\n", def.ID)
+	status := string(state.Status)
+	if state.StatusReason != "" {
+		status += " · " + state.StatusReason
+	}
+	fmt.Printf("Status: %s\n", status)
+	ids := make([]string, 0, len(state.Runs))
+	for id := range state.Runs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	lines := make([]string, 0, len(ids))
+	for _, id := range ids {
+		run := state.Runs[id]
+		line := fmt.Sprintf("%s: %s", id, run.Status)
+		if run.Error != "" {
+			line += " · " + run.Error
+		} else if run.Message != "" {
+			line += " · " + run.Message
+		}
+		lines = append(lines, line)
+	}
+	printSection("Runs", lines)
+	printSection("Runnable", state.Runnable)
+	return runErr
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/config"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/modules"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 	"github.com/kingrea/The-Lattice/plugins"
 	"gopkg.in/yaml.v3"
@@ -24,9 +25,24 @@ func main() {
 	configFile := flag.String("config-file", "", "path to YAML/JSON file with module config overrides")
 	sets := keyValueFlag{}
 	flag.Var(&sets, "set", "module config override (key=value, repeatable)")
+	simulate := flag.Bool("simulate", false, "print the scheduler decision for a workflow instead of running a module")
+	completed := flag.String("completed", "", "comma-separated module instances to treat as complete when simulating")
+	workflowName := flag.String("workflow", "", "workflow ID or definition file to simulate, plan, or auto-run (defaults to the project default)")
+	plan := flag.Bool("plan", false, "print the batches a workflow would run in, without running anything")
+	planJSON := flag.Bool("plan-json", false, "like --plan but print the batches as JSON")
+	autoRun := flag.Bool("auto-run", false, "run the workflow unattended, executing runnable modules in parallel until none remain")
+	releaseVersion := flag.String("release-version", "", "release version for the release module (shorthand for --set version=...)")
+	forceTag := flag.Bool("force-tag", false, "move an existing release tag to the current commit (shorthand for --set force_tag=true)")
+	rerunAudit := flag.String("rerun-audit", "", "re-run one refinement stakeholder audit by role and refresh the synthesis")
+	cycleMeta := keyValueFlag{}
+	flag.Var(&cycleMeta, "cycle-meta", "note recorded with each work cycle (key=value, repeatable; work-process cycle_metadata)")
+	refreshCVIndex := flag.Bool("refresh-cv-index", false, "discard the cached denizen CV index and rescan every community")
 	flag.Parse()
 
-	if strings.TrimSpace(*moduleID) == "" {
+	if strings.TrimSpace(*rerunAudit) != "" && strings.TrimSpace(*moduleID) == "" {
+		*moduleID = "refinement"
+	}
+	if !*simulate && !*plan && !*planJSON && !*autoRun && strings.TrimSpace(*moduleID) == "" {
 		die("--module is required")
 	}
 
@@ -49,6 +65,11 @@ func main() {
 	if err != nil {
 		die("load config: %v", err)
 	}
+	if *refreshCVIndex {
+		if err := orchestrator.RefreshCVIndex(cfg); err != nil {
+			die("refresh cv index: %v", err)
+		}
+	}
 	wf := workflow.New(cfg.LatticeProjectDir)
 	ctx := &module.ModuleContext{
 		Config:     cfg,
@@ -61,20 +82,68 @@ func main() {
 	if err := plugins.RegisterSkillPlugins(reg, cfg); err != nil {
 		die("load plugins: %v", err)
 	}
+	if *simulate {
+		if err := runSimulation(cfg, reg, *workflowName, *completed); err != nil {
+			die("simulate: %v", err)
+		}
+		return
+	}
+	if *plan || *planJSON {
+		if err := runPlan(ctx, reg, *workflowName, *planJSON); err != nil {
+			die("plan: %v", err)
+		}
+		return
+	}
+	if *autoRun {
+		if err := runAuto(ctx, reg, *workflowName); err != nil {
+			die("auto-run: %v", err)
+		}
+		return
+	}
+	if v := strings.TrimSpace(*releaseVersion); v != "" {
+		sets["version"] = v
+	}
+	if *forceTag {
+		sets["force_tag"] = "true"
+	}
 	cfgOverrides, err := buildModuleConfig(*configFile, sets)
 	if err != nil {
 		die("load config overrides: %v", err)
 	}
+	if len(cycleMeta) > 0 {
+		cfgOverrides = withCycleMetadata(cfgOverrides, cycleMeta)
+	}
 	mod, err := reg.Resolve(*moduleID, cfgOverrides)
 	if err != nil {
 		die("resolve module: %v", err)
 	}
 	info := mod.Info()
 	label := moduleLabel(info, *moduleID)
-	result, err := mod.Run(ctx)
+	var rerun auditRerunner
+	if role := strings.TrimSpace(*rerunAudit); role != "" {
+		var ok bool
+		if rerun, ok = mod.(auditRerunner); !ok {
+			die("--rerun-audit: module %s does not run stakeholder audits", *moduleID)
+		}
+	}
+	runCtx, runLog, err := ctx.OpenRunLog(*moduleID, time.Now())
 	if err != nil {
-		die("run module: %v", err)
+		die("open run log: %v", err)
 	}
+	var result module.Result
+	if rerun != nil {
+		result, err = rerun.RerunAudit(runCtx, strings.TrimSpace(*rerunAudit))
+	} else {
+		result, err = mod.Run(runCtx)
+	}
+	runLog.Close()
+	if err != nil {
+		if hint := orchestrator.Remedy(err); hint != "" {
+			die("run module: %v (output: %s)\nhint: %s", err, runCtx.RunLogPath, hint)
+		}
+		die("run module: %v (output: %s)", err, runCtx.RunLogPath)
+	}
+	fmt.Printf("Command output: %s\n", runCtx.RunLogPath)
 	fmt.Printf("Run status: %s\n", result.Status)
 	if result.Message != "" {
 		fmt.Println(result.Message)
@@ -99,6 +168,12 @@ func main() {
 	}
 }
 
+// auditRerunner is implemented by modules that can regenerate a single
+// stakeholder audit (refinement).
+type auditRerunner interface {
+	RerunAudit(*module.ModuleContext, string) (module.Result, error)
+}
+
 func die(format string, args ...any) {
 	fmt.Fprintf(os.Stderr, format+"\n", args...)
 	os.Exit(1)
@@ -133,6 +208,25 @@ func (kv *keyValueFlag) Set(value string) error {
 	return nil
 }
 
+// withCycleMetadata merges --cycle-meta entries into the cycle_metadata map
+// of cfg, keeping entries from the config file that were not overridden.
+func withCycleMetadata(cfg module.Config, entries keyValueFlag) module.Config {
+	if cfg == nil {
+		cfg = module.Config{}
+	}
+	meta := map[string]any{}
+	if existing, ok := cfg["cycle_metadata"].(map[string]any); ok {
+		for key, value := range existing {
+			meta[key] = value
+		}
+	}
+	for key, value := range entries {
+		meta[key] = value
+	}
+	cfg["cycle_metadata"] = meta
+	return cfg
+}
+
 func buildModuleConfig(configFile string, overrides keyValueFlag) (module.Config, error) {
 	var cfg module.Config
 	if path := strings.TrimSpace(configFile); path != "" {
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow/engine"
+	"github.com/kingrea/The-Lattice/internal/workflow/resolver"
+	"github.com/kingrea/The-Lattice/internal/workflow/scheduler"
+)
+
+// planOutput is the --plan-json document.
+type planOutput struct {
+	Workflow string `json:"workflow"`
+	scheduler.Plan
+}
+
+// runPlan resolves the workflow against the project's artifacts and prints
+// the batches the remaining modules would run in.
+func runPlan(ctx *module.ModuleContext, reg *module.Registry, name string, asJSON bool) error {
+	def, err := loadSimulationWorkflow(ctx.Config, name)
+	if err != nil {
+		return err
+	}
+	runtime, err := persistedRuntime(ctx, def.ID)
+	if err != nil {
+		return err
+	}
+	res, err := resolver.New(def, reg)
+	if err != nil {
+		return err
+	}
+	res.SetSkipped(runtime.SkippedModules...)
+	if err := res.Refresh(ctx); err != nil {
+		return err
+	}
+	limits := res.Definition().Runtime
+	if runtime.MaxParallel > 0 {
+		limits.MaxParallel = runtime.MaxParallel
+	}
+	plan, err := scheduler.DescribeBatches(res, limits, runtime.ManualGates)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(planOutput{Workflow: def.ID, Plan: plan})
+	}
+	fmt.Printf("Workflow: %s\n", def.ID)
+	fmt.Printf("Complete: %s\n", joinOrNone(plan.Complete))
+	if len(plan.Batches) == 0 {
+		fmt.Println("Batches: (none)")
+	}
+	for _, batch := range plan.Batches {
+		ids := make([]string, 0, len(batch.Modules))
+		for _, mod := range batch.Modules {
+			ids = append(ids, mod.ID)
+		}
+		fmt.Printf("Batch %d: %s\n", batch.Number, strings.Join(ids, ", "))
+		for _, mod := range batch.Modules {
+			fmt.Printf("  %s: %s\n", mod.ID, plannedGate(mod))
+		}
+	}
+	printSection("Awaiting approval", describeReasons(plan.AwaitingApproval))
+	printSection("Skipped", describeReasons(plan.Skipped))
+	printSection("Unreachable", describeReasons(plan.Unreachable))
+	return nil
+}
+
+// persistedRuntime returns the runtime of the workflow's persisted run, so the
+// plan honours the skips, approvals and parallelism set in the workflow view.
+// Without a run only the definition's own settings apply.
+func persistedRuntime(ctx *module.ModuleContext, workflowID string) (engine.EngineRuntime, error) {
+	state, err := engine.NewWorkflowRepository(ctx.Workflow, workflowID).Load()
+	if errors.Is(err, engine.ErrStateNotFound) {
+		return engine.EngineRuntime{}, nil
+	}
+	if err != nil {
+		return engine.EngineRuntime{}, fmt.Errorf("load workflow state: %w", err)
+	}
+	return state.Runtime, nil
+}
+
+// plannedGate explains why a module sits in its batch.
+func plannedGate(mod scheduler.PlannedModule) string {
+	var parts []string
+	if len(mod.WaitsOn) > 0 {
+		parts = append(parts, "after "+strings.Join(mod.WaitsOn, ", "))
+	}
+	if mod.Deferred != "" {
+		parts = append(parts, "deferred: "+mod.Deferred)
+	}
+	if len(parts) == 0 {
+		return "ready now"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func describeReasons(reasons map[string]string) []string {
+	lines := make([]string, 0, len(reasons))
+	for id, reason := range reasons {
+		lines = append(lines, fmt.Sprintf("%s: %s", id, reason))
+	}
+	sort.Strings(lines)
+	return lines
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+	"github.com/kingrea/The-Lattice/internal/workflow/scheduler"
+)
+
+func runSimulation(cfg *config.Config, reg *module.Registry, name, completed string) error {
+	def, err := loadSimulationWorkflow(cfg, name)
+	if err != nil {
+		return err
+	}
+	var done []string
+	for _, id := range strings.Split(completed, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			done = append(done, id)
+		}
+	}
+	sim, err := scheduler.Simulate(def, reg, done)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Workflow: %s\n", def.ID)
+	fmt.Printf("Complete: %s\n", joinOrNone(sim.Complete))
+	fmt.Printf("Runnable: %s\n", joinOrNone(sim.Runnable))
+	var skipped []string
+	for id, reason := range sim.Skipped {
+		skipped = append(skipped, fmt.Sprintf("%s: %s (%s)", id, reason.Reason, reason.Detail))
+	}
+	printSection("Skipped", skipped)
+	var blocked []string
+	for id, blockers := range sim.Blocked {
+		blocked = append(blocked, fmt.Sprintf("%s: waiting on %s", id, strings.Join(blockers, ", ")))
+	}
+	printSection("Blocked", blocked)
+	return nil
+}
+
+// loadSimulationWorkflow accepts a definition path or a workflow ID looked up
+// in the same workflows/ directories the TUI searches.
+func loadSimulationWorkflow(cfg *config.Config, name string) (workflow.WorkflowDefinition, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = cfg.DefaultWorkflow()
+	}
+	if name == "" {
+		return workflow.WorkflowDefinition{}, fmt.Errorf("--workflow is required when the project has no default workflow")
+	}
+	if info, err := os.Stat(name); err == nil && !info.IsDir() {
+		return workflow.LoadDefinitionFile(name)
+	}
+	bases := []string{cfg.ProjectDir, cfg.LatticeProjectDir, cfg.LatticeRoot}
+	for _, base := range bases {
+		if strings.TrimSpace(base) == "" {
+			continue
+		}
+		for _, ext := range []string{".yaml", ".yml"} {
+			path := filepath.Join(base, "workflows", name+ext)
+			if _, err := os.Stat(path); err == nil {
+				return workflow.LoadDefinitionFile(path)
+			}
+		}
+	}
+	return workflow.LoadDefinitionRelative(workflow.DefaultWorkflowDir, name+".yaml")
+}
+
+func printSection(title string, lines []string) {
+	fmt.Printf("%s:\n", title)
+	if len(lines) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	sort.Strings(lines)
+	for _, line := range lines {
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+func joinOrNone(ids []string) string {
+	if len(ids) == 0 {
+		return "(none)"
+	}
+	return strings.Join(ids, ", ")
+}
@@ -74,6 +74,7 @@ type latticeMetadata struct {
 	Version  string            `yaml:"version"`
 	Workflow string            `yaml:"workflow,omitempty"`
 	Inputs   []string          `yaml:"inputs,omitempty"`
+	Reads    []string          `yaml:"reads,omitempty"`
 	Created  string            `yaml:"created"`
 	Checksum string            `yaml:"checksum,omitempty"`
 	Notes    map[string]string `yaml:"notes,omitempty"`
@@ -93,6 +94,7 @@ func (e latticeEnvelope) toMetadata() (Metadata, error) {
 		Version:    e.Lattice.Version,
 		Workflow:   e.Lattice.Workflow,
 		Inputs:     append([]string{}, e.Lattice.Inputs...),
+		Reads:      append([]string(nil), e.Lattice.Reads...),
 		CreatedAt:  created,
 		Checksum:   e.Lattice.Checksum,
 		Notes:      cloneNotes(e.Lattice.Notes),
@@ -105,6 +107,7 @@ func (e *latticeEnvelope) fromMetadata(meta Metadata) {
 	e.Lattice.Version = meta.Version
 	e.Lattice.Workflow = meta.Workflow
 	e.Lattice.Inputs = append([]string{}, meta.Inputs...)
+	e.Lattice.Reads = append([]string(nil), meta.Reads...)
 	e.Lattice.Created = meta.CreatedAt.UTC().Format(timeLayout)
 	e.Lattice.Checksum = meta.Checksum
 	e.Lattice.Notes = cloneNotes(meta.Notes)
@@ -0,0 +1,69 @@
+package artifact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// Supported fingerprint algorithms.
+const (
+	HashSHA256  = "sha256"
+	HashFNV1a64 = "fnv1a64"
+)
+
+// Hasher produces the fingerprint strings recorded in artifact metadata.
+type Hasher interface {
+	// Algorithm names the hash function.
+	Algorithm() string
+	// Fingerprint hashes data into the stored representation.
+	Fingerprint(data []byte) string
+}
+
+// DefaultHasher is the SHA-256 hasher used when no algorithm is configured.
+var DefaultHasher Hasher = sha256Hasher{}
+
+// NewHasher returns the hasher for algorithm. An empty name selects SHA-256.
+func NewHasher(algorithm string) (Hasher, error) {
+	switch strings.ToLower(strings.TrimSpace(algorithm)) {
+	case "", HashSHA256:
+		return sha256Hasher{}, nil
+	case HashFNV1a64, "fnv":
+		return fnv1a64Hasher{}, nil
+	default:
+		return nil, fmt.Errorf("artifact: unknown fingerprint algorithm %q (expected %s or %s)", algorithm, HashSHA256, HashFNV1a64)
+	}
+}
+
+// FingerprintAlgorithm reports which algorithm produced a stored fingerprint.
+// SHA-256 values are stored as bare hex for compatibility with existing
+// metadata; other algorithms prefix the digest with "<algorithm>:".
+func FingerprintAlgorithm(value string) string {
+	if algo, _, ok := strings.Cut(value, ":"); ok {
+		return algo
+	}
+	return HashSHA256
+}
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Algorithm() string { return HashSHA256 }
+
+func (sha256Hasher) Fingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// fnv1a64Hasher is a fast non-cryptographic hash for cache keys where
+// collision resistance matters less than speed.
+type fnv1a64Hasher struct{}
+
+func (fnv1a64Hasher) Algorithm() string { return HashFNV1a64 }
+
+func (fnv1a64Hasher) Fingerprint(data []byte) string {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return HashFNV1a64 + ":" + hex.EncodeToString(h.Sum(nil))
+}
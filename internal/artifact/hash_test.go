@@ -0,0 +1,37 @@
+package artifact
+
+import "testing"
+
+func TestNewHasherSelectsAlgorithm(t *testing.T) {
+	data := []byte("release notes body")
+	cases := []struct {
+		name string
+		algo string
+		want string
+	}{
+		{name: "default", algo: "", want: "aab453ca6aebfb87b6927f2271a7f2c3652ff0addbd1ed2dbeb5a86b30f9af71"},
+		{name: "sha256", algo: "SHA256", want: "aab453ca6aebfb87b6927f2271a7f2c3652ff0addbd1ed2dbeb5a86b30f9af71"},
+		{name: "fnv", algo: "fnv1a64", want: "fnv1a64:223268e57ebaceed"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasher, err := NewHasher(tc.algo)
+			if err != nil {
+				t.Fatalf("NewHasher: %v", err)
+			}
+			got := hasher.Fingerprint(data)
+			if got != hasher.Fingerprint(append([]byte(nil), data...)) {
+				t.Fatalf("fingerprint is not stable")
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected fingerprint %s", got)
+			}
+			if algo := FingerprintAlgorithm(got); algo != hasher.Algorithm() {
+				t.Fatalf("stored fingerprint %s reports algorithm %s, want %s", got, algo, hasher.Algorithm())
+			}
+		})
+	}
+	if _, err := NewHasher("md5"); err == nil {
+		t.Fatalf("expected unknown algorithm error")
+	}
+}
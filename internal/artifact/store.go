@@ -60,7 +60,13 @@ func (s *Store) Check(ref ArtifactRef) (CheckResult, error) {
 		if info.IsDir() {
 			return invalidResult(ref, path, fmt.Errorf("artifact: expected marker file got directory"))
 		}
-		return CheckResult{Ref: ref, Path: path, State: StateReady}, nil
+		result := CheckResult{Ref: ref, Path: path, State: StateReady}
+		if data, readErr := os.ReadFile(path); readErr == nil {
+			if meta, metaErr := parseMarkerMetadata(data); metaErr == nil {
+				result.Metadata = &meta
+			}
+		}
+		return result, nil
 	case KindDirectory:
 		if !info.IsDir() {
 			return invalidResult(ref, path, fmt.Errorf("artifact: expected directory"))
@@ -103,7 +109,7 @@ func (s *Store) Write(ref ArtifactRef, body []byte, meta Metadata) error {
 	}
 	switch ref.Kind {
 	case KindMarker:
-		return ensureMarker(path)
+		return s.writeMarker(path, ref, meta)
 	case KindDirectory:
 		return os.MkdirAll(path, 0o755)
 	case KindJSON:
@@ -154,11 +160,67 @@ func (s *Store) writeJSON(path string, ref ArtifactRef, body []byte, meta Metada
 	return os.WriteFile(path, encoded, 0o644)
 }
 
-func ensureMarker(path string) error {
+// writeMarker records a small JSON provenance body (module, version, created)
+// inside the marker file. Writes are idempotent: a marker that already carries
+// metadata keeps its original timestamp so it reflects when the gate first
+// fired. Legacy empty markers are upgraded in place.
+func (s *Store) writeMarker(path string, ref ArtifactRef, meta Metadata) error {
+	if data, err := os.ReadFile(path); err == nil {
+		if _, parseErr := parseMarkerMetadata(data); parseErr == nil {
+			return nil
+		}
+	}
+	prepared := meta.WithDefaults(ref, s.now())
+	encoded, err := json.MarshalIndent(markerToJSON(prepared), "", "  ")
+	if err != nil {
+		return fmt.Errorf("artifact: encode marker %s: %w", ref.ID, err)
+	}
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
-	return os.WriteFile(path, []byte{}, 0o644)
+	return os.WriteFile(path, append(encoded, '\n'), 0o644)
+}
+
+func markerToJSON(meta Metadata) map[string]any {
+	result := map[string]any{
+		"artifact": meta.ArtifactID,
+		"created":  meta.CreatedAt.UTC().Format(timeLayout),
+	}
+	if meta.ModuleID != "" {
+		result["module"] = meta.ModuleID
+	}
+	if meta.Version != "" {
+		result["version"] = meta.Version
+	}
+	if meta.Workflow != "" {
+		result["workflow"] = meta.Workflow
+	}
+	return result
+}
+
+// parseMarkerMetadata decodes the JSON body written by writeMarker. Empty
+// markers (touched by older releases or external tools) return an error.
+func parseMarkerMetadata(data []byte) (Metadata, error) {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return Metadata{}, fmt.Errorf("artifact: parse marker metadata: %w", err)
+	}
+	artifactID := stringValue(values["artifact"])
+	created := stringValue(values["created"])
+	if artifactID == "" || created == "" {
+		return Metadata{}, fmt.Errorf("artifact: incomplete marker metadata")
+	}
+	createdAt, err := parseTime(created)
+	if err != nil {
+		return Metadata{}, err
+	}
+	return Metadata{
+		ArtifactID: artifactID,
+		ModuleID:   stringValue(values["module"]),
+		Version:    stringValue(values["version"]),
+		Workflow:   stringValue(values["workflow"]),
+		CreatedAt:  createdAt,
+	}, nil
 }
 
 func invalidResult(ref ArtifactRef, path string, err error) (CheckResult, error) {
@@ -190,6 +252,9 @@ func metadataToJSON(meta Metadata) map[string]any {
 		"inputs":   append([]string{}, meta.Inputs...),
 		"created":  meta.CreatedAt.UTC().Format(timeLayout),
 	}
+	if len(meta.Reads) > 0 {
+		result["reads"] = append([]string{}, meta.Reads...)
+	}
 	if meta.Checksum != "" {
 		result["checksum"] = meta.Checksum
 	}
@@ -223,6 +288,7 @@ func metadataFromMap(values map[string]any) (Metadata, error) {
 		Version:    version,
 		Workflow:   workflow,
 		Inputs:     inputs,
+		Reads:      sliceStringValue(values["reads"]),
 		CreatedAt:  timeValue,
 		Checksum:   stringValue(values["checksum"]),
 		Notes:      notes,
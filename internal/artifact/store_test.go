@@ -0,0 +1,69 @@
+package artifact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func TestMarkerWriteRecordsProvenance(t *testing.T) {
+	wf := workflow.New(filepath.Join(t.TempDir(), ".lattice"))
+	fixed := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	store := NewStore(wf, WithClock(func() time.Time { return fixed }))
+	meta := Metadata{ModuleID: "bead-creation", Version: "1.2.0"}
+	if err := store.Write(BeadsCreatedMarker, nil, meta); err != nil {
+		t.Fatalf("write marker: %v", err)
+	}
+	if _, err := os.Stat(wf.BeadsCreatedPath()); err != nil {
+		t.Fatalf("marker missing on disk: %v", err)
+	}
+	result, err := store.Check(BeadsCreatedMarker)
+	if err != nil {
+		t.Fatalf("check marker: %v", err)
+	}
+	if result.State != StateReady {
+		t.Fatalf("expected ready marker, got %s", result.State)
+	}
+	if result.Metadata == nil {
+		t.Fatalf("expected marker metadata")
+	}
+	if result.Metadata.ModuleID != "bead-creation" || result.Metadata.Version != "1.2.0" {
+		t.Fatalf("unexpected marker provenance: %+v", result.Metadata)
+	}
+	if !result.Metadata.CreatedAt.Equal(fixed) {
+		t.Fatalf("expected timestamp %s, got %s", fixed, result.Metadata.CreatedAt)
+	}
+
+	later := NewStore(wf, WithClock(func() time.Time { return fixed.Add(time.Hour) }))
+	if err := later.Write(BeadsCreatedMarker, nil, Metadata{ModuleID: "other", Version: "9.9.9"}); err != nil {
+		t.Fatalf("rewrite marker: %v", err)
+	}
+	again, err := later.Check(BeadsCreatedMarker)
+	if err != nil {
+		t.Fatalf("recheck marker: %v", err)
+	}
+	if again.Metadata == nil || again.Metadata.ModuleID != "bead-creation" || !again.Metadata.CreatedAt.Equal(fixed) {
+		t.Fatalf("expected idempotent marker write, got %+v", again.Metadata)
+	}
+}
+
+func TestEmptyMarkerStillReady(t *testing.T) {
+	wf := workflow.New(filepath.Join(t.TempDir(), ".lattice"))
+	path := wf.ReviewsAppliedPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte{}, 0o644); err != nil {
+		t.Fatalf("touch: %v", err)
+	}
+	result, err := NewStore(wf).Check(ReviewsAppliedMarker)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if result.State != StateReady || result.Metadata != nil {
+		t.Fatalf("expected ready legacy marker without metadata, got %+v", result)
+	}
+}
@@ -20,7 +20,8 @@ const (
 	KindDocument Kind = "document"
 	// KindJSON represents a JSON document enriched with a _lattice metadata block.
 	KindJSON Kind = "json"
-	// KindMarker represents an empty file used as a marker/flag.
+	// KindMarker represents a marker/flag file. Existence is what matters; the
+	// body carries a small JSON provenance record when written via the Store.
 	KindMarker Kind = "marker"
 	// KindDirectory represents a directory that must exist.
 	KindDirectory Kind = "directory"
@@ -47,6 +48,23 @@ func (r ArtifactRef) Path(wf *workflow.Workflow) string {
 	return filepath.Clean(r.path(wf))
 }
 
+// At returns a copy of the reference that resolves to path instead of its
+// default location. Modules use it when operators relocate an output; the ID
+// is unchanged so the artifact is still checked and tracked as the same one.
+func (r ArtifactRef) At(path string) ArtifactRef {
+	clean := filepath.Clean(path)
+	r.path = func(*workflow.Workflow) string { return clean }
+	return r
+}
+
+// AtProject is At for a path relative to the project root, resolved against
+// the workflow the reference is used with.
+func (r ArtifactRef) AtProject(rel string) ArtifactRef {
+	clean := filepath.Clean(rel)
+	r.path = func(wf *workflow.Workflow) string { return filepath.Join(wf.ProjectDir(), clean) }
+	return r
+}
+
 // Validate ensures the reference is well-formed.
 func (r ArtifactRef) Validate() error {
 	if r.ID == "" {
@@ -73,6 +91,9 @@ type Metadata struct {
 	CreatedAt  time.Time
 	Checksum   string
 	Notes      map[string]string
+	// Reads lists the files the producing module actually read, relative to
+	// the project directory where possible.
+	Reads []string
 }
 
 // WithDefaults ensures metadata carries the artifact ID and timestamps.
@@ -197,6 +218,7 @@ var (
 	ReviewSimplifierDoc = register(newDocRef("review-simplifier", "Simplifier Review", "Expert review focused on DX and simplicity", func(wf *workflow.Workflow) string { return wf.ReviewSimplifierPath() }))
 	ReviewAdvocateDoc   = register(newDocRef("review-advocate", "User Advocate Review", "Expert review focused on user value", func(wf *workflow.Workflow) string { return wf.ReviewAdvocatePath() }))
 	ReviewSkepticDoc    = register(newDocRef("review-skeptic", "Skeptic Review", "Expert review stress-testing risks", func(wf *workflow.Workflow) string { return wf.ReviewSkepticPath() }))
+	ReviewMetaDoc       = register(newDocRef("review-meta", "Meta Review", "REVIEW_META.md comparing the reviewers against past reviews", func(wf *workflow.Workflow) string { return wf.ReviewMetaPath() }))
 	StakeholdersJSON    = register(newJSONRef("stakeholders-json", "Stakeholders Manifest", "stakeholders.json describing refinement reviewer assignments", func(wf *workflow.Workflow) string {
 		return filepath.Join(wf.TeamDir(), "stakeholders.json")
 	}))
@@ -220,6 +242,9 @@ var (
 	RefinementNeededMarker = register(newMarkerRef("refinement-needed", "Refinement Needed Marker", "Marker emitted when no ready beads remain and refinement must run", func(wf *workflow.Workflow) string {
 		return filepath.Join(wf.WorkDir(), workflow.MarkerRefinementNeeded)
 	}))
+	WorkExhaustedMarker = register(newMarkerRef("work-exhausted", "Work Exhausted Marker", "Marker emitted when every bead is closed so release can skip refinement", func(wf *workflow.Workflow) string {
+		return filepath.Join(wf.WorkDir(), workflow.MarkerWorkExhausted)
+	}))
 
 	ReviewsAppliedMarker = register(newMarkerRef("reviews-applied", "Reviews Applied Marker", "Marker file set when review feedback was incorporated", func(wf *workflow.Workflow) string { return wf.ReviewsAppliedPath() }))
 	StaffFeedbackApplied = register(newMarkerRef("staff-feedback-applied", "Staff Feedback Applied Marker", "Marker after staff feedback incorporation", func(wf *workflow.Workflow) string { return wf.StaffFeedbackAppliedPath() }))
@@ -90,6 +90,32 @@ type ProjectConfig struct {
 	Workflows   WorkflowConfig               `yaml:"workflows"`
 	Session     SessionConfig                `yaml:"session"`
 	EventBridge EventBridgeConfig            `yaml:"event_bridge"`
+	Planning    PlanningConfig               `yaml:"planning,omitempty"`
+	Cycle       CycleConfig                  `yaml:"cycle,omitempty"`
+}
+
+// CycleConfig sizes the work cycles the orchestrator stages. Zero values keep
+// the orchestrator's built-in defaults.
+type CycleConfig struct {
+	// MinPoints is the smallest point target a cycle aims for, even when the
+	// scheduled agents' capacity is lower.
+	MinPoints int `yaml:"min_points,omitempty"`
+	// MaxAgentPoints is the capacity given to agents whose roster entry does
+	// not set one.
+	MaxAgentPoints int `yaml:"max_agent_points,omitempty"`
+	// MaxTotalPoints caps the points staged across all worktrees in one cycle.
+	// Zero leaves cycles bounded only by agent capacity.
+	MaxTotalPoints int `yaml:"max_total_points,omitempty"`
+}
+
+// PlanningConfig tunes the interactive planning session.
+type PlanningConfig struct {
+	// AutoProceedWhenClean skips the proceed/keep-chatting decision when no
+	// review written so far flagged blocking issues.
+	AutoProceedWhenClean bool `yaml:"auto_proceed_when_clean,omitempty"`
+	// ProvidedPlan starts planning at the staff review with MODULES.md and
+	// PLAN.md authored outside lattice instead of generating them.
+	ProvidedPlan bool `yaml:"provided_plan,omitempty"`
 }
 
 // SessionConfig governs interactive shell behavior.
@@ -108,6 +134,9 @@ type EventBridgeConfig struct {
 type IdleWatchdogConfig struct {
 	Enabled *bool  `yaml:"enabled,omitempty"`
 	Timeout string `yaml:"timeout,omitempty"`
+	// Retries is how many times a work cycle re-dispatches an agent whose
+	// window went idle before failing its session. Defaults to 1.
+	Retries *int `yaml:"retries,omitempty"`
 }
 
 // Config holds the runtime configuration for Lattice.
@@ -125,6 +154,28 @@ type Config struct {
 	Project ProjectConfig
 }
 
+// LatticeSubdirs lists the directories InitLatticeDir creates, relative to
+// the .lattice directory.
+func LatticeSubdirs() []string {
+	return []string{
+		filepath.Join("setup", "cvs"),
+		"logs",
+		"state",
+		"plan",
+		"action",
+		"workflow",
+		filepath.Join("workflow", "team"),
+		filepath.Join("workflow", "work"),
+		filepath.Join("workflow", "release"),
+		"agents",
+		filepath.Join("agents", "workers"),
+		filepath.Join("agents", "specialists"),
+		"skills",
+		"worktree",
+		"modules",
+	}
+}
+
 // InitLatticeDir creates the .lattice directory structure in the given project directory.
 // This is called when the TUI starts up.
 //
@@ -144,28 +195,8 @@ type Config struct {
 func InitLatticeDir(projectDir string) error {
 	latticeDir := filepath.Join(projectDir, LatticeDir)
 
-	// Create all the subdirectories we need
-	// os.MkdirAll creates parent directories as needed (like mkdir -p)
-	dirs := []string{
-		filepath.Join(latticeDir, "setup", "cvs"),
-		filepath.Join(latticeDir, "logs"),
-		filepath.Join(latticeDir, "state"),
-		filepath.Join(latticeDir, "plan"),
-		filepath.Join(latticeDir, "action"),
-		filepath.Join(latticeDir, "workflow"),
-		filepath.Join(latticeDir, "workflow", "team"),
-		filepath.Join(latticeDir, "workflow", "work"),
-		filepath.Join(latticeDir, "workflow", "release"),
-		filepath.Join(latticeDir, "agents"),
-		filepath.Join(latticeDir, "agents", "workers"),
-		filepath.Join(latticeDir, "agents", "specialists"),
-		filepath.Join(latticeDir, "skills"),
-		filepath.Join(latticeDir, "worktree"),
-		filepath.Join(latticeDir, "modules"),
-	}
-
-	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0755); err != nil {
+	for _, dir := range LatticeSubdirs() {
+		if err := os.MkdirAll(filepath.Join(latticeDir, dir), 0755); err != nil {
 			return err
 		}
 	}
@@ -373,6 +404,23 @@ func (pc *ProjectConfig) validate() error {
 	if err := pc.EventBridge.validate(); err != nil {
 		return fmt.Errorf("event_bridge: %w", err)
 	}
+	if err := pc.Cycle.validate(); err != nil {
+		return fmt.Errorf("cycle: %w", err)
+	}
+	return nil
+}
+
+func (cc CycleConfig) validate() error {
+	switch {
+	case cc.MinPoints < 0:
+		return fmt.Errorf("min_points must not be negative")
+	case cc.MaxAgentPoints < 0:
+		return fmt.Errorf("max_agent_points must not be negative")
+	case cc.MaxTotalPoints < 0:
+		return fmt.Errorf("max_total_points must not be negative")
+	case cc.MaxTotalPoints > 0 && cc.MinPoints > cc.MaxTotalPoints:
+		return fmt.Errorf("min_points (%d) exceeds max_total_points (%d)", cc.MinPoints, cc.MaxTotalPoints)
+	}
 	return nil
 }
 
@@ -394,6 +442,9 @@ func (sc *SessionConfig) normalize() {
 }
 
 func (sc SessionConfig) validate() error {
+	if retries := sc.IdleWatchdog.Retries; retries != nil && *retries < 0 {
+		return fmt.Errorf("idle_watchdog.retries must not be negative")
+	}
 	timeout := strings.TrimSpace(sc.IdleWatchdog.Timeout)
 	if timeout == "" {
 		return nil
@@ -527,10 +578,24 @@ func (c *Config) saveProjectConfig() error {
 	return nil
 }
 
+// AutoProceedWhenClean reports whether planning may skip the user decision
+// after clean reviews.
+func (c *Config) AutoProceedWhenClean() bool {
+	return c != nil && c.Project.Planning.AutoProceedWhenClean
+}
+
+// ProvidedPlan reports whether planning should validate and reuse externally
+// authored MODULES.md and PLAN.md files.
+func (c *Config) ProvidedPlan() bool {
+	return c != nil && c.Project.Planning.ProvidedPlan
+}
+
 // IdleWatchdogSettings describes the derived runtime behavior for idle tracking.
 type IdleWatchdogSettings struct {
 	Enabled bool
 	Timeout time.Duration
+	// Retries is how many times an idle agent is re-dispatched.
+	Retries int
 }
 
 // IdleWatchdogSettings returns the resolved idle watchdog behavior with defaults applied.
@@ -538,6 +603,7 @@ func (c *Config) IdleWatchdogSettings() IdleWatchdogSettings {
 	settings := IdleWatchdogSettings{
 		Enabled: true,
 		Timeout: 5 * time.Minute,
+		Retries: 1,
 	}
 	if c == nil {
 		return settings
@@ -551,5 +617,8 @@ func (c *Config) IdleWatchdogSettings() IdleWatchdogSettings {
 			settings.Timeout = dur
 		}
 	}
+	if watchdog.Retries != nil && *watchdog.Retries >= 0 {
+		settings.Retries = *watchdog.Retries
+	}
 	return settings
 }
@@ -54,6 +54,9 @@ session:
   idle_watchdog:
     enabled: false
     timeout: 10m
+cycle:
+  min_points: 3
+  max_total_points: 20
 `)
 	if err := os.WriteFile(filepath.Join(latticeDir, "config.yaml"), []byte(configYAML), 0644); err != nil {
 		t.Fatal(err)
@@ -86,6 +89,9 @@ session:
 	if settings.Timeout != 10*time.Minute {
 		t.Fatalf("expected idle timeout 10m, got %s", settings.Timeout)
 	}
+	if c.Project.Cycle != (CycleConfig{MinPoints: 3, MaxTotalPoints: 20}) {
+		t.Fatalf("unexpected cycle config: %+v", c.Project.Cycle)
+	}
 }
 
 func TestLoadProjectConfigValidation(t *testing.T) {
@@ -172,6 +178,9 @@ func TestIdleWatchdogSettingsDefaults(t *testing.T) {
 	if settings.Timeout != 5*time.Minute {
 		t.Fatalf("expected default timeout 5m, got %s", settings.Timeout)
 	}
+	if settings.Retries != 1 {
+		t.Fatalf("expected one idle retry by default, got %d", settings.Retries)
+	}
 }
 
 func TestNewConfigUsesEmbeddedDefaultRoot(t *testing.T) {
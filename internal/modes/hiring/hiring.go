@@ -24,7 +24,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kingrea/The-Lattice/internal/modes"
-	"github.com/kingrea/The-Lattice/internal/orchestrator"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 	"github.com/kingrea/The-Lattice/internal/skills"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 )
@@ -175,9 +175,6 @@ func (m *Mode) performHiring(ctx *modes.ModeContext) ([]workflow.WorkerEntry, er
 	for i := range hiRes {
 		entries[i] = hiRes[i].entry
 	}
-	if err := writeWorkersFile(ctx.Workflow, entries); err != nil {
-		return nil, err
-	}
 	if err := updateWorkerList(ctx.Config.WorkerListPath(), entries); err != nil {
 		return nil, err
 	}
@@ -327,30 +324,65 @@ func (m *Mode) selectAgents(ctx *modes.ModeContext, workerCount, totalNeeded int
 	return selected, nil
 }
 
-func writeWorkersFile(wf *workflow.Workflow, workers []workflow.WorkerEntry) error {
-	if err := os.MkdirAll(wf.TeamDir(), 0755); err != nil {
-		return err
-	}
-	return workflow.SaveWorkers(wf.WorkersPath(), workers)
-}
+// workerEntryKeys are the roster fields owned by workflow.WorkerEntry. Any
+// other per-entry keys are left as found when an entry is updated.
+var workerEntryKeys = []string{"name", "community", "role", "isSpark", "capacity"}
 
+// updateWorkerList merges workers into the roster at path. Entries are matched
+// by name and community: matches are updated in place and new hires appended.
+// Every other top-level field, including the orchestrator and the `_lattice`
+// provenance block, is carried over untouched.
 func updateWorkerList(path string, workers []workflow.WorkerEntry) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return err
 	}
-	var state struct {
-		Orchestrator *orchestrator.WorkerRef  `json:"orchestrator,omitempty"`
-		Workers      []orchestrator.WorkerRef `json:"workers,omitempty"`
-		UpdatedAt    string                   `json:"updatedAt"`
-	}
+	state := map[string]json.RawMessage{}
+	var roster []map[string]any
 	if data, err := os.ReadFile(path); err == nil {
-		_ = json.Unmarshal(data, &state)
+		state, roster, err = parseWorkerRoster(data)
+		if err != nil {
+			return fmt.Errorf("parse worker roster %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
 	}
-	state.Workers = make([]orchestrator.WorkerRef, 0, len(workers))
 	for _, worker := range workers {
-		state.Workers = append(state.Workers, orchestrator.WorkerRef{Name: worker.Name})
+		fields, err := workerFields(worker)
+		if err != nil {
+			return err
+		}
+		key := workerKey(worker.Name, worker.Community)
+		matched := false
+		for i, existing := range roster {
+			name, _ := existing["name"].(string)
+			community, _ := existing["community"].(string)
+			if workerKey(name, community) != key {
+				continue
+			}
+			for _, field := range workerEntryKeys {
+				delete(existing, field)
+			}
+			for field, value := range fields {
+				existing[field] = value
+			}
+			roster[i] = existing
+			matched = true
+			break
+		}
+		if !matched {
+			roster = append(roster, fields)
+		}
+	}
+	encoded, err := json.Marshal(roster)
+	if err != nil {
+		return err
+	}
+	state["workers"] = encoded
+	updatedAt, err := json.Marshal(time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
 	}
-	state.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	state["updatedAt"] = updatedAt
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
 		return err
@@ -358,6 +390,48 @@ func updateWorkerList(path string, workers []workflow.WorkerEntry) error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// parseWorkerRoster splits a roster file into its top-level fields and worker
+// entries. Bare arrays written by workflow.SaveWorkers are accepted as well.
+func parseWorkerRoster(data []byte) (map[string]json.RawMessage, []map[string]any, error) {
+	state := map[string]json.RawMessage{}
+	var roster []map[string]any
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return state, nil, nil
+	}
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &roster); err != nil {
+			return nil, nil, err
+		}
+		return state, roster, nil
+	}
+	if err := json.Unmarshal(trimmed, &state); err != nil {
+		return nil, nil, err
+	}
+	if raw, ok := state["workers"]; ok {
+		if err := json.Unmarshal(raw, &roster); err != nil {
+			return nil, nil, err
+		}
+	}
+	return state, roster, nil
+}
+
+func workerFields(worker workflow.WorkerEntry) (map[string]any, error) {
+	data, err := json.Marshal(worker)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func workerKey(name, community string) string {
+	return strings.ToLower(strings.TrimSpace(name)) + "\x00" + strings.ToLower(strings.TrimSpace(community))
+}
+
 func createHireBeads(projectDir string, workers []workflow.WorkerEntry) error {
 	epicID, err := runBdCreate(projectDir, []string{"-t", "epic", "-p", "1"}, "HIRE")
 	if err != nil {
@@ -537,9 +611,11 @@ func killTmuxWindow(name string) {
 }
 
 func sendOpencodePrompt(window, prompt string) error {
-	escaped := strings.ReplaceAll(prompt, "\"", `\"`)
-	escaped = strings.ReplaceAll(escaped, "\n", " ")
-	cmd := exec.Command("tmux", "send-keys", "-t", window, fmt.Sprintf(`opencode --prompt "%s"`, escaped), "Enter")
+	command, err := opencode.Command(prompt, "")
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("tmux", "send-keys", "-t", window, command, "Enter")
 	return cmd.Run()
 }
 
@@ -0,0 +1,105 @@
+package hiring
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func TestUpdateWorkerListMergesAndKeepsProvenance(t *testing.T) {
+	wf := workflow.New(filepath.Join(t.TempDir(), ".lattice"))
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	store := artifact.NewStore(wf)
+	body := []byte(`{
+  "orchestrator": {"name": "Nova"},
+  "workers": [
+    {"name": "Aster", "community": "alpha", "role": "worker", "notes": "keep me"},
+    {"name": "Kai", "community": "beta", "role": "worker", "isSpark": true}
+  ],
+  "updatedAt": "2024-01-01T00:00:00Z"
+}`)
+	meta := artifact.Metadata{ArtifactID: artifact.WorkersJSON.ID, ModuleID: "hiring", Version: "1.0.0", Workflow: wf.Dir()}
+	if err := store.Write(artifact.WorkersJSON, body, meta); err != nil {
+		t.Fatalf("write roster: %v", err)
+	}
+	path := artifact.WorkersJSON.Path(wf)
+	before := readRoster(t, path)
+
+	err := updateWorkerList(path, []workflow.WorkerEntry{
+		{Name: "Aster", Community: "alpha", Role: "specialist", Capacity: 8},
+		{Name: "Kai", Community: "gamma", Role: "worker"},
+		{Name: "Rune", Community: "alpha", Role: "worker", IsSpark: true},
+	})
+	if err != nil {
+		t.Fatalf("update roster: %v", err)
+	}
+
+	after := readRoster(t, path)
+	if string(after["_lattice"]) != string(before["_lattice"]) {
+		t.Fatalf("provenance changed:\nbefore %s\nafter  %s", before["_lattice"], after["_lattice"])
+	}
+	if string(after["orchestrator"]) != string(before["orchestrator"]) {
+		t.Fatalf("orchestrator changed: %s", after["orchestrator"])
+	}
+	if string(after["updatedAt"]) == string(before["updatedAt"]) {
+		t.Fatalf("expected updatedAt to advance")
+	}
+	check, err := store.Check(artifact.WorkersJSON)
+	if err != nil {
+		t.Fatalf("check roster: %v", err)
+	}
+	if check.State != artifact.StateReady || check.Metadata.ModuleID != "hiring" {
+		t.Fatalf("roster lost provenance: state=%s meta=%+v", check.State, check.Metadata)
+	}
+
+	var workers []map[string]any
+	if err := json.Unmarshal(after["workers"], &workers); err != nil {
+		t.Fatalf("decode workers: %v", err)
+	}
+	want := []struct {
+		name, community, role string
+	}{
+		{"Aster", "alpha", "specialist"},
+		{"Kai", "beta", "worker"},
+		{"Kai", "gamma", "worker"},
+		{"Rune", "alpha", "worker"},
+	}
+	if len(workers) != len(want) {
+		t.Fatalf("expected %d workers, got %d: %v", len(want), len(workers), workers)
+	}
+	for i, w := range want {
+		got := workers[i]
+		if got["name"] != w.name || got["community"] != w.community || got["role"] != w.role {
+			t.Fatalf("worker %d = %v, want %+v", i, got, w)
+		}
+	}
+	if workers[0]["notes"] != "keep me" || workers[0]["capacity"] != float64(8) {
+		t.Fatalf("updated entry should keep extra fields and take new capacity: %v", workers[0])
+	}
+	if workers[1]["isSpark"] != true {
+		t.Fatalf("untouched entry changed: %v", workers[1])
+	}
+	loaded, err := workflow.LoadWorkers(path)
+	if err != nil || len(loaded) != len(want) {
+		t.Fatalf("load merged roster: %v (%d entries)", err, len(loaded))
+	}
+}
+
+func readRoster(t *testing.T, path string) map[string]json.RawMessage {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read roster: %v", err)
+	}
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("decode roster: %v", err)
+	}
+	return state
+}
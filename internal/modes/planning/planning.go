@@ -26,6 +26,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kingrea/The-Lattice/internal/modes"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 	"github.com/kingrea/The-Lattice/internal/skills"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 )
@@ -108,14 +109,85 @@ type Mode struct {
 
 	// User decision state
 	cursorPos int // 0 = proceed, 1 = keep chatting
+
+	// Session supervision: when the current phase's sessions launched and how
+	// long each phase may run before its windows are killed.
+	launcher      sessionLauncher
+	now           func() time.Time
+	phaseStarted  time.Time
+	phaseTimeouts map[planningPhase]time.Duration
+
+	// autoProceedWhenClean skips the user decision when every review so far
+	// is clean. The project config can also enable it.
+	autoProceedWhenClean bool
+
+	// providedPlan accepts MODULES.md and PLAN.md authored outside lattice:
+	// planning validates them and starts at the staff review instead of
+	// generating an action plan. The project config can also enable it.
+	providedPlan bool
+}
+
+// defaultPhaseTimeouts are deliberately generous: the interactive phases wait
+// on the user, the rest on a single unattended opencode run.
+var defaultPhaseTimeouts = map[planningPhase]time.Duration{
+	phaseAnchorDocs:         4 * time.Hour,
+	phaseActionPlan:         time.Hour,
+	phaseStaffReview:        time.Hour,
+	phaseStaffIncorporation: time.Hour,
+	phasePlanChat:           4 * time.Hour,
+	phaseParallelReviews:    time.Hour,
+	phaseConsolidation:      time.Hour,
+	phaseBeadCreation:       time.Hour,
+}
+
+// Option customizes the planning mode.
+type Option func(*Mode)
+
+// WithSessionTimeout caps every planning phase at d instead of the per-phase
+// defaults. A zero or negative duration disables the timeout.
+func WithSessionTimeout(d time.Duration) Option {
+	return func(m *Mode) {
+		for phase := range m.phaseTimeouts {
+			m.phaseTimeouts[phase] = d
+		}
+	}
+}
+
+// WithAutoProceedWhenClean skips the proceed/keep-chatting decision when no
+// review flagged blocking issues.
+func WithAutoProceedWhenClean(enabled bool) Option {
+	return func(m *Mode) {
+		m.autoProceedWhenClean = enabled
+	}
+}
+
+// WithProvidedPlan starts planning at the staff review using MODULES.md and
+// PLAN.md written outside lattice. Planning fails instead of regenerating
+// them when either file is missing or malformed.
+func WithProvidedPlan(enabled bool) Option {
+	return func(m *Mode) {
+		m.providedPlan = enabled
+	}
 }
 
 // New creates a new Planning mode
-func New() *Mode {
-	return &Mode{
-		BaseMode: modes.NewBaseMode("Planning Session", workflow.PhasePlanning),
-		phase:    phaseInit,
+func New(opts ...Option) *Mode {
+	m := &Mode{
+		BaseMode:      modes.NewBaseMode("Planning Session", workflow.PhasePlanning),
+		phase:         phaseInit,
+		launcher:      tmuxLauncher{},
+		now:           time.Now,
+		phaseTimeouts: make(map[planningPhase]time.Duration, len(defaultPhaseTimeouts)),
+	}
+	for phase, limit := range defaultPhaseTimeouts {
+		m.phaseTimeouts[phase] = limit
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
 	}
+	return m
 }
 
 // Init initializes the planning mode
@@ -181,14 +253,26 @@ func (m *Mode) Init(ctx *modes.ModeContext) tea.Cmd {
 		if fileExists(wf.ReviewPragmatistPath()) || fileExists(wf.ReviewSimplifierPath()) ||
 			fileExists(wf.ReviewAdvocatePath()) || fileExists(wf.ReviewSkepticPath()) {
 			m.phase = phaseParallelReviews
+			m.phaseStarted = m.now()
 			m.SetStatusMsg("Waiting for parallel reviews to complete...")
 			return m.pollForCompletion()
 		}
 
 		// Staff feedback has been applied—ask the user to review MODULES/PLAN
-		m.phase = phaseUserDecision
-		m.SetStatusMsg("Staff feedback applied. Review MODULES.md and PLAN.md to decide next steps.")
-		return nil
+		return m.enterUserDecision("Staff feedback applied. Review MODULES.md and PLAN.md to decide next steps.")
+	}
+
+	// Externally authored plan files replace the anchor docs and action plan
+	if (m.providedPlan || ctx.Config.ProvidedPlan()) && !fileExists(wf.StaffReviewPath()) {
+		if err := validateProvidedPlan(wf); err != nil {
+			m.SetStatusMsg(fmt.Sprintf("Provided plan rejected: %v", err))
+			return func() tea.Msg {
+				return modes.ModeErrorMsg{Error: err}
+			}
+		}
+		m.phase = phaseStaffReview
+		m.SetStatusMsg("Using the provided action plan, starting Staff Engineer review...")
+		return m.startStaffReview()
 	}
 
 	// Check if action plan exists but staff review doesn't
@@ -279,9 +363,7 @@ func (m *Mode) Update(msg tea.Msg) (modes.Mode, tea.Cmd) {
 
 	case staffFeedbackAppliedMsg:
 		m.killWindow()
-		m.phase = phaseUserDecision
-		m.SetStatusMsg("Staff feedback applied. Review the updated plan and decide.")
-		return m, nil
+		return m, m.enterUserDecision("Staff feedback applied. Review the updated plan and decide.")
 
 	case parallelReviewsCompleteMsg:
 		m.killAllWindows()
@@ -313,6 +395,16 @@ func (m *Mode) Update(msg tea.Msg) (modes.Mode, tea.Cmd) {
 	case pollTickMsg:
 		return m, m.pollForCompletion()
 
+	case phaseTimeoutMsg:
+		if msg.phase != m.phase {
+			return m, nil
+		}
+		m.killAllWindows()
+		err := fmt.Errorf("planning %s timed out after %s; re-open planning to retry", phaseLabel(msg.phase), msg.limit)
+		return m, func() tea.Msg {
+			return modes.ModeErrorMsg{Error: err}
+		}
+
 	case modes.ModeErrorMsg:
 		m.SetStatusMsg(fmt.Sprintf("Error: %v", msg.Error))
 		return m, nil
@@ -423,13 +515,41 @@ type beadsCreatedMsg struct{}
 type planChatCompleteMsg struct{}
 type pollTickMsg struct{}
 
+// phaseTimeoutMsg reports that a phase's sessions ran past their limit.
+type phaseTimeoutMsg struct {
+	phase planningPhase
+	limit time.Duration
+}
+
+// enterUserDecision shows the decision screen, or starts the parallel reviews
+// directly when auto-proceed is enabled and every review so far is clean.
+// This screen, after the staff review, is the only manual decision in
+// planning: parallelReviewsCompleteMsg already moves on to consolidation, so
+// the verdict gate lives here rather than after the parallel reviews.
+func (m *Mode) enterUserDecision(status string) tea.Cmd {
+	ctx := m.Context()
+	if m.autoProceedWhenClean || ctx.Config.AutoProceedWhenClean() {
+		clean, reason := reviewsClean(ctx.Workflow)
+		if clean {
+			m.clearPlanChatMarkers()
+			m.phase = phaseParallelReviews
+			m.SetStatusMsg("Reviews flagged no blocking issues. Starting parallel reviews...")
+			return m.startParallelReviews()
+		}
+		status = fmt.Sprintf("%s (auto-proceed held: %s)", status, reason)
+	}
+	m.phase = phaseUserDecision
+	m.SetStatusMsg(status)
+	return nil
+}
+
 // startAnchorDocsSession spawns an opencode session with the lattice-planning skill
 func (m *Mode) startAnchorDocsSession() tea.Cmd {
 	return func() tea.Msg {
 		ctx := m.Context()
 
 		m.windowName = fmt.Sprintf("lattice-planning-%d", time.Now().Unix())
-		if err := createTmuxWindow(m.windowName); err != nil {
+		if err := m.launcher.CreateWindow(m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to create tmux window: %w", err)}
 		}
 
@@ -448,10 +568,11 @@ func (m *Mode) startAnchorDocsSession() tea.Cmd {
 			skillPath, planDir,
 		)
 
-		if err := runOpenCode(prompt, m.windowName); err != nil {
+		if err := m.launcher.RunOpenCode(prompt, m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to start opencode: %w", err)}
 		}
 
+		m.phaseStarted = m.now()
 		return pollTickMsg{}
 	}
 }
@@ -463,7 +584,7 @@ func (m *Mode) startActionPlanSession() tea.Cmd {
 
 		m.killWindow()
 		m.windowName = fmt.Sprintf("lattice-action-%d", time.Now().Unix())
-		if err := createTmuxWindow(m.windowName); err != nil {
+		if err := m.launcher.CreateWindow(m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to create tmux window: %w", err)}
 		}
 
@@ -483,10 +604,11 @@ func (m *Mode) startActionPlanSession() tea.Cmd {
 			planDir, actionDir, actionDir,
 		)
 
-		if err := runOpenCode(prompt, m.windowName); err != nil {
+		if err := m.launcher.RunOpenCode(prompt, m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to start opencode: %w", err)}
 		}
 
+		m.phaseStarted = m.now()
 		return pollTickMsg{}
 	}
 }
@@ -498,7 +620,7 @@ func (m *Mode) startStaffReview() tea.Cmd {
 
 		m.killWindow()
 		m.windowName = fmt.Sprintf("staff-engineer-%d", time.Now().Unix())
-		if err := createTmuxWindow(m.windowName); err != nil {
+		if err := m.launcher.CreateWindow(m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to create tmux window: %w", err)}
 		}
 
@@ -517,14 +639,16 @@ func (m *Mode) startStaffReview() tea.Cmd {
 				"- What advice would you give before implementation begins? "+
 				"Write your review to %s. "+
 				"Be thorough but constructive. This review will inform the final plan. "+
+				"%s "+
 				"Do not end until your review is written.",
-			planDir, actionDir, reviewPath,
+			planDir, actionDir, reviewPath, verdictInstructions,
 		)
 
-		if err := runOpenCode(prompt, m.windowName); err != nil {
+		if err := m.launcher.RunOpenCode(prompt, m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to start opencode: %w", err)}
 		}
 
+		m.phaseStarted = m.now()
 		return pollTickMsg{}
 	}
 }
@@ -536,7 +660,7 @@ func (m *Mode) startStaffFeedbackIncorporation() tea.Cmd {
 
 		m.killWindow()
 		m.windowName = fmt.Sprintf("staff-incorporation-%d", time.Now().Unix())
-		if err := createTmuxWindow(m.windowName); err != nil {
+		if err := m.launcher.CreateWindow(m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to create tmux window: %w", err)}
 		}
 
@@ -556,10 +680,11 @@ func (m *Mode) startStaffFeedbackIncorporation() tea.Cmd {
 			reviewPath, planDir, actionDir, markerPath,
 		)
 
-		if err := runOpenCode(prompt, m.windowName); err != nil {
+		if err := m.launcher.RunOpenCode(prompt, m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to start opencode: %w", err)}
 		}
 
+		m.phaseStarted = m.now()
 		return pollTickMsg{}
 	}
 }
@@ -581,7 +706,7 @@ func (m *Mode) startPlanChatSession() tea.Cmd {
 
 		m.killWindow()
 		m.windowName = fmt.Sprintf("plan-chat-%d", time.Now().Unix())
-		if err := createTmuxWindow(m.windowName); err != nil {
+		if err := m.launcher.CreateWindow(m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to create tmux window: %w", err)}
 		}
 
@@ -600,10 +725,11 @@ func (m *Mode) startPlanChatSession() tea.Cmd {
 			reviewPath, planDir, actionDir, readyPath,
 		)
 
-		if err := runOpenCode(prompt, m.windowName); err != nil {
+		if err := m.launcher.RunOpenCode(prompt, m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to start plan chat opencode: %w", err)}
 		}
 
+		m.phaseStarted = m.now()
 		return pollTickMsg{}
 	}
 }
@@ -623,7 +749,7 @@ func (m *Mode) startParallelReviews() tea.Cmd {
 			windowName := fmt.Sprintf("reviewer-%s-%d", strings.ToLower(r.name), time.Now().Unix())
 			m.windowNames[i] = windowName
 
-			if err := createTmuxWindow(windowName); err != nil {
+			if err := m.launcher.CreateWindow(windowName); err != nil {
 				return modes.ModeErrorMsg{Error: fmt.Errorf("failed to create window for %s: %w", r.name, err)}
 			}
 
@@ -632,11 +758,12 @@ func (m *Mode) startParallelReviews() tea.Cmd {
 				"%s "+
 					"Read all planning documents from %s and action plan from %s. "+
 					"Write your review to %s. "+
-					"Be specific and actionable. Do not end until your review file is written.",
-				r.personality, planDir, actionDir, reviewPath,
+					"Be specific and actionable. %s "+
+					"Do not end until your review file is written.",
+				r.personality, planDir, actionDir, reviewPath, verdictInstructions,
 			)
 
-			if err := runOpenCode(prompt, windowName); err != nil {
+			if err := m.launcher.RunOpenCode(prompt, windowName); err != nil {
 				return modes.ModeErrorMsg{Error: fmt.Errorf("failed to start %s review: %w", r.name, err)}
 			}
 
@@ -644,6 +771,7 @@ func (m *Mode) startParallelReviews() tea.Cmd {
 			time.Sleep(500 * time.Millisecond)
 		}
 
+		m.phaseStarted = m.now()
 		return pollTickMsg{}
 	}
 }
@@ -655,7 +783,7 @@ func (m *Mode) startConsolidation() tea.Cmd {
 
 		m.killAllWindows()
 		m.windowName = fmt.Sprintf("consolidation-%d", time.Now().Unix())
-		if err := createTmuxWindow(m.windowName); err != nil {
+		if err := m.launcher.CreateWindow(m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to create tmux window: %w", err)}
 		}
 
@@ -684,10 +812,11 @@ func (m *Mode) startConsolidation() tea.Cmd {
 			planDir, actionDir, actionDir, actionDir, actionDir, actionDir, actionDir, markerPath,
 		)
 
-		if err := runOpenCode(prompt, m.windowName); err != nil {
+		if err := m.launcher.RunOpenCode(prompt, m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to start consolidation: %w", err)}
 		}
 
+		m.phaseStarted = m.now()
 		return pollTickMsg{}
 	}
 }
@@ -704,7 +833,7 @@ func (m *Mode) startBeadCreation() tea.Cmd {
 
 		m.killAllWindows()
 		m.windowName = fmt.Sprintf("bead-creation-%d", time.Now().Unix())
-		if err := createTmuxWindow(m.windowName); err != nil {
+		if err := m.launcher.CreateWindow(m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to create tmux window: %w", err)}
 		}
 
@@ -730,10 +859,11 @@ func (m *Mode) startBeadCreation() tea.Cmd {
 			projectDir, actionDir, actionDir, markerPath,
 		)
 
-		if err := runOpenCode(prompt, m.windowName); err != nil {
+		if err := m.launcher.RunOpenCode(prompt, m.windowName); err != nil {
 			return modes.ModeErrorMsg{Error: fmt.Errorf("failed to start bead creation: %w", err)}
 		}
 
+		m.phaseStarted = m.now()
 		return pollTickMsg{}
 	}
 }
@@ -741,52 +871,61 @@ func (m *Mode) startBeadCreation() tea.Cmd {
 // pollForCompletion checks if the required files exist
 func (m *Mode) pollForCompletion() tea.Cmd {
 	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
-		ctx := m.Context()
-		wf := ctx.Workflow
+		return m.checkPhase()
+	})
+}
 
-		switch m.phase {
-		case phaseAnchorDocs:
-			if wf.AnchorDocsComplete() {
-				return anchorDocsCompleteMsg{}
-			}
-		case phaseActionPlan:
-			if fileExists(wf.ModulesPath()) && fileExists(wf.ActionPlanPath()) {
-				return actionPlanCompleteMsg{}
-			}
-		case phaseStaffReview:
-			if fileExists(wf.StaffReviewPath()) {
-				return staffReviewCompleteMsg{}
-			}
-		case phaseStaffIncorporation:
-			if fileExists(wf.StaffFeedbackAppliedPath()) {
-				return staffFeedbackAppliedMsg{}
-			}
-		case phaseParallelReviews:
-			if wf.AllReviewsComplete() {
-				return parallelReviewsCompleteMsg{}
-			}
-		case phaseConsolidation:
-			if fileExists(wf.ReviewsAppliedPath()) {
-				return consolidationCompleteMsg{}
-			}
-		case phaseBeadCreation:
-			if fileExists(wf.BeadsCreatedPath()) {
-				return beadsCreatedMsg{}
-			}
-		case phasePlanChat:
-			if fileExists(wf.PlanChatReadyPath()) {
-				return planChatCompleteMsg{}
-			}
+// checkPhase reports the current phase's completion message, a timeout once
+// the phase has run past its limit, or another poll tick.
+func (m *Mode) checkPhase() tea.Msg {
+	ctx := m.Context()
+	wf := ctx.Workflow
+
+	switch m.phase {
+	case phaseAnchorDocs:
+		if wf.AnchorDocsComplete() {
+			return anchorDocsCompleteMsg{}
+		}
+	case phaseActionPlan:
+		if fileExists(wf.ModulesPath()) && fileExists(wf.ActionPlanPath()) {
+			return actionPlanCompleteMsg{}
+		}
+	case phaseStaffReview:
+		if fileExists(wf.StaffReviewPath()) {
+			return staffReviewCompleteMsg{}
+		}
+	case phaseStaffIncorporation:
+		if fileExists(wf.StaffFeedbackAppliedPath()) {
+			return staffFeedbackAppliedMsg{}
+		}
+	case phaseParallelReviews:
+		if wf.AllReviewsComplete() {
+			return parallelReviewsCompleteMsg{}
+		}
+	case phaseConsolidation:
+		if fileExists(wf.ReviewsAppliedPath()) {
+			return consolidationCompleteMsg{}
 		}
+	case phaseBeadCreation:
+		if fileExists(wf.BeadsCreatedPath()) {
+			return beadsCreatedMsg{}
+		}
+	case phasePlanChat:
+		if fileExists(wf.PlanChatReadyPath()) {
+			return planChatCompleteMsg{}
+		}
+	}
 
-		return pollTickMsg{}
-	})
+	if limit := m.phaseTimeouts[m.phase]; limit > 0 && !m.phaseStarted.IsZero() && m.now().Sub(m.phaseStarted) >= limit {
+		return phaseTimeoutMsg{phase: m.phase, limit: limit}
+	}
+	return pollTickMsg{}
 }
 
 // killWindow cleans up a single tmux window
 func (m *Mode) killWindow() {
 	if m.windowName != "" {
-		killTmuxWindow(m.windowName)
+		m.launcher.KillWindow(m.windowName)
 		m.windowName = ""
 	}
 }
@@ -796,7 +935,7 @@ func (m *Mode) killAllWindows() {
 	m.killWindow()
 	for _, name := range m.windowNames {
 		if name != "" {
-			killTmuxWindow(name)
+			m.launcher.KillWindow(name)
 		}
 	}
 	m.windowNames = nil
@@ -821,6 +960,48 @@ func removeFileIfExists(path string) {
 	}
 }
 
+// phaseLabel names a phase for status and error messages.
+func phaseLabel(phase planningPhase) string {
+	switch phase {
+	case phaseAnchorDocs:
+		return "anchor docs session"
+	case phaseActionPlan:
+		return "action plan session"
+	case phaseStaffReview:
+		return "staff review session"
+	case phaseStaffIncorporation:
+		return "staff feedback session"
+	case phasePlanChat:
+		return "plan chat session"
+	case phaseParallelReviews:
+		return "parallel reviews"
+	case phaseConsolidation:
+		return "consolidation session"
+	case phaseBeadCreation:
+		return "bead creation session"
+	default:
+		return "session"
+	}
+}
+
+// sessionLauncher opens, drives, and closes the windows hosting opencode.
+type sessionLauncher interface {
+	CreateWindow(name string) error
+	RunOpenCode(prompt, windowName string) error
+	KillWindow(name string) error
+}
+
+// tmuxLauncher runs planning sessions in tmux windows.
+type tmuxLauncher struct{}
+
+func (tmuxLauncher) CreateWindow(name string) error { return createTmuxWindow(name) }
+
+func (tmuxLauncher) RunOpenCode(prompt, windowName string) error {
+	return runOpenCode(prompt, windowName)
+}
+
+func (tmuxLauncher) KillWindow(name string) error { return killTmuxWindow(name) }
+
 // Helper functions for tmux and opencode
 
 func createTmuxWindow(name string) error {
@@ -834,9 +1015,10 @@ func killTmuxWindow(name string) error {
 }
 
 func runOpenCode(prompt string, windowName string) error {
-	escapedPrompt := strings.ReplaceAll(prompt, `"`, `\"`)
-	escapedPrompt = strings.ReplaceAll(escapedPrompt, "\n", " ")
-	opencodeCmd := fmt.Sprintf(`opencode --prompt "%s"`, escapedPrompt)
+	opencodeCmd, err := opencode.Command(prompt, "")
+	if err != nil {
+		return err
+	}
 	cmd := exec.Command("tmux", "send-keys", "-t", windowName, opencodeCmd, "Enter")
 	return cmd.Run()
 }
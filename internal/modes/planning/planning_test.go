@@ -0,0 +1,185 @@
+package planning
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/modes"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+type fakeLauncher struct {
+	created []string
+	killed  []string
+	prompts []string
+}
+
+func (f *fakeLauncher) CreateWindow(name string) error {
+	f.created = append(f.created, name)
+	return nil
+}
+
+func (f *fakeLauncher) RunOpenCode(prompt, windowName string) error {
+	f.prompts = append(f.prompts, prompt)
+	return nil
+}
+
+func (f *fakeLauncher) KillWindow(name string) error {
+	f.killed = append(f.killed, name)
+	return nil
+}
+
+func TestStalledPhaseTimesOut(t *testing.T) {
+	wf := workflow.New(filepath.Join(t.TempDir(), ".lattice"))
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	launcher := &fakeLauncher{}
+	clock := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	m := New(WithSessionTimeout(10 * time.Minute))
+	m.launcher = launcher
+	m.now = func() time.Time { return clock }
+	m.SetContext(&modes.ModeContext{Workflow: wf})
+	m.phase = phaseActionPlan
+
+	if _, ok := m.startActionPlanSession()().(pollTickMsg); !ok {
+		t.Fatalf("expected the session launch to start polling")
+	}
+	if len(launcher.created) != 1 || len(launcher.prompts) != 1 {
+		t.Fatalf("expected one launched session, got windows=%v", launcher.created)
+	}
+	window := launcher.created[0]
+
+	clock = clock.Add(9 * time.Minute)
+	if _, ok := m.checkPhase().(pollTickMsg); !ok {
+		t.Fatalf("phase should keep polling before its timeout")
+	}
+
+	clock = clock.Add(time.Minute)
+	msg, ok := m.checkPhase().(phaseTimeoutMsg)
+	if !ok {
+		t.Fatalf("expected a timeout once the phase stalls past its limit")
+	}
+	_, cmd := m.Update(msg)
+	if cmd == nil {
+		t.Fatalf("expected the timeout to surface an error")
+	}
+	errMsg, ok := cmd().(modes.ModeErrorMsg)
+	if !ok || !strings.Contains(errMsg.Error.Error(), "action plan session timed out after 10m0s") {
+		t.Fatalf("unexpected timeout result: %#v", errMsg)
+	}
+	if len(launcher.killed) != 1 || launcher.killed[0] != window {
+		t.Fatalf("expected window %s to be killed, got %v", window, launcher.killed)
+	}
+	if m.windowName != "" {
+		t.Fatalf("timed-out window should be cleared, got %q", m.windowName)
+	}
+}
+
+func TestSessionTimeoutCanBeDisabled(t *testing.T) {
+	wf := workflow.New(filepath.Join(t.TempDir(), ".lattice"))
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	m := New(WithSessionTimeout(0))
+	m.launcher = &fakeLauncher{}
+	m.SetContext(&modes.ModeContext{Workflow: wf})
+	m.phase = phaseStaffReview
+	m.phaseStarted = time.Now().Add(-72 * time.Hour)
+
+	if _, ok := m.checkPhase().(pollTickMsg); !ok {
+		t.Fatalf("disabled timeout should keep polling")
+	}
+}
+
+func TestCleanReviewsAutoProceedPastDecision(t *testing.T) {
+	wf := workflow.New(filepath.Join(t.TempDir(), ".lattice"))
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	review := "## Staff review\nModules are well scoped.\n\nVerdict: clean\n"
+	if err := os.WriteFile(wf.StaffReviewPath(), []byte(review), 0644); err != nil {
+		t.Fatalf("write staff review: %v", err)
+	}
+	launcher := &fakeLauncher{}
+	m := New(WithAutoProceedWhenClean(true))
+	m.launcher = launcher
+	m.SetContext(&modes.ModeContext{Workflow: wf})
+	m.phase = phaseStaffIncorporation
+
+	_, cmd := m.Update(staffFeedbackAppliedMsg{})
+	if m.phase != phaseParallelReviews {
+		t.Fatalf("expected clean reviews to skip the decision, got phase %s", phaseLabel(m.phase))
+	}
+	if cmd == nil {
+		t.Fatalf("expected the parallel reviews to start")
+	}
+	if _, ok := cmd().(pollTickMsg); !ok {
+		t.Fatalf("expected the parallel reviews to start polling")
+	}
+	if len(launcher.prompts) != len(reviewers) {
+		t.Fatalf("expected %d reviewer sessions, got %d", len(reviewers), len(launcher.prompts))
+	}
+	if !strings.Contains(launcher.prompts[0], "Verdict: clean") {
+		t.Fatalf("reviewer prompt should ask for a verdict line: %s", launcher.prompts[0])
+	}
+
+	blocking := "Verdict: clean\n[CRITICAL] The plan has no rollback story.\n"
+	if err := os.WriteFile(wf.StaffReviewPath(), []byte(blocking), 0644); err != nil {
+		t.Fatalf("write staff review: %v", err)
+	}
+	m.phase = phaseStaffIncorporation
+	if _, cmd := m.Update(staffFeedbackAppliedMsg{}); cmd != nil || m.phase != phaseUserDecision {
+		t.Fatalf("a critical issue should hold the decision screen, got phase %s", phaseLabel(m.phase))
+	}
+
+	// There is no decision after the parallel reviews for the gate to skip.
+	m.phase = phaseParallelReviews
+	if m.Update(parallelReviewsCompleteMsg{}); m.phase != phaseConsolidation {
+		t.Fatalf("finished parallel reviews should go straight to consolidation, got phase %s", phaseLabel(m.phase))
+	}
+}
+
+func TestProvidedPlanSkipsActionPlanGeneration(t *testing.T) {
+	wf := workflow.New(filepath.Join(t.TempDir(), ".lattice"))
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	writeProvided := func(path, id, body string) {
+		t.Helper()
+		doc := "---\nlattice:\n  artifact: " + id + "\n  module: external\n  version: 1.0.0\n  created: 2024-01-01T09:00:00Z\n---\n\n" + body
+		if err := os.WriteFile(path, []byte(doc), 0644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	writeProvided(wf.ModulesPath(), "modules-doc", "# Modules\n\n## api\nServes requests.\n")
+
+	launcher := &fakeLauncher{}
+	m := New(WithProvidedPlan(true))
+	m.launcher = launcher
+	cmd := m.Init(&modes.ModeContext{Workflow: wf})
+	errMsg, ok := cmd().(modes.ModeErrorMsg)
+	if !ok || !strings.Contains(errMsg.Error.Error(), "action-plan") {
+		t.Fatalf("a missing PLAN.md should be rejected, got %#v", errMsg)
+	}
+	if len(launcher.created) != 0 {
+		t.Fatalf("a rejected plan must not launch sessions, got %v", launcher.created)
+	}
+
+	writeProvided(wf.ActionPlanPath(), "action-plan", "# Plan\n\n1. Build the api module.\n")
+	m = New(WithProvidedPlan(true))
+	m.launcher = launcher
+	cmd = m.Init(&modes.ModeContext{Workflow: wf})
+	if m.phase != phaseStaffReview {
+		t.Fatalf("expected planning to start at the staff review, got phase %s", phaseLabel(m.phase))
+	}
+	if _, ok := cmd().(pollTickMsg); !ok {
+		t.Fatalf("expected the staff review to start polling")
+	}
+	if len(launcher.created) != 1 || !strings.HasPrefix(launcher.created[0], "staff-engineer-") {
+		t.Fatalf("expected only the staff review session, got %v", launcher.created)
+	}
+}
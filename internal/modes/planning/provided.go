@@ -0,0 +1,50 @@
+package planning
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+// validateProvidedPlan checks MODULES.md and PLAN.md authored outside lattice
+// before planning skips straight to the staff review. Each file must exist,
+// carry lattice front matter naming the artifact it claims to be, and have a
+// body with at least one markdown heading.
+func validateProvidedPlan(wf *workflow.Workflow) error {
+	for _, ref := range []artifact.ArtifactRef{artifact.ModulesDoc, artifact.ActionPlanDoc} {
+		if err := validateProvidedDoc(ref, ref.Path(wf)); err != nil {
+			return fmt.Errorf("provided plan: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateProvidedDoc(ref artifact.ArtifactRef, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", ref.ID, err)
+	}
+	meta, body, err := artifact.ParseFrontMatter(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if meta.ArtifactID != ref.ID {
+		return fmt.Errorf("%s: front matter names artifact %q, want %q", path, meta.ArtifactID, ref.ID)
+	}
+	if !hasHeading(body) {
+		return fmt.Errorf("%s: body has no markdown heading", path)
+	}
+	return nil
+}
+
+func hasHeading(body []byte) bool {
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("#")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package planning
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+// criticalTag marks a blocking issue in a review, matching the tag the
+// consolidation module counts.
+const criticalTag = "[CRITICAL]"
+
+// verdictInstructions is appended to review prompts so planning can tell a
+// clean review from one that flags blocking issues.
+const verdictInstructions = "Put each blocking issue on its own line starting with " + criticalTag + ". " +
+	"End the review with a single line `Verdict: clean` if nothing blocks proceeding, or `Verdict: blocking` otherwise."
+
+type reviewVerdict int
+
+const (
+	verdictMissing reviewVerdict = iota
+	verdictClean
+	verdictBlocking
+)
+
+// parseReviewVerdict reads the verdict a reviewer recorded. Any critical-tagged
+// line makes the review blocking regardless of its verdict line; a review with
+// neither signal reports verdictMissing.
+func parseReviewVerdict(data []byte) reviewVerdict {
+	verdict := verdictMissing
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.Trim(strings.TrimSpace(scanner.Text()), "*_`"))
+		if len(line) >= len(criticalTag) && strings.EqualFold(line[:len(criticalTag)], criticalTag) {
+			return verdictBlocking
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "verdict") {
+			continue
+		}
+		switch strings.ToLower(strings.Trim(strings.TrimSpace(value), "*_`.")) {
+		case "clean", "approve", "approved":
+			verdict = verdictClean
+		default:
+			verdict = verdictBlocking
+		}
+	}
+	return verdict
+}
+
+// reviewsClean reports whether every review written so far carries a clean
+// verdict. The staff review must exist; parallel reviewer files are checked
+// when present. The second return names the first review that is not clean.
+func reviewsClean(wf *workflow.Workflow) (bool, string) {
+	paths := []string{wf.StaffReviewPath()}
+	for _, path := range []string{wf.ReviewPragmatistPath(), wf.ReviewSimplifierPath(), wf.ReviewAdvocatePath(), wf.ReviewSkepticPath()} {
+		if fileExists(path) {
+			paths = append(paths, path)
+		}
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Sprintf("cannot read %s", path)
+		}
+		switch parseReviewVerdict(data) {
+		case verdictClean:
+		case verdictBlocking:
+			return false, fmt.Sprintf("%s flagged blocking issues", path)
+		default:
+			return false, fmt.Sprintf("%s has no verdict", path)
+		}
+	}
+	return true, ""
+}
@@ -1,6 +1,8 @@
 package module
 
 import (
+	"io"
+
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/config"
 	"github.com/kingrea/The-Lattice/internal/logbook"
@@ -17,6 +19,10 @@ type ModuleContext struct {
 	Logbook      *logbook.Logbook
 	Artifacts    *artifact.Store
 	OriginMode   string
+	// CommandLog receives subprocess output for the current run; see OpenRunLog.
+	CommandLog io.Writer
+	// RunLogPath is the file behind CommandLog, when one is attached.
+	RunLogPath string
 }
 
 // NewContext builds a ModuleContext with a fresh ArtifactStore.
@@ -41,6 +41,10 @@ type ConcurrencyProfile struct {
 	// Exclusive forces the module to run without any other modules occupying the
 	// workflow engine. Useful for resources that cannot be shared safely.
 	Exclusive bool
+	// Class groups modules that contend for the same external resource, such
+	// as bd or tmux. A workflow's runtime.class_limits caps how many modules of
+	// one class run at once. Empty means the module belongs to no class.
+	Class string
 }
 
 func (p ConcurrencyProfile) slotsOrDefault() int {
@@ -62,6 +66,11 @@ func (i Info) SlotCost() int {
 	return i.Concurrency.slotsOrDefault()
 }
 
+// ConcurrencyClass returns the class the module shares limits with, or "".
+func (i Info) ConcurrencyClass() string {
+	return i.Concurrency.Class
+}
+
 // RequiresExclusiveExecution reports whether the module must run without other
 // concurrent modules.
 func (i Info) RequiresExclusiveExecution() bool {
@@ -16,11 +16,12 @@ type Factory func(Config) (Module, error)
 type Registry struct {
 	mu        sync.RWMutex
 	factories map[string]Factory
+	schemas   map[string]ConfigSchema
 }
 
 // NewRegistry returns an empty registry.
 func NewRegistry() *Registry {
-	return &Registry{factories: map[string]Factory{}}
+	return &Registry{factories: map[string]Factory{}, schemas: map[string]ConfigSchema{}}
 }
 
 // Register installs a module factory. Returns an error if the ID already exists.
@@ -47,14 +48,52 @@ func (r *Registry) MustRegister(id string, factory Factory) {
 	}
 }
 
-// Resolve constructs a module by ID.
+// RegisterWithSchema installs a module factory whose config is validated
+// against schema on every Resolve.
+func (r *Registry) RegisterWithSchema(id string, schema ConfigSchema, factory Factory) error {
+	if err := r.Register(id, factory); err != nil {
+		return err
+	}
+	if schema != nil {
+		r.mu.Lock()
+		r.schemas[id] = schema
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// MustRegisterWithSchema panics if registration fails.
+func (r *Registry) MustRegisterWithSchema(id string, schema ConfigSchema, factory Factory) {
+	if err := r.RegisterWithSchema(id, schema, factory); err != nil {
+		panic(err)
+	}
+}
+
+// Schema returns the config schema declared for id, if any.
+func (r *Registry) Schema(id string) (ConfigSchema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, ok := r.schemas[id]
+	return schema, ok
+}
+
+// Resolve constructs a module by ID. Modules registered with a schema reject
+// config keys the schema does not declare and values of the wrong type.
 func (r *Registry) Resolve(id string, cfg Config) (Module, error) {
 	r.mu.RLock()
 	factory, ok := r.factories[id]
+	schema, hasSchema := r.schemas[id]
 	r.mu.RUnlock()
 	if !ok {
 		return nil, fmt.Errorf("module: unknown id %s", id)
 	}
+	if hasSchema {
+		validated, err := schema.Apply(id, cfg)
+		if err != nil {
+			return nil, err
+		}
+		cfg = validated
+	}
 	module, err := factory(cfg)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,77 @@
+package module
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+)
+
+// RunLogPath returns where the captured subprocess output of a module run
+// started at the given time is stored:
+// .lattice/logs/modules/<id>-<timestamp>.log.
+func RunLogPath(cfg *config.Config, id string, started time.Time) string {
+	name := fmt.Sprintf("%s-%s.log", id, started.UTC().Format("20060102T150405Z"))
+	return filepath.Join(cfg.LogsDir(), "modules", name)
+}
+
+// OpenRunLog creates the per-run log file for id and returns a context whose
+// LogCommand calls append to it. Callers close the file once the run returns.
+func (ctx *ModuleContext) OpenRunLog(id string, started time.Time) (*ModuleContext, *os.File, error) {
+	if ctx == nil || ctx.Config == nil {
+		return nil, nil, fmt.Errorf("module: config is required for run logs")
+	}
+	path := RunLogPath(ctx.Config, id, started)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("module: create run log dir: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("module: open run log: %w", err)
+	}
+	clone := *ctx
+	clone.CommandLog = &lockedWriter{w: file}
+	clone.RunLogPath = path
+	return &clone, file, nil
+}
+
+// LogCommand records a finished subprocess and its output in the run log. It
+// is a no-op when the context has no run log attached.
+func (ctx *ModuleContext) LogCommand(dir, name string, args []string, output []byte, runErr error) {
+	if ctx == nil || ctx.CommandLog == nil {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ %s", strings.Join(append([]string{name}, args...), " "))
+	if dir != "" {
+		fmt.Fprintf(&b, "  (in %s)", dir)
+	}
+	b.WriteString("\n")
+	if len(output) > 0 {
+		b.Write(output)
+		if output[len(output)-1] != '\n' {
+			b.WriteString("\n")
+		}
+	}
+	if runErr != nil {
+		fmt.Fprintf(&b, "! %v\n", runErr)
+	}
+	_, _ = io.WriteString(ctx.CommandLog, b.String())
+}
+
+// lockedWriter serializes writes from modules that run commands concurrently.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
@@ -0,0 +1,139 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConfigType names the kind of value a config key accepts.
+type ConfigType string
+
+const (
+	ConfigString     ConfigType = "string"
+	ConfigInt        ConfigType = "int"
+	ConfigBool       ConfigType = "bool"
+	ConfigStringList ConfigType = "string list"
+	ConfigMap        ConfigType = "map"
+)
+
+// ConfigField describes one config key a module understands.
+type ConfigField struct {
+	Type ConfigType
+	// Default is filled in when the key is absent; nil leaves it unset.
+	Default     any
+	Description string
+}
+
+// ConfigSchema declares every config key a module accepts. The registry
+// validates resolve-time config against it so typos and mistyped values fail
+// loudly instead of being ignored.
+type ConfigSchema map[string]ConfigField
+
+// Apply checks cfg against the schema and returns a copy with defaults filled
+// in. Integers and booleans given as strings (as `--set` flags produce) are
+// converted to their typed values. Every unknown or mistyped key is reported.
+func (s ConfigSchema) Apply(moduleID string, cfg Config) (Config, error) {
+	out := make(Config, len(cfg)+len(s))
+	var errs []error
+	for _, key := range sortedConfigKeys(cfg) {
+		raw := cfg[key]
+		field, ok := s[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("module: %s config: unknown key %q (accepted keys: %s)", moduleID, key, strings.Join(s.keys(), ", ")))
+			continue
+		}
+		if raw == nil {
+			out[key] = nil
+			continue
+		}
+		value, err := field.Type.coerce(raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("module: %s config: %s %w", moduleID, key, err))
+			continue
+		}
+		out[key] = value
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	for key, field := range s {
+		if _, ok := out[key]; !ok && field.Default != nil {
+			out[key] = field.Default
+		}
+	}
+	return out, nil
+}
+
+func (s ConfigSchema) keys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedConfigKeys(cfg Config) []string {
+	keys := make([]string, 0, len(cfg))
+	for key := range cfg {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// coerce validates raw against t and returns the value modules should see.
+func (t ConfigType) coerce(raw any) (any, error) {
+	switch t {
+	case ConfigString:
+		if _, ok := raw.(string); ok {
+			return raw, nil
+		}
+	case ConfigInt:
+		switch v := raw.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == float64(int(v)) {
+				return int(v), nil
+			}
+		case string:
+			if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				return n, nil
+			}
+		}
+	case ConfigBool:
+		switch v := raw.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+	case ConfigStringList:
+		switch v := raw.(type) {
+		case string, []string:
+			return v, nil
+		case []any:
+			for _, item := range v {
+				if _, ok := item.(string); !ok {
+					return nil, fmt.Errorf("must be a %s, got a %T entry", t, item)
+				}
+			}
+			return v, nil
+		}
+	case ConfigMap:
+		if _, ok := raw.(map[string]any); ok {
+			return raw, nil
+		}
+	default:
+		return nil, fmt.Errorf("has unsupported schema type %q", t)
+	}
+	return nil, fmt.Errorf("must be a %s, got %T %v", t, raw, raw)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/modules/runtime"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 )
 
 const (
@@ -157,8 +158,10 @@ func killTmuxWindow(name string) {
 }
 
 func runOpenCode(window, prompt string) error {
-	escaped := strings.ReplaceAll(prompt, "\"", `\"`)
-	escaped = strings.ReplaceAll(escaped, "\n", " ")
-	cmd := exec.Command("tmux", "send-keys", "-t", window, fmt.Sprintf(`opencode --prompt "%s"`, escaped), "Enter")
+	command, err := opencode.Command(prompt, "")
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("tmux", "send-keys", "-t", window, command, "Enter")
 	return cmd.Run()
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/modules/runtime"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 )
 
 const (
@@ -59,7 +60,7 @@ func (m *BeadCreationModule) Run(ctx *module.ModuleContext) (module.Result, erro
 	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
-	if err := ensureBeadsInitialized(ctx.Config.ProjectDir); err != nil {
+	if err := ensureBeadsInitialized(ctx); err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
 	if missing, err := m.missingInput(ctx); err != nil {
@@ -135,7 +136,8 @@ func (m *BeadCreationModule) stopSession() {
 	m.windowName = ""
 }
 
-func ensureBeadsInitialized(projectDir string) error {
+func ensureBeadsInitialized(ctx *module.ModuleContext) error {
+	projectDir := ctx.Config.ProjectDir
 	needsInit, err := beadsInitRequired(projectDir)
 	if err != nil {
 		return err
@@ -143,7 +145,7 @@ func ensureBeadsInitialized(projectDir string) error {
 	if !needsInit {
 		return nil
 	}
-	return runBeadsInit(projectDir)
+	return runBeadsInit(ctx, projectDir)
 }
 
 func beadsInitRequired(projectDir string) (bool, error) {
@@ -168,13 +170,15 @@ func beadsInitRequired(projectDir string) (bool, error) {
 	return false, nil
 }
 
-func runBeadsInit(projectDir string) error {
+func runBeadsInit(ctx *module.ModuleContext, projectDir string) error {
 	cmd := exec.Command("bd", "init")
 	cmd.Dir = projectDir
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &out
-	if err := cmd.Run(); err != nil {
+	err := cmd.Run()
+	ctx.LogCommand(projectDir, "bd", []string{"init"}, out.Bytes(), err)
+	if err != nil {
 		trimmed := strings.TrimSpace(out.String())
 		if trimmed != "" {
 			return fmt.Errorf("bead-creation: bd init failed: %s: %w", trimmed, err)
@@ -201,8 +205,10 @@ func killTmuxWindow(name string) {
 }
 
 func runOpenCode(window, prompt string) error {
-	escaped := strings.ReplaceAll(prompt, "\"", `\"`)
-	escaped = strings.ReplaceAll(escaped, "\n", " ")
-	cmd := exec.Command("tmux", "send-keys", "-t", window, fmt.Sprintf(`opencode --prompt "%s"`, escaped), "Enter")
+	command, err := opencode.Command(prompt, "")
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("tmux", "send-keys", "-t", window, command, "Enter")
 	return cmd.Run()
 }
@@ -13,9 +13,18 @@ package consolidation
 //     `artifact.ReviewSimplifierDoc`, `artifact.ReviewAdvocateDoc`,
 //     `artifact.ReviewSkepticDoc`)
 //
+// Optional inputs:
+//   - REVIEW_META.md (`artifact.ReviewMetaDoc`) from the parallel-reviews
+//     meta-reviewer. It is added to the orchestrator prompt when present.
+//
 // Outputs:
 //   - Updated MODULES.md and PLAN.md with the consolidated changes attributed to
 //     the `consolidation` module
 //   - `.reviews-applied` marker (`artifact.ReviewsAppliedMarker`) signaling that
 //     the review feedback has been ingested and the plan is ready for bead
 //     creation
+//
+// Configuration:
+//   - `critical_threshold` (default 0, disabled) holds consolidation once that
+//     many reviewer files contain a line starting with `[CRITICAL]`. The run
+//     reports needs-input until `.critical-approved` exists in the action dir.
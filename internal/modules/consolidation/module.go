@@ -1,7 +1,10 @@
 package consolidation
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -9,6 +12,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/modules/runtime"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 )
 
 const (
@@ -16,11 +20,38 @@ const (
 	moduleVersion = "1.0.0"
 )
 
+// criticalThresholdKey is the module config key for the number of reviewers
+// that must flag critical issues before consolidation waits for approval.
+const criticalThresholdKey = "critical_threshold"
+
+// configSchema lists the config keys consolidation accepts.
+var configSchema = module.ConfigSchema{
+	criticalThresholdKey: {Type: module.ConfigInt, Description: "reviewers flagging critical issues before approval is required"},
+}
+
+// CriticalTag marks a line in a reviewer file as a critical issue. Reviewers
+// place it at the start of a line, optionally after a list bullet.
+const CriticalTag = "[CRITICAL]"
+
+// Option customizes the consolidation module.
+type Option func(*ConsolidationModule)
+
+// WithCriticalThreshold requires manual approval once n or more reviewers
+// flag critical issues. Zero disables the gate.
+func WithCriticalThreshold(n int) Option {
+	return func(m *ConsolidationModule) {
+		if n >= 0 {
+			m.criticalThreshold = n
+		}
+	}
+}
+
 // ConsolidationModule launches the orchestrator session that synthesizes all
 // reviewer feedback into MODULES.md and PLAN.md.
 type ConsolidationModule struct {
 	*module.Base
-	windowName string
+	windowName        string
+	criticalThreshold int
 }
 
 // Register installs the module factory.
@@ -28,13 +59,17 @@ func Register(reg *module.Registry) {
 	if reg == nil {
 		return
 	}
-	reg.MustRegister(moduleID, func(module.Config) (module.Module, error) {
-		return New(), nil
+	reg.MustRegisterWithSchema(moduleID, configSchema, func(cfg module.Config) (module.Module, error) {
+		opts, err := optionsFromConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return New(opts...), nil
 	})
 }
 
 // New configures the module description and IO contracts.
-func New() *ConsolidationModule {
+func New(opts ...Option) *ConsolidationModule {
 	info := module.Info{
 		ID:          moduleID,
 		Name:        "Consolidate Reviews",
@@ -59,7 +94,13 @@ func New() *ConsolidationModule {
 		artifact.ActionPlanDoc,
 		artifact.ReviewsAppliedMarker,
 	)
-	return &ConsolidationModule{Base: &base}
+	m := &ConsolidationModule{Base: &base}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
+	}
+	return m
 }
 
 // Run validates prerequisites and launches the tmux session when needed.
@@ -80,11 +121,31 @@ func (m *ConsolidationModule) Run(ctx *module.ModuleContext) (module.Result, err
 	if m.windowName != "" {
 		return module.Result{Status: module.StatusNeedsInput, Message: fmt.Sprintf("consolidation running in %s", m.windowName)}, nil
 	}
+	if msg, err := m.criticalGate(ctx); err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	} else if msg != "" {
+		return module.Result{Status: module.StatusNeedsInput, Message: msg}, nil
+	}
 	window := fmt.Sprintf("consolidation-%d", time.Now().Unix())
 	if err := createTmuxWindow(window, ctx.Config.ProjectDir); err != nil {
 		return module.Result{Status: module.StatusFailed}, fmt.Errorf("consolidation: create tmux window: %w", err)
 	}
-	prompt := fmt.Sprintf(
+	if err := runOpenCode(window, m.prompt(ctx)); err != nil {
+		killTmuxWindow(window)
+		return module.Result{Status: module.StatusFailed}, fmt.Errorf("consolidation: launch opencode: %w", err)
+	}
+	m.windowName = window
+	return module.Result{Status: module.StatusNeedsInput, Message: fmt.Sprintf("consolidation running in %s", window)}, nil
+}
+
+// prompt builds the orchestrator instructions. REVIEW_META.md is listed only
+// when the optional meta-reviewer wrote it.
+func (m *ConsolidationModule) prompt(ctx *module.ModuleContext) string {
+	metaReview := ""
+	if _, err := os.Stat(ctx.Workflow.ReviewMetaPath()); err == nil {
+		metaReview = fmt.Sprintf("- Meta review: %s (issues past review rounds caught that this round missed; weigh them like reviewer feedback) ", ctx.Workflow.ReviewMetaPath())
+	}
+	return fmt.Sprintf(
 		"You are the ORCHESTRATOR consolidating feedback from multiple reviewers. "+
 			"Read all documents: "+
 			"- Original plan: %s (COMMISSION.md, ARCHITECTURE.md, CONVENTIONS.md) "+
@@ -94,6 +155,7 @@ func (m *ConsolidationModule) Run(ctx *module.ModuleContext) (module.Result, err
 			"- Simplifier review: %s/REVIEW_SIMPLIFIER.md "+
 			"- User Advocate review: %s/REVIEW_USER_ADVOCATE.md "+
 			"- Skeptic review: %s/REVIEW_SKEPTIC.md "+
+			"%s"+
 			"Synthesize the feedback, update MODULES.md and PLAN.md, and capture what was applied vs. deferred. "+
 			"When done, create an empty marker file at %s to signal completion. Do not end until the marker exists.",
 		ctx.Workflow.PlanDir(),
@@ -103,14 +165,9 @@ func (m *ConsolidationModule) Run(ctx *module.ModuleContext) (module.Result, err
 		ctx.Workflow.ActionDir(),
 		ctx.Workflow.ActionDir(),
 		ctx.Workflow.ActionDir(),
+		metaReview,
 		ctx.Workflow.ReviewsAppliedPath(),
 	)
-	if err := runOpenCode(window, prompt); err != nil {
-		killTmuxWindow(window)
-		return module.Result{Status: module.StatusFailed}, fmt.Errorf("consolidation: launch opencode: %w", err)
-	}
-	m.windowName = window
-	return module.Result{Status: module.StatusNeedsInput, Message: fmt.Sprintf("consolidation running in %s", window)}, nil
 }
 
 // IsComplete returns true when the marker exists and plan docs were stamped.
@@ -146,6 +203,82 @@ func (m *ConsolidationModule) missingInput(ctx *module.ModuleContext) (string, e
 	return "", nil
 }
 
+// criticalGate returns a non-empty message when enough reviewers flagged
+// critical issues and nobody has approved consolidating anyway.
+func (m *ConsolidationModule) criticalGate(ctx *module.ModuleContext) (string, error) {
+	if m.criticalThreshold <= 0 {
+		return "", nil
+	}
+	approval := ctx.Workflow.CriticalApprovedPath()
+	if _, err := os.Stat(approval); err == nil {
+		return "", nil
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("%s: check approval marker: %w", moduleID, err)
+	}
+	flagged, err := criticalReviewers(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(flagged) < m.criticalThreshold {
+		return "", nil
+	}
+	return fmt.Sprintf(
+		"%d of %d reviewers flagged critical issues (%s); create %s to approve consolidation",
+		len(flagged), len(reviewerDocs), strings.Join(flagged, ", "), approval,
+	), nil
+}
+
+var reviewerDocs = []artifact.ArtifactRef{
+	artifact.ReviewPragmatistDoc,
+	artifact.ReviewSimplifierDoc,
+	artifact.ReviewAdvocateDoc,
+	artifact.ReviewSkepticDoc,
+}
+
+// criticalReviewers lists the reviewer artifacts containing at least one
+// critical-issue tag.
+func criticalReviewers(ctx *module.ModuleContext) ([]string, error) {
+	var flagged []string
+	for _, ref := range reviewerDocs {
+		data, err := os.ReadFile(ref.Path(ctx.Workflow))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("%s: read %s: %w", moduleID, ref.Name, err)
+		}
+		if hasCriticalTag(data) {
+			flagged = append(flagged, ref.Name)
+		}
+	}
+	return flagged, nil
+}
+
+// hasCriticalTag reports whether any line starts with CriticalTag, ignoring
+// case, indentation and a leading list bullet.
+func hasCriticalTag(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*+"))
+		if len(line) >= len(CriticalTag) && strings.EqualFold(line[:len(CriticalTag)], CriticalTag) {
+			return true
+		}
+	}
+	return false
+}
+
+func optionsFromConfig(cfg module.Config) ([]Option, error) {
+	n, ok, err := runtime.IntFromConfig(moduleID, cfg, criticalThresholdKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if n < 0 || n > len(reviewerDocs) {
+		return nil, fmt.Errorf("%s: %s must be between 0 and %d, got %d", moduleID, criticalThresholdKey, len(reviewerDocs), n)
+	}
+	return []Option{WithCriticalThreshold(n)}, nil
+}
+
 func (m *ConsolidationModule) stopSession() {
 	if m.windowName == "" {
 		return
@@ -171,8 +304,10 @@ func killTmuxWindow(name string) {
 }
 
 func runOpenCode(window, prompt string) error {
-	escaped := strings.ReplaceAll(prompt, "\"", `\"`)
-	escaped = strings.ReplaceAll(escaped, "\n", " ")
-	cmd := exec.Command("tmux", "send-keys", "-t", window, fmt.Sprintf(`opencode --prompt "%s"`, escaped), "Enter")
+	command, err := opencode.Command(prompt, "")
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("tmux", "send-keys", "-t", window, command, "Enter")
 	return cmd.Run()
 }
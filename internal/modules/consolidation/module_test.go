@@ -0,0 +1,152 @@
+package consolidation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func TestCriticalThresholdGatesConsolidation(t *testing.T) {
+	ctx := newConsolidationTestContext(t)
+	reg := module.NewRegistry()
+	Register(reg)
+	resolved, err := reg.Resolve(moduleID, module.Config{"critical_threshold": float64(3)})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	mod := resolved.(*ConsolidationModule)
+
+	writeInputs(t, ctx,
+		"## Review\n- [CRITICAL] No rollback plan for the migration",
+		"## Review\n- Merge the two cache layers",
+		"## Review\n[critical] Onboarding flow is missing entirely",
+		"## Review\n  * [CRITICAL] Secrets are logged in plain text",
+	)
+
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if result.Status != module.StatusNeedsInput {
+		t.Fatalf("expected needs-input, got %s", result.Status)
+	}
+	for _, want := range []string{"3 of 4 reviewers", "Pragmatist Review", "Skeptic Review", ctx.Workflow.CriticalApprovedPath()} {
+		if !strings.Contains(result.Message, want) {
+			t.Fatalf("gate message %q missing %q", result.Message, want)
+		}
+	}
+	if strings.Contains(result.Message, "Simplifier Review") {
+		t.Fatalf("simplifier did not flag critical issues: %q", result.Message)
+	}
+	if mod.windowName != "" {
+		t.Fatalf("gated consolidation should not launch a session")
+	}
+
+	if err := os.WriteFile(ctx.Workflow.CriticalApprovedPath(), nil, 0644); err != nil {
+		t.Fatalf("write approval: %v", err)
+	}
+	msg, err := mod.criticalGate(ctx)
+	if err != nil {
+		t.Fatalf("gate after approval: %v", err)
+	}
+	if msg != "" {
+		t.Fatalf("approval should open the gate, got %q", msg)
+	}
+}
+
+func TestCriticalThresholdIgnoresFewerFlags(t *testing.T) {
+	ctx := newConsolidationTestContext(t)
+	mod := New(WithCriticalThreshold(3))
+	writeInputs(t, ctx,
+		"[CRITICAL] Timeline is unrealistic",
+		"Mentions a critical path but no tag",
+		"[CRITICAL] No accessibility review",
+		"Looks fine",
+	)
+	msg, err := mod.criticalGate(ctx)
+	if err != nil {
+		t.Fatalf("gate: %v", err)
+	}
+	if msg != "" {
+		t.Fatalf("two flags should not trip a threshold of three, got %q", msg)
+	}
+}
+
+func TestCriticalThresholdRejectsOutOfRangeConfig(t *testing.T) {
+	reg := module.NewRegistry()
+	Register(reg)
+	if _, err := reg.Resolve(moduleID, module.Config{"critical_threshold": 5}); err == nil {
+		t.Fatalf("expected out-of-range threshold error")
+	}
+}
+
+func TestPromptReadsMetaReviewWhenPresent(t *testing.T) {
+	ctx := newConsolidationTestContext(t)
+	mod := New()
+	writeInputs(t, ctx, "a", "b", "c", "d")
+	if prompt := mod.prompt(ctx); strings.Contains(prompt, "REVIEW_META.md") {
+		t.Fatalf("prompt mentions a meta review that was never written: %s", prompt)
+	}
+	writeDocArtifact(t, ctx, artifact.ReviewMetaDoc, "[MISSED] Skeptic: no rollback plan")
+	if prompt := mod.prompt(ctx); !strings.Contains(prompt, ctx.Workflow.ReviewMetaPath()) {
+		t.Fatalf("prompt should point consolidation at REVIEW_META.md: %s", prompt)
+	}
+	if missing, err := mod.missingInput(ctx); err != nil || missing != "" {
+		t.Fatalf("meta review must stay optional, missing=%q err=%v", missing, err)
+	}
+}
+
+// writeInputs stamps every consolidation input, using reviews as the bodies of
+// the pragmatist, simplifier, advocate and skeptic files in that order.
+func writeInputs(t *testing.T, ctx *module.ModuleContext, reviews ...string) {
+	t.Helper()
+	for _, ref := range []artifact.ArtifactRef{
+		artifact.CommissionDoc,
+		artifact.ArchitectureDoc,
+		artifact.ConventionsDoc,
+		artifact.ModulesDoc,
+		artifact.ActionPlanDoc,
+		artifact.StaffReviewDoc,
+	} {
+		writeDocArtifact(t, ctx, ref, "body")
+	}
+	for i, ref := range reviewerDocs {
+		writeDocArtifact(t, ctx, ref, reviews[i])
+	}
+}
+
+func writeDocArtifact(t *testing.T, ctx *module.ModuleContext, ref artifact.ArtifactRef, body string) {
+	t.Helper()
+	meta := artifact.Metadata{ArtifactID: ref.ID, ModuleID: "test", Version: "0.0.1", Workflow: ctx.Workflow.Dir()}
+	if err := ctx.Artifacts.Write(ref, []byte(body), meta); err != nil {
+		t.Fatalf("write %s: %v", ref.ID, err)
+	}
+}
+
+func newConsolidationTestContext(t *testing.T) *module.ModuleContext {
+	t.Helper()
+	projectDir := t.TempDir()
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	cfg := &config.Config{
+		ProjectDir:        projectDir,
+		LatticeProjectDir: filepath.Join(projectDir, config.LatticeDir),
+		LatticeRoot:       projectDir,
+	}
+	wf := workflow.New(cfg.LatticeProjectDir)
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	return &module.ModuleContext{
+		Config:    cfg,
+		Workflow:  wf,
+		Artifacts: artifact.NewStore(wf),
+	}
+}
@@ -26,6 +26,29 @@ package hiring
 //   - `bd` must be on `$PATH` because workload sizing parses `bd ready --json`
 //     and the module opens a dedicated `HIRE` epic plus one bead per agent to
 //     track AGENT.md generation.
+//   - With `solo: true` (or `WithSolo`) the module skips the
+//     minimum-worker floor and the specialist split and hires exactly one
+//     worker: the denizen with the highest combined precision, autonomy, and
+//     experience, or a SPARK placeholder when no CVs exist.
+//   - `spark_fallback` (default true) controls those SPARK placeholders. When
+//     it is false and the communities cannot fill the roster, Run fails with
+//     the shortfall and instructions for adding communities or CVs.
+//   - `selection_order` picks which denizens fill the roster first: `alpha`
+//     (default), `random-seeded` (shuffled with `selection_seed`, or a
+//     clock seed recorded in the roster analysis), `least-recently-hired`
+//     (ranked by the participation tally in `state/hire-participation.json`,
+//     which every fresh hire updates), or `community-balanced` (one denizen
+//     per community in turn).
+//   - `preserve_roster` (default false) makes a rehire prefer the denizens in
+//     the existing workers.json and keep each AGENT.md whose CV source still
+//     matches the staged copy, so only new or changed hires are rebriefed.
+//   - `reference_links` lists documentation every hire should know about,
+//     such as wiki pages or API references. Each entry is appended to the
+//     References section of every AGENT_SUP.md; URLs are kept as written and
+//     paths become `{file:...}` references relative to the project. The
+//     list is per project only: a denizen's community comes from its CV
+//     front matter, not from a configured community entry, so there is no
+//     per-community list to merge in.
 //
 // Outputs:
 //   - `workflow/team/workers.json` (`artifact.WorkersJSON`) populated with
@@ -39,6 +62,9 @@ package hiring
 //     SPARK hires receive stub documents, while non-SPARK hires run the
 //     `create-agent-file` skill with staged CVs in
 //     `.lattice/setup/cvs/<community>/<name>/`.
+//   - Completion requires both the roster metadata and a non-empty AGENT.md for
+//     every roster entry. A rerun after an interrupted hire keeps the existing
+//     roster and only generates the dossiers that are still missing.
 //
 // Side effects consumed later:
 //   - `bd create` tickets (a parent `HIRE` epic and per-agent beads) which the
@@ -2,9 +2,13 @@ package hiring
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math"
 	"os"
 	"os/exec"
@@ -17,6 +21,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/modules/runtime"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 	"github.com/kingrea/The-Lattice/internal/orchestrator"
 	"github.com/kingrea/The-Lattice/internal/skills"
 	"github.com/kingrea/The-Lattice/internal/workflow"
@@ -34,8 +39,24 @@ const (
 	workerRole            = "worker"
 	specialistRole        = "specialist"
 	opencodeSkillTimeout  = 5 * time.Minute
+
+	sparkFallbackKey  = "spark_fallback"
+	selectionOrderKey = "selection_order"
+	selectionSeedKey  = "selection_seed"
+	preserveRosterKey = "preserve_roster"
+	referenceLinksKey = "reference_links"
+	soloKey           = "solo"
 )
 
+// configSchema lists the config keys hiring accepts.
+var configSchema = module.ConfigSchema{
+	sparkFallbackKey:  {Type: module.ConfigBool, Description: "fill missing hires with SPARK placeholders (default true)"},
+	selectionOrderKey: {Type: module.ConfigString, Description: "alpha, random-seeded, least-recently-hired, or community-balanced"},
+	selectionSeedKey:  {Type: module.ConfigInt, Description: "seed for the random-seeded selection order"},
+	preserveRosterKey: {Type: module.ConfigBool, Description: "keep the previous roster and its current dossiers when rehiring"},
+	referenceLinksKey: {Type: module.ConfigStringList, Description: "doc links or project paths listed in every AGENT_SUP.md"},
+}
+
 // Option customizes the hiring module.
 type Option func(*HiringModule)
 
@@ -51,6 +72,20 @@ type HiringModule struct {
 	now        func() time.Time
 	runCmd     CommandRunner
 	briefMaker AgentBriefWriter
+	solo       bool
+	// sparkFallback fills roster slots no denizen covers with SPARK
+	// placeholders. When false, a shortfall fails the run instead.
+	sparkFallback bool
+	// selectionOrder decides which denizens are hired first; selectionSeed
+	// drives SelectionRandomSeeded and is drawn from the clock when unset.
+	selectionOrder SelectionOrder
+	selectionSeed  *int64
+	// preserveRoster keeps previously hired denizens first in line and reuses
+	// their dossiers when a rehire recomputes the roster.
+	preserveRoster bool
+	// references are the doc links and project paths listed at the end of
+	// every support packet.
+	references []string
 }
 
 // Register adds the module factory to the registry.
@@ -58,8 +93,12 @@ func Register(reg *module.Registry) {
 	if reg == nil {
 		return
 	}
-	reg.MustRegister(moduleID, func(module.Config) (module.Module, error) {
-		return New(), nil
+	reg.MustRegisterWithSchema(moduleID, configSchema, func(cfg module.Config) (module.Module, error) {
+		opts, err := optionsFromConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return New(opts...), nil
 	})
 }
 
@@ -80,10 +119,12 @@ func New(opts ...Option) *HiringModule {
 	)
 	base.SetOutputs(artifact.WorkersJSON)
 	mod := &HiringModule{
-		Base:       &base,
-		now:        time.Now,
-		runCmd:     defaultCommandRunner,
-		briefMaker: defaultBriefWriter,
+		Base:           &base,
+		now:            time.Now,
+		runCmd:         defaultCommandRunner,
+		briefMaker:     defaultBriefWriter,
+		sparkFallback:  true,
+		selectionOrder: SelectionAlpha,
 	}
 	for _, opt := range opts {
 		if opt != nil {
@@ -120,6 +161,108 @@ func WithAgentBriefWriter(writer AgentBriefWriter) Option {
 	}
 }
 
+// WithSolo hires a single worker and no specialists.
+func WithSolo(enabled bool) Option {
+	return func(m *HiringModule) {
+		m.solo = enabled
+	}
+}
+
+// WithSparkFallback controls whether roster slots no denizen covers are
+// filled with SPARK placeholders. With the fallback off, hiring fails with
+// instructions for adding denizens instead.
+func WithSparkFallback(enabled bool) Option {
+	return func(m *HiringModule) {
+		m.sparkFallback = enabled
+	}
+}
+
+// WithSelectionOrder picks which denizens fill the roster first.
+func WithSelectionOrder(order SelectionOrder) Option {
+	return func(m *HiringModule) {
+		if order != "" {
+			m.selectionOrder = order
+		}
+	}
+}
+
+// WithSelectionSeed fixes the seed of the random-seeded selection order so
+// reruns hire the same roster.
+func WithSelectionSeed(seed int64) Option {
+	return func(m *HiringModule) {
+		m.selectionSeed = &seed
+	}
+}
+
+// WithPreserveRoster makes a rehire keep the previous roster: denizens from
+// the old workers.json are selected first, and a dossier whose AGENT.md exists
+// and whose CV source matches the staged copy is kept instead of regenerated.
+func WithPreserveRoster(enabled bool) Option {
+	return func(m *HiringModule) {
+		m.preserveRoster = enabled
+	}
+}
+
+// WithReferenceLinks lists external documentation, such as wiki pages, API
+// references, or project-relative paths, in every AGENT_SUP.md. Blank and
+// repeated entries are dropped.
+func WithReferenceLinks(links ...string) Option {
+	return func(m *HiringModule) {
+		seen := make(map[string]bool, len(links))
+		m.references = nil
+		for _, link := range links {
+			link = strings.TrimSpace(link)
+			if link == "" || seen[link] {
+				continue
+			}
+			seen[link] = true
+			m.references = append(m.references, link)
+		}
+	}
+}
+
+// optionsFromConfig translates workflow config into module options.
+func optionsFromConfig(cfg module.Config) ([]Option, error) {
+	var opts []Option
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, sparkFallbackKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithSparkFallback(enabled))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, preserveRosterKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithPreserveRoster(enabled))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, soloKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithSolo(enabled))
+	}
+	if links, ok, err := runtime.StringListFromConfig(moduleID, cfg, referenceLinksKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithReferenceLinks(links...))
+	}
+	if raw, ok := cfg[selectionOrderKey]; ok && raw != nil {
+		value, isString := raw.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s: %s must be a string, got %T", moduleID, selectionOrderKey, raw)
+		}
+		order, err := ParseSelectionOrder(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", moduleID, selectionOrderKey, err)
+		}
+		opts = append(opts, WithSelectionOrder(order))
+	}
+	if seed, ok, err := runtime.IntFromConfig(moduleID, cfg, selectionSeedKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithSelectionSeed(int64(seed)))
+	}
+	return opts, nil
+}
+
 // Run staffs the commission and emits roster + agent artifacts.
 func (m *HiringModule) Run(ctx *module.ModuleContext) (module.Result, error) {
 	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
@@ -141,13 +284,36 @@ func (m *HiringModule) Run(ctx *module.ModuleContext) (module.Result, error) {
 	} else if complete {
 		return module.Result{Status: module.StatusNoOp, Message: "roster already hired"}, nil
 	}
+	hires, resumed, err := m.resumeHires(ctx)
+	if err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
+	if resumed {
+		pending := pendingDossiers(ctx, hires)
+		if _, err := m.generateAgentFiles(ctx, pending); err != nil {
+			return module.Result{Status: module.StatusFailed}, err
+		}
+		if err := m.finishHiring(ctx, hires); err != nil {
+			return module.Result{Status: module.StatusFailed}, err
+		}
+		return module.Result{
+			Status:  module.StatusCompleted,
+			Message: fmt.Sprintf("resumed hiring: generated %d of %d dossiers", len(pending), len(hires)),
+		}, nil
+	}
 	totalPoints, beadCount, err := m.analyzeWorkload(ctx)
 	if err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
 	baseWorkers := maxInt(minWorkersRequired, computeMaxParallel(totalPoints, beadCount))
-	totalNeeded := baseWorkers + defaultSpecialists
-	hires, err := m.selectAgents(ctx, baseWorkers, totalNeeded)
+	specialists := defaultSpecialists
+	mode := "max(points/maxSP, beadCount, minWorkers)"
+	if m.solo {
+		baseWorkers, specialists, mode = 1, 0, "solo"
+	}
+	totalNeeded := baseWorkers + specialists
+	seed := m.seed()
+	hires, err = m.selectAgents(ctx, baseWorkers, totalNeeded, m.solo, seed)
 	if err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
@@ -155,25 +321,45 @@ func (m *HiringModule) Run(ctx *module.ModuleContext) (module.Result, error) {
 		TotalPoints:     totalPoints,
 		BeadCount:       beadCount,
 		BaseWorkers:     baseWorkers,
-		Specialists:     defaultSpecialists,
+		Specialists:     specialists,
 		TotalRequested:  totalNeeded,
 		TotalHires:      len(hires),
 		SparkCount:      countSparks(hires),
-		ComputationMode: "max(points/maxSP, beadCount, minWorkers)",
+		ComputationMode: mode,
+		SelectionOrder:  string(m.selectionOrder),
+	}
+	if m.selectionOrder == SelectionRandomSeeded {
+		analysis.SelectionSeed = seed
 	}
 	if err := m.writeWorkerRoster(ctx, hires, analysis); err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
-	if err := m.generateAgentFiles(ctx, hires); err != nil {
+	if err := recordParticipation(ctx.Config, hires, m.now().UTC()); err != nil {
+		return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: record participation: %w", moduleID, err)
+	}
+	kept, err := m.generateAgentFiles(ctx, hires)
+	if err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
-	if err := m.createHireBeads(ctx, hires); err != nil {
+	if err := m.finishHiring(ctx, hires); err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
+	message := fmt.Sprintf("hired %d denizens", len(hires))
+	if kept > 0 {
+		message = fmt.Sprintf("%s, kept %d existing dossiers", message, kept)
+	}
+	return module.Result{Status: module.StatusCompleted, Message: message}, nil
+}
+
+// finishHiring opens the dossier beads and points opencode at the new roster.
+func (m *HiringModule) finishHiring(ctx *module.ModuleContext, hires []rosterAssignment) error {
+	if err := m.createHireBeads(ctx, hires); err != nil {
+		return err
+	}
 	if err := ctx.Orchestrator.RefreshOpenCodeConfig(); err != nil {
-		return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: refresh opencode config: %w", moduleID, err)
+		return fmt.Errorf("%s: refresh opencode config: %w", moduleID, err)
 	}
-	return module.Result{Status: module.StatusCompleted, Message: fmt.Sprintf("hired %d denizens", len(hires))}, nil
+	return nil
 }
 
 // IsComplete reports true when workers.json carries hiring metadata.
@@ -191,7 +377,75 @@ func (m *HiringModule) IsComplete(ctx *module.ModuleContext) (bool, error) {
 	if result.Metadata == nil || result.Metadata.ModuleID != moduleID || result.Metadata.Version != moduleVersion {
 		return false, nil
 	}
-	return true, nil
+	roster, err := workflow.LoadWorkers(result.Path)
+	if err != nil {
+		return false, fmt.Errorf("%s: read workers.json: %w", moduleID, err)
+	}
+	hires := make([]rosterAssignment, len(roster))
+	for i, entry := range roster {
+		hires[i] = rosterAssignment{Entry: entry}
+	}
+	return len(pendingDossiers(ctx, hires)) == 0, nil
+}
+
+// resumeHires rebuilds the hire list from a roster this module version already
+// wrote, so an interrupted run only has to finish the missing dossiers. It
+// reports false when no such roster exists.
+func (m *HiringModule) resumeHires(ctx *module.ModuleContext) ([]rosterAssignment, bool, error) {
+	result, err := ctx.Artifacts.Check(artifact.WorkersJSON)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: check workers.json: %w", moduleID, err)
+	}
+	if result.State != artifact.StateReady || result.Metadata == nil ||
+		result.Metadata.ModuleID != moduleID || result.Metadata.Version != moduleVersion {
+		return nil, false, nil
+	}
+	roster, err := workflow.LoadWorkers(result.Path)
+	if err != nil || len(roster) == 0 {
+		return nil, false, nil
+	}
+	agents, err := ctx.Orchestrator.LoadDenizenCVs()
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: load denizen cvs: %w", moduleID, err)
+	}
+	sources := make(map[string]string, len(agents))
+	for _, agent := range agents {
+		sources[hireKey(agent.Name, agent.Community)] = filepath.Dir(agent.CVPath)
+	}
+	hires := make([]rosterAssignment, 0, len(roster))
+	for _, entry := range roster {
+		hire := rosterAssignment{Entry: entry}
+		if !entry.IsSpark {
+			hire.Source = sources[hireKey(entry.Name, entry.Community)]
+		}
+		hires = append(hires, hire)
+	}
+	return hires, true, nil
+}
+
+// pendingDossiers lists the hires whose AGENT.md is missing or empty.
+func pendingDossiers(ctx *module.ModuleContext, hires []rosterAssignment) []rosterAssignment {
+	var pending []rosterAssignment
+	for _, hire := range hires {
+		info, err := os.Stat(filepath.Join(agentDir(ctx, hire.Entry), "AGENT.md"))
+		if err != nil || info.IsDir() || info.Size() == 0 {
+			pending = append(pending, hire)
+		}
+	}
+	return pending
+}
+
+// agentDir returns the dossier directory for a roster entry.
+func agentDir(ctx *module.ModuleContext, entry workflow.WorkerEntry) string {
+	roleDir := "workers"
+	if entry.Role == specialistRole {
+		roleDir = "specialists"
+	}
+	return filepath.Join(ctx.Config.AgentsDir(), roleDir, slugifyName(entry.Name))
+}
+
+func hireKey(name, community string) string {
+	return strings.ToLower(strings.TrimSpace(name)) + "|" + strings.ToLower(strings.TrimSpace(community))
 }
 
 func (m *HiringModule) missingInput(ctx *module.ModuleContext) (string, error) {
@@ -208,9 +462,14 @@ func (m *HiringModule) missingInput(ctx *module.ModuleContext) (string, error) {
 }
 
 func (m *HiringModule) analyzeWorkload(ctx *module.ModuleContext) (int, int, error) {
-	out, err := m.runCmd(ctx.Config.ProjectDir, "bd", "ready", "--json")
-	if err != nil {
-		return 0, 0, fmt.Errorf("%s: bd ready --json failed: %s: %w", moduleID, strings.TrimSpace(string(out)), err)
+	// A live ready-bead subscription already holds the current set.
+	out, ok := ctx.Orchestrator.PushedReadyBeads()
+	if !ok {
+		polled, err := m.runCommand(ctx, "bd", "ready", "--json")
+		if err != nil {
+			return 0, 0, fmt.Errorf("%s: bd ready --json failed: %s: %w", moduleID, strings.TrimSpace(string(polled)), err)
+		}
+		out = polled
 	}
 	points, count, parseErr := parseBeadStats(out)
 	if parseErr != nil {
@@ -219,14 +478,42 @@ func (m *HiringModule) analyzeWorkload(ctx *module.ModuleContext) (int, int, err
 	return points, count, nil
 }
 
-func (m *HiringModule) selectAgents(ctx *module.ModuleContext, workerCount, totalNeeded int) ([]rosterAssignment, error) {
+// seed returns the configured selection seed, or one drawn from the clock.
+func (m *HiringModule) seed() int64 {
+	if m.selectionSeed != nil {
+		return *m.selectionSeed
+	}
+	return m.now().UnixNano()
+}
+
+// selectAgents fills the roster in the configured selection order, name order
+// by default. A solo run instead takes the most capable denizen, ranked by
+// precision, autonomy, and experience.
+func (m *HiringModule) selectAgents(ctx *module.ModuleContext, workerCount, totalNeeded int, solo bool, seed int64) ([]rosterAssignment, error) {
 	agents, err := ctx.Orchestrator.LoadDenizenCVs()
-	if err != nil {
+	// Without SPARK placeholders a missing communities directory is just a
+	// shortfall, reported below with instructions for adding denizens.
+	if err != nil && !(errors.Is(err, fs.ErrNotExist) && !m.sparkFallback) {
 		return nil, fmt.Errorf("%s: load denizen cvs: %w", moduleID, err)
 	}
 	sort.SliceStable(agents, func(i, j int) bool {
 		return strings.ToLower(strings.TrimSpace(agents[i].Name)) < strings.ToLower(strings.TrimSpace(agents[j].Name))
 	})
+	var tally participationTally
+	if m.selectionOrder == SelectionLeastRecentlyHired {
+		if tally, err = loadParticipation(ctx.Config); err != nil {
+			return nil, fmt.Errorf("%s: load participation: %w", moduleID, err)
+		}
+	}
+	orderCandidates(agents, m.selectionOrder, seed, tally)
+	if solo {
+		sort.SliceStable(agents, func(i, j int) bool {
+			return capabilityScore(agents[i]) > capabilityScore(agents[j])
+		})
+	}
+	if m.preserveRoster {
+		preferPreviousHires(agents, previousHires(ctx))
+	}
 	used := make(map[string]struct{})
 	selected := make([]rosterAssignment, 0, totalNeeded)
 	for _, agent := range agents {
@@ -245,6 +532,9 @@ func (m *HiringModule) selectAgents(ctx *module.ModuleContext, workerCount, tota
 			break
 		}
 	}
+	if len(selected) < totalNeeded && !m.sparkFallback {
+		return nil, denizenShortfallError(ctx, totalNeeded, len(selected))
+	}
 	sparkCounter := 1
 	for len(selected) < totalNeeded {
 		name := fmt.Sprintf(sparkNameFormat, sparkCounter)
@@ -264,6 +554,24 @@ func (m *HiringModule) selectAgents(ctx *module.ModuleContext, workerCount, tota
 	return selected, nil
 }
 
+// denizenShortfallError explains how to staff a roster the communities cannot
+// cover when SPARK placeholders are disabled.
+func denizenShortfallError(ctx *module.ModuleContext, needed, found int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: roster needs %d denizens but only %d are available (short by %d) and %s is false", moduleID, needed, found, needed-found, sparkFallbackKey)
+	if found == 0 {
+		b.WriteString("; no denizen CVs were found")
+	}
+	fmt.Fprintf(&b, ". Add a community under communities: in %s (source: local with a path, or source: github with a repository),", ctx.Config.ProjectConfigPath())
+	fmt.Fprintf(&b, " or add cv.md files under %s.", filepath.Join(ctx.Config.CommunitiesDir(), "<community>", "cvs", "<name>"))
+	fmt.Fprintf(&b, " To hire SPARK placeholders for the missing slots instead, set %s: true", sparkFallbackKey)
+	return errors.New(b.String())
+}
+
+func capabilityScore(agent orchestrator.Agent) int {
+	return agent.Precision + agent.Autonomy + agent.Experience
+}
+
 func (m *HiringModule) writeWorkerRoster(ctx *module.ModuleContext, hires []rosterAssignment, analysis hiringAnalysis) error {
 	entries := make([]workflow.WorkerEntry, 0, len(hires))
 	specialists := make([]workflow.WorkerEntry, 0, len(hires))
@@ -301,46 +609,129 @@ func (m *HiringModule) writeWorkerRoster(ctx *module.ModuleContext, hires []rost
 	return nil
 }
 
-func (m *HiringModule) generateAgentFiles(ctx *module.ModuleContext, hires []rosterAssignment) error {
-	baseDir := ctx.Config.AgentsDir()
+// generateAgentFiles writes the dossiers for hires and reports how many
+// existing ones it kept under preserve_roster. Kept dossiers still get a fresh
+// support packet because capacity and plan paths can change between hires.
+func (m *HiringModule) generateAgentFiles(ctx *module.ModuleContext, hires []rosterAssignment) (int, error) {
+	kept := 0
 	for _, hire := range hires {
-		roleDir := "workers"
 		roleContext := workerRole
 		if hire.Entry.Role == specialistRole {
-			roleDir = "specialists"
 			roleContext = specialistRole
 		}
-		slug := slugifyName(hire.Entry.Name)
-		targetDir := filepath.Join(baseDir, roleDir, slug)
+		targetDir := agentDir(ctx, hire.Entry)
+		agentPath := filepath.Join(targetDir, "AGENT.md")
+		supportPath := filepath.Join(targetDir, "AGENT_SUP.md")
+		if m.preserveRoster && dossierCurrent(ctx, hire) {
+			if err := writeSupportPacket(ctx, hire.Entry, agentPath, supportPath, m.references); err != nil {
+				return kept, err
+			}
+			kept++
+			continue
+		}
 		if err := os.RemoveAll(targetDir); err != nil {
-			return fmt.Errorf("%s: reset %s: %w", moduleID, targetDir, err)
+			return kept, fmt.Errorf("%s: reset %s: %w", moduleID, targetDir, err)
 		}
 		if err := os.MkdirAll(targetDir, 0o755); err != nil {
-			return fmt.Errorf("%s: mkdir %s: %w", moduleID, targetDir, err)
+			return kept, fmt.Errorf("%s: mkdir %s: %w", moduleID, targetDir, err)
 		}
-		agentPath := filepath.Join(targetDir, "AGENT.md")
-		supportPath := filepath.Join(targetDir, "AGENT_SUP.md")
 		if hire.Entry.IsSpark {
 			if err := writeSparkAgent(agentPath, hire.Entry.Name, hire.Entry.Role); err != nil {
-				return err
+				return kept, err
 			}
-			if err := writeSupportPacket(ctx, hire.Entry, agentPath, supportPath); err != nil {
-				return err
+			if err := writeSupportPacket(ctx, hire.Entry, agentPath, supportPath, m.references); err != nil {
+				return kept, err
 			}
 			continue
 		}
 		stagedDir, err := stageHireSource(ctx, hire)
 		if err != nil {
-			return err
+			return kept, err
 		}
 		if err := m.briefMaker(ctx, hire.Entry, stagedDir, agentPath, roleContext); err != nil {
-			return fmt.Errorf("%s: generate agent file for %s: %w", moduleID, hire.Entry.Name, err)
+			return kept, fmt.Errorf("%s: generate agent file for %s: %w", moduleID, hire.Entry.Name, err)
+		}
+		if err := writeSupportPacket(ctx, hire.Entry, agentPath, supportPath, m.references); err != nil {
+			return kept, err
+		}
+	}
+	return kept, nil
+}
+
+// previousHires returns the denizens named in the existing workers.json,
+// whatever module version wrote it, keyed by hireKey.
+func previousHires(ctx *module.ModuleContext) map[string]bool {
+	roster, err := workflow.LoadWorkers(ctx.Workflow.WorkersPath())
+	if err != nil {
+		return nil
+	}
+	keys := make(map[string]bool, len(roster))
+	for _, entry := range roster {
+		if !entry.IsSpark {
+			keys[hireKey(entry.Name, entry.Community)] = true
+		}
+	}
+	return keys
+}
+
+// preferPreviousHires moves previously hired denizens ahead of the rest while
+// keeping the selection order within each group.
+func preferPreviousHires(agents []orchestrator.Agent, previous map[string]bool) {
+	if len(previous) == 0 {
+		return
+	}
+	sort.SliceStable(agents, func(i, j int) bool {
+		return previous[hireKey(agents[i].Name, agents[i].Community)] && !previous[hireKey(agents[j].Name, agents[j].Community)]
+	})
+}
+
+// dossierCurrent reports whether a non-SPARK hire already has an AGENT.md
+// generated from its current CV, judged by comparing the CV source directory
+// with the copy staged for the last brief.
+func dossierCurrent(ctx *module.ModuleContext, hire rosterAssignment) bool {
+	if hire.Entry.IsSpark || strings.TrimSpace(hire.Source) == "" {
+		return false
+	}
+	if len(pendingDossiers(ctx, []rosterAssignment{hire})) > 0 {
+		return false
+	}
+	current, err := treeDigest(hire.Source)
+	if err != nil {
+		return false
+	}
+	previous, err := treeDigest(stagedSourceDir(ctx, hire.Entry))
+	if err != nil {
+		return false
+	}
+	return current == previous
+}
+
+// treeDigest hashes the relative paths and contents of every file under root.
+func treeDigest(root string) (string, error) {
+	hash := sha256.New()
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
 		}
-		if err := writeSupportPacket(ctx, hire.Entry, agentPath, supportPath); err != nil {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
 			return err
 		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(rel), len(data))
+		hash.Write(data)
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
-	return nil
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 func (m *HiringModule) loadOrchestratorRef(ctx *module.ModuleContext) (rosterAgent, error) {
@@ -363,7 +754,7 @@ func stageHireSource(ctx *module.ModuleContext, hire rosterAssignment) (string,
 	if strings.TrimSpace(hire.Source) == "" {
 		return "", fmt.Errorf("%s: hire %s missing source directory", moduleID, hire.Entry.Name)
 	}
-	destDir := filepath.Join(ctx.Config.CVsDir(), hire.Entry.Community, hire.Entry.Name)
+	destDir := stagedSourceDir(ctx, hire.Entry)
 	if err := os.RemoveAll(destDir); err != nil {
 		return "", fmt.Errorf("%s: reset staged dir for %s: %w", moduleID, hire.Entry.Name, err)
 	}
@@ -373,6 +764,11 @@ func stageHireSource(ctx *module.ModuleContext, hire rosterAssignment) (string,
 	return destDir, nil
 }
 
+// stagedSourceDir is where a hire's CV directory is copied for the brief writer.
+func stagedSourceDir(ctx *module.ModuleContext, entry workflow.WorkerEntry) string {
+	return filepath.Join(ctx.Config.CVsDir(), entry.Community, entry.Name)
+}
+
 func writeSparkAgent(path, name, role string) error {
 	content := fmt.Sprintf(`# %s
 
@@ -386,7 +782,7 @@ This is a SPARK placeholder agent created automatically during hiring. Flesh out
 	return nil
 }
 
-func writeSupportPacket(ctx *module.ModuleContext, entry workflow.WorkerEntry, agentPath, supportPath string) error {
+func writeSupportPacket(ctx *module.ModuleContext, entry workflow.WorkerEntry, agentPath, supportPath string, references []string) error {
 	relAgent := relativeToProject(ctx, agentPath)
 	relPlan := relativeToProject(ctx, ctx.Workflow.ActionPlanPath())
 	var b strings.Builder
@@ -410,9 +806,29 @@ func writeSupportPacket(ctx *module.ModuleContext, entry workflow.WorkerEntry, a
 	} else {
 		b.WriteString("Review the AGENT.md brief before assigning beads. Update this packet with task-specific guardrails so supervisors can track progress.\n")
 	}
+	if len(references) > 0 {
+		b.WriteString("\n## References\n")
+		for _, ref := range references {
+			fmt.Fprintf(&b, "- %s\n", referenceEntry(ctx, ref))
+		}
+	}
 	return os.WriteFile(supportPath, []byte(b.String()), 0o644)
 }
 
+// referenceEntry renders a reference link as given, or a path as a {file:}
+// reference relative to the project, resolving relative paths against the
+// project directory.
+func referenceEntry(ctx *module.ModuleContext, ref string) string {
+	if strings.Contains(ref, "://") {
+		return ref
+	}
+	path := ref
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(ctx.Config.ProjectDir, path)
+	}
+	return relativeToProject(ctx, path)
+}
+
 func relativeToProject(ctx *module.ModuleContext, target string) string {
 	rel, err := filepath.Rel(ctx.Config.ProjectDir, target)
 	if err != nil {
@@ -443,7 +859,7 @@ func (m *HiringModule) createHireBeads(ctx *module.ModuleContext, hires []roster
 func (m *HiringModule) runBdCreate(ctx *module.ModuleContext, extra []string, title string) (string, error) {
 	args := append([]string{"create", title}, extra...)
 	args = append(args, "--json")
-	out, err := m.runCmd(ctx.Config.ProjectDir, "bd", args...)
+	out, err := m.runCommand(ctx, "bd", args...)
 	trimmed := strings.TrimSpace(string(out))
 	if err != nil {
 		return "", fmt.Errorf("%s: bd create %q failed: %s: %w", moduleID, title, trimmed, err)
@@ -548,6 +964,8 @@ type hiringAnalysis struct {
 	TotalHires      int    `json:"totalHires"`
 	SparkCount      int    `json:"sparkCount"`
 	ComputationMode string `json:"computationMode"`
+	SelectionOrder  string `json:"selectionOrder,omitempty"`
+	SelectionSeed   int64  `json:"selectionSeed,omitempty"`
 }
 
 type workerRosterPayload struct {
@@ -623,6 +1041,15 @@ func copyFile(src, dst string, mode os.FileMode) error {
 	return nil
 }
 
+// runCommand executes name in the project directory and copies its output into
+// the run log.
+func (m *HiringModule) runCommand(ctx *module.ModuleContext, name string, args ...string) ([]byte, error) {
+	dir := ctx.Config.ProjectDir
+	out, err := m.runCmd(dir, name, args...)
+	ctx.LogCommand(dir, name, args, out, err)
+	return out, err
+}
+
 func defaultCommandRunner(dir, name string, args ...string) ([]byte, error) {
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
@@ -680,9 +1107,11 @@ func killTmuxWindow(name string) {
 }
 
 func sendOpencodePrompt(window, prompt string) error {
-	escaped := strings.ReplaceAll(prompt, "\"", `\"`)
-	escaped = strings.ReplaceAll(escaped, "\n", " ")
-	cmd := exec.Command("tmux", "send-keys", "-t", window, fmt.Sprintf(`opencode --prompt "%s"`, escaped), "Enter")
+	command, err := opencode.Command(prompt, "")
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("tmux", "send-keys", "-t", window, command, "Enter")
 	return cmd.Run()
 }
 
@@ -1,6 +1,7 @@
 package hiring
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -90,6 +91,407 @@ func TestHiringModuleRunWritesRosterAndAgents(t *testing.T) {
 	}
 }
 
+func TestHiringModuleResumesMissingDossiers(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	seedPlanningArtifacts(t, ctx)
+	seedOrchestratorState(t, ctx)
+	seedCommunityCVs(t, ctx.Config, []agentFixture{
+		{Name: "Lyra", Precision: 7, Autonomy: 8, Experience: 6},
+		{Name: "Cass", Precision: 8, Autonomy: 9, Experience: 9},
+		{Name: "Mira", Precision: 6, Autonomy: 7, Experience: 8},
+	})
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	var written []string
+	agentWriter := func(_ *module.ModuleContext, entry workflow.WorkerEntry, stagedDir, targetFile, roleContext string) error {
+		written = append(written, entry.Name)
+		content := fmt.Sprintf("# %s\nRole: %s\nSource: %s\n", entry.Name, roleContext, stagedDir)
+		return os.WriteFile(targetFile, []byte(content), 0o644)
+	}
+	runner := &fakeCommandRunner{}
+	mod := New(WithCommandRunner(runner.Run), WithAgentBriefWriter(agentWriter))
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("initial run: %v", err)
+	}
+
+	roster, err := workflow.LoadWorkers(ctx.Workflow.WorkersPath())
+	if err != nil {
+		t.Fatalf("load roster: %v", err)
+	}
+	var missing []workflow.WorkerEntry
+	for _, entry := range roster {
+		if entry.Name == "Lyra" || entry.Name == fmt.Sprintf(sparkNameFormat, 2) {
+			missing = append(missing, entry)
+		}
+	}
+	if len(missing) != 2 {
+		t.Fatalf("expected Lyra and a spark in roster, got %+v", roster)
+	}
+	for _, entry := range missing {
+		if err := os.RemoveAll(agentDir(ctx, entry)); err != nil {
+			t.Fatalf("remove dossier: %v", err)
+		}
+	}
+	keptPath := filepath.Join(agentDir(ctx, workflow.WorkerEntry{Name: "Cass", Role: workerRole}), "AGENT.md")
+	if err := os.WriteFile(keptPath, []byte("# Cass\nhand-edited\n"), 0o644); err != nil {
+		t.Fatalf("edit kept dossier: %v", err)
+	}
+	if complete, err := mod.IsComplete(ctx); err != nil || complete {
+		t.Fatalf("expected incomplete hiring with missing dossiers (complete=%v err=%v)", complete, err)
+	}
+
+	written = nil
+	readyBefore := runner.readyCount
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("resume run: %v", err)
+	}
+	if result.Status != module.StatusCompleted || !strings.Contains(result.Message, fmt.Sprintf("generated 2 of %d dossiers", len(roster))) {
+		t.Fatalf("unexpected resume result: %+v", result)
+	}
+	if len(written) != 1 || written[0] != "Lyra" {
+		t.Fatalf("expected only Lyra's brief to be regenerated, got %v", written)
+	}
+	if runner.readyCount != readyBefore {
+		t.Fatalf("resume should reuse the roster instead of re-analysing beads")
+	}
+	for _, entry := range missing {
+		if _, err := os.Stat(filepath.Join(agentDir(ctx, entry), "AGENT.md")); err != nil {
+			t.Fatalf("dossier for %s not regenerated: %v", entry.Name, err)
+		}
+	}
+	kept, err := os.ReadFile(keptPath)
+	if err != nil || !strings.Contains(string(kept), "hand-edited") {
+		t.Fatalf("existing dossier was regenerated: %s (%v)", kept, err)
+	}
+	if complete, err := mod.IsComplete(ctx); err != nil || !complete {
+		t.Fatalf("expected hiring complete after resume (complete=%v err=%v)", complete, err)
+	}
+}
+
+func TestHiringModulePreserveRosterKeepsCurrentDossiers(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	seedPlanningArtifacts(t, ctx)
+	seedOrchestratorState(t, ctx)
+	seedCommunityCVs(t, ctx.Config, []agentFixture{
+		{Name: "Lyra", Precision: 7, Autonomy: 8, Experience: 6},
+		{Name: "Cass", Precision: 8, Autonomy: 9, Experience: 9},
+		{Name: "Mira", Precision: 6, Autonomy: 7, Experience: 8},
+	})
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	var written []string
+	agentWriter := func(_ *module.ModuleContext, entry workflow.WorkerEntry, _ string, targetFile, roleContext string) error {
+		written = append(written, entry.Name)
+		content := fmt.Sprintf("# %s\nRole: %s\n", entry.Name, roleContext)
+		return os.WriteFile(targetFile, []byte(content), 0o644)
+	}
+	opts, err := optionsFromConfig(module.Config{preserveRosterKey: "true"})
+	if err != nil {
+		t.Fatalf("options from config: %v", err)
+	}
+	runner := &fakeCommandRunner{}
+	mod := New(append(opts, WithCommandRunner(runner.Run), WithAgentBriefWriter(agentWriter))...)
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("initial run: %v", err)
+	}
+	if len(written) != 3 {
+		t.Fatalf("expected every denizen briefed on the first run, got %v", written)
+	}
+
+	// An older module version wrote this roster, so the next run rehires.
+	payload := readJSONFile(t, ctx.Workflow.WorkersPath())
+	payload["_lattice"].(map[string]any)["version"] = "0.9.0"
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("encode stale roster: %v", err)
+	}
+	if err := os.WriteFile(ctx.Workflow.WorkersPath(), data, 0o644); err != nil {
+		t.Fatalf("write stale roster: %v", err)
+	}
+	cassPath := filepath.Join(agentDir(ctx, workflow.WorkerEntry{Name: "Cass", Role: workerRole}), "AGENT.md")
+	if err := os.WriteFile(cassPath, []byte("# Cass\nhand-edited\n"), 0o644); err != nil {
+		t.Fatalf("edit kept dossier: %v", err)
+	}
+	miraCV := filepath.Join(ctx.Config.LatticeRoot, "communities", "atlas", "cvs", slugifyName("Mira"), "cv.md")
+	if err := os.WriteFile(miraCV, []byte("---\nname: Mira\ncommunity: Atlas Collective\n---\nprecision: 9\n"), 0o644); err != nil {
+		t.Fatalf("edit cv: %v", err)
+	}
+
+	written = nil
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("rehire: %v", err)
+	}
+	if len(written) != 1 || written[0] != "Mira" {
+		t.Fatalf("expected only Mira's changed CV to be rebriefed, got %v", written)
+	}
+	if !strings.Contains(result.Message, "kept 2 existing dossiers") {
+		t.Fatalf("unexpected rehire result: %+v", result)
+	}
+	kept, err := os.ReadFile(cassPath)
+	if err != nil || !strings.Contains(string(kept), "hand-edited") {
+		t.Fatalf("preserved dossier was regenerated: %s (%v)", kept, err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(cassPath), "AGENT_SUP.md")); err != nil {
+		t.Fatalf("kept dossier lost its support packet: %v", err)
+	}
+}
+
+func TestHiringModuleAppendsReferenceLinksToSupportPackets(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	seedPlanningArtifacts(t, ctx)
+	seedOrchestratorState(t, ctx)
+	seedCommunityCVs(t, ctx.Config, []agentFixture{
+		{Name: "Lyra", Precision: 7, Autonomy: 8, Experience: 6},
+	})
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	opts, err := optionsFromConfig(module.Config{referenceLinksKey: []any{
+		"https://wiki.example.com/runbooks",
+		"docs/api.md",
+		" https://wiki.example.com/runbooks ",
+	}})
+	if err != nil {
+		t.Fatalf("options from config: %v", err)
+	}
+	runner := &fakeCommandRunner{}
+	agentWriter := func(_ *module.ModuleContext, entry workflow.WorkerEntry, _ string, targetFile, roleContext string) error {
+		return os.WriteFile(targetFile, []byte(fmt.Sprintf("# %s\nRole: %s\n", entry.Name, roleContext)), 0o644)
+	}
+	mod := New(append(opts, WithCommandRunner(runner.Run), WithAgentBriefWriter(agentWriter))...)
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	supportPath := filepath.Join(agentDir(ctx, workflow.WorkerEntry{Name: "Lyra", Role: workerRole}), "AGENT_SUP.md")
+	data, err := os.ReadFile(supportPath)
+	if err != nil {
+		t.Fatalf("read support packet: %v", err)
+	}
+	packet := string(data)
+	if !strings.Contains(packet, "## References\n") {
+		t.Fatalf("support packet missing references section:\n%s", packet)
+	}
+	if strings.Count(packet, "- https://wiki.example.com/runbooks\n") != 1 {
+		t.Fatalf("expected the wiki link listed once:\n%s", packet)
+	}
+	if !strings.Contains(packet, "- {file:docs/api.md}\n") {
+		t.Fatalf("expected the project path as a file reference:\n%s", packet)
+	}
+}
+
+func TestHiringModuleSizesRosterFromSubscribedReadyBeads(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	seedPlanningArtifacts(t, ctx)
+	seedOrchestratorState(t, ctx)
+	seedCommunityCVs(t, ctx.Config, []agentFixture{
+		{Name: "Lyra", Precision: 7, Autonomy: 8, Experience: 6},
+	})
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	ctx.Orchestrator.SetBeadSubscriber(readySetSubscriber(`[{"id":"task-1","points":5},{"id":"task-2","points":8}]`))
+	watchCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := ctx.Orchestrator.WatchReadyBeads(watchCtx)
+	if err != nil {
+		t.Fatalf("watch ready beads: %v", err)
+	}
+	<-changes
+	runner := &fakeCommandRunner{}
+	agentWriter := func(_ *module.ModuleContext, entry workflow.WorkerEntry, _ string, targetFile, roleContext string) error {
+		return os.WriteFile(targetFile, []byte(fmt.Sprintf("# %s\nRole: %s\n", entry.Name, roleContext)), 0o644)
+	}
+	if _, err := New(WithCommandRunner(runner.Run), WithAgentBriefWriter(agentWriter)).Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if runner.readyCount != 0 {
+		t.Fatalf("expected the pushed ready set instead of polling bd, got %d poll(s)", runner.readyCount)
+	}
+	analysis := readJSONFile(t, ctx.Workflow.WorkersPath())["analysis"].(map[string]any)
+	if int(analysis["totalPoints"].(float64)) != 13 || int(analysis["beadCount"].(float64)) != 2 {
+		t.Fatalf("analysis should reflect the pushed ready set: %+v", analysis)
+	}
+}
+
+func TestHiringModuleSoloHiresOneWorker(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	seedPlanningArtifacts(t, ctx)
+	seedOrchestratorState(t, ctx)
+	seedCommunityCVs(t, ctx.Config, []agentFixture{
+		{Name: "Lyra", Precision: 7, Autonomy: 8, Experience: 6},
+		{Name: "Cass", Precision: 8, Autonomy: 9, Experience: 9},
+		{Name: "Mira", Precision: 6, Autonomy: 7, Experience: 8},
+	})
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	runner := &fakeCommandRunner{}
+	agentWriter := func(_ *module.ModuleContext, entry workflow.WorkerEntry, _ string, targetFile, roleContext string) error {
+		return os.WriteFile(targetFile, []byte(fmt.Sprintf("# %s\nRole: %s\n", entry.Name, roleContext)), 0o644)
+	}
+	opts, err := optionsFromConfig(module.Config{soloKey: true})
+	if err != nil {
+		t.Fatalf("optionsFromConfig: %v", err)
+	}
+	mod := New(append(opts, WithCommandRunner(runner.Run), WithAgentBriefWriter(agentWriter))...)
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != module.StatusCompleted {
+		t.Fatalf("unexpected status: %+v", result)
+	}
+	payload := readJSONFile(t, ctx.Workflow.WorkersPath())
+	workers := payload["workers"].([]any)
+	if len(workers) != 1 {
+		t.Fatalf("solo run should hire one worker, got %d: %v", len(workers), workers)
+	}
+	hire := workers[0].(map[string]any)
+	if hire["name"] != "Cass" || hire["role"] != workerRole {
+		t.Fatalf("expected the most capable denizen as the solo worker, got %v", hire)
+	}
+	if specialists, _ := payload["specialists"].([]any); len(specialists) != 0 {
+		t.Fatalf("solo run should not hire specialists: %v", specialists)
+	}
+	if _, err := os.Stat(filepath.Join(ctx.Config.AgentsDir(), "workers", slugifyName("Cass"), "AGENT.md")); err != nil {
+		t.Fatalf("solo worker agent file missing: %v", err)
+	}
+}
+
+func TestHiringModuleWithoutSparkFallbackExplainsShortfall(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	seedPlanningArtifacts(t, ctx)
+	seedOrchestratorState(t, ctx)
+	if err := os.MkdirAll(ctx.Config.CommunitiesDir(), 0o755); err != nil {
+		t.Fatalf("mkdir communities: %v", err)
+	}
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	runner := &fakeCommandRunner{}
+	opts, err := optionsFromConfig(module.Config{sparkFallbackKey: false})
+	if err != nil {
+		t.Fatalf("options from config: %v", err)
+	}
+	mod := New(append(opts, WithCommandRunner(runner.Run))...)
+	result, err := mod.Run(ctx)
+	if err == nil {
+		t.Fatalf("expected hiring to fail without denizens, got %+v", result)
+	}
+	if result.Status != module.StatusFailed {
+		t.Fatalf("unexpected status: %+v", result)
+	}
+	needed := minWorkersRequired + defaultSpecialists
+	for _, want := range []string{
+		fmt.Sprintf("roster needs %d denizens but only 0 are available (short by %d)", needed, needed),
+		"no denizen CVs were found",
+		"communities: in " + ctx.Config.ProjectConfigPath(),
+		filepath.Join(ctx.Config.CommunitiesDir(), "<community>", "cvs", "<name>"),
+		"set spark_fallback: true",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error missing %q:\n%v", want, err)
+		}
+	}
+	if _, statErr := os.Stat(ctx.Workflow.WorkersPath()); !os.IsNotExist(statErr) {
+		t.Fatalf("workers.json should not be written on a shortfall: %v", statErr)
+	}
+}
+
+func TestSelectAgentsRandomSeededIsDeterministic(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	seedCommunityCVs(t, ctx.Config, []agentFixture{
+		{Name: "Aria"}, {Name: "Bram"}, {Name: "Cass"}, {Name: "Dune"}, {Name: "Esme"}, {Name: "Fenn"},
+	})
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	pick := func(seed int64) []string {
+		t.Helper()
+		mod := New(WithSelectionOrder(SelectionRandomSeeded))
+		hires, err := mod.selectAgents(ctx, 3, 3, false, seed)
+		if err != nil {
+			t.Fatalf("select agents: %v", err)
+		}
+		return hireNames(hires)
+	}
+	first, again := pick(42), pick(42)
+	if strings.Join(first, ",") != strings.Join(again, ",") {
+		t.Fatalf("seed 42 picked %v then %v", first, again)
+	}
+	if got := strings.Join(first, ","); got == "Aria,Bram,Cass" {
+		t.Fatalf("random-seeded order should not match alpha for seed 42: %s", got)
+	}
+	if strings.Join(pick(7), ",") == strings.Join(first, ",") {
+		t.Fatalf("seeds 7 and 42 picked the same roster %v", first)
+	}
+}
+
+func TestSelectAgentsLeastRecentlyHiredUsesParticipation(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	seedCommunityCVs(t, ctx.Config, []agentFixture{{Name: "Aria"}, {Name: "Bram"}, {Name: "Cass"}, {Name: "Dune"}})
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	earlier := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	if err := recordParticipation(ctx.Config, []rosterAssignment{
+		{Entry: workflow.WorkerEntry{Name: "Aria"}},
+		{Entry: workflow.WorkerEntry{Name: "Bram"}},
+		{Entry: workflow.WorkerEntry{Name: "[spark-01]", IsSpark: true}},
+	}, earlier); err != nil {
+		t.Fatalf("record participation: %v", err)
+	}
+	if err := recordParticipation(ctx.Config, []rosterAssignment{{Entry: workflow.WorkerEntry{Name: "Aria"}}}, later); err != nil {
+		t.Fatalf("record participation: %v", err)
+	}
+	mod := New(WithSelectionOrder(SelectionLeastRecentlyHired))
+	hires, err := mod.selectAgents(ctx, 4, 4, false, 0)
+	if err != nil {
+		t.Fatalf("select agents: %v", err)
+	}
+	if got, want := strings.Join(hireNames(hires), ","), "Cass,Dune,Bram,Aria"; got != want {
+		t.Fatalf("least-recently-hired order = %s, want %s", got, want)
+	}
+	tally, err := loadParticipation(ctx.Config)
+	if err != nil {
+		t.Fatalf("load participation: %v", err)
+	}
+	if tally["aria"].Hires != 2 || !tally["aria"].LastHired.Equal(later) {
+		t.Fatalf("aria tally = %+v", tally["aria"])
+	}
+	if _, ok := tally["[spark-01]"]; ok {
+		t.Fatalf("SPARK hires should not be tallied: %v", tally)
+	}
+}
+
+func hireNames(hires []rosterAssignment) []string {
+	names := make([]string, len(hires))
+	for i, hire := range hires {
+		names[i] = hire.Entry.Name
+	}
+	return names
+}
+
+func TestHiringModuleCapturesCommandOutput(t *testing.T) {
+	ctx := newHiringTestContext(t)
+	started := time.Date(2026, 2, 4, 9, 30, 0, 0, time.UTC)
+	runCtx, file, err := ctx.OpenRunLog(moduleID, started)
+	if err != nil {
+		t.Fatalf("open run log: %v", err)
+	}
+	runner := &fakeCommandRunner{}
+	mod := New(WithCommandRunner(runner.Run))
+	if _, _, err := mod.analyzeWorkload(runCtx); err != nil {
+		t.Fatalf("analyze workload: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close run log: %v", err)
+	}
+
+	want := filepath.Join(ctx.Config.LatticeProjectDir, "logs", "modules", "hiring-20260204T093000Z.log")
+	if runCtx.RunLogPath != want {
+		t.Fatalf("run log path = %s, want %s", runCtx.RunLogPath, want)
+	}
+	body, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatalf("read run log: %v", err)
+	}
+	for _, line := range []string{"$ bd ready --json  (in " + ctx.Config.ProjectDir + ")", `[{"id":"task-1","points":5}]`} {
+		if !strings.Contains(string(body), line) {
+			t.Fatalf("run log missing %q:\n%s", line, body)
+		}
+	}
+}
+
 type fakeCommandRunner struct {
 	createCount int
 	readyCount  int
@@ -251,3 +653,16 @@ func readJSONFile(t *testing.T, path string) map[string]any {
 	}
 	return payload
 }
+
+// readySetSubscriber delivers one ready set and ends with the subscription.
+type readySetSubscriber string
+
+func (s readySetSubscriber) SubscribeReady(ctx context.Context) (<-chan []byte, error) {
+	updates := make(chan []byte, 1)
+	updates <- []byte(s)
+	go func() {
+		<-ctx.Done()
+		close(updates)
+	}()
+	return updates, nil
+}
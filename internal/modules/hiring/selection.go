@@ -0,0 +1,146 @@
+package hiring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
+)
+
+// SelectionOrder decides which denizens fill the roster first.
+type SelectionOrder string
+
+const (
+	// SelectionAlpha takes denizens in name order (the default).
+	SelectionAlpha SelectionOrder = "alpha"
+	// SelectionRandomSeeded shuffles denizens with a seed, so the same seed
+	// always hires the same roster.
+	SelectionRandomSeeded SelectionOrder = "random-seeded"
+	// SelectionLeastRecentlyHired puts denizens who were never hired first,
+	// then those whose last hire is oldest, using the participation tally.
+	SelectionLeastRecentlyHired SelectionOrder = "least-recently-hired"
+	// SelectionCommunityBalanced alternates between communities so no single
+	// community fills the roster while others have denizens to offer.
+	SelectionCommunityBalanced SelectionOrder = "community-balanced"
+)
+
+// participationFile is the tally of past hires under the state directory.
+const participationFile = "hire-participation.json"
+
+// ParseSelectionOrder validates a selection_order setting. An empty value
+// selects SelectionAlpha.
+func ParseSelectionOrder(value string) (SelectionOrder, error) {
+	switch order := SelectionOrder(strings.ToLower(strings.TrimSpace(value))); order {
+	case "":
+		return SelectionAlpha, nil
+	case SelectionAlpha, SelectionRandomSeeded, SelectionLeastRecentlyHired, SelectionCommunityBalanced:
+		return order, nil
+	default:
+		return "", fmt.Errorf("unknown selection order %q (want alpha, random-seeded, least-recently-hired, or community-balanced)", value)
+	}
+}
+
+// participation records how often and how recently a denizen was hired.
+type participation struct {
+	Hires     int       `json:"hires"`
+	LastHired time.Time `json:"lastHired"`
+}
+
+// participationTally maps a lowercased denizen name to its hire record.
+type participationTally map[string]participation
+
+func participationPath(cfg *config.Config) string {
+	return filepath.Join(cfg.StateDir(), participationFile)
+}
+
+// loadParticipation reads the tally; a missing file is an empty tally.
+func loadParticipation(cfg *config.Config) (participationTally, error) {
+	data, err := os.ReadFile(participationPath(cfg))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return participationTally{}, nil
+		}
+		return nil, err
+	}
+	tally := participationTally{}
+	if err := json.Unmarshal(data, &tally); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", participationFile, err)
+	}
+	return tally, nil
+}
+
+// recordParticipation adds one hire at now for every non-SPARK hire.
+func recordParticipation(cfg *config.Config, hires []rosterAssignment, now time.Time) error {
+	tally, err := loadParticipation(cfg)
+	if err != nil {
+		return err
+	}
+	for _, hire := range hires {
+		if hire.Entry.IsSpark {
+			continue
+		}
+		key := denizenKey(hire.Entry.Name)
+		record := tally[key]
+		record.Hires++
+		record.LastHired = now
+		tally[key] = record
+	}
+	data, err := json.MarshalIndent(tally, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(cfg.StateDir(), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(participationPath(cfg), data, 0o644)
+}
+
+func denizenKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// orderCandidates reorders agents, which arrive in name order, for order.
+func orderCandidates(agents []orchestrator.Agent, order SelectionOrder, seed int64, tally participationTally) {
+	switch order {
+	case SelectionRandomSeeded:
+		rng := rand.New(rand.NewSource(seed))
+		rng.Shuffle(len(agents), func(i, j int) { agents[i], agents[j] = agents[j], agents[i] })
+	case SelectionLeastRecentlyHired:
+		sort.SliceStable(agents, func(i, j int) bool {
+			a, b := tally[denizenKey(agents[i].Name)], tally[denizenKey(agents[j].Name)]
+			if !a.LastHired.Equal(b.LastHired) {
+				return a.LastHired.Before(b.LastHired)
+			}
+			return a.Hires < b.Hires
+		})
+	case SelectionCommunityBalanced:
+		byCommunity := make(map[string][]orchestrator.Agent)
+		var communities []string
+		for _, agent := range agents {
+			key := denizenKey(agent.Community)
+			if _, ok := byCommunity[key]; !ok {
+				communities = append(communities, key)
+			}
+			byCommunity[key] = append(byCommunity[key], agent)
+		}
+		sort.Strings(communities)
+		balanced := agents[:0:0]
+		for len(balanced) < len(agents) {
+			for _, community := range communities {
+				if members := byCommunity[community]; len(members) > 0 {
+					balanced = append(balanced, members[0])
+					byCommunity[community] = members[1:]
+				}
+			}
+		}
+		copy(agents, balanced)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/modules/bead_creation"
 	"github.com/kingrea/The-Lattice/internal/modules/consolidation"
 	"github.com/kingrea/The-Lattice/internal/modules/hiring"
+	"github.com/kingrea/The-Lattice/internal/modules/opencode_config"
 	"github.com/kingrea/The-Lattice/internal/modules/orchestrator_selection"
 	"github.com/kingrea/The-Lattice/internal/modules/parallel_reviews"
 	"github.com/kingrea/The-Lattice/internal/modules/refinement"
@@ -27,6 +28,7 @@ func RegisterBuiltins(reg *module.Registry) {
 	action_plan.Register(reg)
 	bead_creation.Register(reg)
 	consolidation.Register(reg)
+	opencode_config.Register(reg)
 	orchestrator_selection.Register(reg)
 	parallel_reviews.Register(reg)
 	refinement.Register(reg)
@@ -0,0 +1,23 @@
+package opencode_config
+
+// Package opencode_config documents the standalone module that regenerates
+// `opencode.jsonc` from the current orchestrator state.
+//
+// Required inputs (read-only artifacts under `.lattice/workflow/`):
+//   - `orchestrator.json` (`artifact.OrchestratorState`) describing the active
+//     orchestrator. The payload must parse as JSON and carry a non-empty `name`
+//     that matches a generated AGENT.md under `.lattice/agents/`.
+//
+// Runtime dependencies:
+//   - `ModuleContext.Orchestrator` is used when present; otherwise the module
+//     builds one from `ModuleContext.Config` so it can run via
+//     `module-runner --module opencode-config`.
+//
+// Outputs:
+//   - A rewritten `opencode.jsonc` in the project root whose `default_agent`
+//     is the orchestrator named in `orchestrator.json`. The previous file is
+//     backed up the same way `orchestrator.RefreshOpenCodeConfig()` does.
+//
+// The module is not part of the built-in workflows. Run it on demand when the
+// config drifts or the orchestrator identity changes; every run refreshes the
+// file even when it already exists.
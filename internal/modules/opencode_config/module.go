@@ -0,0 +1,130 @@
+package opencode_config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/modules/runtime"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
+)
+
+const (
+	moduleID      = "opencode-config"
+	moduleVersion = "1.0.0"
+
+	openCodeConfigFile = "opencode.jsonc"
+)
+
+// OpenCodeConfigModule regenerates opencode.jsonc from the orchestrator state
+// on demand.
+type OpenCodeConfigModule struct {
+	*module.Base
+}
+
+// Register installs the module factory.
+func Register(reg *module.Registry) {
+	if reg == nil {
+		return
+	}
+	reg.MustRegister(moduleID, func(module.Config) (module.Module, error) {
+		return New(), nil
+	})
+}
+
+// New configures IO contracts.
+func New() *OpenCodeConfigModule {
+	info := module.Info{
+		ID:          moduleID,
+		Name:        "Refresh OpenCode Config",
+		Description: "Validates orchestrator.json and regenerates opencode.jsonc so sessions default to the current orchestrator.",
+		Version:     moduleVersion,
+	}
+	base := module.NewBase(info)
+	base.SetInputs(artifact.OrchestratorState)
+	return &OpenCodeConfigModule{Base: &base}
+}
+
+// Run validates orchestrator.json and rewrites opencode.jsonc. The refresh
+// happens on every run so drifted configs can be repaired.
+func (m *OpenCodeConfigModule) Run(ctx *module.ModuleContext) (module.Result, error) {
+	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
+	name, err := m.orchestratorName(ctx)
+	if err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
+	orch := ctx.Orchestrator
+	if orch == nil {
+		if ctx.Config == nil {
+			return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: orchestrator handle unavailable", moduleID)
+		}
+		orch = orchestrator.New(ctx.Config)
+	}
+	if err := orch.RefreshOpenCodeConfigFor(name); err != nil {
+		return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: refresh opencode config: %w", moduleID, err)
+	}
+	if ctx.Logbook != nil {
+		ctx.Logbook.Info("Refreshed %s for orchestrator %s", openCodeConfigFile, name)
+	}
+	return module.Result{Status: module.StatusCompleted, Message: fmt.Sprintf("refreshed %s for %s", openCodeConfigFile, name)}, nil
+}
+
+// IsComplete reports true when opencode.jsonc exists and is at least as new as
+// orchestrator.json.
+func (m *OpenCodeConfigModule) IsComplete(ctx *module.ModuleContext) (bool, error) {
+	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
+		return false, err
+	}
+	if ctx.Config == nil {
+		return false, nil
+	}
+	stateInfo, err := os.Stat(artifact.OrchestratorState.Path(ctx.Workflow))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s: stat orchestrator.json: %w", moduleID, err)
+	}
+	configInfo, err := os.Stat(filepath.Join(ctx.Config.ProjectDir, openCodeConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s: stat %s: %w", moduleID, openCodeConfigFile, err)
+	}
+	return !configInfo.ModTime().Before(stateInfo.ModTime()), nil
+}
+
+func (m *OpenCodeConfigModule) orchestratorName(ctx *module.ModuleContext) (string, error) {
+	result, err := ctx.Artifacts.Check(artifact.OrchestratorState)
+	if err != nil {
+		return "", fmt.Errorf("%s: check orchestrator.json: %w", moduleID, err)
+	}
+	if result.State != artifact.StateReady {
+		if result.Err != nil {
+			return "", fmt.Errorf("%s: orchestrator.json is %s: %w", moduleID, result.State, result.Err)
+		}
+		return "", fmt.Errorf("%s: orchestrator.json is %s", moduleID, result.State)
+	}
+	data, err := os.ReadFile(artifact.OrchestratorState.Path(ctx.Workflow))
+	if err != nil {
+		return "", fmt.Errorf("%s: read orchestrator.json: %w", moduleID, err)
+	}
+	var payload struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", fmt.Errorf("%s: parse orchestrator.json: %w", moduleID, err)
+	}
+	name := strings.TrimSpace(payload.Name)
+	if name == "" {
+		return "", fmt.Errorf("%s: orchestrator.json does not name an orchestrator", moduleID)
+	}
+	return name, nil
+}
@@ -0,0 +1,154 @@
+package opencode_config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/modules/opencode_config"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func TestRunRegeneratesConfigFromOrchestratorState(t *testing.T) {
+	ctx := newModuleContext(t)
+	writeProjectAgent(t, ctx.Config, "orchestrator/cass", "Cass", "Orchestrator")
+	writeProjectAgent(t, ctx.Config, "workers/lyra", "Lyra", "Engineer")
+	writeOrchestratorState(t, ctx, `{"name":"Lyra"}`)
+	stale := filepath.Join(ctx.Config.ProjectDir, "opencode.jsonc")
+	if err := os.WriteFile(stale, []byte("{\"default_agent\":\"ghost\"}\n"), 0o644); err != nil {
+		t.Fatalf("write stale config: %v", err)
+	}
+
+	mod := opencode_config.New()
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != module.StatusCompleted {
+		t.Fatalf("unexpected status: %+v", result)
+	}
+	cfg := readOpenCodeConfig(t, stale)
+	if cfg.DefaultAgent != "lyra" {
+		t.Fatalf("expected lyra as default agent, got %q", cfg.DefaultAgent)
+	}
+	for _, slug := range []string{"cass", "lyra"} {
+		if _, ok := cfg.Agent[slug]; !ok {
+			t.Fatalf("expected agent %s in regenerated config: %+v", slug, cfg.Agent)
+		}
+	}
+	if complete, err := mod.IsComplete(ctx); err != nil || !complete {
+		t.Fatalf("expected completion after refresh (complete=%v err=%v)", complete, err)
+	}
+}
+
+func TestRunRejectsInvalidOrchestratorState(t *testing.T) {
+	cases := map[string]string{
+		"missing":       "",
+		"no metadata":   `{"name":"Cass"}`,
+		"empty name":    `{"name":"  "}`,
+		"unknown agent": `{"name":"Nobody"}`,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := newModuleContext(t)
+			writeProjectAgent(t, ctx.Config, "orchestrator/cass", "Cass", "Orchestrator")
+			switch name {
+			case "missing":
+			case "no metadata":
+				path := ctx.Workflow.OrchestratorPath()
+				if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+					t.Fatalf("write orchestrator.json: %v", err)
+				}
+			default:
+				writeOrchestratorState(t, ctx, body)
+			}
+			result, err := opencode_config.New().Run(ctx)
+			if err == nil {
+				t.Fatalf("expected error, got %+v", result)
+			}
+			if result.Status != module.StatusFailed {
+				t.Fatalf("expected failed status, got %+v", result)
+			}
+			if _, statErr := os.Stat(filepath.Join(ctx.Config.ProjectDir, "opencode.jsonc")); !os.IsNotExist(statErr) {
+				t.Fatalf("expected opencode.jsonc to stay untouched, stat err=%v", statErr)
+			}
+		})
+	}
+}
+
+func newModuleContext(t *testing.T) *module.ModuleContext {
+	t.Helper()
+	projectDir := t.TempDir()
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	cfg := &config.Config{
+		ProjectDir:        projectDir,
+		LatticeProjectDir: filepath.Join(projectDir, config.LatticeDir),
+		LatticeRoot:       projectDir,
+	}
+	wf := workflow.New(cfg.LatticeProjectDir)
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	return &module.ModuleContext{
+		Config:    cfg,
+		Workflow:  wf,
+		Artifacts: artifact.NewStore(wf),
+	}
+}
+
+func writeProjectAgent(t *testing.T, cfg *config.Config, rel, name, role string) {
+	t.Helper()
+	dir := filepath.Join(cfg.AgentsDir(), rel)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir agent dir: %v", err)
+	}
+	content := "---\nname: " + name + "\nrole: " + role + "\n---\n\n" + name + " keeps the work moving.\n"
+	if err := os.WriteFile(filepath.Join(dir, "AGENT.md"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write AGENT.md: %v", err)
+	}
+}
+
+func writeOrchestratorState(t *testing.T, ctx *module.ModuleContext, body string) {
+	t.Helper()
+	meta := artifact.Metadata{
+		ArtifactID: artifact.OrchestratorState.ID,
+		ModuleID:   "orchestrator-selection",
+		Version:    "1.0.0",
+		Workflow:   ctx.Workflow.Dir(),
+	}
+	if err := ctx.Artifacts.Write(artifact.OrchestratorState, []byte(body), meta); err != nil {
+		t.Fatalf("write orchestrator.json: %v", err)
+	}
+}
+
+type openCodeConfig struct {
+	DefaultAgent string                    `json:"default_agent"`
+	Agent        map[string]map[string]any `json:"agent"`
+}
+
+func readOpenCodeConfig(t *testing.T, path string) openCodeConfig {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read opencode.jsonc: %v", err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	var cfg openCodeConfig
+	if err := json.Unmarshal([]byte(strings.Join(lines, "\n")), &cfg); err != nil {
+		t.Fatalf("parse opencode.jsonc: %v", err)
+	}
+	return cfg
+}
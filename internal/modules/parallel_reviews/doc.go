@@ -15,3 +15,14 @@ package parallel_reviews
 //   - REVIEW_SIMPLIFIER.md (`artifact.ReviewSimplifierDoc`)
 //   - REVIEW_USER_ADVOCATE.md (`artifact.ReviewAdvocateDoc`)
 //   - REVIEW_SKEPTIC.md (`artifact.ReviewSkepticDoc`)
+//
+// Each reviewer may declare extra context documents through the
+// `reviewer_context` module config (reviewer key to list of paths, relative to
+// the project directory). Those paths are only added to that reviewer's prompt.
+//
+// Setting `meta_review: true` adds a fifth step that runs after the other four.
+// Once the four reviews exist, a meta-reviewer compares them with past rounds
+// archived under `.lattice/state/review-archive/` and writes REVIEW_META.md
+// (`artifact.ReviewMetaDoc`). It flags issues that earlier rounds caught but
+// this round missed. Every completed round is archived there, with or without
+// the meta-reviewer, so history builds up before it is switched on.
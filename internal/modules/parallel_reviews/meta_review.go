@@ -0,0 +1,127 @@
+package parallel_reviews
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/module"
+)
+
+// reviewArchiveDir holds one directory per completed review round under the
+// state directory, so archives survive workflow resets.
+const reviewArchiveDir = "review-archive"
+
+// archiveStampFormat names a round's archive directory after its newest file.
+const archiveStampFormat = "20060102T150405Z"
+
+const metaReviewPersonality = `You are THE META-REVIEWER. Your role is to check the reviewers against their own history.
+Ask yourself: Which problems did earlier review rounds catch that this round missed, even though they still apply to the plan?
+Look for: Recurring risks that went unmentioned, reviewers that stopped flagging issues they used to raise, earlier [CRITICAL] findings with no counterpart now.
+Your tone: Factual and specific. Name the reviewer, the past round, and the issue.`
+
+// metaReviewDue reports whether the meta-reviewer is enabled, the four reviews
+// are ready, and REVIEW_META.md has not been written yet.
+func (m *ParallelReviewsModule) metaReviewDue(ctx *module.ModuleContext) (bool, error) {
+	if !m.metaReview {
+		return false, nil
+	}
+	if ready, err := m.reviewsReady(ctx); err != nil || !ready {
+		return false, err
+	}
+	result, err := ctx.Artifacts.Check(artifact.ReviewMetaDoc)
+	if result.State == artifact.StateError {
+		return false, fmt.Errorf("%s: check %s: %w", moduleID, artifact.ReviewMetaDoc.ID, err)
+	}
+	return result.State == artifact.StateMissing, nil
+}
+
+func (m *ParallelReviewsModule) launchMetaReview(ctx *module.ModuleContext) (module.Result, error) {
+	window := fmt.Sprintf("review-meta-%d", time.Now().Unix())
+	if err := createTmuxWindow(window, ctx.Config.ProjectDir); err != nil {
+		return module.Result{Status: module.StatusFailed}, fmt.Errorf("parallel-reviews: create window for meta review: %w", err)
+	}
+	if err := runOpenCode(window, m.metaReviewPrompt(ctx)); err != nil {
+		killTmuxWindow(window)
+		return module.Result{Status: module.StatusFailed}, fmt.Errorf("parallel-reviews: launch meta review: %w", err)
+	}
+	m.metaWindow = window
+	return module.Result{Status: module.StatusNeedsInput, Message: fmt.Sprintf("meta review running in %s", window)}, nil
+}
+
+func (m *ParallelReviewsModule) metaReviewPrompt(ctx *module.ModuleContext) string {
+	reviews := make([]string, 0, len(m.reviewers))
+	for _, reviewer := range m.reviewers {
+		reviews = append(reviews, fmt.Sprintf("%s (%s)", reviewer.artifactRef.Path(ctx.Workflow), reviewer.name))
+	}
+	return fmt.Sprintf(
+		"%s Read this round's reviews: %s. Read the plan from %s. "+
+			"Past review rounds are archived under %s, one directory per round holding that round's reviewer files. "+
+			"If the archive is missing or empty, write that there is no history to compare against yet. "+
+			"Otherwise list every issue a past round raised that still applies to the current plan but no reviewer raised this time, "+
+			"starting each such line with [MISSED] and the reviewer who raised it before. "+
+			"Write your review to %s. Do not end until the file is written.",
+		metaReviewPersonality,
+		strings.Join(reviews, ", "),
+		ctx.Workflow.ActionDir(),
+		filepath.Join(ctx.Config.StateDir(), reviewArchiveDir),
+		artifact.ReviewMetaDoc.Path(ctx.Workflow),
+	)
+}
+
+func (m *ParallelReviewsModule) reviewerRefs() []artifact.ArtifactRef {
+	refs := make([]artifact.ArtifactRef, 0, len(m.reviewers))
+	for _, reviewer := range m.reviewers {
+		refs = append(refs, reviewer.artifactRef)
+	}
+	return refs
+}
+
+// archivedRefs lists the files copied into the review archive for a round.
+func (m *ParallelReviewsModule) archivedRefs() []artifact.ArtifactRef {
+	refs := m.reviewerRefs()
+	if m.metaReview {
+		refs = append(refs, artifact.ReviewMetaDoc)
+	}
+	return refs
+}
+
+// archiveReviewRound copies a completed round into the review archive. The
+// directory is named after the newest file's creation time, so calling it
+// again for the same round is a no-op.
+func archiveReviewRound(ctx *module.ModuleContext, refs []artifact.ArtifactRef) error {
+	var newest time.Time
+	for _, ref := range refs {
+		result, err := ctx.Artifacts.Check(ref)
+		if err != nil {
+			return fmt.Errorf("%s: check %s: %w", moduleID, ref.ID, err)
+		}
+		if result.Metadata != nil && result.Metadata.CreatedAt.After(newest) {
+			newest = result.Metadata.CreatedAt
+		}
+	}
+	dir := filepath.Join(ctx.Config.StateDir(), reviewArchiveDir, newest.UTC().Format(archiveStampFormat))
+	if _, err := os.Stat(dir); err == nil {
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s: check review archive: %w", moduleID, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("%s: create review archive: %w", moduleID, err)
+	}
+	for _, ref := range refs {
+		path := ref.Path(ctx.Workflow)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: archive %s: %w", moduleID, ref.ID, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(path)), data, 0o644); err != nil {
+			return fmt.Errorf("%s: archive %s: %w", moduleID, ref.ID, err)
+		}
+	}
+	return nil
+}
@@ -3,12 +3,15 @@ package parallel_reviews
 import (
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/modules/runtime"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 )
 
 const (
@@ -20,6 +23,12 @@ type reviewer struct {
 	name        string
 	artifactRef artifact.ArtifactRef
 	personality string
+	contextDocs []string
+}
+
+// key returns the config identifier for the reviewer ("user-advocate").
+func (r reviewer) key() string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(r.name)), " ", "-")
 }
 
 var reviewerConfigs = []reviewer{
@@ -61,11 +70,59 @@ Write your review to the specified file.`,
 	},
 }
 
+// reviewerContextKey is the module config key mapping reviewer keys to extra
+// context documents, e.g. {"skeptic": ["docs/threat-model.md"]}.
+const reviewerContextKey = "reviewer_context"
+
+// metaReviewKey is the module config key enabling the meta-reviewer.
+const metaReviewKey = "meta_review"
+
+// configSchema lists the config keys parallel-reviews accepts.
+var configSchema = module.ConfigSchema{
+	reviewerContextKey: {Type: module.ConfigMap, Description: "extra context documents per reviewer"},
+	metaReviewKey:      {Type: module.ConfigBool, Description: "run a meta-reviewer against past review rounds after the four reviewers"},
+}
+
+// Option customizes the parallel reviews module.
+type Option func(*ParallelReviewsModule)
+
+// WithReviewerContext adds extra context documents to a single reviewer's
+// prompt. Reviewers are matched by name ("Skeptic") or key ("user-advocate").
+// Relative paths resolve against the project directory.
+func WithReviewerContext(name string, paths ...string) Option {
+	return func(m *ParallelReviewsModule) {
+		target := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "-")
+		for i := range m.reviewers {
+			if m.reviewers[i].key() != target {
+				continue
+			}
+			for _, path := range paths {
+				if trimmed := strings.TrimSpace(path); trimmed != "" {
+					m.reviewers[i].contextDocs = append(m.reviewers[i].contextDocs, trimmed)
+				}
+			}
+		}
+	}
+}
+
+// WithMetaReview runs the meta-reviewer once the four reviews are written.
+// It compares them against archived past rounds and writes REVIEW_META.md.
+func WithMetaReview(enabled bool) Option {
+	return func(m *ParallelReviewsModule) {
+		m.metaReview = enabled
+	}
+}
+
 // ParallelReviewsModule spawns four tmux windows (one per reviewer) and tracks
 // the resulting artifacts.
 type ParallelReviewsModule struct {
 	*module.Base
+	reviewers   []reviewer
 	windowNames []string
+	// metaReview adds REVIEW_META.md to the outputs; metaWindow is the tmux
+	// window of a running meta-reviewer.
+	metaReview bool
+	metaWindow string
 }
 
 // Register installs the module factory.
@@ -73,13 +130,17 @@ func Register(reg *module.Registry) {
 	if reg == nil {
 		return
 	}
-	reg.MustRegister(moduleID, func(module.Config) (module.Module, error) {
-		return New(), nil
+	reg.MustRegisterWithSchema(moduleID, configSchema, func(cfg module.Config) (module.Module, error) {
+		opts, err := optionsFromConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return New(opts...), nil
 	})
 }
 
 // New configures module metadata and IO contracts.
-func New() *ParallelReviewsModule {
+func New(opts ...Option) *ParallelReviewsModule {
 	info := module.Info{
 		ID:          moduleID,
 		Name:        "Parallel Reviews",
@@ -101,7 +162,16 @@ func New() *ParallelReviewsModule {
 		artifact.ReviewAdvocateDoc,
 		artifact.ReviewSkepticDoc,
 	)
-	return &ParallelReviewsModule{Base: &base}
+	mod := &ParallelReviewsModule{Base: &base, reviewers: cloneReviewers(reviewerConfigs)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(mod)
+		}
+	}
+	if mod.metaReview {
+		mod.SetOutputs(append(mod.Outputs(), artifact.ReviewMetaDoc)...)
+	}
+	return mod
 }
 
 // Run validates prerequisites and starts the reviewer sessions when needed.
@@ -122,24 +192,22 @@ func (m *ParallelReviewsModule) Run(ctx *module.ModuleContext) (module.Result, e
 	if len(m.windowNames) > 0 {
 		return module.Result{Status: module.StatusNeedsInput, Message: fmt.Sprintf("parallel reviews running in %d windows", len(m.windowNames))}, nil
 	}
-	planDir := ctx.Workflow.PlanDir()
-	actionDir := ctx.Workflow.ActionDir()
-	windows := make([]string, len(reviewerConfigs))
-	for i, reviewer := range reviewerConfigs {
+	if m.metaWindow != "" {
+		return module.Result{Status: module.StatusNeedsInput, Message: fmt.Sprintf("meta review running in %s", m.metaWindow)}, nil
+	}
+	if due, err := m.metaReviewDue(ctx); err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	} else if due {
+		return m.launchMetaReview(ctx)
+	}
+	windows := make([]string, len(m.reviewers))
+	for i, reviewer := range m.reviewers {
 		window := fmt.Sprintf("review-%s-%d", strings.ToLower(reviewer.name), time.Now().Unix())
 		if err := createTmuxWindow(window, ctx.Config.ProjectDir); err != nil {
 			m.killWindows(windows[:i])
 			return module.Result{Status: module.StatusFailed}, fmt.Errorf("parallel-reviews: create window for %s: %w", reviewer.name, err)
 		}
-		reviewPath := reviewer.artifactRef.Path(ctx.Workflow)
-		prompt := fmt.Sprintf(
-			"%s Read all planning documents from %s and action plan from %s. Write your review to %s. Be specific and actionable. Do not end until your review file is written.",
-			reviewer.personality,
-			planDir,
-			actionDir,
-			reviewPath,
-		)
-		if err := runOpenCode(window, prompt); err != nil {
+		if err := runOpenCode(window, m.reviewerPrompt(ctx, reviewer)); err != nil {
 			m.killWindows(windows[:i+1])
 			return module.Result{Status: module.StatusFailed}, fmt.Errorf("parallel-reviews: launch %s: %w", reviewer.name, err)
 		}
@@ -150,13 +218,36 @@ func (m *ParallelReviewsModule) Run(ctx *module.ModuleContext) (module.Result, e
 	return module.Result{Status: module.StatusNeedsInput, Message: fmt.Sprintf("parallel reviews running in %d windows", len(windows))}, nil
 }
 
-// IsComplete checks whether all reviewer artifacts exist with metadata.
+// IsComplete checks whether all reviewer artifacts exist with metadata, plus
+// REVIEW_META.md when the meta-reviewer is enabled. A complete round is copied
+// to the review archive so later meta reviews can compare against it.
 func (m *ParallelReviewsModule) IsComplete(ctx *module.ModuleContext) (bool, error) {
 	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
 		return false, err
 	}
+	if ready, err := m.reviewsReady(ctx); err != nil || !ready {
+		return false, err
+	}
+	m.killWindows(m.windowNames)
+	m.windowNames = nil
+	if m.metaReview {
+		ready, err := runtime.EnsureDocument(ctx, moduleID, moduleVersion, artifact.ReviewMetaDoc, runtime.WithInputs(m.reviewerRefs()...))
+		if err != nil || !ready {
+			return false, err
+		}
+		killTmuxWindow(m.metaWindow)
+		m.metaWindow = ""
+	}
+	if err := archiveReviewRound(ctx, m.archivedRefs()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// reviewsReady reports whether the four reviewer files exist with metadata.
+func (m *ParallelReviewsModule) reviewsReady(ctx *module.ModuleContext) (bool, error) {
 	inputs := runtime.WithInputs(m.Inputs()...)
-	for _, reviewer := range reviewerConfigs {
+	for _, reviewer := range m.reviewers {
 		ready, err := runtime.EnsureDocument(ctx, moduleID, moduleVersion, reviewer.artifactRef, inputs)
 		if err != nil {
 			return false, err
@@ -165,11 +256,30 @@ func (m *ParallelReviewsModule) IsComplete(ctx *module.ModuleContext) (bool, err
 			return false, nil
 		}
 	}
-	m.killWindows(m.windowNames)
-	m.windowNames = nil
 	return true, nil
 }
 
+func (m *ParallelReviewsModule) reviewerPrompt(ctx *module.ModuleContext, reviewer reviewer) string {
+	prompt := fmt.Sprintf(
+		"%s Read all planning documents from %s and action plan from %s.",
+		reviewer.personality,
+		ctx.Workflow.PlanDir(),
+		ctx.Workflow.ActionDir(),
+	)
+	if len(reviewer.contextDocs) > 0 {
+		paths := make([]string, len(reviewer.contextDocs))
+		for i, doc := range reviewer.contextDocs {
+			paths[i] = resolveContextPath(ctx.Config.ProjectDir, doc)
+		}
+		prompt += fmt.Sprintf(" Also read these reviewer-specific context documents: %s.", strings.Join(paths, ", "))
+	}
+	prompt += fmt.Sprintf(
+		" Write your review to %s. Be specific and actionable. Start any line describing a blocking, critical issue with [CRITICAL]. Do not end until your review file is written.",
+		reviewer.artifactRef.Path(ctx.Workflow),
+	)
+	return prompt
+}
+
 func (m *ParallelReviewsModule) missingInput(ctx *module.ModuleContext) (string, error) {
 	for _, ref := range m.Inputs() {
 		result, err := ctx.Artifacts.Check(ref)
@@ -192,6 +302,60 @@ func (m *ParallelReviewsModule) killWindows(names []string) {
 	}
 }
 
+func resolveContextPath(projectDir, path string) string {
+	if filepath.IsAbs(path) || strings.TrimSpace(projectDir) == "" {
+		return path
+	}
+	return filepath.Join(projectDir, path)
+}
+
+func cloneReviewers(src []reviewer) []reviewer {
+	out := make([]reviewer, len(src))
+	for i, r := range src {
+		out[i] = r
+		out[i].contextDocs = append([]string(nil), r.contextDocs...)
+	}
+	return out
+}
+
+func optionsFromConfig(cfg module.Config) ([]Option, error) {
+	var opts []Option
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, metaReviewKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithMetaReview(enabled))
+	}
+	raw, ok := cfg[reviewerContextKey]
+	if !ok || raw == nil {
+		return opts, nil
+	}
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s must be a map of reviewer to paths", moduleID, reviewerContextKey)
+	}
+	known := map[string]bool{}
+	for _, r := range reviewerConfigs {
+		known[r.key()] = true
+	}
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "-")
+		if !known[key] {
+			return nil, fmt.Errorf("%s: unknown reviewer %q in %s", moduleID, name, reviewerContextKey)
+		}
+		paths, err := runtime.StringList(entries[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s.%s: %w", moduleID, reviewerContextKey, name, err)
+		}
+		opts = append(opts, WithReviewerContext(key, paths...))
+	}
+	return opts, nil
+}
+
 func createTmuxWindow(name, dir string) error {
 	args := []string{"new-window", "-n", name}
 	if strings.TrimSpace(dir) != "" {
@@ -209,8 +373,10 @@ func killTmuxWindow(name string) {
 }
 
 func runOpenCode(window, prompt string) error {
-	escaped := strings.ReplaceAll(prompt, "\"", `\"`)
-	escaped = strings.ReplaceAll(escaped, "\n", " ")
-	cmd := exec.Command("tmux", "send-keys", "-t", window, fmt.Sprintf(`opencode --prompt "%s"`, escaped), "Enter")
+	command, err := opencode.Command(prompt, "")
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("tmux", "send-keys", "-t", window, command, "Enter")
 	return cmd.Run()
 }
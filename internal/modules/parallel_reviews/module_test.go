@@ -0,0 +1,136 @@
+package parallel_reviews
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func TestReviewerContextDocsOnlyReachConfiguredReviewer(t *testing.T) {
+	ctx := newReviewTestContext(t)
+	reg := module.NewRegistry()
+	Register(reg)
+	resolved, err := reg.Resolve(moduleID, module.Config{
+		"reviewer_context": map[string]any{
+			"skeptic": []any{"docs/threat-model.md"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	mod := resolved.(*ParallelReviewsModule)
+	threatModel := filepath.Join(ctx.Config.ProjectDir, "docs", "threat-model.md")
+	for _, reviewer := range mod.reviewers {
+		prompt := mod.reviewerPrompt(ctx, reviewer)
+		hasDoc := strings.Contains(prompt, threatModel)
+		if reviewer.name == "Skeptic" && !hasDoc {
+			t.Fatalf("skeptic prompt missing context doc: %s", prompt)
+		}
+		if reviewer.name != "Skeptic" && hasDoc {
+			t.Fatalf("%s prompt unexpectedly includes context doc", reviewer.name)
+		}
+	}
+}
+
+func TestReviewerContextRejectsUnknownReviewer(t *testing.T) {
+	reg := module.NewRegistry()
+	Register(reg)
+	_, err := reg.Resolve(moduleID, module.Config{
+		"reviewer_context": map[string]any{"auditor": []any{"docs/a.md"}},
+	})
+	if err == nil {
+		t.Fatalf("expected unknown reviewer error")
+	}
+}
+
+func TestMetaReviewRunsAfterReviewersAndArchivesRound(t *testing.T) {
+	ctx := newReviewTestContext(t)
+	reg := module.NewRegistry()
+	Register(reg)
+	resolved, err := reg.Resolve(moduleID, module.Config{"meta_review": true})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	mod := resolved.(*ParallelReviewsModule)
+	outputs := mod.Outputs()
+	if last := outputs[len(outputs)-1]; last.ID != artifact.ReviewMetaDoc.ID {
+		t.Fatalf("expected REVIEW_META.md among outputs, got %v", outputs)
+	}
+
+	if due, err := mod.metaReviewDue(ctx); err != nil || due {
+		t.Fatalf("meta review due before the reviewers finished: due=%t err=%v", due, err)
+	}
+	for _, reviewer := range mod.reviewers {
+		writeReview(t, ctx, reviewer.artifactRef, "## "+reviewer.name)
+	}
+	if due, err := mod.metaReviewDue(ctx); err != nil || !due {
+		t.Fatalf("meta review should be due once all four reviews exist: due=%t err=%v", due, err)
+	}
+	if complete, err := mod.IsComplete(ctx); err != nil || complete {
+		t.Fatalf("module complete without REVIEW_META.md: complete=%t err=%v", complete, err)
+	}
+	prompt := mod.metaReviewPrompt(ctx)
+	archive := filepath.Join(ctx.Config.StateDir(), "review-archive")
+	for _, want := range []string{ctx.Workflow.ReviewSkepticPath(), ctx.Workflow.ReviewPragmatistPath(), archive, ctx.Workflow.ReviewMetaPath()} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("meta prompt missing %s: %s", want, prompt)
+		}
+	}
+
+	writeReview(t, ctx, artifact.ReviewMetaDoc, "[MISSED] Skeptic: no rollback plan")
+	if due, err := mod.metaReviewDue(ctx); err != nil || due {
+		t.Fatalf("meta review still due after REVIEW_META.md was written: due=%t err=%v", due, err)
+	}
+	if complete, err := mod.IsComplete(ctx); err != nil || !complete {
+		t.Fatalf("expected completion with meta review: complete=%t err=%v", complete, err)
+	}
+	rounds, err := os.ReadDir(archive)
+	if err != nil || len(rounds) != 1 {
+		t.Fatalf("expected one archived round, got %v (%v)", rounds, err)
+	}
+	if _, err := os.Stat(filepath.Join(archive, rounds[0].Name(), workflow.FileReviewMeta)); err != nil {
+		t.Fatalf("archived round missing REVIEW_META.md: %v", err)
+	}
+	if complete, err := mod.IsComplete(ctx); err != nil || !complete {
+		t.Fatalf("second completion check: complete=%t err=%v", complete, err)
+	}
+	if again, _ := os.ReadDir(archive); len(again) != 1 {
+		t.Fatalf("round archived twice: %v", again)
+	}
+}
+
+func writeReview(t *testing.T, ctx *module.ModuleContext, ref artifact.ArtifactRef, body string) {
+	t.Helper()
+	meta := artifact.Metadata{ModuleID: moduleID, Version: moduleVersion, Workflow: ctx.Workflow.Dir()}
+	if err := ctx.Artifacts.Write(ref, []byte(body), meta); err != nil {
+		t.Fatalf("write %s: %v", ref.ID, err)
+	}
+}
+
+func newReviewTestContext(t *testing.T) *module.ModuleContext {
+	t.Helper()
+	projectDir := t.TempDir()
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	cfg := &config.Config{
+		ProjectDir:        projectDir,
+		LatticeProjectDir: filepath.Join(projectDir, config.LatticeDir),
+		LatticeRoot:       projectDir,
+	}
+	wf := workflow.New(cfg.LatticeProjectDir)
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	return &module.ModuleContext{
+		Config:    cfg,
+		Workflow:  wf,
+		Artifacts: artifact.NewStore(wf),
+	}
+}
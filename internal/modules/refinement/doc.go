@@ -20,7 +20,13 @@ package refinement
 //     not rewrite them but expects them in place.)
 //   - Repository metadata under `ModuleContext.Config.ProjectDir` (package.json,
 //     go.mod, etc.). Refinement samples these files to label the project profile
-//     and select the 10 stakeholder roles to run.
+//     and select the stakeholder roles to run (10 by default).
+//
+// Configuration:
+//   - `stakeholder_count` – number of stakeholder audits to run. Defaults to 10.
+//   - `stakeholder_roles` – explicit role list that replaces the profile-derived
+//     roles. When set without `stakeholder_count`, one audit runs per role;
+//     otherwise the list is truncated or padded with fallback roles to match.
 //
 // Runtime + configuration requirements:
 //   - `ModuleContext.Orchestrator` must be initialised with a functional `bd`,
@@ -36,19 +42,24 @@ package refinement
 //
 // Outputs + side effects:
 //   - `workflow/team/stakeholders.json` – JSON manifest describing the detected
-//     project profile and the agents assigned to ~10 stakeholder roles. Each
+//     project profile and the agents assigned to each stakeholder role. Each
 //     entry records whether the reviewer already worked on the cycle so future
 //     audits can reuse or rotate coverage intentionally.
-//   - `workflow/audit/` – Directory populated with `<role>-audit.md` files and a
-//     `SYNTHESIS.md` summary. The orchestrator reads every audit, calls `bd
-//     create` for each actionable finding, and documents which beads were
-//     opened plus any “no action” notes.
+//   - `workflow/audit/` – Directory populated with one `<role>-audit.md` file per
+//     configured stakeholder and a `SYNTHESIS.md` summary. Audits left over from
+//     earlier passes with different roles are pruned before synthesis. The
+//     orchestrator reads every audit, calls `bd create` for each actionable
+//     finding, and documents which beads were opened plus any “no action”
+//     notes. `Module.RerunAudit` (`module-runner --rerun-audit <role>`)
+//     regenerates a single role's audit with its recorded agent and refreshes
+//     `SYNTHESIS.md` without touching the other audits or the markers.
 //   - Work markers – Refinement ensures `workflow/work/.in-progress` exists
 //     while it drives the follow-up cycle, rewrites `.complete` on success, and
 //     removes `.refinement-needed` after the operator acknowledges completion.
-//     If `PrepareWorkCycle` reports `ErrNoReadyBeads` the module records that no
-//     additional work was required but still clears the refinement marker so
-//     release can proceed.
+//     If `PrepareWorkCycle` reports `ErrNoReadyBeads` (or stages no sessions)
+//     the module writes `.complete`, clears the refinement marker so release
+//     can proceed, and returns `StatusNoOp` with the reason in the message
+//     instead of failing.
 //   - Follow-up beads – Audit synthesis shells out to `bd` and may also spawn a
 //     manual review tmux window. Those beads, plus any tmux session summary, act
 //     as the downstream contract for release and future work cycles.
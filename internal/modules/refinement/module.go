@@ -17,8 +17,18 @@ import (
 const (
 	moduleID      = "refinement"
 	moduleVersion = "1.0.0"
+
+	stakeholderCountKey = "stakeholder_count"
+	stakeholderRolesKey = "stakeholder_roles"
 )
 
+// configSchema lists the config keys refinement accepts.
+var configSchema = module.ConfigSchema{
+	runtime.FingerprintAlgorithmKey: {Type: module.ConfigString, Description: "hash used for artifact fingerprints"},
+	stakeholderCountKey:             {Type: module.ConfigInt, Description: "number of stakeholder audits"},
+	stakeholderRolesKey:             {Type: module.ConfigStringList, Description: "stakeholder roles to audit"},
+}
+
 // Option customizes the refinement module.
 type Option func(*Module)
 
@@ -27,6 +37,9 @@ type Module struct {
 	*module.Base
 	now       func() time.Time
 	newClient orchestratorFactory
+	roleCount int
+	roles     []string
+	hasher    artifact.Hasher
 }
 
 // Register adds the module to the registry.
@@ -34,8 +47,12 @@ func Register(reg *module.Registry) {
 	if reg == nil {
 		return
 	}
-	reg.MustRegister(moduleID, func(module.Config) (module.Module, error) {
-		return New(), nil
+	reg.MustRegisterWithSchema(moduleID, configSchema, func(cfg module.Config) (module.Module, error) {
+		opts, err := optionsFromConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return New(opts...), nil
 	})
 }
 
@@ -62,6 +79,8 @@ func New(opts ...Option) *Module {
 		Base:      &base,
 		now:       time.Now,
 		newClient: defaultClientFactory,
+		roleCount: defaultStakeholderCount,
+		hasher:    artifact.DefaultHasher,
 	}
 	for _, opt := range opts {
 		if opt != nil {
@@ -89,6 +108,35 @@ func WithOrchestratorFactory(factory orchestratorFactory) Option {
 	}
 }
 
+// WithStakeholderCount caps how many stakeholder audits run per refinement
+// pass. Values below one are ignored.
+func WithStakeholderCount(count int) Option {
+	return func(m *Module) {
+		if count > 0 {
+			m.roleCount = count
+		}
+	}
+}
+
+// WithStakeholderRoles replaces the profile-derived role list. The configured
+// count still applies; when it exceeds the list, fallback roles pad the rest.
+func WithStakeholderRoles(roles ...string) Option {
+	return func(m *Module) {
+		if cleaned := dedupe(roles); len(cleaned) > 0 {
+			m.roles = cleaned
+		}
+	}
+}
+
+// WithHasher selects the algorithm used to fingerprint the stakeholder roster.
+func WithHasher(h artifact.Hasher) Option {
+	return func(m *Module) {
+		if h != nil {
+			m.hasher = h
+		}
+	}
+}
+
 // Run executes the refinement lifecycle.
 func (m *Module) Run(ctx *module.ModuleContext) (module.Result, error) {
 	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
@@ -119,7 +167,19 @@ func (m *Module) Run(ctx *module.ModuleContext) (module.Result, error) {
 		return module.Result{Status: module.StatusFailed}, err
 	}
 	followUpMsg, err := m.runFollowUpCycle(ctx, client)
-	if err != nil {
+	var idle *noFollowUpError
+	switch {
+	case err == nil:
+	case errors.As(err, &idle):
+		if err := m.writeCompletionMarker(ctx); err != nil {
+			return module.Result{Status: module.StatusFailed}, err
+		}
+		if err := m.clearRefinementMarker(ctx); err != nil {
+			return module.Result{Status: module.StatusFailed}, err
+		}
+		message := fmt.Sprintf("audits:%d follow-up:%s", len(assignments), idle.reason)
+		return module.Result{Status: module.StatusNoOp, Message: message}, nil
+	default:
 		return module.Result{Status: module.StatusFailed}, err
 	}
 	if err := m.clearRefinementMarker(ctx); err != nil {
@@ -129,6 +189,50 @@ func (m *Module) Run(ctx *module.ModuleContext) (module.Result, error) {
 	return module.Result{Status: module.StatusCompleted, Message: message}, nil
 }
 
+// RerunAudit regenerates the audit for one stakeholder role from the last
+// refinement pass and refreshes the synthesis to include it. The role keeps
+// the agent recorded in stakeholders.json; other audits, the manifest, and
+// the work markers are left alone and no follow-up cycle runs.
+func (m *Module) RerunAudit(ctx *module.ModuleContext, role string) (module.Result, error) {
+	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
+	client, err := m.newClient(ctx)
+	if err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
+	assignment, err := recordedAssignment(ctx, client, role)
+	if err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
+	profile := detectProjectProfile(ctx.Config.ProjectDir)
+	auditDir := artifact.AuditDirectory.Path(ctx.Workflow)
+	if err := m.runStakeholderAudits(ctx, client, auditDir, []stakeholderAssignment{assignment}, profile); err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
+	message := fmt.Sprintf("re-ran %s audit (%s), synthesis refreshed", assignment.Role, assignment.Agent.Name)
+	return module.Result{Status: module.StatusCompleted, Message: message}, nil
+}
+
+// noFollowUpError reports that the audits produced no ready work, so the
+// follow-up cycle was skipped. Run treats it as a clean no-op rather than a
+// failure.
+type noFollowUpError struct {
+	reason string
+	err    error
+}
+
+func (e *noFollowUpError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("%s: follow-up skipped: %s", moduleID, e.reason)
+	}
+	return fmt.Sprintf("%s: follow-up skipped: %s: %v", moduleID, e.reason, e.err)
+}
+
+func (e *noFollowUpError) Unwrap() error {
+	return e.err
+}
+
 // IsComplete returns true when the refinement marker is absent.
 func (m *Module) IsComplete(ctx *module.ModuleContext) (bool, error) {
 	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
@@ -163,22 +267,29 @@ func (m *Module) refinementRequested(ctx *module.ModuleContext) (bool, error) {
 }
 
 func (m *Module) prepareStakeholders(ctx *module.ModuleContext, client orchestratorClient, profile projectProfile) ([]stakeholderAssignment, string, error) {
-	assignments, err := planStakeholderAssignments(ctx, client, profile)
+	roles := generateRoles(profile, m.roles, m.roleCount)
+	assignments, err := planStakeholderAssignments(client, roles)
 	if err != nil {
 		return nil, "", err
 	}
 	if err := ctx.Artifacts.Write(artifact.AuditDirectory, nil, artifact.Metadata{}); err != nil {
 		return nil, "", fmt.Errorf("%s: ensure audit directory: %w", moduleID, err)
 	}
+	auditDir := artifact.AuditDirectory.Path(ctx.Workflow)
+	if err := pruneStaleAudits(auditDir, assignments); err != nil {
+		return nil, "", fmt.Errorf("%s: prune stale audits: %w", moduleID, err)
+	}
 	if err := m.writeStakeholdersManifest(ctx, assignments, profile); err != nil {
 		return nil, "", err
 	}
-	return assignments, artifact.AuditDirectory.Path(ctx.Workflow), nil
+	return assignments, auditDir, nil
 }
 
+// runStakeholderAudits runs each assignment's audit and then re-synthesizes
+// every audit in auditDir, including ones not rerun here.
 func (m *Module) runStakeholderAudits(ctx *module.ModuleContext, client orchestratorClient, auditDir string, assignments []stakeholderAssignment, profile projectProfile) error {
 	for _, assignment := range assignments {
-		auditPath := filepath.Join(auditDir, fmt.Sprintf("%s-audit.md", slugify(assignment.Role)))
+		auditPath := filepath.Join(auditDir, auditFileName(assignment.Role))
 		if err := client.RunStakeholderAudit(assignment.Role, assignment.Agent, auditPath, profile.Summary()); err != nil {
 			return fmt.Errorf("%s: run %s audit: %w", moduleID, assignment.Role, err)
 		}
@@ -204,21 +315,15 @@ func (m *Module) runFollowUpCycle(ctx *module.ModuleContext, client orchestrator
 	case err == nil:
 		// continue
 	case errors.Is(err, orchestrator.ErrNoReadyBeads), errors.Is(err, orchestrator.ErrNoTrackedSessions):
-		if err := m.writeCompletionMarker(ctx); err != nil {
-			return "", err
-		}
-		return "no ready beads", nil
+		return "", &noFollowUpError{reason: "no ready beads", err: err}
 	default:
 		return "", fmt.Errorf("%s: prepare follow-up cycle: %w", moduleID, err)
 	}
 	if len(sessions) == 0 {
-		if err := m.writeCompletionMarker(ctx); err != nil {
-			return "", err
-		}
-		return "no sessions staged", nil
+		return "", &noFollowUpError{reason: "no sessions staged"}
 	}
-	if err := ctx.Artifacts.Write(artifact.WorkInProgressMarker, nil, artifact.Metadata{}); err != nil {
-		return "", fmt.Errorf("%s: mark work in progress: %w", moduleID, err)
+	if err := runtime.WriteMarker(ctx, moduleID, moduleVersion, artifact.WorkInProgressMarker); err != nil {
+		return "", err
 	}
 	if err := client.RunUpCycle(context.Background(), sessions); err != nil {
 		_ = removeIfExists(artifact.WorkInProgressMarker.Path(ctx.Workflow))
@@ -235,10 +340,7 @@ func (m *Module) writeCompletionMarker(ctx *module.ModuleContext) error {
 	if err := removeIfExists(artifact.WorkInProgressMarker.Path(ctx.Workflow)); err != nil {
 		return err
 	}
-	if err := ctx.Artifacts.Write(artifact.WorkCompleteMarker, nil, artifact.Metadata{}); err != nil {
-		return fmt.Errorf("%s: mark work complete: %w", moduleID, err)
-	}
-	return nil
+	return runtime.WriteMarker(ctx, moduleID, moduleVersion, artifact.WorkCompleteMarker)
 }
 
 func (m *Module) clearRefinementMarker(ctx *module.ModuleContext) error {
@@ -261,6 +363,34 @@ func (m *Module) metadataFor(ctx *module.ModuleContext, ref artifact.ArtifactRef
 	return meta
 }
 
+func optionsFromConfig(cfg module.Config) ([]Option, error) {
+	var opts []Option
+	hasher, ok, err := runtime.HasherFromConfig(moduleID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, WithHasher(hasher))
+	}
+	if count, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, stakeholderCountKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithStakeholderCount(count))
+	}
+	if roles, ok, err := runtime.StringListFromConfig(moduleID, cfg, stakeholderRolesKey); err != nil {
+		return nil, err
+	} else if ok {
+		if len(dedupe(roles)) == 0 {
+			return nil, fmt.Errorf("%s: %s must list at least one role", moduleID, stakeholderRolesKey)
+		}
+		opts = append(opts, WithStakeholderRoles(roles...))
+		if _, hasCount := cfg[stakeholderCountKey]; !hasCount {
+			opts = append(opts, WithStakeholderCount(len(dedupe(roles))))
+		}
+	}
+	return opts, nil
+}
+
 func summarizeSessions(sessions []orchestrator.WorktreeSession) (points int, beads int) {
 	for _, session := range sessions {
 		points += session.TotalPoints()
@@ -13,6 +13,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/config"
 	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/modules/runtime"
 	"github.com/kingrea/The-Lattice/internal/orchestrator"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 )
@@ -107,7 +108,7 @@ func TestModuleRunAuditFailure(t *testing.T) {
 	ensureMissing(t, artifact.WorkCompleteMarker.Path(ctx.Workflow))
 }
 
-func TestModuleRunNoReadyBeadsStillCompletes(t *testing.T) {
+func TestModuleRunNoReadyBeadsIsCleanNoOp(t *testing.T) {
 	ctx := newRefinementTestContext(t)
 	seedRefinementInputs(t, ctx)
 	if err := ctx.Artifacts.Write(artifact.RefinementNeededMarker, nil, artifact.Metadata{}); err != nil {
@@ -123,16 +124,169 @@ func TestModuleRunNoReadyBeadsStillCompletes(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Run: %v", err)
 	}
-	if result.Status != module.StatusCompleted {
-		t.Fatalf("unexpected status: %+v", result)
+	if result.Status != module.StatusNoOp {
+		t.Fatalf("expected no-op status, got %+v", result)
+	}
+	if !strings.Contains(result.Message, "no ready beads") {
+		t.Fatalf("expected no-op reason in message, got %q", result.Message)
 	}
 	if stub.runUpCycleCalls != 0 {
 		t.Fatalf("expected no follow-up execution")
 	}
+	ensureMissing(t, artifact.WorkInProgressMarker.Path(ctx.Workflow))
+	if complete, err := mod.IsComplete(ctx); err != nil || !complete {
+		t.Fatalf("expected refinement complete, got %v (err=%v)", complete, err)
+	}
 	ensureExists(t, artifact.WorkCompleteMarker.Path(ctx.Workflow))
 	ensureMissing(t, artifact.RefinementNeededMarker.Path(ctx.Workflow))
 }
 
+func TestModuleRunHonorsStakeholderCount(t *testing.T) {
+	ctx := newRefinementTestContext(t)
+	seedRefinementInputs(t, ctx)
+	if err := ctx.Artifacts.Write(artifact.RefinementNeededMarker, nil, artifact.Metadata{}); err != nil {
+		t.Fatalf("write refinement marker: %v", err)
+	}
+	staleAudit := filepath.Join(artifact.AuditDirectory.Path(ctx.Workflow), "old-role-audit.md")
+	if err := os.MkdirAll(filepath.Dir(staleAudit), 0o755); err != nil {
+		t.Fatalf("mkdir audit dir: %v", err)
+	}
+	if err := os.WriteFile(staleAudit, []byte("# Old\n"), 0o644); err != nil {
+		t.Fatalf("write stale audit: %v", err)
+	}
+	stub := &stubOrchestratorClient{
+		t:          t,
+		agents:     []orchestrator.ProjectAgent{{Name: "Aster"}, {Name: "Beryl"}},
+		prepareErr: orchestrator.ErrNoReadyBeads,
+	}
+	mod := New(
+		WithStakeholderCount(3),
+		WithOrchestratorFactory(func(*module.ModuleContext) (orchestratorClient, error) { return stub, nil }),
+	)
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(stub.auditRoles) != 3 {
+		t.Fatalf("expected 3 audits, got %d (%v)", len(stub.auditRoles), stub.auditRoles)
+	}
+	if !strings.HasPrefix(result.Message, "audits:3 ") {
+		t.Fatalf("unexpected result message: %q", result.Message)
+	}
+	manifest := readStakeholdersManifest(t, artifact.StakeholdersJSON.Path(ctx.Workflow))
+	if len(manifest.Roles) != 3 {
+		t.Fatalf("expected 3 roles in manifest, got %d", len(manifest.Roles))
+	}
+	ensureMissing(t, staleAudit)
+}
+
+func TestRegisterParsesStakeholderConfig(t *testing.T) {
+	reg := module.NewRegistry()
+	Register(reg)
+	mod, err := reg.Resolve(moduleID, module.Config{
+		stakeholderRolesKey: []any{"Release Captain", "Security Analyst"},
+	})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	refinement := mod.(*Module)
+	roles := generateRoles(projectProfile{Type: "general"}, refinement.roles, refinement.roleCount)
+	if len(roles) != 2 || roles[0] != "Release Captain" || roles[1] != "Security Analyst" {
+		t.Fatalf("unexpected roles: %v", roles)
+	}
+	if _, err := reg.Resolve(moduleID, module.Config{stakeholderCountKey: 0}); err == nil {
+		t.Fatalf("expected zero stakeholder count to be rejected")
+	}
+	hashed, err := reg.Resolve(moduleID, module.Config{runtime.FingerprintAlgorithmKey: artifact.HashFNV1a64})
+	if err != nil {
+		t.Fatalf("resolve with fingerprint algorithm: %v", err)
+	}
+	if got := hashed.(*Module).hasher.Algorithm(); got != artifact.HashFNV1a64 {
+		t.Fatalf("expected %s hasher, got %s", artifact.HashFNV1a64, got)
+	}
+}
+
+func TestRerunAuditRegeneratesOnlyThatRole(t *testing.T) {
+	ctx := newRefinementTestContext(t)
+	seedRefinementInputs(t, ctx)
+	if err := ctx.Artifacts.Write(artifact.RefinementNeededMarker, nil, artifact.Metadata{}); err != nil {
+		t.Fatalf("write refinement marker: %v", err)
+	}
+	stub := &stubOrchestratorClient{
+		t:           t,
+		agents:      []orchestrator.ProjectAgent{{Name: "Aster"}, {Name: "Beryl"}},
+		prepareErr:  orchestrator.ErrNoReadyBeads,
+		summaryBody: "# Summary\n\n- first pass\n",
+	}
+	mod := New(
+		WithStakeholderRoles("Release Captain", "Security Analyst", "Docs Writer"),
+		WithStakeholderCount(3),
+		WithOrchestratorFactory(func(*module.ModuleContext) (orchestratorClient, error) { return stub, nil }),
+	)
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	auditDir := artifact.AuditDirectory.Path(ctx.Workflow)
+	before := map[string]string{}
+	for _, role := range []string{"Release Captain", "Security Analyst", "Docs Writer"} {
+		path := filepath.Join(auditDir, auditFileName(role))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s audit: %v", role, err)
+		}
+		before[path] = string(data)
+	}
+	manifestBefore, err := os.ReadFile(artifact.StakeholdersJSON.Path(ctx.Workflow))
+	if err != nil {
+		t.Fatalf("read stakeholders: %v", err)
+	}
+
+	stub.auditRoles = nil
+	stub.auditNote = "\nRevised after review.\n"
+	stub.summaryBody = "# Summary\n\n- revised security findings\n"
+	result, err := mod.RerunAudit(ctx, "security analyst")
+	if err != nil {
+		t.Fatalf("RerunAudit: %v", err)
+	}
+	if result.Status != module.StatusCompleted {
+		t.Fatalf("unexpected status: %+v", result)
+	}
+	if len(stub.auditRoles) != 1 || stub.auditRoles[0] != "Security Analyst" {
+		t.Fatalf("expected only the Security Analyst audit to run, got %v", stub.auditRoles)
+	}
+	target := filepath.Join(auditDir, auditFileName("Security Analyst"))
+	for path, previous := range before {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		changed := string(data) != previous
+		if path == target && (!changed || !strings.Contains(string(data), "Revised after review.")) {
+			t.Fatalf("expected %s to be regenerated, got %q", filepath.Base(path), data)
+		}
+		if path != target && changed {
+			t.Fatalf("expected %s to be untouched, got %q", filepath.Base(path), data)
+		}
+	}
+	summary, err := os.ReadFile(artifact.AuditSynthesisDoc.Path(ctx.Workflow))
+	if err != nil {
+		t.Fatalf("read synthesis: %v", err)
+	}
+	if !strings.Contains(string(summary), "revised security findings") {
+		t.Fatalf("expected synthesis to be refreshed, got %q", summary)
+	}
+	manifestAfter, err := os.ReadFile(artifact.StakeholdersJSON.Path(ctx.Workflow))
+	if err != nil {
+		t.Fatalf("read stakeholders: %v", err)
+	}
+	if string(manifestAfter) != string(manifestBefore) {
+		t.Fatalf("expected stakeholders manifest to be left alone")
+	}
+	if _, err := mod.RerunAudit(ctx, "Chief Snack Officer"); err == nil {
+		t.Fatalf("expected unknown role to be rejected")
+	}
+}
+
 type stubOrchestratorClient struct {
 	t               *testing.T
 	agents          []orchestrator.ProjectAgent
@@ -141,7 +295,9 @@ type stubOrchestratorClient struct {
 	prepareErr      error
 	RunErr          error
 	runUpCycleCalls int
+	auditRoles      []string
 	auditErr        error
+	auditNote       string
 	summaryBody     string
 	synthesisErr    error
 }
@@ -161,10 +317,11 @@ func (s *stubOrchestratorClient) RunStakeholderAudit(role string, agent orchestr
 	if s.auditErr != nil {
 		return s.auditErr
 	}
+	s.auditRoles = append(s.auditRoles, role)
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
-	content := fmt.Sprintf("# %s\n\nAgent: %s\n", role, agent.Name)
+	content := fmt.Sprintf("# %s\n\nAgent: %s\n%s", role, agent.Name, s.auditNote)
 	return os.WriteFile(path, []byte(content), 0o644)
 }
 
@@ -1,7 +1,6 @@
 package refinement
 
 import (
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -23,7 +22,11 @@ type stakeholderAssignment struct {
 	Repeat bool
 }
 
-func planStakeholderAssignments(ctx *module.ModuleContext, client orchestratorClient, profile projectProfile) ([]stakeholderAssignment, error) {
+// defaultStakeholderCount is the number of audits a refinement pass runs when
+// no stakeholder_count override is configured.
+const defaultStakeholderCount = 10
+
+func planStakeholderAssignments(client orchestratorClient, roles []string) ([]stakeholderAssignment, error) {
 	agents, err := client.LoadProjectAgents()
 	if err != nil {
 		return nil, fmt.Errorf("%s: load project agents: %w", moduleID, err)
@@ -43,7 +46,6 @@ func planStakeholderAssignments(ctx *module.ModuleContext, client orchestratorCl
 			used[name] = struct{}{}
 		}
 	}
-	roles := generateRoles(profile)
 	assignments, err := assignRoles(roles, agents, used)
 	if err != nil {
 		return nil, err
@@ -86,14 +88,14 @@ func (m *Module) writeStakeholdersManifest(ctx *module.ModuleContext, assignment
 		return fmt.Errorf("%s: encode stakeholders manifest: %w", moduleID, err)
 	}
 	meta := m.metadataFor(ctx, artifact.StakeholdersJSON, artifact.RefinementNeededMarker)
-	runtime.WithFingerprint(artifact.StakeholdersJSON, stakeholderFingerprint(assignments, profile))(&meta)
+	runtime.WithFingerprint(artifact.StakeholdersJSON, stakeholderFingerprint(m.hasher, assignments, profile))(&meta)
 	if err := ctx.Artifacts.Write(artifact.StakeholdersJSON, data, meta); err != nil {
 		return fmt.Errorf("%s: write stakeholders manifest: %w", moduleID, err)
 	}
 	return nil
 }
 
-func stakeholderFingerprint(assignments []stakeholderAssignment, profile projectProfile) string {
+func stakeholderFingerprint(hasher artifact.Hasher, assignments []stakeholderAssignment, profile projectProfile) string {
 	parts := []string{strings.ToLower(strings.TrimSpace(profile.Type)), strings.Join(profile.Tags, ",")}
 	sorted := append([]stakeholderAssignment(nil), assignments...)
 	sort.SliceStable(sorted, func(i, j int) bool {
@@ -102,8 +104,7 @@ func stakeholderFingerprint(assignments []stakeholderAssignment, profile project
 	for _, assignment := range sorted {
 		parts = append(parts, fmt.Sprintf("%s|%s|%t|%t", strings.ToLower(strings.TrimSpace(assignment.Role)), strings.ToLower(strings.TrimSpace(assignment.Agent.Name)), assignment.Reused, assignment.Repeat))
 	}
-	sum := sha256.Sum256([]byte(strings.Join(parts, ";")))
-	return fmt.Sprintf("%x", sum[:])
+	return hasher.Fingerprint([]byte(strings.Join(parts, ";")))
 }
 
 func assignRoles(roles []string, agents []orchestrator.ProjectAgent, used map[string]struct{}) ([]stakeholderAssignment, error) {
@@ -373,18 +374,55 @@ var fallbackRoles = []string{
 	"Accessibility Champion",
 }
 
-func generateRoles(profile projectProfile) []string {
-	roles := append([]string{}, roleTemplates[profile.Type]...)
-	if len(roles) < 10 {
+func generateRoles(profile projectProfile, overrides []string, count int) []string {
+	if count <= 0 {
+		count = defaultStakeholderCount
+	}
+	roles := append([]string{}, overrides...)
+	if len(roles) == 0 {
+		roles = append(roles, roleTemplates[profile.Type]...)
+	}
+	if len(roles) < count {
 		roles = append(roles, fallbackRoles...)
 	}
 	unique := dedupe(roles)
-	if len(unique) < 10 {
-		for i := len(unique); i < 10; i++ {
-			unique = append(unique, fallbackRoles[i%len(fallbackRoles)])
+	for i := 0; len(unique) < count; i++ {
+		unique = append(unique, fmt.Sprintf("%s %d", fallbackRoles[i%len(fallbackRoles)], i/len(fallbackRoles)+2))
+	}
+	return unique[:count]
+}
+
+func auditFileName(role string) string {
+	return fmt.Sprintf("%s-audit.md", slugify(role))
+}
+
+// pruneStaleAudits removes audit files left behind by earlier passes that ran
+// different roles so synthesis only reads the current stakeholders' findings.
+func pruneStaleAudits(auditDir string, assignments []stakeholderAssignment) error {
+	current := make(map[string]struct{}, len(assignments))
+	for _, assignment := range assignments {
+		current[auditFileName(assignment.Role)] = struct{}{}
+	}
+	entries, err := os.ReadDir(auditDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
 		}
+		return err
 	}
-	return unique[:10]
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, "-audit.md") {
+			continue
+		}
+		if _, ok := current[name]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(auditDir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
 }
 
 func slugify(value string) string {
@@ -453,3 +491,66 @@ func mapKeys(m map[string]string) []string {
 	}
 	return keys
 }
+
+// recordedAssignment looks up role in the stakeholders manifest written by the
+// last refinement pass. Roles match by slug, so "security analyst" finds
+// "Security Analyst". The live agent roster is preferred over the manifest's
+// copy so the re-run sees the agent's current dossier paths.
+func recordedAssignment(ctx *module.ModuleContext, client orchestratorClient, role string) (stakeholderAssignment, error) {
+	want := slugify(role)
+	if want == "" {
+		return stakeholderAssignment{}, fmt.Errorf("%s: stakeholder role is required", moduleID)
+	}
+	data, err := os.ReadFile(artifact.StakeholdersJSON.Path(ctx.Workflow))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return stakeholderAssignment{}, fmt.Errorf("%s: no stakeholders manifest; run refinement first", moduleID)
+		}
+		return stakeholderAssignment{}, fmt.Errorf("%s: read stakeholders manifest: %w", moduleID, err)
+	}
+	var manifest struct {
+		Roles map[string]struct {
+			Name       string `json:"name"`
+			AgentPath  string `json:"agentPath"`
+			MemoryPath string `json:"memoryPath"`
+			Reused     bool   `json:"reused"`
+			Repeat     bool   `json:"repeat"`
+		} `json:"roles"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return stakeholderAssignment{}, fmt.Errorf("%s: decode stakeholders manifest: %w", moduleID, err)
+	}
+	for _, name := range sortedRoleNames(manifest.Roles) {
+		if slugify(name) != want {
+			continue
+		}
+		record := manifest.Roles[name]
+		assignment := stakeholderAssignment{
+			Role:   name,
+			Agent:  orchestrator.ProjectAgent{Name: record.Name, Path: record.AgentPath, Memory: record.MemoryPath},
+			Reused: record.Reused,
+			Repeat: record.Repeat,
+		}
+		agents, err := client.LoadProjectAgents()
+		if err != nil {
+			return stakeholderAssignment{}, fmt.Errorf("%s: load project agents: %w", moduleID, err)
+		}
+		for _, agent := range agents {
+			if strings.EqualFold(strings.TrimSpace(agent.Name), strings.TrimSpace(record.Name)) {
+				assignment.Agent = agent
+				break
+			}
+		}
+		return assignment, nil
+	}
+	return stakeholderAssignment{}, fmt.Errorf("%s: role %q is not in the stakeholders manifest (have %s)", moduleID, role, strings.Join(sortedRoleNames(manifest.Roles), ", "))
+}
+
+func sortedRoleNames[T any](roles map[string]T) []string {
+	names := make([]string, 0, len(roles))
+	for name := range roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
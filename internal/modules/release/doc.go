@@ -3,7 +3,9 @@ package release
 // Package release documents the release module contract. The module only runs
 // once work-process has written `workflow/work/.complete` (exposed as
 // artifact.WorkCompleteMarker) and any refinement follow-ups cleared the
-// `.refinement-needed` gate. Its inputs include the final work log, per-agent
+// `.refinement-needed` gate. A `.work-exhausted` marker
+// (artifact.WorkExhaustedMarker) means every bead closed, and release skips the
+// refinement gate. Its inputs include the final work log, per-agent
 // summaries, audit synthesis, orchestrator + roster manifests, and the
 // reconciled bead queue so it can describe what shipped versus what remains. On
 // success the module emits release notes under `.lattice/workflow/release`
@@ -13,3 +15,26 @@ package release
 // markers let the workflow engine short-circuit future release attempts during
 // restarts, and the notes/package become immutable release records for auditors
 // and downstream tooling.
+//
+// The `extra_files` config key lists additional glob patterns copied into each
+// package alongside the defaults (e.g. `CHANGELOG.md`, `LICENSE*`). Patterns are
+// relative to the project root, or to the lattice directory when prefixed with
+// `lattice:`. Absolute paths, patterns that escape their base, malformed globs,
+// and matches that would overwrite a default package entry are rejected.
+//
+// The `version` config key (or `module-runner --release-version`) labels the
+// release: it titles the notes, names the package directory, and is recorded
+// as `release_version` in the notes metadata. Without it the run timestamp is
+// used. Setting `git_tag: true` alongside a version also creates an annotated
+// git tag of that name in the project repository. The tag is created before
+// any output is written, so a failed tag leaves the release incomplete. A tag
+// that already exists fails the run; set `force_tag: true` (or
+// `module-runner --force-tag`) to move it to the current commit, e.g. when
+// retrying a release that failed after tagging. Re-running a version clears
+// its package directory first.
+//
+// The `packages_dir` config key (e.g. `module-runner --set
+// packages_dir=dist/release`) writes packages to an external directory for CI
+// artifact collection. Relative paths resolve against the project directory,
+// the directory is probed for writability before packaging starts, and the
+// release-packages output is tracked at the new location.
@@ -2,7 +2,6 @@ package release
 
 import (
 	"context"
-	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,16 +23,62 @@ const (
 	moduleID      = "release"
 	moduleVersion = "1.0.0"
 	defaultBDWait = 15 * time.Second
+
+	extraFilesKey  = "extra_files"
+	versionKey     = "version"
+	gitTagKey      = "git_tag"
+	forceTagKey    = "force_tag"
+	packagesDirKey = "packages_dir"
+	// versionNoteKey records the release label in the notes' _lattice
+	// metadata.
+	versionNoteKey = "release_version"
+	// packageTimeFormat labels unversioned releases.
+	packageTimeFormat = "20060102-150405"
+	// latticePrefix marks an extra_files glob as relative to the lattice
+	// directory instead of the project root.
+	latticePrefix = "lattice:"
 )
 
+// configSchema lists the config keys release accepts.
+var configSchema = module.ConfigSchema{
+	runtime.FingerprintAlgorithmKey: {Type: module.ConfigString, Description: "hash used for artifact fingerprints"},
+	extraFilesKey:                   {Type: module.ConfigStringList, Description: "extra globs copied into each package"},
+	versionKey:                      {Type: module.ConfigString, Description: "release version label"},
+	gitTagKey:                       {Type: module.ConfigBool, Description: "tag the release version in git"},
+	forceTagKey:                     {Type: module.ConfigBool, Description: "move an existing release tag to the current commit"},
+	packagesDirKey:                  {Type: module.ConfigString, Description: "directory release packages are written to"},
+}
+
+// reservedPackageEntries are the top-level package names written by default;
+// extra files may not overwrite them.
+var reservedPackageEntries = map[string]struct{}{
+	"work-log.md":       {},
+	"orchestrator.json": {},
+	"workers.json":      {},
+	"audit":             {},
+	"logs":              {},
+	"worktree":          {},
+}
+
 // Option customizes the release module.
 type Option func(*Module)
 
 // Module orchestrates packaging and release notes emission.
 type Module struct {
 	*module.Base
-	now   func() time.Time
-	beads beadLister
+	now        func() time.Time
+	beads      beadLister
+	extraFiles []string
+	hasher     artifact.Hasher
+	// version titles the notes and names the package; empty uses a timestamp.
+	version string
+	gitTag  bool
+	// forceTag moves an existing version tag instead of failing the run.
+	forceTag bool
+	runGit   func(dir string, args ...string) error
+	// packages is where release packages are written; it defaults to
+	// artifact.ReleasePackagesDir and is relocated by WithPackagesDir.
+	packages artifact.ArtifactRef
 }
 
 // Register installs the release module factory.
@@ -41,8 +86,12 @@ func Register(reg *module.Registry) {
 	if reg == nil {
 		return
 	}
-	reg.MustRegister(moduleID, func(module.Config) (module.Module, error) {
-		return New(), nil
+	reg.MustRegisterWithSchema(moduleID, configSchema, func(cfg module.Config) (module.Module, error) {
+		opts, err := optionsFromConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return New(opts...), nil
 	})
 }
 
@@ -62,23 +111,26 @@ func New(opts ...Option) *Module {
 		artifact.WorkersJSON,
 		artifact.OrchestratorState,
 	)
-	base.SetOutputs(
-		artifact.ReleaseNotesDoc,
-		artifact.ReleasePackagesDir,
-		artifact.AgentsReleasedMarker,
-		artifact.CleanupDoneMarker,
-		artifact.OrchestratorReleasedMarker,
-	)
 	mod := &Module{
-		Base:  &base,
-		now:   time.Now,
-		beads: execBeadLister{},
+		Base:     &base,
+		now:      time.Now,
+		beads:    execBeadLister{},
+		hasher:   artifact.DefaultHasher,
+		runGit:   execGit,
+		packages: artifact.ReleasePackagesDir,
 	}
 	for _, opt := range opts {
 		if opt != nil {
 			opt(mod)
 		}
 	}
+	base.SetOutputs(
+		artifact.ReleaseNotesDoc,
+		mod.packages,
+		artifact.AgentsReleasedMarker,
+		artifact.CleanupDoneMarker,
+		artifact.OrchestratorReleasedMarker,
+	)
 	return mod
 }
 
@@ -100,6 +152,69 @@ func WithBeadLister(l beadLister) Option {
 	}
 }
 
+// WithExtraFiles adds glob patterns whose matches are copied into every release
+// package. Patterns are relative to the project root, or to the lattice
+// directory when prefixed with "lattice:".
+func WithExtraFiles(patterns ...string) Option {
+	return func(m *Module) {
+		for _, pattern := range patterns {
+			if trimmed := strings.TrimSpace(pattern); trimmed != "" {
+				m.extraFiles = append(m.extraFiles, trimmed)
+			}
+		}
+	}
+}
+
+// WithVersion titles the release notes, names the package directory, and is
+// recorded in the notes metadata. Without it the release is labelled with the
+// run timestamp.
+func WithVersion(version string) Option {
+	return func(m *Module) {
+		m.version = strings.TrimSpace(version)
+	}
+}
+
+// WithGitTag creates an annotated git tag named after the release version
+// before any release output is written.
+func WithGitTag(enabled bool) Option {
+	return func(m *Module) {
+		m.gitTag = enabled
+	}
+}
+
+// WithForceTag moves an existing version tag to the current commit instead of
+// failing the release.
+func WithForceTag(enabled bool) Option {
+	return func(m *Module) {
+		m.forceTag = enabled
+	}
+}
+
+// WithPackagesDir writes release packages beneath dir instead of
+// workflow/release/packages, e.g. so CI can collect them. Relative paths are
+// resolved against the project directory.
+func WithPackagesDir(dir string) Option {
+	return func(m *Module) {
+		dir = strings.TrimSpace(dir)
+		switch {
+		case dir == "":
+		case filepath.IsAbs(dir):
+			m.packages = artifact.ReleasePackagesDir.At(dir)
+		default:
+			m.packages = artifact.ReleasePackagesDir.AtProject(dir)
+		}
+	}
+}
+
+// WithHasher selects the algorithm used to fingerprint release notes.
+func WithHasher(h artifact.Hasher) Option {
+	return func(m *Module) {
+		if h != nil {
+			m.hasher = h
+		}
+	}
+}
+
 // Run orchestrates release packaging.
 func (m *Module) Run(ctx *module.ModuleContext) (module.Result, error) {
 	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
@@ -120,30 +235,38 @@ func (m *Module) Run(ctx *module.ModuleContext) (module.Result, error) {
 	} else if done {
 		return module.Result{Status: module.StatusNoOp, Message: "release already finalized"}, nil
 	}
+	if err := m.tagRelease(ctx); err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
 	releaseDir := ctx.Workflow.ReleaseDir()
 	if err := os.MkdirAll(releaseDir, 0o755); err != nil {
 		return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: ensure release dir: %w", moduleID, err)
 	}
-	if err := ctx.Artifacts.Write(artifact.ReleasePackagesDir, nil, artifact.Metadata{}); err != nil {
+	if err := ctx.Artifacts.Write(m.packages, nil, artifact.Metadata{}); err != nil {
 		return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: ensure packages dir: %w", moduleID, err)
 	}
-	packagePath, err := m.createReleasePackage(ctx)
+	if err := checkWritable(m.packages.Path(ctx.Workflow)); err != nil {
+		return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: packages dir: %w", moduleID, err)
+	}
+	label := m.releaseLabel()
+	packagePath, err := m.createReleasePackage(ctx, label)
 	if err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
 	beads, beadWarning := m.listOutstandingBeads()
-	workLogBody, err := m.readDocumentBody(artifact.WorkLogDoc.Path(ctx.Workflow))
+	reads := runtime.NewReadSet(ctx.Config.ProjectDir)
+	workLogBody, err := m.readDocumentBody(reads, artifact.WorkLogDoc.Path(ctx.Workflow))
 	if err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
-	workers, err := m.readWorkerNames(artifact.WorkersJSON.Path(ctx.Workflow))
+	workers, err := m.readWorkerNames(reads, artifact.WorkersJSON.Path(ctx.Workflow))
 	if err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
-	orchestratorName, _ := m.readOrchestratorName(artifact.OrchestratorState.Path(ctx.Workflow))
-	auditBody, _ := m.readDocumentBody(artifact.AuditSynthesisDoc.Path(ctx.Workflow))
-	releaseBody := m.renderReleaseNotes(workLogBody, auditBody, workers, orchestratorName, beads, filepath.Base(packagePath), beadWarning)
-	if err := m.writeReleaseNotes(ctx, []byte(releaseBody)); err != nil {
+	orchestratorName, _ := m.readOrchestratorName(reads, artifact.OrchestratorState.Path(ctx.Workflow))
+	auditBody, _ := m.readDocumentBody(reads, artifact.AuditSynthesisDoc.Path(ctx.Workflow))
+	releaseBody := m.renderReleaseNotes(label, workLogBody, auditBody, workers, orchestratorName, beads, filepath.Base(packagePath), beadWarning)
+	if err := m.writeReleaseNotes(ctx, label, []byte(releaseBody), reads); err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
 	if err := m.archiveWorkLog(ctx); err != nil {
@@ -174,6 +297,27 @@ func (m *Module) IsComplete(ctx *module.ModuleContext) (bool, error) {
 	return ready, err
 }
 
+// tagRelease creates the annotated version tag before any output is written,
+// so a failed tag leaves the release incomplete and the next run retries it.
+// An existing tag, whether from another release or an earlier run that failed
+// after tagging, fails the run unless force_tag moves it.
+func (m *Module) tagRelease(ctx *module.ModuleContext) error {
+	if !m.gitTag || m.version == "" {
+		return nil
+	}
+	args := []string{"tag", "-a", m.version, "-m", fmt.Sprintf("Release %s", m.version)}
+	if err := m.git(ctx, "rev-parse", "-q", "--verify", "refs/tags/"+m.version); err == nil {
+		if !m.forceTag {
+			return fmt.Errorf("%s: tag %s already exists; set %s to move it to the current commit", moduleID, m.version, forceTagKey)
+		}
+		args = append(args, "-f")
+	}
+	if err := m.git(ctx, args...); err != nil {
+		return fmt.Errorf("%s: tag %s: %w", moduleID, m.version, err)
+	}
+	return nil
+}
+
 func (m *Module) missingInput(ctx *module.ModuleContext) (string, error) {
 	for _, ref := range m.Inputs() {
 		result, err := ctx.Artifacts.Check(ref)
@@ -187,7 +331,17 @@ func (m *Module) missingInput(ctx *module.ModuleContext) (string, error) {
 	return "", nil
 }
 
+// refinementPending reports whether the refinement gate is still open. A
+// work-exhausted marker means every bead closed, so there is nothing for
+// refinement to pick up and release proceeds directly.
 func (m *Module) refinementPending(ctx *module.ModuleContext) (bool, error) {
+	exhausted, err := ctx.Artifacts.Check(artifact.WorkExhaustedMarker)
+	if err != nil {
+		return false, fmt.Errorf("%s: check work exhausted marker: %w", moduleID, err)
+	}
+	if exhausted.State == artifact.StateReady {
+		return false, nil
+	}
 	result, err := ctx.Artifacts.Check(artifact.RefinementNeededMarker)
 	if err != nil {
 		return false, fmt.Errorf("%s: check refinement marker: %w", moduleID, err)
@@ -218,7 +372,15 @@ func (m *Module) listOutstandingBeads() ([]beadSummary, string) {
 	return sorted, ""
 }
 
-func (m *Module) writeReleaseNotes(ctx *module.ModuleContext, body []byte) error {
+// releaseLabel is the configured version, or the run timestamp.
+func (m *Module) releaseLabel() string {
+	if m.version != "" {
+		return m.version
+	}
+	return m.now().UTC().Format(packageTimeFormat)
+}
+
+func (m *Module) writeReleaseNotes(ctx *module.ModuleContext, label string, body []byte, reads *runtime.ReadSet) error {
 	meta := artifact.Metadata{
 		ArtifactID: artifact.ReleaseNotesDoc.ID,
 		ModuleID:   moduleID,
@@ -226,22 +388,28 @@ func (m *Module) writeReleaseNotes(ctx *module.ModuleContext, body []byte) error
 		Workflow:   ctx.Workflow.Dir(),
 	}
 	runtime.WithInputs(m.Inputs()...)(&meta)
-	runtime.WithFingerprint(artifact.ReleaseNotesDoc, fingerprint(body))(&meta)
+	runtime.WithReads(reads)(&meta)
+	runtime.WithFingerprint(artifact.ReleaseNotesDoc, m.hasher.Fingerprint(body))(&meta)
+	if meta.Notes == nil {
+		meta.Notes = map[string]string{}
+	}
+	meta.Notes[versionNoteKey] = label
 	if err := ctx.Artifacts.Write(artifact.ReleaseNotesDoc, body, meta); err != nil {
 		return fmt.Errorf("%s: write release notes: %w", moduleID, err)
 	}
 	return nil
 }
 
-func (m *Module) createReleasePackage(ctx *module.ModuleContext) (string, error) {
-	root := artifact.ReleasePackagesDir.Path(ctx.Workflow)
+func (m *Module) createReleasePackage(ctx *module.ModuleContext, label string) (string, error) {
+	root := m.packages.Path(ctx.Workflow)
 	if root == "" {
 		return "", fmt.Errorf("%s: release packages path unavailable", moduleID)
 	}
-	timestamp := m.now().UTC().Format("20060102-150405")
-	dest := filepath.Join(root, timestamp)
-	if err := os.MkdirAll(dest, 0o755); err != nil {
-		return "", fmt.Errorf("%s: create package dir: %w", moduleID, err)
+	dest := filepath.Join(root, label)
+	// A package left by an earlier attempt at the same version is cleared so
+	// it only holds this run's files.
+	if err := resetDirectory(dest); err != nil {
+		return "", err
 	}
 	copyFiles := []struct{ src, dst string }{
 		{artifact.WorkLogDoc.Path(ctx.Workflow), filepath.Join(dest, "work-log.md")},
@@ -263,9 +431,211 @@ func (m *Module) createReleasePackage(ctx *module.ModuleContext) (string, error)
 	if err := copyDirIfExists(ctx.Config.WorktreeDir(), filepath.Join(dest, "worktree")); err != nil {
 		return "", err
 	}
+	if err := m.copyExtraFiles(ctx, dest); err != nil {
+		return "", err
+	}
 	return dest, nil
 }
 
+func (m *Module) copyExtraFiles(ctx *module.ModuleContext, dest string) error {
+	packagesRoot := filepath.Dir(dest)
+	for _, raw := range m.extraFiles {
+		pattern, err := parseExtraFile(raw)
+		if err != nil {
+			return err
+		}
+		base := ctx.Config.ProjectDir
+		if pattern.lattice {
+			base = ctx.Config.LatticeProjectDir
+		}
+		matches, err := filepath.Glob(filepath.Join(base, pattern.glob))
+		if err != nil {
+			return fmt.Errorf("%s: expand %s %q: %w", moduleID, extraFilesKey, raw, err)
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			rel, err := filepath.Rel(base, match)
+			if err != nil {
+				return fmt.Errorf("%s: resolve %s: %w", moduleID, match, err)
+			}
+			if isWithin(match, packagesRoot) || isWithin(packagesRoot, match) {
+				return fmt.Errorf("%s: %s %q overlaps the release packages directory", moduleID, extraFilesKey, raw)
+			}
+			top := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+			if _, reserved := reservedPackageEntries[top]; reserved {
+				return fmt.Errorf("%s: %s %q would overwrite package entry %s", moduleID, extraFilesKey, raw, top)
+			}
+			if err := copyFileIfExists(match, filepath.Join(dest, rel)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkWritable confirms files can be created in dir by writing and removing
+// a probe file.
+func checkWritable(dir string) error {
+	probe, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	name := probe.Name()
+	probe.Close()
+	return os.Remove(name)
+}
+
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+type extraFilePattern struct {
+	glob    string
+	lattice bool
+}
+
+func parseExtraFile(raw string) (extraFilePattern, error) {
+	value := strings.TrimSpace(raw)
+	pattern := extraFilePattern{}
+	if strings.HasPrefix(value, latticePrefix) {
+		pattern.lattice = true
+		value = strings.TrimSpace(strings.TrimPrefix(value, latticePrefix))
+	}
+	if value == "" {
+		return extraFilePattern{}, fmt.Errorf("%s: %s entry %q is empty", moduleID, extraFilesKey, raw)
+	}
+	if filepath.IsAbs(value) {
+		return extraFilePattern{}, fmt.Errorf("%s: %s entry %q must be relative", moduleID, extraFilesKey, raw)
+	}
+	clean := filepath.Clean(value)
+	if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return extraFilePattern{}, fmt.Errorf("%s: %s entry %q escapes its base directory", moduleID, extraFilesKey, raw)
+	}
+	if _, err := filepath.Match(clean, ""); err != nil {
+		return extraFilePattern{}, fmt.Errorf("%s: %s entry %q: %w", moduleID, extraFilesKey, raw, err)
+	}
+	pattern.glob = clean
+	return pattern, nil
+}
+
+func optionsFromConfig(cfg module.Config) ([]Option, error) {
+	var opts []Option
+	hasher, ok, err := runtime.HasherFromConfig(moduleID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, WithHasher(hasher))
+	}
+	versionOpts, err := versionFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, versionOpts...)
+	if raw, ok := cfg[packagesDirKey]; ok && raw != nil {
+		dir, isString := raw.(string)
+		if !isString || strings.TrimSpace(dir) == "" {
+			return nil, fmt.Errorf("%s: %s must be a non-empty path, got %v", moduleID, packagesDirKey, raw)
+		}
+		opts = append(opts, WithPackagesDir(dir))
+	}
+	raw, ok := cfg[extraFilesKey]
+	if !ok || raw == nil {
+		return opts, nil
+	}
+	var patterns []string
+	switch v := raw.(type) {
+	case string:
+		patterns = []string{v}
+	case []string:
+		patterns = append(patterns, v...)
+	case []any:
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: %s expects string globs, got %T", moduleID, extraFilesKey, item)
+			}
+			patterns = append(patterns, str)
+		}
+	default:
+		return nil, fmt.Errorf("%s: %s must be a list of globs, got %T", moduleID, extraFilesKey, raw)
+	}
+	for _, pattern := range patterns {
+		if _, err := parseExtraFile(pattern); err != nil {
+			return nil, err
+		}
+	}
+	return append(opts, WithExtraFiles(patterns...)), nil
+}
+
+// versionFromConfig reads the release version and the git tag toggle. The
+// version names a directory, so it is limited to a single path segment of
+// letters, digits, dots, dashes, underscores, and plus signs.
+func versionFromConfig(cfg module.Config) ([]Option, error) {
+	var opts []Option
+	version := ""
+	if raw, ok := cfg[versionKey]; ok && raw != nil {
+		value, isString := raw.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s: %s must be a string, got %T", moduleID, versionKey, raw)
+		}
+		version = strings.TrimSpace(value)
+		if err := validateVersion(version); err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithVersion(version))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, gitTagKey); err != nil {
+		return nil, err
+	} else if ok {
+		if enabled && version == "" {
+			return nil, fmt.Errorf("%s: %s requires %s", moduleID, gitTagKey, versionKey)
+		}
+		opts = append(opts, WithGitTag(enabled))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, forceTagKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithForceTag(enabled))
+	}
+	return opts, nil
+}
+
+func validateVersion(version string) error {
+	if version == "" || version == "." || version == ".." {
+		return fmt.Errorf("%s: %s %q is not a valid release name", moduleID, versionKey, version)
+	}
+	for _, r := range version {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.', r == '-', r == '_', r == '+':
+		default:
+			return fmt.Errorf("%s: %s %q may only contain letters, digits, '.', '-', '_', and '+'", moduleID, versionKey, version)
+		}
+	}
+	return nil
+}
+
+// git runs git in the project directory and records the call in the run log.
+func (m *Module) git(ctx *module.ModuleContext, args ...string) error {
+	err := m.runGit(ctx.Config.ProjectDir, args...)
+	ctx.LogCommand(ctx.Config.ProjectDir, "git", args, nil, err)
+	return err
+}
+
+func execGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 func (m *Module) archiveWorkLog(ctx *module.ModuleContext) error {
 	path := artifact.WorkLogDoc.Path(ctx.Workflow)
 	data, err := os.ReadFile(path)
@@ -275,7 +645,7 @@ func (m *Module) archiveWorkLog(ctx *module.ModuleContext) error {
 		}
 		return fmt.Errorf("%s: read work log: %w", moduleID, err)
 	}
-	name := fmt.Sprintf("work-log-%s.md", m.now().UTC().Format("20060102-150405"))
+	name := fmt.Sprintf("work-log-%s.md", m.now().UTC().Format(packageTimeFormat))
 	dest := filepath.Join(ctx.Workflow.ReleaseDir(), name)
 	if err := os.WriteFile(dest, data, 0o644); err != nil {
 		return fmt.Errorf("%s: archive work log: %w", moduleID, err)
@@ -295,7 +665,7 @@ func (m *Module) archiveWorkerRoster(ctx *module.ModuleContext) error {
 		}
 		return fmt.Errorf("%s: read workers manifest: %w", moduleID, err)
 	}
-	name := fmt.Sprintf("workers-%s.json", m.now().UTC().Format("20060102-150405"))
+	name := fmt.Sprintf("workers-%s.json", m.now().UTC().Format(packageTimeFormat))
 	dest := filepath.Join(ctx.Workflow.ReleaseDir(), name)
 	if err := os.WriteFile(dest, data, 0o644); err != nil {
 		return fmt.Errorf("%s: archive workers: %w", moduleID, err)
@@ -329,17 +699,17 @@ func (m *Module) writeMarkers(ctx *module.ModuleContext) error {
 		artifact.OrchestratorReleasedMarker,
 	}
 	for _, ref := range markers {
-		if err := ctx.Artifacts.Write(ref, nil, artifact.Metadata{}); err != nil {
-			return fmt.Errorf("%s: write %s: %w", moduleID, ref.ID, err)
+		if err := runtime.WriteMarker(ctx, moduleID, moduleVersion, ref); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func (m *Module) renderReleaseNotes(workLog, audit string, workers []string, orchestrator string, beads []beadSummary, packageName, warning string) string {
+func (m *Module) renderReleaseNotes(label, workLog, audit string, workers []string, orchestrator string, beads []beadSummary, packageName, warning string) string {
 	var b strings.Builder
 	timestamp := m.now().UTC().Format(time.RFC3339)
-	b.WriteString("# Release Notes\n\n")
+	b.WriteString(fmt.Sprintf("# Release Notes %s\n\n", label))
 	b.WriteString(fmt.Sprintf("Generated at %s UTC by module %s/%s.\n\n", timestamp, moduleID, moduleVersion))
 	b.WriteString("## Delivery Snapshot\n\n")
 	if orchestrator != "" {
@@ -387,11 +757,11 @@ func (m *Module) renderReleaseNotes(workLog, audit string, workers []string, orc
 	return b.String()
 }
 
-func (m *Module) readDocumentBody(path string) (string, error) {
+func (m *Module) readDocumentBody(reads *runtime.ReadSet, path string) (string, error) {
 	if strings.TrimSpace(path) == "" {
 		return "", nil
 	}
-	data, err := os.ReadFile(path)
+	data, err := reads.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return "", nil
@@ -409,8 +779,8 @@ func (m *Module) readDocumentBody(path string) (string, error) {
 	return string(body), nil
 }
 
-func (m *Module) readWorkerNames(path string) ([]string, error) {
-	data, err := os.ReadFile(path)
+func (m *Module) readWorkerNames(reads *runtime.ReadSet, path string) ([]string, error) {
+	data, err := reads.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("%s: read workers json: %w", moduleID, err)
 	}
@@ -432,8 +802,8 @@ func (m *Module) readWorkerNames(path string) ([]string, error) {
 	return result, nil
 }
 
-func (m *Module) readOrchestratorName(path string) (string, error) {
-	data, err := os.ReadFile(path)
+func (m *Module) readOrchestratorName(reads *runtime.ReadSet, path string) (string, error) {
+	data, err := reads.ReadFile(path)
 	if err != nil {
 		return "", err
 	}
@@ -562,8 +932,3 @@ func resetDirectory(path string) error {
 	}
 	return os.MkdirAll(path, 0o755)
 }
-
-func fingerprint(body []byte) string {
-	sum := sha256.Sum256(body)
-	return fmt.Sprintf("%x", sum[:])
-}
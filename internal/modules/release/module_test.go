@@ -1,9 +1,12 @@
 package release
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +15,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/config"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/workflow"
+	"github.com/kingrea/The-Lattice/internal/workflow/reset"
 )
 
 func TestReleaseRunProducesNotesAndMarkers(t *testing.T) {
@@ -89,6 +93,35 @@ func TestReleaseRunRequiresCompleteMarker(t *testing.T) {
 	}
 }
 
+func TestReleaseRefinesAgainAfterWorkProcessReset(t *testing.T) {
+	ctx := newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	if err := ctx.Artifacts.Write(artifact.WorkExhaustedMarker, nil, artifact.Metadata{}); err != nil {
+		t.Fatalf("write exhausted marker: %v", err)
+	}
+	plan, err := reset.NewPlan(ctx.Config, reset.Options{Phase: workflow.PhaseWorkProcess})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	// The rerun work process leaves beads open and asks for refinement.
+	if err := ctx.Artifacts.Write(artifact.WorkCompleteMarker, nil, artifact.Metadata{}); err != nil {
+		t.Fatalf("write complete marker: %v", err)
+	}
+	if err := ctx.Artifacts.Write(artifact.RefinementNeededMarker, nil, artifact.Metadata{}); err != nil {
+		t.Fatalf("write refinement marker: %v", err)
+	}
+	pending, err := New().refinementPending(ctx)
+	if err != nil {
+		t.Fatalf("refinementPending: %v", err)
+	}
+	if !pending {
+		t.Fatalf("refinement should run after a reset discarded the stale work-exhausted marker")
+	}
+}
+
 func TestReleaseRunFailsWhenPackagesDirBlocked(t *testing.T) {
 	ctx := newReleaseTestContext(t)
 	seedReleaseInputs(t, ctx)
@@ -109,6 +142,279 @@ func TestReleaseRunFailsWhenPackagesDirBlocked(t *testing.T) {
 	}
 }
 
+func TestReleasePackageIncludesExtraFiles(t *testing.T) {
+	ctx := newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	if err := os.WriteFile(filepath.Join(ctx.Config.ProjectDir, "CHANGELOG.md"), []byte("# Changes\n"), 0o644); err != nil {
+		t.Fatalf("seed changelog: %v", err)
+	}
+	repoMemory := filepath.Join(ctx.Config.LatticeProjectDir, "state", "REPO_MEMORY.md")
+	if err := os.MkdirAll(filepath.Dir(repoMemory), 0o755); err != nil {
+		t.Fatalf("mkdir state: %v", err)
+	}
+	if err := os.WriteFile(repoMemory, []byte("memory"), 0o644); err != nil {
+		t.Fatalf("seed repo memory: %v", err)
+	}
+	reg := module.NewRegistry()
+	Register(reg)
+	resolved, err := reg.Resolve(moduleID, module.Config{
+		extraFilesKey: []any{"CHANGELOG*", "lattice:state/*.md"},
+	})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	mod := resolved.(*Module)
+	fixed := time.Date(2026, 2, 4, 10, 0, 0, 0, time.UTC)
+	WithClock(func() time.Time { return fixed })(mod)
+	WithBeadLister(stubBeadLister{})(mod)
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	pkg := filepath.Join(artifact.ReleasePackagesDir.Path(ctx.Workflow), "20260204-100000")
+	ensureExists(t, filepath.Join(pkg, "CHANGELOG.md"))
+	ensureExists(t, filepath.Join(pkg, "state", "REPO_MEMORY.md"))
+	ensureExists(t, filepath.Join(pkg, "work-log.md"))
+}
+
+func TestReleasePackagesDirOverride(t *testing.T) {
+	ctx := newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	external := filepath.Join(t.TempDir(), "ci", "packages")
+	reg := module.NewRegistry()
+	Register(reg)
+	resolved, err := reg.Resolve(moduleID, module.Config{packagesDirKey: external, versionKey: "v2.0.0"})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	mod := resolved.(*Module)
+	WithBeadLister(stubBeadLister{})(mod)
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	ensureExists(t, filepath.Join(external, "v2.0.0", "work-log.md"))
+	if _, err := os.Stat(artifact.ReleasePackagesDir.Path(ctx.Workflow)); !os.IsNotExist(err) {
+		t.Fatalf("default packages dir should be left alone, stat err = %v", err)
+	}
+	var tracked artifact.ArtifactRef
+	for _, ref := range mod.Outputs() {
+		if ref.ID == artifact.ReleasePackagesDir.ID {
+			tracked = ref
+		}
+	}
+	result, err := ctx.Artifacts.Check(tracked)
+	if err != nil {
+		t.Fatalf("check packages output: %v", err)
+	}
+	if result.State != artifact.StateReady || result.Path != external {
+		t.Fatalf("expected packages output tracked at %s, got %s (%s)", external, result.Path, result.State)
+	}
+
+	// A relative packages_dir lands under the project, wherever the process
+	// was started.
+	ctx = newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	relative := New(WithPackagesDir("dist/release"), WithVersion("v2.0.1"), WithBeadLister(stubBeadLister{}))
+	if _, err := relative.Run(ctx); err != nil {
+		t.Fatalf("Run with relative packages dir: %v", err)
+	}
+	ensureExists(t, filepath.Join(ctx.Config.ProjectDir, "dist", "release", "v2.0.1", "work-log.md"))
+
+	blocked := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocked, []byte("file"), 0o644); err != nil {
+		t.Fatalf("seed blocked path: %v", err)
+	}
+	ctx = newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	failed, err := New(WithPackagesDir(blocked), WithBeadLister(stubBeadLister{})).Run(ctx)
+	if err == nil || failed.Status != module.StatusFailed {
+		t.Fatalf("expected unusable packages dir to fail, got %+v, %v", failed, err)
+	}
+}
+
+func TestReleaseRejectsInvalidExtraFiles(t *testing.T) {
+	reg := module.NewRegistry()
+	Register(reg)
+	for _, pattern := range []string{"[", "/etc/passwd", "../secrets", "lattice:"} {
+		if _, err := reg.Resolve(moduleID, module.Config{extraFilesKey: []any{pattern}}); err == nil {
+			t.Fatalf("expected %q to be rejected", pattern)
+		}
+	}
+}
+
+func TestReleaseVersionLabelsNotesAndPackage(t *testing.T) {
+	opts, err := optionsFromConfig(module.Config{versionKey: "v1.4.0", gitTagKey: true})
+	if err != nil {
+		t.Fatalf("optionsFromConfig: %v", err)
+	}
+	ctx := newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	var tagged []string
+	mod := New(append(opts, WithBeadLister(stubBeadLister{}))...)
+	mod.runGit = func(dir string, args ...string) error {
+		if dir != ctx.Config.ProjectDir {
+			t.Fatalf("git ran in %s", dir)
+		}
+		if args[0] == "rev-parse" {
+			return errors.New("no such tag")
+		}
+		tagged = args
+		return nil
+	}
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	data, err := os.ReadFile(artifact.ReleaseNotesDoc.Path(ctx.Workflow))
+	if err != nil {
+		t.Fatalf("read release notes: %v", err)
+	}
+	meta, body, err := artifact.ParseFrontMatter(data)
+	if err != nil {
+		t.Fatalf("parse release notes: %v", err)
+	}
+	if !strings.Contains(string(body), "# Release Notes v1.4.0\n") {
+		t.Fatalf("release notes title missing version: %s", body)
+	}
+	if meta.Notes[versionNoteKey] != "v1.4.0" {
+		t.Fatalf("expected version in metadata, got %+v", meta.Notes)
+	}
+	pkg := filepath.Join(artifact.ReleasePackagesDir.Path(ctx.Workflow), "v1.4.0")
+	ensureExists(t, filepath.Join(pkg, "work-log.md"))
+	if strings.Join(tagged, " ") != "tag -a v1.4.0 -m Release v1.4.0" {
+		t.Fatalf("unexpected git tag call: %q", tagged)
+	}
+	for _, cfg := range []module.Config{
+		{versionKey: "../v1"},
+		{versionKey: "v1/2"},
+		{gitTagKey: true},
+	} {
+		if _, err := optionsFromConfig(cfg); err == nil {
+			t.Fatalf("expected %v to be rejected", cfg)
+		}
+	}
+}
+
+func TestReleaseTagFailureLeavesReleaseIncomplete(t *testing.T) {
+	ctx := newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	pkg := filepath.Join(artifact.ReleasePackagesDir.Path(ctx.Workflow), "v1.4.0")
+	if err := os.MkdirAll(pkg, 0o755); err != nil {
+		t.Fatalf("seed stale package: %v", err)
+	}
+	stale := filepath.Join(pkg, "stale.txt")
+	if err := os.WriteFile(stale, []byte("old"), 0o644); err != nil {
+		t.Fatalf("seed stale file: %v", err)
+	}
+	mod := New(WithVersion("v1.4.0"), WithGitTag(true), WithBeadLister(stubBeadLister{}))
+	mod.runGit = func(dir string, args ...string) error {
+		return errors.New("git unavailable")
+	}
+	runCtx, logFile, err := ctx.OpenRunLog(moduleID, time.Now())
+	if err != nil {
+		t.Fatalf("open run log: %v", err)
+	}
+	if result, err := mod.Run(runCtx); err == nil || result.Status != module.StatusFailed {
+		t.Fatalf("expected tag failure to fail the run, got %+v, %v", result, err)
+	}
+	logFile.Close()
+	if body, err := os.ReadFile(runCtx.RunLogPath); err != nil || !strings.Contains(string(body), "$ git tag -a v1.4.0 -m Release v1.4.0") || !strings.Contains(string(body), "! git unavailable") {
+		t.Fatalf("run log should record the failed tag, got %q, %v", body, err)
+	}
+	if _, err := os.Stat(artifact.ReleaseNotesDoc.Path(ctx.Workflow)); !os.IsNotExist(err) {
+		t.Fatalf("release notes should not be written before the tag, stat err = %v", err)
+	}
+	if done, err := mod.IsComplete(ctx); err != nil || done {
+		t.Fatalf("expected release incomplete after tag failure, got %v, %v", done, err)
+	}
+
+	var calls []string
+	mod.runGit = func(dir string, args ...string) error {
+		calls = append(calls, strings.Join(args, " "))
+		return nil // the tag already exists
+	}
+	if _, err := mod.Run(ctx); err == nil || !strings.Contains(err.Error(), "tag v1.4.0 already exists") {
+		t.Fatalf("an existing tag should fail the rerun, got %v", err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("an existing tag must not be retagged without force_tag, git calls = %v", calls)
+	}
+
+	calls = nil
+	WithForceTag(true)(mod)
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("forced rerun: %v", err)
+	}
+	if len(calls) != 2 || calls[1] != "tag -a v1.4.0 -m Release v1.4.0 -f" {
+		t.Fatalf("force_tag should move the existing tag, git calls = %v", calls)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("stale package file should be cleared, stat err = %v", err)
+	}
+	ensureExists(t, filepath.Join(pkg, "work-log.md"))
+}
+
+func TestReleaseNotesRecordFilesRead(t *testing.T) {
+	ctx := newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	fixed := time.Date(2026, 2, 4, 10, 0, 0, 0, time.UTC)
+	mod := New(WithClock(func() time.Time { return fixed }), WithBeadLister(stubBeadLister{}))
+	if _, err := mod.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	data, err := os.ReadFile(artifact.ReleaseNotesDoc.Path(ctx.Workflow))
+	if err != nil {
+		t.Fatalf("read release notes: %v", err)
+	}
+	meta, _, err := artifact.ParseFrontMatter(data)
+	if err != nil {
+		t.Fatalf("parse release notes: %v", err)
+	}
+	for _, ref := range []artifact.ArtifactRef{artifact.WorkLogDoc, artifact.WorkersJSON} {
+		rel, err := filepath.Rel(ctx.Config.ProjectDir, ref.Path(ctx.Workflow))
+		if err != nil {
+			t.Fatalf("rel: %v", err)
+		}
+		if !slices.Contains(meta.Reads, filepath.ToSlash(rel)) {
+			t.Fatalf("expected %s in read set %v", rel, meta.Reads)
+		}
+	}
+	if !slices.IsSorted(meta.Reads) {
+		t.Fatalf("expected sorted read set, got %v", meta.Reads)
+	}
+}
+
+func TestReleaseFingerprintUsesConfiguredAlgorithm(t *testing.T) {
+	opts, err := optionsFromConfig(module.Config{"fingerprint_algorithm": "fnv1a64"})
+	if err != nil {
+		t.Fatalf("optionsFromConfig: %v", err)
+	}
+	ctx := newReleaseTestContext(t)
+	seedReleaseInputs(t, ctx)
+	fixed := time.Date(2026, 2, 4, 10, 0, 0, 0, time.UTC)
+	opts = append(opts, WithClock(func() time.Time { return fixed }), WithBeadLister(stubBeadLister{}))
+	if _, err := New(opts...).Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	data, err := os.ReadFile(artifact.ReleaseNotesDoc.Path(ctx.Workflow))
+	if err != nil {
+		t.Fatalf("read release notes: %v", err)
+	}
+	meta, body, err := artifact.ParseFrontMatter(data)
+	if err != nil {
+		t.Fatalf("parse release notes: %v", err)
+	}
+	stored := meta.Notes[module.FingerprintNoteKey(artifact.ReleaseNotesDoc.ID)]
+	if got := artifact.FingerprintAlgorithm(stored); got != artifact.HashFNV1a64 {
+		t.Fatalf("expected fnv1a64 fingerprint, got %q", stored)
+	}
+	hasher, _ := artifact.NewHasher(artifact.HashFNV1a64)
+	if want := hasher.Fingerprint(bytes.TrimPrefix(body, []byte("\n"))); stored != want {
+		t.Fatalf("fingerprint %s does not match release notes body (%s)", stored, want)
+	}
+	if _, err := optionsFromConfig(module.Config{"fingerprint_algorithm": "md5"}); err == nil {
+		t.Fatalf("expected unknown algorithm to be rejected")
+	}
+}
+
 func newReleaseTestContext(t *testing.T) *module.ModuleContext {
 	projectDir := t.TempDir()
 	if err := config.InitLatticeDir(projectDir); err != nil {
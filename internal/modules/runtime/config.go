@@ -0,0 +1,111 @@
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/module"
+)
+
+// BoolFromConfig reads a boolean key for moduleID, accepting YAML booleans and
+// the strings passed by --set flags. set is false when the key is absent.
+func BoolFromConfig(moduleID string, cfg module.Config, key string) (value, set bool, err error) {
+	raw, ok := cfg[key]
+	if !ok || raw == nil {
+		return false, false, nil
+	}
+	switch v := raw.(type) {
+	case bool:
+		return v, true, nil
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, false, fmt.Errorf("%s: %s must be a boolean, got %q", moduleID, key, v)
+		}
+		return parsed, true, nil
+	default:
+		return false, false, fmt.Errorf("%s: %s must be a boolean, got %T", moduleID, key, raw)
+	}
+}
+
+// IntFromConfig reads a whole number for moduleID, accepting YAML numbers and
+// the strings passed by --set flags. set is false when the key is absent.
+func IntFromConfig(moduleID string, cfg module.Config, key string) (value int, set bool, err error) {
+	raw, ok := cfg[key]
+	if !ok || raw == nil {
+		return 0, false, nil
+	}
+	switch v := raw.(type) {
+	case int:
+		return v, true, nil
+	case int64:
+		return int(v), true, nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false, fmt.Errorf("%s: %s must be a whole number, got %v", moduleID, key, v)
+		}
+		return int(v), true, nil
+	case string:
+		parsed, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0, false, fmt.Errorf("%s: %s must be a whole number, got %q", moduleID, key, v)
+		}
+		return parsed, true, nil
+	default:
+		return 0, false, fmt.Errorf("%s: %s must be a number, got %T", moduleID, key, raw)
+	}
+}
+
+// PositiveIntFromConfig reads a whole number of at least 1 for moduleID. set
+// is false when the key is absent.
+func PositiveIntFromConfig(moduleID string, cfg module.Config, key string) (value int, set bool, err error) {
+	value, set, err = IntFromConfig(moduleID, cfg, key)
+	if err != nil || !set {
+		return 0, false, err
+	}
+	if value < 1 {
+		return 0, false, fmt.Errorf("%s: %s must be at least 1, got %d", moduleID, key, value)
+	}
+	return value, true, nil
+}
+
+// StringListFromConfig reads a key for moduleID holding one string or a list of
+// strings. set is false when the key is absent.
+func StringListFromConfig(moduleID string, cfg module.Config, key string) (values []string, set bool, err error) {
+	raw, ok := cfg[key]
+	if !ok || raw == nil {
+		return nil, false, nil
+	}
+	values, err = StringList(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %s: %w", moduleID, key, err)
+	}
+	return values, true, nil
+}
+
+// StringList converts a config value holding one string or a list of strings,
+// as decoded from YAML or passed by --set flags, into a string slice. A nil
+// value yields an empty list.
+func StringList(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []string:
+		return append([]string(nil), v...), nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string entries, got %T", item)
+			}
+			out = append(out, str)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("expected a list of strings, got %T", value)
+	}
+}
@@ -43,6 +43,28 @@ func WithFingerprint(ref artifact.ArtifactRef, value string) MetadataOption {
 	}
 }
 
+// FingerprintAlgorithmKey is the module config key selecting the hash used for
+// artifact fingerprints.
+const FingerprintAlgorithmKey = "fingerprint_algorithm"
+
+// HasherFromConfig resolves the optional fingerprint_algorithm config value.
+// The boolean is false when the key is absent.
+func HasherFromConfig(moduleID string, cfg module.Config) (artifact.Hasher, bool, error) {
+	raw, ok := cfg[FingerprintAlgorithmKey]
+	if !ok || raw == nil {
+		return nil, false, nil
+	}
+	name, ok := raw.(string)
+	if !ok {
+		return nil, false, fmt.Errorf("%s: %s must be a string, got %T", moduleID, FingerprintAlgorithmKey, raw)
+	}
+	hasher, err := artifact.NewHasher(name)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", moduleID, err)
+	}
+	return hasher, true, nil
+}
+
 // ValidateContext ensures modules receive a usable context.
 func ValidateContext(moduleID string, ctx *module.ModuleContext) error {
 	if ctx == nil {
@@ -132,6 +154,15 @@ func EnsureMarker(ctx *module.ModuleContext, moduleID, version string, ref artif
 	}
 }
 
+// WriteMarker stamps a marker artifact with the writing module and version.
+func WriteMarker(ctx *module.ModuleContext, moduleID, version string, ref artifact.ArtifactRef) error {
+	meta := artifact.Metadata{ArtifactID: ref.ID, ModuleID: moduleID, Version: version, Workflow: ctx.Workflow.Dir()}
+	if err := ctx.Artifacts.Write(ref, nil, meta); err != nil {
+		return fmt.Errorf("%s: write %s: %w", moduleID, ref.ID, err)
+	}
+	return nil
+}
+
 func writeDocument(ctx *module.ModuleContext, moduleID, version string, ref artifact.ArtifactRef, opts ...MetadataOption) error {
 	path := ref.Path(ctx.Workflow)
 	if path == "" {
@@ -0,0 +1,88 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+)
+
+// ReadSet records the concrete files a module read during a run so the paths
+// can be stored as provenance in its output metadata. Paths under the root
+// are kept relative to it; others stay absolute. A nil ReadSet reads without
+// recording.
+type ReadSet struct {
+	root  string
+	mu    sync.Mutex
+	paths map[string]struct{}
+}
+
+// NewReadSet returns an empty read set whose paths are relative to root,
+// normally the project directory.
+func NewReadSet(root string) *ReadSet {
+	return &ReadSet{root: root, paths: map[string]struct{}{}}
+}
+
+// ReadFile reads path and records it when the read succeeds.
+func (r *ReadSet) ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		r.Record(path)
+	}
+	return data, err
+}
+
+// Open opens path for reading and records it when the open succeeds.
+func (r *ReadSet) Open(path string) (*os.File, error) {
+	file, err := os.Open(path)
+	if err == nil {
+		r.Record(path)
+	}
+	return file, err
+}
+
+// Record adds a path read through some other means.
+func (r *ReadSet) Record(path string) {
+	if r == nil || strings.TrimSpace(path) == "" {
+		return
+	}
+	clean := filepath.Clean(path)
+	if r.root != "" && filepath.IsAbs(clean) {
+		if rel, err := filepath.Rel(r.root, clean); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			clean = filepath.ToSlash(rel)
+		}
+	}
+	r.mu.Lock()
+	r.paths[clean] = struct{}{}
+	r.mu.Unlock()
+}
+
+// Paths returns the recorded paths in sorted order.
+func (r *ReadSet) Paths() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.paths) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(r.paths))
+	for path := range r.paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// WithReads records the files in reads as the artifact's read set.
+func WithReads(reads *ReadSet) MetadataOption {
+	return func(meta *artifact.Metadata) {
+		if paths := reads.Paths(); len(paths) > 0 {
+			meta.Reads = paths
+		}
+	}
+}
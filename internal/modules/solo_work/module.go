@@ -249,16 +249,7 @@ func (m *Module) ensureSoloOrchestrator(ctx *module.ModuleContext) error {
 }
 
 func (m *Module) writeCompletionMarker(ctx *module.ModuleContext) error {
-	meta := artifact.Metadata{
-		ArtifactID: artifact.WorkCompleteMarker.ID,
-		ModuleID:   moduleID,
-		Version:    moduleVersion,
-		Workflow:   ctx.Workflow.Dir(),
-	}
-	if err := ctx.Artifacts.Write(artifact.WorkCompleteMarker, nil, meta); err != nil {
-		return fmt.Errorf("%s: write work-complete marker: %w", moduleID, err)
-	}
-	return nil
+	return runtime.WriteMarker(ctx, moduleID, moduleVersion, artifact.WorkCompleteMarker)
 }
 
 func readDocumentSnippet(path string) (string, error) {
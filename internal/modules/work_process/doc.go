@@ -27,7 +27,10 @@ package work_process
 //     `local-dreaming`) can be installed on demand.
 //   - `ModuleContext.Config.WorkerListPath()` and `AgentsDir()` must remain in
 //     sync with hiring outputs; the orchestrator cross-links roster entries to
-//     dossiers before dispatching OpenCode windows.
+//     dossiers before dispatching OpenCode windows. Before a cycle launches,
+//     every scheduled agent must have its `AGENT.md` and the `AGENT_SUP.md`
+//     support packet hiring writes beside it; otherwise the run fails naming
+//     the agents that are missing files.
 //   - `ModuleContext.Config.StateDir()` stores `state/cycle.json` (cycle counter)
 //     plus per-cycle summaries (`state/cycle-*/SUMMARY.md`) and
 //     `state/REPO_MEMORY.md`, all of which the module rewrites while landing a
@@ -36,13 +39,70 @@ package work_process
 // Outputs:
 //   - Work markers beneath `.lattice/workflow/work/`: `.in-progress` stamped
 //     before sessions launch, `.complete` written after down-cycle cleanup, and
-//     `.refinement-needed` emitted when no ready beads exist (refinement treats
-//     this as a gate signal).
+//     `.refinement-needed` emitted when no ready beads exist or when
+//     consecutive cycles close no beads (refinement treats this as a gate
+//     signal). When every bead is closed the module instead writes
+//     `.work-exhausted` alongside `.complete` and logs the drain, so release
+//     can run without a refinement pass. The stall limit defaults to 3 and can
+//     be changed with the `max_stalled_cycles` config key. Setting
+//     `min_agents_per_cycle` holds the cycle (needs-input, no markers left
+//     behind) until at least that many agents can be scheduled. With
+//     `strict_bead_ids` enabled, an agent event naming bead IDs outside its
+//     session fails the cycle instead of only being logged to the worktree
+//     `LOG.md`. `bead_order` (`points`, `priority`, `created`, or `id`) picks
+//     which ready beads a cycle takes first. `assignment_strategy` (`balanced`,
+//     `round-robin`, `bin-packing`, or `skill-weighted`) picks how those beads
+//     are split across agents; `role_routing` first gives beads tagged
+//     `role:<name>` to an agent whose AGENT.md role mentions `<name>` and has
+//     room. `session_cpu_seconds`, `session_memory_mb`, and `session_max_procs`
+//     wrap each opencode session in prlimit/ulimit on Linux. `repo_memory` adds
+//     `state/REPO_MEMORY.md` to each agent prompt to load before working;
+//     `repo_memory_max_kb` (default 64) caps the size agents are told to load
+//     in full. `max_unrelated_bug_beads` caps the beads created from
+//     '# unrelated bugs' entries per cycle; entries past the cap are folded
+//     into one "misc unrelated bugs" bead. `cycle_cooldown_seconds` pauses
+//     between cycles before the orchestrator restarts, for rate-limited model
+//     backends. `bead_sync_interval_seconds` runs `bd sync` between agent
+//     cycles at most that often; failures go to `logs/bead-sync.log`. Beads
+//     that stay blocked for `max_blocked_cycles` cycles (default 3) are logged
+//     to `logs/stuck-beads.log`. `archive_retention` keeps only that many
+//     recent cycles of archived WORKTREE.md files and events in each session.
+//     `max_prompt_beads` lists at most that many assigned beads inline in each
+//     agent prompt; the rest are summarized with a pointer to the full list in
+//     WORKTREE.md. `cycle_metadata` is a map of free-form notes (an experiment
+//     ID, the prompt under test) recorded in the cycle tracker and the
+//     down-cycle log; module-runner's `--cycle-meta key=value` sets entries.
+//     `solo` schedules only the first rostered agent, so each cycle runs a
+//     single session. `invocation_budget` caps the opencode sessions a cycle
+//     launches; past it, agent retries wait for the next cycle, dreaming is
+//     skipped, and questions get a "no automatic answer" response instead of an
+//     auto-answer, as recorded in the work log. `max_concurrent_auto_responses`
+//     caps how many question auto-responses run at once across all sessions;
+//     further questions wait for a slot. `worktree_base_branch` makes agent
+//     worktrees branch from the named branch instead of the current checkout;
+//     the run fails if it is missing. `co_conductors` names extra orchestrators
+//     recorded in the roster that share question handling and post-cycle
+//     reviews, sharded by session. `annotate_completed_beads` comments on each
+//     completed bead in bd with the agent and cycle that closed it.
+//     `review_only` runs cycles where agents write findings to
+//     `outbox/reports/` instead of committing; the findings land in the work
+//     log and worktrees are not landed. `offload_over_capacity` moves beads an
+//     agent has not started off its session once the agent's re-estimates push
+//     it past its capacity; they stay open for the next cycle.
+//     `escalation_notifier` (`none` or `webhook`) picks where questions the
+//     orchestrator could not answer, and agents' `# need help` entries, are
+//     announced; `escalation_webhook_url` is the URL the webhook notifier posts
+//     JSON to. When `event_bridge` is enabled and the orchestrator has a
+//     router, the cycle's structured events are published on it under
+//     `work-process`. Every bd, git, and tmux command the orchestrator runs is
+//     recorded in the module run log.
 //   - `current-cycle.json` in `.lattice/workflow/work/` tracking prepared
 //     sessions so the TUI and scheduler can resume partially finished cycles.
 //   - Worktree directories under `.lattice/worktree/<cycle>/<session>/` with
 //     refreshed `WORKTREE.md`, `LOG.md`, per-cycle archives, and question
-//     mailboxes. These directories act as the agent IO contract for OpenCode.
+//     mailboxes. These directories act as the agent IO contract for OpenCode;
+//     `orchestrator.ValidateSessionDir` checks them before each agent cycle is
+//     dispatched.
 //   - Down-cycle artifacts: a cycle summary for each agent and the orchestrator
 //     (`state/cycle-<n>/SUMMARY.md`), appended personal memories in
 //     `.lattice/agents/**/MEMORY.md`, and a refreshed `state/REPO_MEMORY.md`.
@@ -55,4 +115,5 @@ package work_process
 //     tickets) plus updated PLAN.md references that keep hiring/refinement in
 //     sync with real progress.
 //   - A restarted orchestrator prompt with the next cycle number so future runs
-//     can be resumed without manual reconfiguration.
+//     can be resumed without manual reconfiguration. The restart is skipped
+//     when the stall limit trips (`orchestrator.ErrNoProgress`).
@@ -2,15 +2,16 @@ package work_process
 
 import (
 	"context"
-	"crypto/sha256"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/eventbridge"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/modules/runtime"
 	"github.com/kingrea/The-Lattice/internal/orchestrator"
@@ -19,8 +20,72 @@ import (
 const (
 	moduleID      = "work-process"
 	moduleVersion = "1.0.0"
+
+	maxStalledCyclesKey  = "max_stalled_cycles"
+	maxBlockedCyclesKey  = "max_blocked_cycles"
+	minAgentsPerCycleKey = "min_agents_per_cycle"
+	strictBeadIDsKey     = "strict_bead_ids"
+	beadOrderKey         = "bead_order"
+	assignmentKey        = "assignment_strategy"
+	roleRoutingKey       = "role_routing"
+	sparkPolicyKey       = "spark_policy"
+	sessionCPUSecondsKey = "session_cpu_seconds"
+	sessionMemoryMBKey   = "session_memory_mb"
+	sessionMaxProcsKey   = "session_max_procs"
+	repoMemoryKey        = "repo_memory"
+	repoMemoryMaxKBKey   = "repo_memory_max_kb"
+	maxUnrelatedBugsKey  = "max_unrelated_bug_beads"
+	cycleCooldownKey     = "cycle_cooldown_seconds"
+	beadSyncIntervalKey  = "bead_sync_interval_seconds"
+	archiveRetentionKey  = "archive_retention"
+	soloKey              = "solo"
+	invocationBudgetKey  = "invocation_budget"
+	worktreeBaseKey      = "worktree_base_branch"
+	annotateBeadsKey     = "annotate_completed_beads"
+	reviewOnlyKey        = "review_only"
+	notifierKey          = "escalation_notifier"
+	webhookURLKey        = "escalation_webhook_url"
+	maxAutoResponsesKey  = "max_concurrent_auto_responses"
+	cycleMetadataKey     = "cycle_metadata"
+	maxPromptBeadsKey    = "max_prompt_beads"
+	offloadKey           = "offload_over_capacity"
+	coConductorsKey      = "co_conductors"
 )
 
+// configSchema lists the config keys work-process accepts.
+var configSchema = module.ConfigSchema{
+	runtime.FingerprintAlgorithmKey: {Type: module.ConfigString, Description: "hash used for artifact fingerprints"},
+	maxStalledCyclesKey:             {Type: module.ConfigInt, Description: "consecutive no-progress cycles before refinement"},
+	maxBlockedCyclesKey:             {Type: module.ConfigInt, Description: "cycles a bead may stay blocked before it is reported"},
+	minAgentsPerCycleKey:            {Type: module.ConfigInt, Description: "agents required before a cycle starts"},
+	strictBeadIDsKey:                {Type: module.ConfigBool, Description: "fail cycles on unknown bead IDs"},
+	beadOrderKey:                    {Type: module.ConfigString, Description: "ready-bead sort key"},
+	assignmentKey:                   {Type: module.ConfigString, Description: "how beads are distributed across agents"},
+	roleRoutingKey:                  {Type: module.ConfigBool, Description: "send role:<name> tagged beads to agents with that role first"},
+	sparkPolicyKey:                  {Type: module.ConfigString, Description: "SPARK agents: skip, schedule, or fail"},
+	sessionCPUSecondsKey:            {Type: module.ConfigInt, Description: "CPU seconds per agent session"},
+	sessionMemoryMBKey:              {Type: module.ConfigInt, Description: "memory in MB per agent session"},
+	sessionMaxProcsKey:              {Type: module.ConfigInt, Description: "processes per agent session"},
+	repoMemoryKey:                   {Type: module.ConfigBool, Description: "add REPO_MEMORY.md to agent prompts"},
+	repoMemoryMaxKBKey:              {Type: module.ConfigInt, Description: "repo memory size agents load in full"},
+	maxUnrelatedBugsKey:             {Type: module.ConfigInt, Description: "unrelated-bug beads per cycle"},
+	cycleCooldownKey:                {Type: module.ConfigInt, Description: "seconds to wait between cycles"},
+	beadSyncIntervalKey:             {Type: module.ConfigInt, Description: "seconds between bd sync runs during long cycles"},
+	archiveRetentionKey:             {Type: module.ConfigInt, Description: "archived cycles kept per worktree session"},
+	soloKey:                         {Type: module.ConfigBool, Description: "run one agent in one session per cycle"},
+	invocationBudgetKey:             {Type: module.ConfigInt, Description: "opencode sessions a cycle may launch"},
+	worktreeBaseKey:                 {Type: module.ConfigString, Description: "branch new worktrees start from"},
+	annotateBeadsKey:                {Type: module.ConfigBool, Description: "comment on completed beads with agent and cycle"},
+	reviewOnlyKey:                   {Type: module.ConfigBool, Description: "collect findings without committing or landing code"},
+	notifierKey:                     {Type: module.ConfigString, Description: "where escalated questions are sent: none or webhook"},
+	webhookURLKey:                   {Type: module.ConfigString, Description: "URL the webhook escalation notifier posts to"},
+	maxAutoResponsesKey:             {Type: module.ConfigInt, Description: "question auto-responses running at once across sessions"},
+	cycleMetadataKey:                {Type: module.ConfigMap, Description: "free-form notes recorded with each cycle"},
+	maxPromptBeadsKey:               {Type: module.ConfigInt, Description: "beads listed inline in an agent prompt"},
+	offloadKey:                      {Type: module.ConfigBool, Description: "move unstarted beads off sessions pushed over capacity"},
+	coConductorsKey:                 {Type: module.ConfigStringList, Description: "extra orchestrators sharing question handling and reviews"},
+}
+
 // Option customizes the work process module.
 type Option func(*WorkProcessModule)
 
@@ -42,6 +107,234 @@ func WithRunner(r cycleRunner) Option {
 	}
 }
 
+// WithMaxStalledCycles sets how many consecutive cycles may close no beads
+// before the orchestrator halts and refinement is requested.
+func WithMaxStalledCycles(n int) Option {
+	return func(m *WorkProcessModule) {
+		if n > 0 {
+			m.maxStalledCycles = n
+		}
+	}
+}
+
+// WithMaxBlockedCycles sets how many cycles a bead may stay blocked before it
+// is reported as stuck.
+func WithMaxBlockedCycles(n int) Option {
+	return func(m *WorkProcessModule) {
+		if n > 0 {
+			m.maxBlockedCycles = n
+		}
+	}
+}
+
+// WithMinAgentsPerCycle holds the cycle until at least n agents can be
+// scheduled.
+func WithMinAgentsPerCycle(n int) Option {
+	return func(m *WorkProcessModule) {
+		if n > 0 {
+			m.minAgentsPerCycle = n
+		}
+	}
+}
+
+// WithSolo schedules a single agent per cycle.
+func WithSolo(enabled bool) Option {
+	return func(m *WorkProcessModule) {
+		m.solo = enabled
+	}
+}
+
+// WithStrictBeadIDs fails a session whose agent reports bead IDs it was not
+// assigned, instead of only logging them.
+func WithStrictBeadIDs(strict bool) Option {
+	return func(m *WorkProcessModule) {
+		m.strictBeadIDs = strict
+	}
+}
+
+// WithBeadOrder selects the primary sort key for ready beads.
+func WithBeadOrder(order orchestrator.BeadOrder) Option {
+	return func(m *WorkProcessModule) {
+		m.beadOrder = order
+	}
+}
+
+// WithAssignmentStrategy selects how each cycle's beads are distributed
+// across agents.
+func WithAssignmentStrategy(strategy orchestrator.AssignmentStrategy) Option {
+	return func(m *WorkProcessModule) {
+		m.assignment = strategy
+	}
+}
+
+// WithRoleRouting gives beads tagged role:<name> to an agent whose role
+// mentions <name> while one has capacity, ahead of the assignment strategy.
+func WithRoleRouting(enabled bool) Option {
+	return func(m *WorkProcessModule) {
+		m.roleRouting = enabled
+	}
+}
+
+// WithSparkPolicy decides whether SPARK placeholder agents are scheduled.
+func WithSparkPolicy(policy orchestrator.SparkPolicy) Option {
+	return func(m *WorkProcessModule) {
+		m.sparkPolicy = policy
+	}
+}
+
+// WithSessionLimits constrains the resources of each agent session process.
+func WithSessionLimits(limits orchestrator.SessionLimits) Option {
+	return func(m *WorkProcessModule) {
+		m.sessionLimits = limits
+	}
+}
+
+// WithRepoMemory points agent prompts at state/REPO_MEMORY.md. Files larger
+// than maxKB (when positive) are referenced with a note to read selectively.
+func WithRepoMemory(enabled bool, maxKB int) Option {
+	return func(m *WorkProcessModule) {
+		m.repoMemory = enabled
+		if maxKB > 0 {
+			m.repoMemoryMaxKB = maxKB
+		}
+	}
+}
+
+// WithMaxUnrelatedBugBeads caps the beads created from agents' unrelated-bug
+// entries per cycle; the rest are aggregated into one bead per session.
+func WithMaxUnrelatedBugBeads(n int) Option {
+	return func(m *WorkProcessModule) {
+		if n > 0 {
+			m.maxUnrelatedBugBeads = n
+		}
+	}
+}
+
+// WithCycleCooldown waits d between cycles before restarting the orchestrator.
+func WithCycleCooldown(d time.Duration) Option {
+	return func(m *WorkProcessModule) {
+		if d > 0 {
+			m.cycleCooldown = d
+		}
+	}
+}
+
+// WithBeadSyncInterval runs bd sync between agent cycles at most every d.
+func WithBeadSyncInterval(d time.Duration) Option {
+	return func(m *WorkProcessModule) {
+		if d > 0 {
+			m.beadSyncInterval = d
+		}
+	}
+}
+
+// WithMaxPromptBeads lists at most n beads inline in each agent prompt and
+// points the agent at WORKTREE.md for the rest.
+func WithMaxPromptBeads(n int) Option {
+	return func(m *WorkProcessModule) {
+		if n > 0 {
+			m.maxPromptBeads = n
+		}
+	}
+}
+
+// WithCycleMetadata records free-form notes, such as an experiment ID, in
+// the cycle tracker and down-cycle log of every cycle the module runs.
+func WithCycleMetadata(meta map[string]string) Option {
+	return func(m *WorkProcessModule) {
+		if len(meta) > 0 {
+			m.cycleMetadata = meta
+		}
+	}
+}
+
+// WithCoConductors records extra orchestrators that share question handling
+// and post-cycle reviews with the primary one. An empty list clears any
+// co-conductors already in the roster.
+func WithCoConductors(names ...string) Option {
+	return func(m *WorkProcessModule) {
+		m.coConductors = append([]string{}, names...)
+	}
+}
+
+// WithArchiveRetention keeps only the last n cycles of archived WORKTREE.md
+// files and events in each worktree session.
+func WithArchiveRetention(n int) Option {
+	return func(m *WorkProcessModule) {
+		if n > 0 {
+			m.archiveRetention = n
+		}
+	}
+}
+
+// WithInvocationBudget caps the opencode sessions each cycle may launch.
+func WithInvocationBudget(n int) Option {
+	return func(m *WorkProcessModule) {
+		if n > 0 {
+			m.invocationBudget = n
+		}
+	}
+}
+
+// WithMaxConcurrentAutoResponses caps the question auto-responses running at
+// once across all sessions.
+func WithMaxConcurrentAutoResponses(n int) Option {
+	return func(m *WorkProcessModule) {
+		if n > 0 {
+			m.maxAutoResponses = n
+		}
+	}
+}
+
+// WithWorktreeBaseBranch makes agent worktrees branch from branch instead of
+// the project's current checkout.
+func WithWorktreeBaseBranch(branch string) Option {
+	return func(m *WorkProcessModule) {
+		m.worktreeBaseBranch = strings.TrimSpace(branch)
+	}
+}
+
+// WithAnnotateCompletedBeads comments on each completed bead in bd with the
+// agent and cycle that closed it.
+func WithAnnotateCompletedBeads(enabled bool) Option {
+	return func(m *WorkProcessModule) {
+		m.annotateCompletedBeads = enabled
+	}
+}
+
+// WithOffloadOverCapacity moves beads an agent has not started off its
+// session when re-estimates push the session over the agent's capacity.
+func WithOffloadOverCapacity(enabled bool) Option {
+	return func(m *WorkProcessModule) {
+		m.offloadOverCapacity = enabled
+	}
+}
+
+// WithReviewOnly runs review-only cycles: agents report findings instead of
+// committing, and worktrees are not landed.
+func WithReviewOnly(enabled bool) Option {
+	return func(m *WorkProcessModule) {
+		m.reviewOnly = enabled
+	}
+}
+
+// WithEscalationNotifier sends questions the orchestrator could not answer to
+// notifier.
+func WithEscalationNotifier(notifier orchestrator.EscalationNotifier) Option {
+	return func(m *WorkProcessModule) {
+		m.escalations = notifier
+	}
+}
+
+// WithHasher selects the algorithm used to fingerprint the staged plan.
+func WithHasher(h artifact.Hasher) Option {
+	return func(m *WorkProcessModule) {
+		if h != nil {
+			m.hasher = h
+		}
+	}
+}
+
 type cycleRunner interface {
 	Prepare(*module.ModuleContext) ([]orchestrator.WorktreeSession, error)
 	Execute(context.Context, *module.ModuleContext, []orchestrator.WorktreeSession) error
@@ -52,6 +345,63 @@ type WorkProcessModule struct {
 	*module.Base
 	now    func() time.Time
 	runner cycleRunner
+	// maxStalledCycles overrides the orchestrator's stall limit when positive.
+	maxStalledCycles int
+	// maxBlockedCycles overrides the orchestrator's stuck-bead threshold when
+	// positive.
+	maxBlockedCycles int
+	// minAgentsPerCycle holds the cycle until enough agents are available.
+	minAgentsPerCycle int
+	// solo runs one agent in one session per cycle.
+	solo bool
+	// strictBeadIDs turns unknown bead IDs in agent events into errors.
+	strictBeadIDs bool
+	// beadOrder overrides the orchestrator's ready-bead ordering when set.
+	beadOrder orchestrator.BeadOrder
+	// assignment overrides the orchestrator's bead assignment when set.
+	assignment orchestrator.AssignmentStrategy
+	// roleRouting routes role:-tagged beads to matching agents first.
+	roleRouting bool
+	// sparkPolicy overrides the orchestrator's SPARK handling when set.
+	sparkPolicy orchestrator.SparkPolicy
+	// sessionLimits caps agent session resources when any field is set.
+	sessionLimits orchestrator.SessionLimits
+	// repoMemory adds state/REPO_MEMORY.md to agent prompts, capped at
+	// repoMemoryMaxKB when positive.
+	repoMemory      bool
+	repoMemoryMaxKB int
+	// maxUnrelatedBugBeads caps unrelated-bug beads per cycle when positive.
+	maxUnrelatedBugBeads int
+	// cycleCooldown delays the restart into the next cycle when positive.
+	cycleCooldown time.Duration
+	// beadSyncInterval spaces bd sync runs between agent cycles when
+	// positive.
+	beadSyncInterval time.Duration
+	// maxPromptBeads caps the beads listed inline in agent prompts when
+	// positive.
+	maxPromptBeads int
+	// cycleMetadata is recorded with every cycle when set.
+	cycleMetadata map[string]string
+	// archiveRetention caps the archived cycles kept per session when
+	// positive.
+	archiveRetention int
+	// invocationBudget caps opencode launches per cycle when positive.
+	invocationBudget int
+	// maxAutoResponses caps concurrent auto-responses when positive.
+	maxAutoResponses int
+	// worktreeBaseBranch is the branch worktrees start from when set.
+	worktreeBaseBranch string
+	// annotateCompletedBeads records the closing agent and cycle on beads.
+	annotateCompletedBeads bool
+	// reviewOnly collects findings instead of landing code.
+	reviewOnly bool
+	// offloadOverCapacity sheds unstarted beads from over-capacity sessions.
+	offloadOverCapacity bool
+	// escalations receives questions left for a human when set.
+	escalations orchestrator.EscalationNotifier
+	// coConductors replaces the roster's co-conductors when non-nil.
+	coConductors []string
+	hasher       artifact.Hasher
 }
 
 // Register installs the module factory.
@@ -59,8 +409,12 @@ func Register(reg *module.Registry) {
 	if reg == nil {
 		return
 	}
-	reg.MustRegister(moduleID, func(module.Config) (module.Module, error) {
-		return New(), nil
+	reg.MustRegisterWithSchema(moduleID, configSchema, func(cfg module.Config) (module.Module, error) {
+		opts, err := optionsFromConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return New(opts...), nil
 	})
 }
 
@@ -88,6 +442,7 @@ func New(opts ...Option) *WorkProcessModule {
 		Base:   &base,
 		now:    time.Now,
 		runner: defaultCycleRunner{},
+		hasher: artifact.DefaultHasher,
 	}
 	for _, opt := range opts {
 		if opt != nil {
@@ -97,6 +452,92 @@ func New(opts ...Option) *WorkProcessModule {
 	return mod
 }
 
+// ConfigureOrchestrator applies the module's cycle settings to orch, as Run
+// does before preparing sessions. Tools that render cycle output outside a
+// run, such as the prompt preview, use it so they match the workflow config.
+func (m *WorkProcessModule) ConfigureOrchestrator(orch *orchestrator.Orchestrator) error {
+	if m.maxStalledCycles > 0 {
+		orch.SetMaxStalledCycles(m.maxStalledCycles)
+	}
+	if m.maxBlockedCycles > 0 {
+		orch.SetMaxBlockedCycles(m.maxBlockedCycles)
+	}
+	if m.minAgentsPerCycle > 0 {
+		orch.SetMinAgentsPerCycle(m.minAgentsPerCycle)
+	}
+	if m.solo {
+		orch.SetSolo(true)
+	}
+	if m.strictBeadIDs {
+		orch.SetStrictBeadIDs(true)
+	}
+	if m.beadOrder != "" {
+		orch.SetBeadOrder(m.beadOrder)
+	}
+	if m.assignment != nil {
+		orch.SetAssignmentStrategy(m.assignment)
+	}
+	if m.roleRouting {
+		orch.SetRoleRouting(true)
+	}
+	if m.sparkPolicy != "" {
+		orch.SetSparkPolicy(m.sparkPolicy)
+	}
+	if !m.sessionLimits.IsZero() {
+		orch.SetSessionLimits(m.sessionLimits)
+	}
+	if m.repoMemory {
+		orch.SetRepoMemory(true, int64(m.repoMemoryMaxKB)*1024)
+	}
+	if m.maxUnrelatedBugBeads > 0 {
+		orch.SetMaxUnrelatedBugBeads(m.maxUnrelatedBugBeads)
+	}
+	if m.cycleCooldown > 0 {
+		orch.SetCycleCooldown(m.cycleCooldown)
+	}
+	if m.beadSyncInterval > 0 {
+		orch.SetBeadSyncInterval(m.beadSyncInterval)
+	}
+	if len(m.cycleMetadata) > 0 {
+		orch.SetCycleMetadata(m.cycleMetadata)
+	}
+	if m.maxPromptBeads > 0 {
+		orch.SetMaxPromptBeads(m.maxPromptBeads)
+	}
+	if m.archiveRetention > 0 {
+		orch.SetArchiveRetention(m.archiveRetention)
+	}
+	if m.invocationBudget > 0 {
+		orch.SetInvocationBudget(m.invocationBudget)
+	}
+	if m.maxAutoResponses > 0 {
+		orch.SetMaxConcurrentAutoResponses(m.maxAutoResponses)
+	}
+	if m.annotateCompletedBeads {
+		orch.SetAnnotateCompletedBeads(true)
+	}
+	if m.reviewOnly {
+		orch.SetReviewOnly(true)
+	}
+	if m.offloadOverCapacity {
+		orch.SetOffloadOverCapacity(true)
+	}
+	if m.escalations != nil {
+		orch.SetEscalationNotifier(m.escalations)
+	}
+	if m.worktreeBaseBranch != "" {
+		if err := orch.SetWorktreeBaseBranch(m.worktreeBaseBranch); err != nil {
+			return fmt.Errorf("%s: %s: %w", moduleID, worktreeBaseKey, err)
+		}
+	}
+	if m.coConductors != nil {
+		if err := orch.SetCoConductors(m.coConductors...); err != nil {
+			return fmt.Errorf("%s: %s: %w", moduleID, coConductorsKey, err)
+		}
+	}
+	return nil
+}
+
 // Run prepares the next work cycle, executes sessions, and updates artifacts.
 func (m *WorkProcessModule) Run(ctx *module.ModuleContext) (module.Result, error) {
 	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
@@ -112,7 +553,20 @@ func (m *WorkProcessModule) Run(ctx *module.ModuleContext) (module.Result, error
 	} else if complete {
 		return module.Result{Status: module.StatusNoOp, Message: "work already completed"}, nil
 	}
-	if _, err := ensureOrchestrator(ctx); err != nil {
+	orch, err := ensureOrchestrator(ctx)
+	if err != nil {
+		return module.Result{Status: module.StatusFailed}, err
+	}
+	if sink := bridgeEventSink(ctx, orch); sink != nil {
+		orch.SetEventSink(sink)
+	}
+	if ctx.CommandLog != nil {
+		// The orchestrator may outlive this run, so stop writing to its log
+		// once the run returns.
+		orch.SetCommandLog(ctx.LogCommand)
+		defer orch.SetCommandLog(nil)
+	}
+	if err := m.ConfigureOrchestrator(orch); err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
 	if err := m.ensureWorkDir(ctx); err != nil {
@@ -122,12 +576,24 @@ func (m *WorkProcessModule) Run(ctx *module.ModuleContext) (module.Result, error
 	if err := m.markInProgress(ctx); err != nil {
 		return module.Result{Status: module.StatusFailed}, err
 	}
+	watchReadyBeads(orch)
 	sessions, err := m.runner.Prepare(ctx)
 	if err != nil {
+		if errors.Is(err, orchestrator.ErrWorkExhausted) {
+			if err := m.markExhausted(ctx); err != nil {
+				_ = m.clearInProgress(ctx)
+				return module.Result{Status: module.StatusFailed}, err
+			}
+			return module.Result{Status: module.StatusCompleted, Message: "work exhausted; every bead is closed"}, nil
+		}
 		if errors.Is(err, orchestrator.ErrNoReadyBeads) || errors.Is(err, orchestrator.ErrNoTrackedSessions) {
 			_ = m.markRefinementNeeded(ctx)
 			return module.Result{Status: module.StatusNeedsInput, Message: "no ready beads available"}, nil
 		}
+		if errors.Is(err, orchestrator.ErrTooFewAgents) {
+			_ = m.clearInProgress(ctx)
+			return module.Result{Status: module.StatusNeedsInput, Message: err.Error()}, nil
+		}
 		_ = m.clearInProgress(ctx)
 		return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: prepare cycle: %w", moduleID, err)
 	}
@@ -135,6 +601,10 @@ func (m *WorkProcessModule) Run(ctx *module.ModuleContext) (module.Result, error
 		_ = m.markRefinementNeeded(ctx)
 		return module.Result{Status: module.StatusNeedsInput, Message: "no sessions staged"}, nil
 	}
+	if err := checkAgentFiles(sessions); err != nil {
+		_ = m.clearInProgress(ctx)
+		return module.Result{Status: module.StatusFailed}, err
+	}
 	if err := m.writeTasksDoc(ctx, sessions); err != nil {
 		_ = m.clearInProgress(ctx)
 		return module.Result{Status: module.StatusFailed}, err
@@ -142,6 +612,10 @@ func (m *WorkProcessModule) Run(ctx *module.ModuleContext) (module.Result, error
 	started := m.now()
 	if err := m.runner.Execute(context.Background(), ctx, sessions); err != nil {
 		_ = m.clearInProgress(ctx)
+		if errors.Is(err, orchestrator.ErrNoProgress) {
+			_ = m.markRefinementNeeded(ctx)
+			return module.Result{Status: module.StatusNeedsInput, Message: "work cycles stalled; refinement requested"}, nil
+		}
 		return module.Result{Status: module.StatusFailed}, fmt.Errorf("%s: run cycle: %w", moduleID, err)
 	}
 	if err := m.markComplete(ctx); err != nil {
@@ -154,6 +628,51 @@ func (m *WorkProcessModule) Run(ctx *module.ModuleContext) (module.Result, error
 	return module.Result{Status: module.StatusCompleted, Message: fmt.Sprintf("ran %d session(s)", len(sessions))}, nil
 }
 
+// checkAgentFiles verifies every scheduled agent has the AGENT.md brief and the
+// AGENT_SUP.md support packet hiring writes beside it, naming each agent that
+// is missing either file.
+func checkAgentFiles(sessions []orchestrator.WorktreeSession) error {
+	var missingBrief, missingSupport []string
+	seen := map[string]bool{}
+	for _, session := range sessions {
+		agent := session.Agent
+		name := strings.TrimSpace(agent.Name)
+		if name == "" {
+			name = session.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if !fileExists(agent.Path) {
+			missingBrief = append(missingBrief, name)
+			continue
+		}
+		if !fileExists(filepath.Join(filepath.Dir(agent.Path), "AGENT_SUP.md")) {
+			missingSupport = append(missingSupport, name)
+		}
+	}
+	var problems []string
+	if len(missingBrief) > 0 {
+		problems = append(problems, fmt.Sprintf("missing AGENT.md: %s", strings.Join(missingBrief, ", ")))
+	}
+	if len(missingSupport) > 0 {
+		problems = append(problems, fmt.Sprintf("missing AGENT_SUP.md support packet: %s", strings.Join(missingSupport, ", ")))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: scheduled agents %s; rerun hiring to regenerate them", moduleID, strings.Join(problems, "; "))
+}
+
+func fileExists(path string) bool {
+	if strings.TrimSpace(path) == "" {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
 // IsComplete returns true when the work complete marker exists.
 func (m *WorkProcessModule) IsComplete(ctx *module.ModuleContext) (bool, error) {
 	if err := runtime.ValidateContext(moduleID, ctx); err != nil {
@@ -187,7 +706,7 @@ func (m *WorkProcessModule) ensureWorkDir(ctx *module.ModuleContext) error {
 }
 
 func (m *WorkProcessModule) markInProgress(ctx *module.ModuleContext) error {
-	return ctx.Artifacts.Write(artifact.WorkInProgressMarker, nil, artifact.Metadata{})
+	return runtime.WriteMarker(ctx, moduleID, moduleVersion, artifact.WorkInProgressMarker)
 }
 
 func (m *WorkProcessModule) clearInProgress(ctx *module.ModuleContext) error {
@@ -201,7 +720,25 @@ func (m *WorkProcessModule) markComplete(ctx *module.ModuleContext) error {
 	if err := m.clearRefinementMarker(ctx); err != nil {
 		return err
 	}
-	return ctx.Artifacts.Write(artifact.WorkCompleteMarker, nil, artifact.Metadata{})
+	// A work-exhausted marker left by an earlier run would make release skip
+	// refinement for work that did not drain the queue.
+	if err := removeIfExists(artifact.WorkExhaustedMarker.Path(ctx.Workflow)); err != nil {
+		return err
+	}
+	return runtime.WriteMarker(ctx, moduleID, moduleVersion, artifact.WorkCompleteMarker)
+}
+
+// markExhausted records that no open beads remain: it logs the drain,
+// completes work without requesting refinement, and writes the
+// work-exhausted marker release consumes.
+func (m *WorkProcessModule) markExhausted(ctx *module.ModuleContext) error {
+	if err := m.appendExhaustedLog(ctx); err != nil {
+		return err
+	}
+	if err := m.markComplete(ctx); err != nil {
+		return err
+	}
+	return runtime.WriteMarker(ctx, moduleID, moduleVersion, artifact.WorkExhaustedMarker)
 }
 
 func (m *WorkProcessModule) markRefinementNeeded(ctx *module.ModuleContext) error {
@@ -211,7 +748,10 @@ func (m *WorkProcessModule) markRefinementNeeded(ctx *module.ModuleContext) erro
 	if err := removeIfExists(artifact.WorkCompleteMarker.Path(ctx.Workflow)); err != nil {
 		return err
 	}
-	return ctx.Artifacts.Write(artifact.RefinementNeededMarker, nil, artifact.Metadata{})
+	if err := removeIfExists(artifact.WorkExhaustedMarker.Path(ctx.Workflow)); err != nil {
+		return err
+	}
+	return runtime.WriteMarker(ctx, moduleID, moduleVersion, artifact.RefinementNeededMarker)
 }
 
 func (m *WorkProcessModule) clearRefinementMarker(ctx *module.ModuleContext) error {
@@ -242,23 +782,12 @@ func (m *WorkProcessModule) writeTasksDoc(ctx *module.ModuleContext, sessions []
 	if meta.Notes == nil {
 		meta.Notes = map[string]string{}
 	}
-	meta.Notes[module.FingerprintNoteKey(artifact.WorkTasksDoc.ID)] = planFingerprint(sorted)
+	meta.Notes[module.FingerprintNoteKey(artifact.WorkTasksDoc.ID)] = planFingerprint(m.hasher, sorted)
 	return ctx.Artifacts.Write(artifact.WorkTasksDoc, []byte(b.String()), meta)
 }
 
 func (m *WorkProcessModule) appendWorkLog(ctx *module.ModuleContext, sessions []orchestrator.WorktreeSession, started time.Time) error {
-	body, err := existingBody(ctx, artifact.WorkLogDoc)
-	if err != nil {
-		return err
-	}
 	var b strings.Builder
-	if len(body) > 0 {
-		b.Write(body)
-		if !strings.HasSuffix(strings.TrimSpace(string(body)), "\n") {
-			b.WriteString("\n")
-		}
-		b.WriteString("\n")
-	}
 	timestamp := started.UTC().Format(time.RFC3339)
 	fmt.Fprintf(&b, "## Cycle dispatch (%s)\n\n", timestamp)
 	for _, session := range sessions {
@@ -270,6 +799,29 @@ func (m *WorkProcessModule) appendWorkLog(ctx *module.ModuleContext, sessions []
 	if len(sessions) == 0 {
 		b.WriteString("- no sessions executed\n")
 	}
+	return m.appendWorkLogSection(ctx, b.String())
+}
+
+func (m *WorkProcessModule) appendExhaustedLog(ctx *module.ModuleContext) error {
+	section := fmt.Sprintf("## Work exhausted (%s)\n\n- every bead is closed; refinement skipped\n", m.now().UTC().Format(time.RFC3339))
+	return m.appendWorkLogSection(ctx, section)
+}
+
+// appendWorkLogSection adds section to the end of the work log.
+func (m *WorkProcessModule) appendWorkLogSection(ctx *module.ModuleContext, section string) error {
+	body, err := existingBody(ctx, artifact.WorkLogDoc)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	if len(body) > 0 {
+		b.Write(body)
+		if !strings.HasSuffix(strings.TrimSpace(string(body)), "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(section)
 	return ctx.Artifacts.Write(artifact.WorkLogDoc, []byte(b.String()), m.metadataFor(ctx, artifact.WorkLogDoc))
 }
 
@@ -283,7 +835,7 @@ func (m *WorkProcessModule) metadataFor(ctx *module.ModuleContext, ref artifact.
 	}
 }
 
-func planFingerprint(sessions []orchestrator.WorktreeSession) string {
+func planFingerprint(hasher artifact.Hasher, sessions []orchestrator.WorktreeSession) string {
 	if len(sessions) == 0 {
 		return "none"
 	}
@@ -296,9 +848,7 @@ func planFingerprint(sessions []orchestrator.WorktreeSession) string {
 		sort.Strings(beadIDs)
 		parts = append(parts, fmt.Sprintf("%s|%s|%s", strings.ToLower(strings.TrimSpace(session.Name)), strings.ToLower(strings.TrimSpace(session.Agent.Name)), strings.Join(beadIDs, ",")))
 	}
-	joined := strings.Join(parts, ";")
-	sum := sha256.Sum256([]byte(joined))
-	return fmt.Sprintf("%x", sum[:])
+	return hasher.Fingerprint([]byte(strings.Join(parts, ";")))
 }
 
 func existingBody(ctx *module.ModuleContext, ref artifact.ArtifactRef) ([]byte, error) {
@@ -348,6 +898,32 @@ func ensureOrchestrator(ctx *module.ModuleContext) (*orchestrator.Orchestrator,
 	return ctx.Orchestrator, nil
 }
 
+// bridgeEventSink publishes structured cycle events on the orchestrator's
+// event bridge router under the work-process module ID when the project's
+// event_bridge is enabled, so monitors can subscribe to them. It returns nil
+// when no router is attached or the bridge is disabled.
+func bridgeEventSink(ctx *module.ModuleContext, orch *orchestrator.Orchestrator) orchestrator.EventSink {
+	router := orch.EventRouter()
+	if router == nil || !eventbridge.SettingsFromConfig(ctx.Config).Enabled {
+		return nil
+	}
+	return orchestrator.NewBridgeEventSink(router, moduleID, ctx.WorkflowID)
+}
+
+// watchReadyBeads joins the orchestrator's shared ready-bead subscription,
+// starting it when none is running yet, such as under module-runner, so every
+// cycle's Prepare reads the pushed set instead of polling `bd ready --json`.
+// It waits for the subscription's first ready set, or for it to end, so the
+// first Prepare never races the stream. Stores without a watch mode are left
+// to polling.
+func watchReadyBeads(orch *orchestrator.Orchestrator) {
+	first, err := orch.SharedReadyBeads()
+	if err != nil {
+		return
+	}
+	<-first
+}
+
 type defaultCycleRunner struct{}
 
 func (defaultCycleRunner) Prepare(ctx *module.ModuleContext) ([]orchestrator.WorktreeSession, error) {
@@ -366,6 +942,214 @@ func (defaultCycleRunner) Execute(goCtx context.Context, ctx *module.ModuleConte
 	return orch.RunUpCycle(goCtx, sessions)
 }
 
+func optionsFromConfig(cfg module.Config) ([]Option, error) {
+	var opts []Option
+	hasher, ok, err := runtime.HasherFromConfig(moduleID, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, WithHasher(hasher))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, maxStalledCyclesKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithMaxStalledCycles(n))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, maxBlockedCyclesKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithMaxBlockedCycles(n))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, minAgentsPerCycleKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithMinAgentsPerCycle(n))
+	}
+	if solo, ok, err := runtime.BoolFromConfig(moduleID, cfg, soloKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithSolo(solo))
+	}
+	if strict, ok, err := runtime.BoolFromConfig(moduleID, cfg, strictBeadIDsKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithStrictBeadIDs(strict))
+	}
+	if raw, ok := cfg[beadOrderKey]; ok && raw != nil {
+		value, isString := raw.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s: %s must be a string, got %T", moduleID, beadOrderKey, raw)
+		}
+		order, err := orchestrator.ParseBeadOrder(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", moduleID, beadOrderKey, err)
+		}
+		opts = append(opts, WithBeadOrder(order))
+	}
+	if raw, ok := cfg[sparkPolicyKey]; ok && raw != nil {
+		value, isString := raw.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s: %s must be a string, got %T", moduleID, sparkPolicyKey, raw)
+		}
+		policy, err := orchestrator.ParseSparkPolicy(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", moduleID, sparkPolicyKey, err)
+		}
+		opts = append(opts, WithSparkPolicy(policy))
+	}
+	if raw, ok := cfg[assignmentKey]; ok && raw != nil {
+		value, isString := raw.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s: %s must be a string, got %T", moduleID, assignmentKey, raw)
+		}
+		strategy, err := orchestrator.ParseAssignmentStrategy(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", moduleID, assignmentKey, err)
+		}
+		opts = append(opts, WithAssignmentStrategy(strategy))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, roleRoutingKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithRoleRouting(enabled))
+	}
+	var limits orchestrator.SessionLimits
+	for key, target := range map[string]*int{
+		sessionCPUSecondsKey: &limits.CPUSeconds,
+		sessionMemoryMBKey:   &limits.MemoryMB,
+		sessionMaxProcsKey:   &limits.MaxProcs,
+	} {
+		n, _, err := runtime.PositiveIntFromConfig(moduleID, cfg, key)
+		if err != nil {
+			return nil, err
+		}
+		*target = n
+	}
+	if !limits.IsZero() {
+		opts = append(opts, WithSessionLimits(limits))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, repoMemoryKey); err != nil {
+		return nil, err
+	} else if ok {
+		maxKB, _, err := runtime.PositiveIntFromConfig(moduleID, cfg, repoMemoryMaxKBKey)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithRepoMemory(enabled, maxKB))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, maxUnrelatedBugsKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithMaxUnrelatedBugBeads(n))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, cycleCooldownKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithCycleCooldown(time.Duration(n)*time.Second))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, beadSyncIntervalKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithBeadSyncInterval(time.Duration(n)*time.Second))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, maxPromptBeadsKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithMaxPromptBeads(n))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, archiveRetentionKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithArchiveRetention(n))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, invocationBudgetKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithInvocationBudget(n))
+	}
+	if n, ok, err := runtime.PositiveIntFromConfig(moduleID, cfg, maxAutoResponsesKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithMaxConcurrentAutoResponses(n))
+	}
+	if raw, ok := cfg[worktreeBaseKey]; ok && raw != nil {
+		value, isString := raw.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s: %s must be a string, got %T", moduleID, worktreeBaseKey, raw)
+		}
+		opts = append(opts, WithWorktreeBaseBranch(value))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, annotateBeadsKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithAnnotateCompletedBeads(enabled))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, reviewOnlyKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithReviewOnly(enabled))
+	}
+	if enabled, ok, err := runtime.BoolFromConfig(moduleID, cfg, offloadKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithOffloadOverCapacity(enabled))
+	}
+	if names, ok, err := runtime.StringListFromConfig(moduleID, cfg, coConductorsKey); err != nil {
+		return nil, err
+	} else if ok {
+		opts = append(opts, WithCoConductors(names...))
+	}
+	if raw, ok := cfg[cycleMetadataKey]; ok && raw != nil {
+		entries, isMap := raw.(map[string]any)
+		if !isMap {
+			return nil, fmt.Errorf("%s: %s must be a map of notes, got %T", moduleID, cycleMetadataKey, raw)
+		}
+		meta := make(map[string]string, len(entries))
+		for key, value := range entries {
+			meta[key] = fmt.Sprint(value)
+		}
+		opts = append(opts, WithCycleMetadata(meta))
+	}
+	notifierOpt, err := notifierFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if notifierOpt != nil {
+		opts = append(opts, notifierOpt)
+	}
+	return opts, nil
+}
+
+// notifierFromConfig builds the escalation notifier option from
+// escalation_notifier and escalation_webhook_url, or returns nil when neither
+// is set.
+func notifierFromConfig(cfg module.Config) (Option, error) {
+	var kind, url string
+	for key, dst := range map[string]*string{notifierKey: &kind, webhookURLKey: &url} {
+		raw, ok := cfg[key]
+		if !ok || raw == nil {
+			continue
+		}
+		value, isString := raw.(string)
+		if !isString {
+			return nil, fmt.Errorf("%s: %s must be a string, got %T", moduleID, key, raw)
+		}
+		*dst = value
+	}
+	if kind == "" && url == "" {
+		return nil, nil
+	}
+	if kind == "" {
+		kind = orchestrator.EscalationNotifierWebhook
+	}
+	notifier, err := orchestrator.NewEscalationNotifier(kind, url)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", moduleID, notifierKey, err)
+	}
+	return WithEscalationNotifier(notifier), nil
+}
+
 func removeIfExists(path string) error {
 	if path == "" {
 		return nil
@@ -2,6 +2,7 @@ package work_process
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,7 +12,9 @@ import (
 
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/eventbridge"
 	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/modules/release"
 	"github.com/kingrea/The-Lattice/internal/orchestrator"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 )
@@ -23,7 +26,7 @@ func TestWorkProcessRunWritesArtifacts(t *testing.T) {
 		{
 			Number: 1,
 			Name:   "tree-1-alpha",
-			Agent:  orchestrator.ProjectAgent{Name: "Aster"},
+			Agent:  hiredAgent(t, ctx, "Aster", true),
 			Beads: []orchestrator.Bead{
 				{ID: "task-1", Title: "Build feature", Points: 3},
 			},
@@ -75,13 +78,78 @@ func TestWorkProcessRunNoReadyBeadsMarksRefinement(t *testing.T) {
 	ensureMissing(t, artifact.WorkInProgressMarker.Path(ctx.Workflow))
 }
 
+func TestWorkProcessRunWorkExhaustedSkipsRefinement(t *testing.T) {
+	ctx := newWorkProcessTestContext(t)
+	seedWorkProcessInputs(t, ctx)
+	runner := &stubCycleRunner{prepareErr: orchestrator.ErrWorkExhausted}
+	mod := New(WithRunner(runner))
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != module.StatusCompleted {
+		t.Fatalf("unexpected status: %+v", result)
+	}
+	ensureExists(t, artifact.WorkExhaustedMarker.Path(ctx.Workflow))
+	ensureMissing(t, artifact.RefinementNeededMarker.Path(ctx.Workflow))
+	ensureMissing(t, artifact.WorkInProgressMarker.Path(ctx.Workflow))
+	if runner.executed {
+		t.Fatalf("exhausted work should not execute a cycle")
+	}
+	if complete, err := mod.IsComplete(ctx); err != nil || !complete {
+		t.Fatalf("expected work-process complete, got %v (%v)", complete, err)
+	}
+	if log := readDoc(t, artifact.WorkLogDoc.Path(ctx.Workflow)); !strings.Contains(log.body, "## Work exhausted") {
+		t.Fatalf("work log missing exhaustion entry:\n%s", log.body)
+	}
+	for _, ref := range release.New().Inputs() {
+		check, err := ctx.Artifacts.Check(ref)
+		if err != nil {
+			t.Fatalf("check %s: %v", ref.ID, err)
+		}
+		if check.State != artifact.StateReady {
+			t.Fatalf("release input %s not ready: %s", ref.ID, check.State)
+		}
+	}
+}
+
+func TestWorkProcessRunWaitsForMinimumAgents(t *testing.T) {
+	ctx := newWorkProcessTestContext(t)
+	seedWorkProcessInputs(t, ctx)
+	reg := module.NewRegistry()
+	Register(reg)
+	resolved, err := reg.Resolve(moduleID, module.Config{"min_agents_per_cycle": float64(3)})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	mod := resolved.(*WorkProcessModule)
+	if mod.minAgentsPerCycle != 3 {
+		t.Fatalf("expected min agents 3, got %d", mod.minAgentsPerCycle)
+	}
+	runner := &stubCycleRunner{prepareErr: fmt.Errorf("%w: 1 of 3 required agent(s) available", orchestrator.ErrTooFewAgents)}
+	WithRunner(runner)(mod)
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != module.StatusNeedsInput || !strings.Contains(result.Message, "waiting for more agents") {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if runner.executed {
+		t.Fatalf("under-staffed cycle should not execute")
+	}
+	ensureMissing(t, artifact.RefinementNeededMarker.Path(ctx.Workflow))
+	ensureMissing(t, artifact.WorkInProgressMarker.Path(ctx.Workflow))
+	ensureMissing(t, artifact.WorkCompleteMarker.Path(ctx.Workflow))
+}
+
 func TestWorkProcessRunPropagatesRunnerError(t *testing.T) {
 	ctx := newWorkProcessTestContext(t)
 	seedWorkProcessInputs(t, ctx)
 	sessions := []orchestrator.WorktreeSession{{
 		Number: 1,
 		Name:   "tree-err",
-		Agent:  orchestrator.ProjectAgent{Name: "Kai"},
+		Agent:  hiredAgent(t, ctx, "Kai", true),
 		Beads:  []orchestrator.Bead{{ID: "task-9", Title: "Investigate", Points: 2}},
 	}}
 	runner := &stubCycleRunner{
@@ -100,14 +168,232 @@ func TestWorkProcessRunPropagatesRunnerError(t *testing.T) {
 	ensureMissing(t, artifact.WorkCompleteMarker.Path(ctx.Workflow))
 }
 
+func TestWorkProcessRunStalledCyclesRequestRefinement(t *testing.T) {
+	ctx := newWorkProcessTestContext(t)
+	seedWorkProcessInputs(t, ctx)
+	sessions := []orchestrator.WorktreeSession{{
+		Number: 1,
+		Name:   "tree-stuck",
+		Agent:  hiredAgent(t, ctx, "Kai", true),
+		Beads:  []orchestrator.Bead{{ID: "task-3", Title: "Flaky test", Points: 1}},
+	}}
+	runner := &stubCycleRunner{
+		sessions:   sessions,
+		executeErr: fmt.Errorf("cycle 4: %w", orchestrator.ErrNoProgress),
+	}
+	mod := New(WithRunner(runner), WithMaxStalledCycles(2))
+	result, err := mod.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Status != module.StatusNeedsInput {
+		t.Fatalf("unexpected status: %+v", result)
+	}
+	ensureExists(t, artifact.RefinementNeededMarker.Path(ctx.Workflow))
+	ensureMissing(t, artifact.WorkInProgressMarker.Path(ctx.Workflow))
+	ensureMissing(t, artifact.WorkCompleteMarker.Path(ctx.Workflow))
+}
+
+func TestWorkProcessRunRequiresAgentSupportPackets(t *testing.T) {
+	ctx := newWorkProcessTestContext(t)
+	seedWorkProcessInputs(t, ctx)
+	sessions := []orchestrator.WorktreeSession{
+		{Number: 1, Name: "tree-1", Agent: hiredAgent(t, ctx, "Aster", true), Beads: []orchestrator.Bead{{ID: "task-1", Title: "One", Points: 1}}},
+		{Number: 2, Name: "tree-2", Agent: hiredAgent(t, ctx, "Kai", false), Beads: []orchestrator.Bead{{ID: "task-2", Title: "Two", Points: 1}}},
+	}
+	runner := &stubCycleRunner{sessions: sessions}
+	mod := New(WithRunner(runner))
+	result, err := mod.Run(ctx)
+	if err == nil {
+		t.Fatalf("expected missing support packet error")
+	}
+	if result.Status != module.StatusFailed {
+		t.Fatalf("unexpected status: %+v", result)
+	}
+	if !strings.Contains(err.Error(), "missing AGENT_SUP.md support packet: Kai") || strings.Contains(err.Error(), "Aster") {
+		t.Fatalf("expected error naming only Kai, got %v", err)
+	}
+	if runner.executed {
+		t.Fatalf("cycle should not execute without support packets")
+	}
+	ensureMissing(t, artifact.WorkInProgressMarker.Path(ctx.Workflow))
+	ensureMissing(t, artifact.WorkCompleteMarker.Path(ctx.Workflow))
+}
+
+func TestWorkProcessRunPreparesFromSubscribedReadyBeads(t *testing.T) {
+	ctx := newWorkProcessTestContext(t)
+	seedWorkProcessInputs(t, ctx)
+	ctx.Orchestrator = orchestrator.New(ctx.Config)
+	subscriptions := 0
+	ctx.Orchestrator.SetBeadSubscriber(stubBeadSubscriber{
+		payload:       `[{"id":"task-9","title":"Pushed","status":"open"}]`,
+		subscriptions: &subscriptions,
+	})
+	var pushed []string
+	runner := &stubCycleRunner{
+		prepareErr: orchestrator.ErrNoReadyBeads,
+		onPrepare: func(ctx *module.ModuleContext) {
+			payload, _ := ctx.Orchestrator.PushedReadyBeads()
+			pushed = append(pushed, string(payload))
+		},
+	}
+	for cycle := 0; cycle < 2; cycle++ {
+		if _, err := New(WithRunner(runner)).Run(ctx); err != nil {
+			t.Fatalf("Run %d: %v", cycle+1, err)
+		}
+	}
+	if len(pushed) != 2 || !strings.Contains(pushed[0], "task-9") || !strings.Contains(pushed[1], "task-9") {
+		t.Fatalf("every prepare should see the subscribed ready set, got %q", pushed)
+	}
+	if subscriptions != 1 {
+		t.Fatalf("cycles should share one subscription, got %d", subscriptions)
+	}
+}
+
+func TestBridgeEventSinkPublishesCycleEventsWhenBridgeEnabled(t *testing.T) {
+	ctx := newWorkProcessTestContext(t)
+	ctx.WorkflowID = "commission-work"
+	orch := orchestrator.New(ctx.Config)
+	if sink := bridgeEventSink(ctx, orch); sink != nil {
+		t.Fatalf("expected no sink without an attached router, got %T", sink)
+	}
+	router := eventbridge.NewRouter()
+	orch.AttachEventBridge("", router)
+	sub := router.Subscribe(moduleID)
+	defer sub.Close()
+	sink := bridgeEventSink(ctx, orch)
+	if sink == nil {
+		t.Fatalf("expected a bridge sink when the event bridge is enabled")
+	}
+	sink.Emit(orchestrator.CycleEvent{Type: orchestrator.CycleEventCycleStarted, Cycle: 2, Time: time.Now()})
+	select {
+	case event := <-sub.Events:
+		if event.Type != string(orchestrator.CycleEventCycleStarted) || event.Workflow != "commission-work" {
+			t.Fatalf("unexpected bridge event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("cycle event was not routed to the subscription")
+	}
+
+	disabled := false
+	ctx.Config.Project.EventBridge.Enabled = &disabled
+	if sink := bridgeEventSink(ctx, orch); sink != nil {
+		t.Fatalf("expected no sink when event_bridge is disabled")
+	}
+}
+
+// hiredAgent writes the AGENT.md brief hiring produces, plus its AGENT_SUP.md
+// support packet when withSupport is set.
+func hiredAgent(t *testing.T, ctx *module.ModuleContext, name string, withSupport bool) orchestrator.ProjectAgent {
+	t.Helper()
+	dir := filepath.Join(ctx.Config.AgentsDir(), "workers", strings.ToLower(name))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir agent: %v", err)
+	}
+	agentPath := filepath.Join(dir, "AGENT.md")
+	if err := os.WriteFile(agentPath, []byte("# "+name+"\n"), 0o644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+	if withSupport {
+		if err := os.WriteFile(filepath.Join(dir, "AGENT_SUP.md"), []byte("# Support\n"), 0o644); err != nil {
+			t.Fatalf("write support packet: %v", err)
+		}
+	}
+	return orchestrator.ProjectAgent{Name: name, Path: agentPath}
+}
+
+func TestRegistryValidatesWorkProcessConfig(t *testing.T) {
+	reg := module.NewRegistry()
+	Register(reg)
+
+	_, err := reg.Resolve(moduleID, module.Config{"max_staled_cycles": 3})
+	if err == nil {
+		t.Fatalf("expected unknown key to fail resolve")
+	}
+	if !strings.Contains(err.Error(), `work-process config: unknown key "max_staled_cycles"`) || !strings.Contains(err.Error(), maxStalledCyclesKey) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := reg.Resolve(moduleID, module.Config{strictBeadIDsKey: 3}); err == nil || !strings.Contains(err.Error(), "strict_bead_ids must be a bool") {
+		t.Fatalf("expected mistyped value to fail resolve, got %v", err)
+	}
+	mod, err := reg.Resolve(moduleID, module.Config{maxStalledCyclesKey: "4"})
+	if err != nil {
+		t.Fatalf("string override from --set should resolve: %v", err)
+	}
+	if got := mod.(*WorkProcessModule).maxStalledCycles; got != 4 {
+		t.Fatalf("maxStalledCycles = %d, want 4", got)
+	}
+	mod, err = reg.Resolve(moduleID, module.Config{cycleMetadataKey: map[string]any{"experiment": "exp-42", "run": 3}})
+	if err != nil {
+		t.Fatalf("cycle metadata map should resolve: %v", err)
+	}
+	if got := mod.(*WorkProcessModule).cycleMetadata; got["experiment"] != "exp-42" || got["run"] != "3" {
+		t.Fatalf("cycleMetadata = %v", got)
+	}
+}
+
+func TestConfigureOrchestratorShapesPromptPreview(t *testing.T) {
+	ctx := newWorkProcessTestContext(t)
+	reg := module.NewRegistry()
+	Register(reg)
+	mod, err := reg.Resolve(moduleID, module.Config{maxPromptBeadsKey: 1})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	orch := orchestrator.New(ctx.Config)
+	if err := mod.(*WorkProcessModule).ConfigureOrchestrator(orch); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	session, err := orch.SessionFromJSON([]byte(`{"number": 1, "name": "tree-1", "path": "tree-1", "agentName": "Aster",
+  "beads": [{"id": "lat-1", "title": "Wire login", "points": 3}, {"id": "lat-2", "title": "Add logout", "points": 2}]}`))
+	if err != nil {
+		t.Fatalf("decode session: %v", err)
+	}
+	prompt, err := orch.PreviewAgentPrompt(session, 1)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if !strings.Contains(prompt, "lat-1 · Wire login") || strings.Contains(prompt, "lat-2 · Add logout") {
+		t.Fatalf("preview should list one bead inline under max_prompt_beads:\n%s", prompt)
+	}
+}
+
+func TestConfigureOrchestratorRecordsCoConductors(t *testing.T) {
+	ctx := newWorkProcessTestContext(t)
+	reg := module.NewRegistry()
+	Register(reg)
+	mod, err := reg.Resolve(moduleID, module.Config{coConductorsKey: []any{"Vega", "Orion", "vega"}})
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if err := mod.(*WorkProcessModule).ConfigureOrchestrator(orchestrator.New(ctx.Config)); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	data, err := os.ReadFile(ctx.Config.WorkerListPath())
+	if err != nil {
+		t.Fatalf("read worker list: %v", err)
+	}
+	var roster orchestrator.WorkerList
+	if err := json.Unmarshal(data, &roster); err != nil {
+		t.Fatalf("decode worker list: %v", err)
+	}
+	if len(roster.CoConductors) != 2 || roster.CoConductors[0].Name != "Vega" || roster.CoConductors[1].Name != "Orion" {
+		t.Fatalf("expected Vega and Orion as co-conductors, got %+v", roster.CoConductors)
+	}
+}
+
 type stubCycleRunner struct {
 	sessions   []orchestrator.WorktreeSession
 	prepareErr error
 	executeErr error
 	executed   bool
+	onPrepare  func(*module.ModuleContext)
 }
 
-func (s *stubCycleRunner) Prepare(*module.ModuleContext) ([]orchestrator.WorktreeSession, error) {
+func (s *stubCycleRunner) Prepare(ctx *module.ModuleContext) ([]orchestrator.WorktreeSession, error) {
+	if s.onPrepare != nil {
+		s.onPrepare(ctx)
+	}
 	if s.prepareErr != nil {
 		return nil, s.prepareErr
 	}
@@ -122,6 +408,26 @@ func (s *stubCycleRunner) Execute(context.Context, *module.ModuleContext, []orch
 	return nil
 }
 
+// stubBeadSubscriber delivers one ready set and ends with the subscription,
+// counting subscriptions when subscriptions is set.
+type stubBeadSubscriber struct {
+	payload       string
+	subscriptions *int
+}
+
+func (s stubBeadSubscriber) SubscribeReady(ctx context.Context) (<-chan []byte, error) {
+	if s.subscriptions != nil {
+		*s.subscriptions++
+	}
+	updates := make(chan []byte, 1)
+	updates <- []byte(s.payload)
+	go func() {
+		<-ctx.Done()
+		close(updates)
+	}()
+	return updates, nil
+}
+
 func newWorkProcessTestContext(t *testing.T) *module.ModuleContext {
 	t.Helper()
 	projectDir := t.TempDir()
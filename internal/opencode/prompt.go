@@ -0,0 +1,100 @@
+// Package opencode builds the opencode command lines Lattice types into tmux
+// windows. Prompts pass through tmux send-keys and an interactive shell, so
+// they must be delivered in a form neither will reinterpret.
+package opencode
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PromptStrategyEnv selects the PromptStrategy used by Command and PromptArg.
+const PromptStrategyEnv = "LATTICE_PROMPT_STRATEGY"
+
+// PromptStrategy decides how a prompt reaches opencode's --prompt flag.
+type PromptStrategy string
+
+const (
+	// PromptFile writes the prompt to a file and expands it with
+	// "$(cat <file>)", so backticks, dollar signs, backslashes, quotes, and
+	// newlines arrive exactly as written. This is the default.
+	PromptFile PromptStrategy = "file"
+	// PromptInline single-quotes the prompt on the command line. Shell
+	// metacharacters survive, but newlines are folded into spaces so the
+	// command stays on one line.
+	PromptInline PromptStrategy = "inline"
+)
+
+// ParsePromptStrategy resolves a LATTICE_PROMPT_STRATEGY value. An empty
+// value selects PromptFile.
+func ParsePromptStrategy(value string) (PromptStrategy, error) {
+	switch strategy := PromptStrategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case "":
+		return PromptFile, nil
+	case PromptFile, PromptInline:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("opencode: unknown prompt strategy %q (want %s or %s)", value, PromptFile, PromptInline)
+	}
+}
+
+// PromptStrategyFromEnv returns the strategy named by LATTICE_PROMPT_STRATEGY,
+// falling back to PromptFile when it is unset or unrecognised.
+func PromptStrategyFromEnv() PromptStrategy {
+	strategy, err := ParsePromptStrategy(os.Getenv(PromptStrategyEnv))
+	if err != nil {
+		return PromptFile
+	}
+	return strategy
+}
+
+// Command returns `opencode --prompt ...` for prompt using the strategy from
+// the environment. The file strategy writes the prompt to path, or to a
+// temporary file the command deletes once read when path is empty.
+func Command(prompt, path string) (string, error) {
+	arg, err := PromptArg(prompt, path)
+	if err != nil {
+		return "", err
+	}
+	return "opencode " + arg, nil
+}
+
+// PromptArg returns the --prompt flag for prompt using the strategy from the
+// environment.
+func PromptArg(prompt, path string) (string, error) {
+	return PromptStrategyFromEnv().Arg(prompt, path)
+}
+
+// Arg returns the --prompt flag carrying prompt. Trailing newlines are not
+// significant to opencode and are dropped by every strategy.
+func (s PromptStrategy) Arg(prompt, path string) (string, error) {
+	prompt = strings.TrimRight(prompt, "\n")
+	if s == PromptInline {
+		return "--prompt " + ShellQuote(strings.ReplaceAll(prompt, "\n", " ")), nil
+	}
+	cleanup := false
+	if path == "" {
+		cleanup = true
+		file, err := os.CreateTemp("", "lattice-prompt-*.txt")
+		if err != nil {
+			return "", fmt.Errorf("opencode: create prompt file: %w", err)
+		}
+		path = file.Name()
+		if err := file.Close(); err != nil {
+			return "", fmt.Errorf("opencode: create prompt file: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(prompt), 0644); err != nil {
+		return "", fmt.Errorf("opencode: write prompt file: %w", err)
+	}
+	if cleanup {
+		return fmt.Sprintf(`--prompt "$(cat %[1]s && rm -f %[1]s)"`, ShellQuote(path)), nil
+	}
+	return fmt.Sprintf(`--prompt "$(cat %s)"`, ShellQuote(path)), nil
+}
+
+// ShellQuote wraps value in single quotes for a POSIX shell.
+func ShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
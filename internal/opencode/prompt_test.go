@@ -0,0 +1,57 @@
+package opencode
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// deliver runs command in sh with a stand-in opencode that prints the value
+// of its --prompt flag, which is what a tmux window would hand opencode.
+func deliver(t *testing.T, command string) string {
+	t.Helper()
+	script := `opencode() { [ "$1" = --prompt ] || exit 2; printf '%s' "$2"; }; ` + command
+	out, err := exec.Command("sh", "-c", script).Output()
+	if err != nil {
+		t.Fatalf("run %q: %v", command, err)
+	}
+	return string(out)
+}
+
+func TestPromptSurvivesShellMetacharacters(t *testing.T) {
+	prompt := "Run `make test` with $HOME and ${PATH}, keep C:\\tmp\\n and \\\"quotes\\\".\n" +
+		`Don't expand $(whoami); 'single' and "double" quotes, * globs, & | ; < > ! # ~` + "\n\ttabbed line"
+	t.Setenv(PromptStrategyEnv, "")
+	command, err := Command(prompt, filepath.Join(t.TempDir(), "prompt.txt"))
+	if err != nil {
+		t.Fatalf("command: %v", err)
+	}
+	if got := deliver(t, command); got != prompt {
+		t.Fatalf("prompt was mangled:\ngot:  %q\nwant: %q", got, prompt)
+	}
+
+	command, err = Command(prompt, "")
+	if err != nil {
+		t.Fatalf("temp file command: %v", err)
+	}
+	if got := deliver(t, command); got != prompt {
+		t.Fatalf("prompt from a temp file was mangled:\ngot:  %q\nwant: %q", got, prompt)
+	}
+
+	t.Setenv(PromptStrategyEnv, "inline")
+	command, err = Command(prompt, "")
+	if err != nil {
+		t.Fatalf("inline command: %v", err)
+	}
+	if strings.Contains(command, "\n") {
+		t.Fatalf("inline command must stay on one line: %q", command)
+	}
+	if got, want := deliver(t, command), strings.ReplaceAll(prompt, "\n", " "); got != want {
+		t.Fatalf("inline prompt was mangled:\ngot:  %q\nwant: %q", got, want)
+	}
+
+	if _, err := ParsePromptStrategy("base64"); err == nil {
+		t.Fatalf("expected an unknown strategy to be rejected")
+	}
+}
@@ -0,0 +1,63 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// archivedCyclePattern pulls the cycle number out of archived files such as
+// CYCLE-3-WORKTREE.md, agent-cycle-3.json, and orchestrator-cycle-3.json.
+var archivedCyclePattern = regexp.MustCompile(`(?i)cycle-([0-9]+)`)
+
+// pruneSessionArchive removes archived WORKTREE.md files and events from
+// every cycle but the keep most recent ones in the session at path. Files
+// without a cycle number are left alone. keep <= 0 retains everything.
+func pruneSessionArchive(path string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	byCycle := make(map[int][]string)
+	for _, dir := range []string{filepath.Join(path, "archive"), filepath.Join(path, "archive", "events")} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("read archive %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			match := archivedCyclePattern.FindStringSubmatch(entry.Name())
+			if match == nil {
+				continue
+			}
+			cycle, err := strconv.Atoi(match[1])
+			if err != nil {
+				continue
+			}
+			byCycle[cycle] = append(byCycle[cycle], filepath.Join(dir, entry.Name()))
+		}
+	}
+	if len(byCycle) <= keep {
+		return nil
+	}
+	cycles := make([]int, 0, len(byCycle))
+	for cycle := range byCycle {
+		cycles = append(cycles, cycle)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(cycles)))
+	for _, cycle := range cycles[keep:] {
+		for _, file := range byCycle[cycle] {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("prune archive: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,383 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// AssignmentSlot is one agent's share of a cycle while beads are handed out.
+type AssignmentSlot struct {
+	Agent    ProjectAgent
+	Beads    []Bead
+	Points   int
+	Capacity int
+}
+
+// AssignmentStrategy decides which agent receives each bead selected for a
+// cycle. Beads are offered one at a time in ready order; Pick returns the
+// index of the slot that takes bead. Beads chained by a dependency within the
+// cycle are offered once, as their first bead carrying the chain's combined
+// points and tags, and all land in the picked slot. Slots are never empty and
+// an index out of range falls back to the first slot.
+type AssignmentStrategy interface {
+	Name() string
+	Pick(slots []AssignmentSlot, bead Bead) int
+}
+
+// Built-in assignment strategy names accepted by ParseAssignmentStrategy.
+const (
+	// AssignmentBalanced gives each bead to the agent with the lowest
+	// points-to-capacity ratio (the default).
+	AssignmentBalanced = "balanced"
+	// AssignmentRoundRobin deals beads to agents in turn.
+	AssignmentRoundRobin = "round-robin"
+	// AssignmentBinPacking fills each agent to capacity before using the next,
+	// so a cycle runs as few agents as possible.
+	AssignmentBinPacking = "bin-packing"
+	// AssignmentSkillWeighted prefers agents whose role or summary mentions
+	// the bead's tags, balancing load among equally skilled agents.
+	AssignmentSkillWeighted = "skill-weighted"
+)
+
+// ParseAssignmentStrategy resolves an assignment_strategy setting to a
+// built-in strategy. An empty value selects AssignmentBalanced.
+func ParseAssignmentStrategy(value string) (AssignmentStrategy, error) {
+	switch name := strings.ToLower(strings.TrimSpace(value)); name {
+	case "", AssignmentBalanced:
+		return balancedStrategy{}, nil
+	case AssignmentRoundRobin:
+		return &roundRobinStrategy{}, nil
+	case AssignmentBinPacking:
+		return binPackingStrategy{}, nil
+	case AssignmentSkillWeighted:
+		return skillWeightedStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown assignment strategy %q (want %s, %s, %s, or %s)", value, AssignmentBalanced, AssignmentRoundRobin, AssignmentBinPacking, AssignmentSkillWeighted)
+	}
+}
+
+func assignBeadsToAgents(strategy AssignmentStrategy, agents []scheduledAgent, beads []Bead) ([]AssignmentSlot, error) {
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("no agents available to assign work")
+	}
+	if len(beads) == 0 {
+		return nil, fmt.Errorf("no beads to assign")
+	}
+	if strategy == nil {
+		strategy = balancedStrategy{}
+	}
+	strategy = freshStrategy(strategy)
+	limit := len(agents)
+	if limit > len(beads) {
+		limit = len(beads)
+	}
+	if limit == 0 {
+		limit = len(agents)
+	}
+	slots := make([]AssignmentSlot, 0, limit)
+	for i := 0; i < limit; i++ {
+		cap := agents[i].Capacity
+		if cap <= 0 {
+			cap = maxAgentStoryPoints
+		}
+		slots = append(slots, AssignmentSlot{Agent: agents[i].Agent, Capacity: cap})
+	}
+	for _, chain := range chainBeads(beads) {
+		lead := chain[0]
+		for _, bead := range chain[1:] {
+			lead.Points += bead.Points
+			lead.Tags = append(append([]string{}, lead.Tags...), bead.Tags...)
+		}
+		idx := strategy.Pick(slots, lead)
+		if idx < 0 || idx >= len(slots) {
+			idx = 0
+		}
+		slot := &slots[idx]
+		for _, bead := range chain {
+			slot.Beads = append(slot.Beads, bead)
+			slot.Points += bead.Points
+		}
+	}
+	var result []AssignmentSlot
+	for _, slot := range slots {
+		if len(slot.Beads) == 0 {
+			continue
+		}
+		result = append(result, slot)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no assignments were created")
+	}
+	return result, nil
+}
+
+// chainBeads groups beads linked by dependencies on each other, keeping the
+// input order within and across groups so a dependency precedes its
+// dependents. Beads without such links form groups of one.
+func chainBeads(beads []Bead) [][]Bead {
+	index := make(map[string]int, len(beads))
+	for i, bead := range beads {
+		index[canonicalBeadKey(bead.ID)] = i
+	}
+	parent := make([]int, len(beads))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	for i, bead := range beads {
+		for _, dep := range bead.dependencies() {
+			if j, ok := index[canonicalBeadKey(dep)]; ok {
+				a, b := find(i), find(j)
+				if a > b {
+					a, b = b, a
+				}
+				parent[b] = a
+			}
+		}
+	}
+	var chains [][]Bead
+	position := map[int]int{}
+	for i, bead := range beads {
+		root := find(i)
+		pos, ok := position[root]
+		if !ok {
+			pos = len(chains)
+			position[root] = pos
+			chains = append(chains, nil)
+		}
+		chains[pos] = append(chains[pos], bead)
+	}
+	return chains
+}
+
+type balancedStrategy struct{}
+
+func (balancedStrategy) Name() string { return AssignmentBalanced }
+
+func (balancedStrategy) Pick(slots []AssignmentSlot, _ Bead) int {
+	return pickAssignment(slots, nil)
+}
+
+// statefulStrategy is implemented by strategies that track state while one
+// cycle's beads are handed out. assignBeadsToAgents works on a fresh copy so
+// each cycle starts over and concurrent assignments do not share it.
+type statefulStrategy interface {
+	fresh() AssignmentStrategy
+}
+
+func freshStrategy(strategy AssignmentStrategy) AssignmentStrategy {
+	if stateful, ok := strategy.(statefulStrategy); ok {
+		return stateful.fresh()
+	}
+	return strategy
+}
+
+// roundRobinStrategy deals each offered bead, or chain of beads, to the next
+// slot in turn, however many beads the chain holds.
+type roundRobinStrategy struct {
+	turn int
+}
+
+func (*roundRobinStrategy) Name() string { return AssignmentRoundRobin }
+
+func (*roundRobinStrategy) fresh() AssignmentStrategy { return &roundRobinStrategy{} }
+
+func (s *roundRobinStrategy) Pick(slots []AssignmentSlot, _ Bead) int {
+	idx := s.turn % len(slots)
+	s.turn++
+	return idx
+}
+
+type binPackingStrategy struct{}
+
+func (binPackingStrategy) Name() string { return AssignmentBinPacking }
+
+// Pick is first-fit: the first agent with room for the bead takes it, and
+// beads that fit nowhere go to the least loaded agent.
+func (binPackingStrategy) Pick(slots []AssignmentSlot, bead Bead) int {
+	for i, slot := range slots {
+		if slot.Points+bead.Points <= slot.Capacity {
+			return i
+		}
+	}
+	return pickAssignment(slots, nil)
+}
+
+type skillWeightedStrategy struct{}
+
+func (skillWeightedStrategy) Name() string { return AssignmentSkillWeighted }
+
+func (skillWeightedStrategy) Pick(slots []AssignmentSlot, bead Bead) int {
+	best := 0
+	var candidates []int
+	for i, slot := range slots {
+		score := skillMatches(slot.Agent, bead.Tags)
+		switch {
+		case score > best:
+			best = score
+			candidates = []int{i}
+		case score == best && best > 0:
+			candidates = append(candidates, i)
+		}
+	}
+	return pickAssignment(slots, candidates)
+}
+
+// skillMatches counts the bead tags named in the agent's role or summary.
+func skillMatches(agent ProjectAgent, tags []string) int {
+	profile := strings.ToLower(agent.Role + " " + agent.Summary)
+	matches := 0
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && strings.Contains(profile, tag) {
+			matches++
+		}
+	}
+	return matches
+}
+
+// roleTagPrefix marks a bead tag naming the role that should take the bead,
+// as in "role:security".
+const roleTagPrefix = "role:"
+
+// roleRoutedStrategy sends a bead tagged with a role hint to an agent whose
+// AGENT.md role mentions it and who still has room for the bead, least
+// loaded first. Beads without a hint, or whose matching agents are full, are
+// left to next.
+type roleRoutedStrategy struct {
+	next AssignmentStrategy
+}
+
+func (s roleRoutedStrategy) Name() string { return s.next.Name() }
+
+func (s roleRoutedStrategy) fresh() AssignmentStrategy {
+	return roleRoutedStrategy{next: freshStrategy(s.next)}
+}
+
+func (s roleRoutedStrategy) Pick(slots []AssignmentSlot, bead Bead) int {
+	roles := beadRoleHints(bead.Tags)
+	if len(roles) > 0 {
+		var candidates []int
+		for i, slot := range slots {
+			if slot.Points+bead.Points <= slot.Capacity && roleMatches(slot.Agent.Role, roles) {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) > 0 {
+			return pickAssignment(slots, candidates)
+		}
+	}
+	return s.next.Pick(slots, bead)
+}
+
+// beadRoleHints returns the lower-cased roles named by role: tags.
+func beadRoleHints(tags []string) []string {
+	var roles []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if role := strings.TrimSpace(strings.TrimPrefix(tag, roleTagPrefix)); strings.HasPrefix(tag, roleTagPrefix) && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// roleMatches reports whether an agent role names any of roles as whole
+// words, so role:ui does not match "Build Engineer" and role:dev does not
+// match "DevOps". A multi-word role such as "site-reliability" must appear as
+// consecutive words.
+func roleMatches(agentRole string, roles []string) bool {
+	words := roleWords(agentRole)
+	for _, role := range roles {
+		if want := roleWords(role); len(want) > 0 && containsWordRun(words, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// roleWords lower-cases s and splits it on anything but letters and digits.
+func roleWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsWordRun reports whether want appears as consecutive entries of words.
+func containsWordRun(words, want []string) bool {
+	for start := 0; start+len(want) <= len(words); start++ {
+		matched := true
+		for i, word := range want {
+			if words[start+i] != word {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// pickAssignment returns the least loaded slot among candidates, or among
+// every slot when candidates is empty.
+func pickAssignment(slots []AssignmentSlot, candidates []int) int {
+	if len(candidates) == 0 {
+		candidates = make([]int, len(slots))
+		for i := range slots {
+			candidates[i] = i
+		}
+	}
+	best := candidates[0]
+	for _, idx := range candidates[1:] {
+		if compareAssignmentLoad(&slots[idx], &slots[best]) < 0 {
+			best = idx
+		}
+	}
+	return best
+}
+
+func compareAssignmentLoad(a, b *AssignmentSlot) int {
+	loadA := loadRatio(a)
+	loadB := loadRatio(b)
+	switch {
+	case loadA < loadB:
+		return -1
+	case loadA > loadB:
+		return 1
+	default:
+		if len(a.Beads) < len(b.Beads) {
+			return -1
+		}
+		if len(a.Beads) > len(b.Beads) {
+			return 1
+		}
+		if a.Agent.Name < b.Agent.Name {
+			return -1
+		}
+		if a.Agent.Name > b.Agent.Name {
+			return 1
+		}
+	}
+	return 0
+}
+
+func loadRatio(a *AssignmentSlot) float64 {
+	cap := a.Capacity
+	if cap <= 0 {
+		cap = maxAgentStoryPoints
+	}
+	if cap == 0 {
+		return 0
+	}
+	return float64(a.Points) / float64(cap)
+}
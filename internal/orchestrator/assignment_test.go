@@ -0,0 +1,146 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAssignmentStrategiesDistributeBeads(t *testing.T) {
+	agents := []scheduledAgent{
+		{Agent: ProjectAgent{Name: "Ada"}, Capacity: 8},
+		{Agent: ProjectAgent{Name: "Bo"}, Capacity: 8},
+		{Agent: ProjectAgent{Name: "Cy"}, Capacity: 8},
+	}
+	beads := []Bead{
+		{ID: "bd-1", Points: 5},
+		{ID: "bd-2", Points: 3},
+		{ID: "bd-3", Points: 3},
+		{ID: "bd-4", Points: 2},
+		{ID: "bd-5", Points: 2},
+		{ID: "bd-6", Points: 1},
+	}
+	cases := []struct {
+		strategy string
+		want     string
+	}{
+		{AssignmentBalanced, "Ada[bd-1 bd-6] Bo[bd-2 bd-4] Cy[bd-3 bd-5]"},
+		{AssignmentRoundRobin, "Ada[bd-1 bd-4] Bo[bd-2 bd-5] Cy[bd-3 bd-6]"},
+		{AssignmentBinPacking, "Ada[bd-1 bd-2] Bo[bd-3 bd-4 bd-5 bd-6]"},
+	}
+	for _, tc := range cases {
+		strategy, err := ParseAssignmentStrategy(tc.strategy)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tc.strategy, err)
+		}
+		slots, err := assignBeadsToAgents(strategy, agents, beads)
+		if err != nil {
+			t.Fatalf("%s: assign: %v", tc.strategy, err)
+		}
+		if got := describeSlots(slots); got != tc.want {
+			t.Fatalf("%s: got %s, want %s", tc.strategy, got, tc.want)
+		}
+	}
+	if _, err := ParseAssignmentStrategy("random"); err == nil {
+		t.Fatalf("expected unknown assignment strategy to be rejected")
+	}
+	if strategy, err := ParseAssignmentStrategy(""); err != nil || strategy.Name() != AssignmentBalanced {
+		t.Fatalf("empty strategy should default to balanced, got %v, %v", strategy, err)
+	}
+}
+
+func TestRoundRobinTakesTurnsPerChain(t *testing.T) {
+	agents := []scheduledAgent{
+		{Agent: ProjectAgent{Name: "Ada"}, Capacity: 8},
+		{Agent: ProjectAgent{Name: "Bo"}, Capacity: 8},
+	}
+	beads := []Bead{
+		{ID: "bd-1", Points: 1},
+		{ID: "bd-2", Points: 1, DependsOn: []string{"bd-1"}},
+		{ID: "bd-3", Points: 1},
+		{ID: "bd-4", Points: 1},
+	}
+	strategy, err := ParseAssignmentStrategy(AssignmentRoundRobin)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	// The bd-1/bd-2 chain is one turn, so bd-3 goes to Bo, not back to Ada.
+	want := "Ada[bd-1 bd-2 bd-4] Bo[bd-3]"
+	for run := 0; run < 2; run++ {
+		slots, err := assignBeadsToAgents(strategy, agents, beads)
+		if err != nil {
+			t.Fatalf("assign: %v", err)
+		}
+		if got := describeSlots(slots); got != want {
+			t.Fatalf("run %d: got %s, want %s", run, got, want)
+		}
+	}
+}
+
+func TestRoleRoutingSendsTaggedBeadToMatchingSpecialist(t *testing.T) {
+	agents := []scheduledAgent{
+		{Agent: ProjectAgent{Name: "Ada", Role: "Backend engineer"}, Capacity: 8},
+		{Agent: ProjectAgent{Name: "Bo", Role: "Frontend engineer"}, Capacity: 8},
+		{Agent: ProjectAgent{Name: "Sec", Role: "Security specialist"}, Capacity: 4},
+	}
+	beads := []Bead{
+		{ID: "bd-1", Points: 3},
+		{ID: "bd-2", Points: 3},
+		{ID: "bd-3", Points: 2},
+		{ID: "bd-4", Points: 2, Tags: []string{"role:security"}},
+	}
+	slots, err := assignBeadsToAgents(balancedStrategy{}, agents, beads)
+	if err != nil {
+		t.Fatalf("balanced assign: %v", err)
+	}
+	if got := describeSlots(slots); got != "Ada[bd-1 bd-4] Bo[bd-2] Sec[bd-3]" {
+		t.Fatalf("balanced baseline changed: %s", got)
+	}
+
+	routed := roleRoutedStrategy{next: balancedStrategy{}}
+	slots, err = assignBeadsToAgents(routed, agents, beads)
+	if err != nil {
+		t.Fatalf("routed assign: %v", err)
+	}
+	if got, want := describeSlots(slots), "Ada[bd-1] Bo[bd-2] Sec[bd-3 bd-4]"; got != want {
+		t.Fatalf("role routing: got %s, want %s", got, want)
+	}
+
+	// With no room left for the specialist the bead falls back to balancing.
+	beads[3].Points = 3
+	slots, err = assignBeadsToAgents(routed, agents, beads)
+	if err != nil {
+		t.Fatalf("over-capacity assign: %v", err)
+	}
+	if got, want := describeSlots(slots), "Ada[bd-1 bd-4] Bo[bd-2] Sec[bd-3]"; got != want {
+		t.Fatalf("role routing fallback: got %s, want %s", got, want)
+	}
+}
+
+func TestRoleMatchesWholeWordsOnly(t *testing.T) {
+	cases := []struct {
+		agentRole string
+		role      string
+		want      bool
+	}{
+		{"Security specialist", "security", true},
+		{"Senior UI Engineer", "ui", true},
+		{"Site Reliability Engineer", "site-reliability", true},
+		{"Build Engineer", "ui", false},
+		{"DevOps", "dev", false},
+		{"Reliability Engineer", "site-reliability", false},
+	}
+	for _, tc := range cases {
+		if got := roleMatches(tc.agentRole, []string{tc.role}); got != tc.want {
+			t.Errorf("roleMatches(%q, %q) = %t, want %t", tc.agentRole, tc.role, got, tc.want)
+		}
+	}
+}
+
+func describeSlots(slots []AssignmentSlot) string {
+	var parts []string
+	for _, slot := range slots {
+		parts = append(parts, fmt.Sprintf("%s[%s]", slot.Agent.Name, strings.Join(beadIDs(slot.Beads), " ")))
+	}
+	return strings.Join(parts, " ")
+}
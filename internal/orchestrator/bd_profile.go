@@ -0,0 +1,130 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// bdVersion is a parsed `bd --version` number.
+type bdVersion struct {
+	Major, Minor, Patch int
+}
+
+func (v bdVersion) less(other bdVersion) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// snakeCaseBdVersion is the first bd release whose JSON output lattice has
+// seen use created_at and points instead of createdAt and size. It only
+// decides which name wins when a record carries both: every profile still
+// reads the other schema's fields, so a bd on the wrong side of the cutoff
+// loses no blocking or estimate data and draws no warning.
+var snakeCaseBdVersion = bdVersion{Major: 0, Minor: 20}
+
+var bdVersionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+func parseBdVersion(output string) (bdVersion, bool) {
+	match := bdVersionPattern.FindStringSubmatch(output)
+	if match == nil {
+		return bdVersion{}, false
+	}
+	var v bdVersion
+	v.Major, _ = strconv.Atoi(match[1])
+	v.Minor, _ = strconv.Atoi(match[2])
+	if match[3] != "" {
+		v.Patch, _ = strconv.Atoi(match[3])
+	}
+	return v, true
+}
+
+// bdProfile is the JSON shape one generation of bd emits for beads.
+type bdProfile struct {
+	Name string
+	// normalize moves the values under this generation's field names into
+	// the slots partitionBeadRecords reads first. Values under the other
+	// schema's names are kept as fallbacks, and blocking lists from both are
+	// still merged.
+	normalize func(rec beadRecord) beadRecord
+}
+
+var (
+	// bdProfileCamelCase prefers the names older bd uses: createdAt, with
+	// the estimate in size.
+	bdProfileCamelCase = bdProfile{Name: "camel-case", normalize: func(rec beadRecord) beadRecord {
+		out := rec
+		out.Points = firstPositiveNumber(rec.Size, rec.Estimate, rec.Points)
+		if rec.CreatedAtAlt != "" {
+			out.CreatedAt, out.CreatedAtAlt = rec.CreatedAtAlt, rec.CreatedAt
+		}
+		return out
+	}}
+	// bdProfileSnakeCase prefers the names newer bd uses: created_at, with
+	// the estimate in points.
+	bdProfileSnakeCase = bdProfile{Name: "snake-case", normalize: func(rec beadRecord) beadRecord {
+		out := rec
+		out.Points = firstPositiveNumber(rec.Points, rec.Estimate, rec.Size)
+		return out
+	}}
+	// bdProfileTolerant accepts every known field name in the default order.
+	// It is used when the bd version cannot be determined.
+	bdProfileTolerant = bdProfile{Name: "tolerant", normalize: func(rec beadRecord) beadRecord { return rec }}
+)
+
+// firstPositiveNumber returns the first number above zero, or "" when there
+// is none.
+func firstPositiveNumber(numbers ...json.Number) json.Number {
+	for _, num := range numbers {
+		if firstNonZeroNumber(num) > 0 {
+			return num
+		}
+	}
+	return ""
+}
+
+// profileForBdVersion picks the parsing profile for a `bd --version` output,
+// falling back to the tolerant profile when no version can be read.
+func profileForBdVersion(output string) bdProfile {
+	version, ok := parseBdVersion(output)
+	switch {
+	case !ok:
+		return bdProfileTolerant
+	case version.less(snakeCaseBdVersion):
+		return bdProfileCamelCase
+	default:
+		return bdProfileSnakeCase
+	}
+}
+
+// beadProfile probes `bd --version` once per orchestrator and returns the
+// parsing profile for the installed bd. A probe that fails to run is
+// recorded in logs/bd-compat.log.
+func (o *Orchestrator) beadProfile() bdProfile {
+	o.bdProbe.Do(func() {
+		output, err := o.runProjectCommand("bd", "--version")
+		if err != nil {
+			o.bdFormat = bdProfileTolerant
+			_ = o.appendBdCompatLog(fmt.Sprintf("bd --version failed (%v); accepting every known bead field name", err))
+			return
+		}
+		o.bdFormat = profileForBdVersion(output)
+	})
+	return o.bdFormat
+}
+
+func (o *Orchestrator) bdCompatLogPath() string {
+	return filepath.Join(o.config.LogsDir(), "bd-compat.log")
+}
+
+func (o *Orchestrator) appendBdCompatLog(line string) error {
+	return appendTimestampedLog(o.bdCompatLogPath(), time.Now(), line)
+}
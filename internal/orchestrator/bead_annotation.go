@@ -0,0 +1,30 @@
+package orchestrator
+
+import "fmt"
+
+// annotateCompletedBeads records in bd which agent closed each completed bead
+// and in which cycle. Only beads assigned to the session are annotated. The
+// first failure is logged to the worktree LOG.md and turns annotation off for
+// the rest of the cycle, since it usually means the bd install has no
+// comment support.
+func (m *upCycleManager) annotateCompletedBeads(cs *cycleSession, evt worktreeEvent) {
+	if !m.config.AnnotateCompletedBeads {
+		return
+	}
+	for _, id := range evt.CompletedBeads {
+		if m.annotationsOff.Load() {
+			return
+		}
+		bead, ok := cs.allBeads[canonicalBeadKey(id)]
+		if !ok {
+			continue
+		}
+		note := fmt.Sprintf("Completed by %s in cycle %d (session %s, agent cycle %d)", cs.Agent.Name, m.cycleNumber, cs.Name, cs.cycle)
+		if _, err := m.orchestrator.runProjectCommand("bd", "comments", "add", bead.ID, note); err != nil {
+			if m.annotationsOff.CompareAndSwap(false, true) {
+				_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("bd rejected the completion note for %s (%v); skipping bead annotations this cycle", bead.ID, err))
+			}
+			return
+		}
+	}
+}
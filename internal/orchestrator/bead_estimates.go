@@ -0,0 +1,132 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// beadEstimateState remembers the points agents re-estimated beads to, so a
+// later cycle that loads those beads from bd schedules them at the revised
+// size.
+type beadEstimateState struct {
+	Beads map[string]beadEstimate `json:"beads"`
+}
+
+// beadEstimate is one revision. From is the estimate bd reported when the
+// bead was first revised; once bd reports something else, bd's value wins
+// and the revision is forgotten.
+type beadEstimate struct {
+	ID     string `json:"id"`
+	From   int    `json:"from"`
+	Points int    `json:"points"`
+}
+
+func (o *Orchestrator) beadEstimatesPath() string {
+	return filepath.Join(o.config.StateDir(), "bead-estimates.json")
+}
+
+// recordReestimates stores revised estimates for later cycles. A bead revised
+// again keeps the bd estimate it was first revised from.
+func (o *Orchestrator) recordReestimates(revisions []beadEstimate) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+	o.beadEstimatesMu.Lock()
+	defer o.beadEstimatesMu.Unlock()
+	state := o.readBeadEstimateState()
+	for _, revision := range revisions {
+		key := canonicalBeadKey(revision.ID)
+		if existing, ok := state.Beads[key]; ok {
+			revision.From = existing.From
+		}
+		state.Beads[key] = revision
+	}
+	return o.writeBeadEstimateState(state)
+}
+
+// forgetReestimates drops the recorded revisions of closed beads so the file
+// does not keep an entry for every bead ever re-estimated.
+func (o *Orchestrator) forgetReestimates(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	o.beadEstimatesMu.Lock()
+	defer o.beadEstimatesMu.Unlock()
+	state := o.readBeadEstimateState()
+	changed := false
+	for _, id := range ids {
+		key := canonicalBeadKey(id)
+		if _, ok := state.Beads[key]; ok {
+			delete(state.Beads, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return o.writeBeadEstimateState(state)
+}
+
+// applyRecordedEstimates replaces the points of beads with their recorded
+// re-estimates, and forgets revisions whose bead bd has since re-estimated
+// itself. Failing to forget one is harmless: a revision whose From no longer
+// matches bd is never applied.
+func (o *Orchestrator) applyRecordedEstimates(beads []Bead) {
+	o.beadEstimatesMu.Lock()
+	defer o.beadEstimatesMu.Unlock()
+	state := o.readBeadEstimateState()
+	if len(state.Beads) == 0 {
+		return
+	}
+	changed := false
+	for i, bead := range beads {
+		key := canonicalBeadKey(bead.ID)
+		revision, ok := state.Beads[key]
+		if !ok {
+			continue
+		}
+		if bead.Points != revision.From {
+			delete(state.Beads, key)
+			changed = true
+			continue
+		}
+		beads[i].Points = revision.Points
+	}
+	if changed {
+		_ = o.writeBeadEstimateState(state)
+	}
+}
+
+func (o *Orchestrator) readBeadEstimateState() beadEstimateState {
+	state := beadEstimateState{}
+	if data, err := os.ReadFile(o.beadEstimatesPath()); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+	if state.Beads == nil {
+		state.Beads = map[string]beadEstimate{}
+	}
+	return state
+}
+
+func (o *Orchestrator) writeBeadEstimateState(state beadEstimateState) error {
+	path := o.beadEstimatesPath()
+	if len(state.Beads) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("clear bead estimates: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("prepare bead estimates: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode bead estimates: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write bead estimates: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// SetBeadSyncInterval makes the orchestrator run `bd sync` between agent
+// cycles once at least d has passed since the last sync, so the ready set
+// stays close to the shared bead store during long sessions. A failed sync is
+// logged to logs/bead-sync.log and never stops the cycle. Non-positive values
+// disable periodic syncing.
+func (o *Orchestrator) SetBeadSyncInterval(d time.Duration) {
+	if o == nil {
+		return
+	}
+	if d < 0 {
+		d = 0
+	}
+	o.beadSyncInterval = d
+}
+
+// syncBeadsIfDue runs `bd sync` when the configured interval has elapsed
+// since the last attempt and reports whether it ran. The first call after the
+// orchestrator starts always syncs. Concurrent sessions share one schedule.
+func (o *Orchestrator) syncBeadsIfDue(before string) bool {
+	if o == nil || o.beadSyncInterval <= 0 {
+		return false
+	}
+	o.beadSyncMu.Lock()
+	defer o.beadSyncMu.Unlock()
+	now := o.clock()
+	if !o.lastBeadSync.IsZero() && now.Sub(o.lastBeadSync) < o.beadSyncInterval {
+		return false
+	}
+	o.lastBeadSync = now
+	if _, err := o.runProjectCommand("bd", "sync"); err != nil {
+		_ = o.appendBeadSyncLog(now, fmt.Sprintf("bd sync before %s failed: %v", before, err))
+	}
+	return true
+}
+
+func (o *Orchestrator) clock() time.Time {
+	if o.now != nil {
+		return o.now()
+	}
+	return time.Now()
+}
+
+func (o *Orchestrator) beadSyncLogPath() string {
+	return filepath.Join(o.config.LogsDir(), "bead-sync.log")
+}
+
+func (o *Orchestrator) appendBeadSyncLog(at time.Time, line string) error {
+	return appendTimestampedLog(o.beadSyncLogPath(), at, line)
+}
@@ -0,0 +1,223 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// ErrBeadWatchUnsupported is returned by WatchReadyBeads when neither a
+// configured BeadSubscriber nor bd itself can stream ready-set changes.
+// Callers keep polling PrepareWorkCycle in that case.
+var ErrBeadWatchUnsupported = errors.New("bead store cannot stream ready beads")
+
+// BeadSubscriber streams the ready set as it changes. Each payload uses the
+// `bd ready --json` format and replaces the previous ready set. The channel
+// is closed when the subscription ends or ctx is cancelled.
+type BeadSubscriber interface {
+	SubscribeReady(ctx context.Context) (<-chan []byte, error)
+}
+
+// SetBeadSubscriber overrides the subscriber WatchReadyBeads uses instead of
+// detecting bd's watch mode. Nil restores detection.
+func (o *Orchestrator) SetBeadSubscriber(subscriber BeadSubscriber) {
+	if o == nil {
+		return
+	}
+	o.beadSubscriber = subscriber
+}
+
+// readyBeadFeed holds the latest ready set pushed by a subscription. While
+// live, loadReadyBeads reads it instead of shelling out to bd. first is
+// closed once the subscription delivers its first ready set or ends.
+type readyBeadFeed struct {
+	mu        sync.Mutex
+	live      bool
+	ended     bool
+	payload   []byte
+	first     chan struct{}
+	firstOnce sync.Once
+}
+
+func newReadyBeadFeed() *readyBeadFeed {
+	return &readyBeadFeed{first: make(chan struct{})}
+}
+
+func (f *readyBeadFeed) latest() ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.live || f.payload == nil {
+		return nil, false
+	}
+	return f.payload, true
+}
+
+// push stores payload and reports whether it differs from the previous one.
+func (f *readyBeadFeed) push(payload []byte) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	changed := !f.live || !bytes.Equal(f.payload, payload)
+	f.live = true
+	f.payload = payload
+	f.firstOnce.Do(func() { close(f.first) })
+	return changed
+}
+
+func (f *readyBeadFeed) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.live = false
+	f.ended = true
+	f.payload = nil
+	f.firstOnce.Do(func() { close(f.first) })
+}
+
+func (f *readyBeadFeed) active() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return !f.ended
+}
+
+// WatchReadyBeads subscribes to ready-set changes so the next PrepareWorkCycle
+// uses the pushed set instead of polling `bd ready --json`. The returned
+// channel receives a value whenever the ready set changes and is closed when
+// the subscription ends, after which bd is polled again. It returns
+// ErrBeadWatchUnsupported when no subscriber is configured and the installed
+// bd has no watch mode.
+func (o *Orchestrator) WatchReadyBeads(ctx context.Context) (<-chan struct{}, error) {
+	if o == nil || o.config == nil {
+		return nil, ErrNotInitialized
+	}
+	subscriber := o.beadSubscriber
+	if subscriber == nil {
+		if !o.bdSupportsWatch() {
+			return nil, ErrBeadWatchUnsupported
+		}
+		subscriber = bdWatchSubscriber{dir: o.config.ProjectDir}
+	}
+	updates, err := subscriber.SubscribeReady(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe to ready beads: %w", err)
+	}
+	feed := newReadyBeadFeed()
+	o.readyFeedMu.Lock()
+	o.readyFeed = feed
+	o.readyFeedMu.Unlock()
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+		defer feed.stop()
+		for payload := range updates {
+			if _, err := decodeBeadRecords(payload); err != nil {
+				continue
+			}
+			if !feed.push(payload) {
+				continue
+			}
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// SharedReadyBeads makes sure one ready-bead subscription is running for the
+// orchestrator and returns a channel that is closed once it has delivered its
+// first ready set or ended. A subscription that is already running, such as
+// the TUI's or one a previous cycle started, is reused rather than opening
+// another `bd ready --watch`; otherwise a new one is started that lives until
+// the bead store stops streaming. It returns ErrBeadWatchUnsupported like
+// WatchReadyBeads.
+func (o *Orchestrator) SharedReadyBeads() (<-chan struct{}, error) {
+	if o == nil || o.config == nil {
+		return nil, ErrNotInitialized
+	}
+	o.readyWatchMu.Lock()
+	defer o.readyWatchMu.Unlock()
+	o.readyFeedMu.Lock()
+	feed := o.readyFeed
+	o.readyFeedMu.Unlock()
+	if feed != nil && feed.active() {
+		return feed.first, nil
+	}
+	if _, err := o.WatchReadyBeads(context.Background()); err != nil {
+		return nil, err
+	}
+	o.readyFeedMu.Lock()
+	feed = o.readyFeed
+	o.readyFeedMu.Unlock()
+	return feed.first, nil
+}
+
+// PushedReadyBeads returns the ready set, in `bd ready --json` format, from a
+// live WatchReadyBeads subscription. It reports false when no subscription is
+// live or none has delivered a set yet; callers then poll bd.
+func (o *Orchestrator) PushedReadyBeads() ([]byte, bool) {
+	if o == nil {
+		return nil, false
+	}
+	o.readyFeedMu.Lock()
+	feed := o.readyFeed
+	o.readyFeedMu.Unlock()
+	if feed == nil {
+		return nil, false
+	}
+	return feed.latest()
+}
+
+// bdSupportsWatch reports whether `bd ready` advertises a --watch flag.
+func (o *Orchestrator) bdSupportsWatch() bool {
+	help, err := o.runProjectCommand("bd", "ready", "--help")
+	if err != nil {
+		return false
+	}
+	return strings.Contains(help, "--watch")
+}
+
+// bdWatchSubscriber streams `bd ready --json --watch`, which writes a new
+// JSON ready set every time it changes.
+type bdWatchSubscriber struct {
+	dir string
+}
+
+func (s bdWatchSubscriber) SubscribeReady(ctx context.Context) (<-chan []byte, error) {
+	cmd := exec.CommandContext(ctx, "bd", "ready", "--json", "--watch")
+	cmd.Dir = s.dir
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	updates := make(chan []byte)
+	go func() {
+		defer close(updates)
+		defer cmd.Wait()
+		decoder := json.NewDecoder(stdout)
+		for {
+			var payload json.RawMessage
+			if err := decoder.Decode(&payload); err != nil {
+				if !errors.Is(err, io.EOF) {
+					_ = cmd.Process.Kill()
+				}
+				return
+			}
+			select {
+			case updates <- []byte(payload):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
@@ -0,0 +1,143 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultMaxBlockedCycles is how many cycles a bead may stay blocked before
+// it is reported as stuck.
+const defaultMaxBlockedCycles = 3
+
+// blockedBeadState remembers, per bead, the cycles in which it was seen
+// blocked so beads waiting on a dependency that never lands surface instead
+// of silently never running.
+type blockedBeadState struct {
+	Beads map[string]blockedBeadEntry `json:"beads"`
+}
+
+type blockedBeadEntry struct {
+	ID         string `json:"id"`
+	FirstCycle int    `json:"firstCycle"`
+	LastCycle  int    `json:"lastCycle"`
+	Cycles     int    `json:"cycles"`
+	// Reported is set once the bead has been logged as stuck, so the warning
+	// fires once per blocked streak.
+	Reported bool `json:"reported,omitempty"`
+}
+
+func (o *Orchestrator) blockedBeadsPath() string {
+	return filepath.Join(o.config.StateDir(), "blocked-beads.json")
+}
+
+func (o *Orchestrator) stuckBeadLogPath() string {
+	return filepath.Join(o.config.LogsDir(), "stuck-beads.log")
+}
+
+func (o *Orchestrator) blockedCycleLimit() int {
+	if o.maxBlockedCycles > 0 {
+		return o.maxBlockedCycles
+	}
+	return defaultMaxBlockedCycles
+}
+
+// trackBlockedBeads counts another cycle for every bead in blocked, forgets
+// beads that are no longer blocked, and logs each bead that has now been
+// blocked for the configured number of cycles. A bead seen twice in the same
+// cycle is only counted once.
+func (o *Orchestrator) trackBlockedBeads(blocked []Bead) error {
+	cycle, err := o.currentCycleNumber()
+	if err != nil {
+		return fmt.Errorf("track blocked beads: %w", err)
+	}
+	state := o.readBlockedBeadState()
+	if len(blocked) == 0 && len(state.Beads) == 0 {
+		return nil
+	}
+	next := blockedBeadState{Beads: make(map[string]blockedBeadEntry, len(blocked))}
+	limit := o.blockedCycleLimit()
+	var stuck []string
+	for _, bead := range blocked {
+		key := canonicalBeadKey(bead.ID)
+		entry, ok := state.Beads[key]
+		if !ok {
+			entry = blockedBeadEntry{ID: bead.ID, FirstCycle: cycle}
+		}
+		if entry.LastCycle != cycle {
+			entry.LastCycle = cycle
+			entry.Cycles++
+		}
+		if entry.Cycles >= limit && !entry.Reported {
+			entry.Reported = true
+			stuck = append(stuck, describeStuckBead(bead, entry))
+		}
+		next.Beads[key] = entry
+	}
+	if err := o.writeBlockedBeadState(next); err != nil {
+		return err
+	}
+	if len(stuck) == 0 {
+		return nil
+	}
+	sort.Strings(stuck)
+	return o.appendStuckBeadLog(cycle, stuck)
+}
+
+func describeStuckBead(bead Bead, entry blockedBeadEntry) string {
+	line := fmt.Sprintf("%s · %s has been blocked for %d cycles (since cycle %d)", bead.ID, bead.Title, entry.Cycles, entry.FirstCycle)
+	if waits := dedupeStrings(append(append([]string{}, bead.BlockedBy...), bead.DependsOn...)); len(waits) > 0 {
+		line += fmt.Sprintf("; waiting on %s", strings.Join(waits, ", "))
+	}
+	return line
+}
+
+func (o *Orchestrator) readBlockedBeadState() blockedBeadState {
+	state := blockedBeadState{}
+	if data, err := os.ReadFile(o.blockedBeadsPath()); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+	if state.Beads == nil {
+		state.Beads = map[string]blockedBeadEntry{}
+	}
+	return state
+}
+
+func (o *Orchestrator) writeBlockedBeadState(state blockedBeadState) error {
+	path := o.blockedBeadsPath()
+	if len(state.Beads) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("clear blocked bead state: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("prepare blocked bead state: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode blocked bead state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write blocked bead state: %w", err)
+	}
+	return nil
+}
+
+// appendStuckBeadLogLine records a problem with blocked-bead tracking that did
+// not stop the cycle.
+func (o *Orchestrator) appendStuckBeadLogLine(line string) error {
+	return appendTimestampedLog(o.stuckBeadLogPath(), time.Now(), line)
+}
+
+func (o *Orchestrator) appendStuckBeadLog(cycle int, lines []string) error {
+	prefixed := make([]string, 0, len(lines))
+	for _, line := range lines {
+		prefixed = append(prefixed, fmt.Sprintf("cycle %d · %s", cycle, line))
+	}
+	return appendTimestampedLog(o.stuckBeadLogPath(), time.Now(), prefixed...)
+}
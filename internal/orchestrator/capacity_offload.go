@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+// applyReestimates replaces the points of remaining beads the agent
+// re-estimated in its event, logs each change, and records it so later cycles
+// load the bead at its revised size. Re-estimates for beads outside remaining
+// are ignored.
+func (m *upCycleManager) applyReestimates(cs *cycleSession, evt worktreeEvent, remaining []Bead) []Bead {
+	if len(evt.Reestimates) == 0 {
+		return remaining
+	}
+	points := make(map[string]int, len(evt.Reestimates))
+	for id, value := range evt.Reestimates {
+		if value > 0 {
+			points[canonicalBeadKey(id)] = value
+		}
+	}
+	var revisions []beadEstimate
+	for i, bead := range remaining {
+		value, ok := points[canonicalBeadKey(bead.ID)]
+		if !ok || value == bead.Points {
+			continue
+		}
+		_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("%s re-estimated %s from %d to %d pt", cs.Agent.Name, bead.ID, bead.Points, value))
+		revisions = append(revisions, beadEstimate{ID: bead.ID, From: bead.Points, Points: value})
+		remaining[i].Points = value
+	}
+	if err := m.orchestrator.recordReestimates(revisions); err != nil {
+		_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Re-estimates not saved for later cycles: %v", err))
+	}
+	return remaining
+}
+
+// offloadOverCapacity moves beads the agent has not started off a session
+// whose remaining points exceed its agent's capacity, largest first, until
+// it fits or a single bead is left. Offloaded beads stay open in bd, so the
+// next work cycle schedules them again; they no longer count toward this
+// session's progress.
+func (m *upCycleManager) offloadOverCapacity(cs *cycleSession, evt worktreeEvent, remaining []Bead) []Bead {
+	if !m.config.OffloadOverCapacity || len(remaining) < 2 {
+		return remaining
+	}
+	capacity := m.orchestrator.agentCapacity(cs.Agent.Name)
+	total := 0
+	for _, bead := range remaining {
+		total += bead.Points
+	}
+	if total <= capacity {
+		return remaining
+	}
+	started := make(map[string]bool, len(evt.StartedBeads))
+	for _, id := range evt.StartedBeads {
+		started[canonicalBeadKey(id)] = true
+	}
+	var candidates []Bead
+	for _, bead := range remaining {
+		if !started[canonicalBeadKey(bead.ID)] {
+			candidates = append(candidates, bead)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Points > candidates[j].Points
+	})
+	before := total
+	offload := make(map[string]bool)
+	var labels []string
+	for _, bead := range candidates {
+		if total <= capacity || len(offload) == len(remaining)-1 {
+			break
+		}
+		key := canonicalBeadKey(bead.ID)
+		offload[key] = true
+		total -= bead.Points
+		labels = append(labels, fmt.Sprintf("%s (%d pt)", bead.ID, bead.Points))
+		cs.offloaded = append(cs.offloaded, cs.beadLabel(bead.ID))
+		delete(cs.allBeads, key)
+	}
+	if len(offload) == 0 {
+		return remaining
+	}
+	kept := make([]Bead, 0, len(remaining)-len(offload))
+	for _, bead := range remaining {
+		if !offload[canonicalBeadKey(bead.ID)] {
+			kept = append(kept, bead)
+		}
+	}
+	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("%s is over capacity (%d/%d pt); offloaded %s to the next cycle", cs.Agent.Name, before, capacity, strings.Join(labels, ", ")))
+	return kept
+}
+
+// agentCapacity returns the story-point capacity hiring recorded for the
+// named agent, or the cycle's per-agent default when the roster does not
+// list it.
+func (o *Orchestrator) agentCapacity(name string) int {
+	fallback := o.cycleConfig().MaxAgentPoints
+	if o == nil || o.config == nil {
+		return fallback
+	}
+	roster, err := workflow.LoadWorkers(o.config.WorkerListPath())
+	if err != nil {
+		return fallback
+	}
+	for _, entry := range roster {
+		if strings.EqualFold(strings.TrimSpace(entry.Name), strings.TrimSpace(name)) {
+			return capacityForEntry(entry, fallback)
+		}
+	}
+	return fallback
+}
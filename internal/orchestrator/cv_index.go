@@ -0,0 +1,167 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/community"
+	"github.com/kingrea/The-Lattice/internal/config"
+)
+
+const cvIndexVersion = 2
+
+// cvIndex caches the denizens parsed from every community so repeated hiring
+// and selection runs skip the directory walk. Stamps record the modification
+// time and size of each directory, community.yaml, and cv.md the index was
+// built from, including CVs that did not parse; any change invalidates the
+// whole index.
+type cvIndex struct {
+	Version        int                `json:"version"`
+	CommunitiesDir string             `json:"communitiesDir"`
+	Stamps         map[string]cvStamp `json:"stamps"`
+	Agents         []Agent            `json:"agents"`
+}
+
+// cvStamp is what the index remembers about one path it read.
+type cvStamp struct {
+	ModTime int64 `json:"modTime"`
+	Size    int64 `json:"size"`
+}
+
+// CVIndexPath returns where the denizen CV index is cached for the project.
+func CVIndexPath(cfg *config.Config) string {
+	return filepath.Join(cfg.StateDir(), "cv-index.json")
+}
+
+// RefreshCVIndex discards the cached denizen CV index so the next
+// LoadDenizenCVs rescans every community.
+func RefreshCVIndex(cfg *config.Config) error {
+	if err := os.Remove(CVIndexPath(cfg)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove cv index: %w", err)
+	}
+	return nil
+}
+
+// loadCVIndex returns the cached index when it was built from communitiesDir
+// and none of its stamped paths changed since.
+func loadCVIndex(path, communitiesDir string) (*cvIndex, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var index cvIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, false
+	}
+	if index.Version != cvIndexVersion || index.CommunitiesDir != communitiesDir || len(index.Stamps) == 0 {
+		return nil, false
+	}
+	for stamped, want := range index.Stamps {
+		info, err := os.Stat(stamped)
+		if err != nil || info.ModTime().UnixNano() != want.ModTime || info.Size() != want.Size {
+			return nil, false
+		}
+	}
+	return &index, true
+}
+
+// save writes the index, creating the state directory when needed.
+func (idx *cvIndex) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// stamp records the modification time and size of path in the index.
+func (idx *cvIndex) stamp(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	idx.Stamps[path] = cvStamp{ModTime: info.ModTime().UnixNano(), Size: info.Size()}
+}
+
+// scanDenizenCVs walks every community under communitiesDir and parses the
+// cv.md of each denizen, stamping what it visits.
+func (o *Orchestrator) scanDenizenCVs(communitiesDir string) (*cvIndex, error) {
+	communities, err := os.ReadDir(communitiesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read communities dir: %w", err)
+	}
+
+	index := &cvIndex{
+		Version:        cvIndexVersion,
+		CommunitiesDir: communitiesDir,
+		Stamps:         map[string]cvStamp{},
+	}
+	index.stamp(communitiesDir)
+
+	for _, entry := range communities {
+		if !entry.IsDir() {
+			continue
+		}
+
+		communityRoot := filepath.Join(communitiesDir, entry.Name())
+		index.stamp(communityRoot)
+		// Stamped before loading so fixing a broken community.yaml
+		// invalidates the index.
+		index.stamp(filepath.Join(communityRoot, "community.yaml"))
+		cfg, err := community.Load(communityRoot)
+		if err != nil {
+			continue
+		}
+
+		communityName := strings.TrimSpace(cfg.Config.Name)
+		if communityName == "" {
+			communityName = entry.Name()
+		}
+
+		denizensDir := cfg.CVsPath()
+		denizens, err := os.ReadDir(denizensDir)
+		if err != nil {
+			continue // Community might not have denizens yet
+		}
+		index.stamp(denizensDir)
+
+		for _, denizen := range denizens {
+			if !denizen.IsDir() {
+				continue // Skip files like denizen-file-structure.md
+			}
+
+			denizenDir := filepath.Join(denizensDir, denizen.Name())
+			index.stamp(denizenDir)
+			cvPath := filepath.Join(denizenDir, "cv.md")
+			index.stamp(cvPath)
+			agent, err := o.parseCVFile(cvPath, communityName)
+			if err != nil {
+				continue // Skip denizens without CVs
+			}
+
+			index.Agents = append(index.Agents, agent)
+		}
+	}
+
+	return index, nil
+}
+
+func (o *Orchestrator) cvIndexLogPath() string {
+	return filepath.Join(o.config.LogsDir(), "cv-index.log")
+}
+
+// appendCVIndexLog records a CV index problem that did not stop the load.
+func (o *Orchestrator) appendCVIndexLog(line string) error {
+	return appendTimestampedLog(o.cvIndexLogPath(), time.Now(), line)
+}
@@ -0,0 +1,135 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestLoadDenizenCVsUsesIndexUntilTreeChanges(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	communityRoot := filepath.Join(orch.config.CommunitiesDir(), "atlas")
+	config := "lattice:\n  type: community\n  version: 1\nname: Atlas Collective\npaths:\n  identities: identities\n  denizens: denizens\n  cvs: cvs\n"
+	if err := os.MkdirAll(filepath.Join(communityRoot, "cvs"), 0o755); err != nil {
+		t.Fatalf("mkdir cvs: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(communityRoot, "community.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatalf("write community.yaml: %v", err)
+	}
+	writeCV := func(slug, name string) string {
+		t.Helper()
+		dir := filepath.Join(communityRoot, "cvs", slug)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir denizen: %v", err)
+		}
+		path := filepath.Join(dir, "cv.md")
+		cv := fmt.Sprintf("---\nname: %s\nbyline: worker\n---\nprecision: 2\n", name)
+		if err := os.WriteFile(path, []byte(cv), 0o644); err != nil {
+			t.Fatalf("write cv: %v", err)
+		}
+		return path
+	}
+	names := func() string {
+		t.Helper()
+		agents, err := orch.LoadDenizenCVs()
+		if err != nil {
+			t.Fatalf("load cvs: %v", err)
+		}
+		var out []string
+		for _, agent := range agents {
+			out = append(out, agent.Name)
+		}
+		sort.Strings(out)
+		return fmt.Sprint(out)
+	}
+
+	cvPath := writeCV("cass", "Cass")
+	if got := names(); got != "[Cass]" {
+		t.Fatalf("first load = %s", got)
+	}
+	if _, err := os.Stat(CVIndexPath(orch.config)); err != nil {
+		t.Fatalf("expected cv index to be written: %v", err)
+	}
+
+	// Rewrite the CV with a same-length name and keep its modification
+	// time: only a cached load can still report the old name.
+	info, err := os.Stat(cvPath)
+	if err != nil {
+		t.Fatalf("stat cv: %v", err)
+	}
+	writeCV("cass", "Cara")
+	if err := os.Chtimes(cvPath, time.Now(), info.ModTime()); err != nil {
+		t.Fatalf("restore cv mtime: %v", err)
+	}
+	if got := names(); got != "[Cass]" {
+		t.Fatalf("second load should come from the index, got %s", got)
+	}
+
+	writeCV("orin", "Orin")
+	if got := names(); got != "[Cara Orin]" {
+		t.Fatalf("load after adding a denizen = %s", got)
+	}
+
+	orinPath := filepath.Join(communityRoot, "cvs", "orin", "cv.md")
+	info, err = os.Stat(orinPath)
+	if err != nil {
+		t.Fatalf("stat cv: %v", err)
+	}
+	writeCV("orin", "Orion")
+	if err := os.Chtimes(orinPath, time.Now(), info.ModTime()); err != nil {
+		t.Fatalf("restore cv mtime: %v", err)
+	}
+	if err := RefreshCVIndex(orch.config); err != nil {
+		t.Fatalf("refresh index: %v", err)
+	}
+	if got := names(); got != "[Cara Orion]" {
+		t.Fatalf("load after refresh = %s", got)
+	}
+}
+
+func TestLoadDenizenCVsRescansWhenBrokenCVIsFixed(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	communityRoot := filepath.Join(orch.config.CommunitiesDir(), "atlas")
+	config := "lattice:\n  type: community\n  version: 1\nname: Atlas Collective\npaths:\n  identities: identities\n  denizens: denizens\n  cvs: cvs\n"
+	cvDir := filepath.Join(communityRoot, "cvs", "cass")
+	if err := os.MkdirAll(cvDir, 0o755); err != nil {
+		t.Fatalf("mkdir denizen: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(communityRoot, "community.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatalf("write community.yaml: %v", err)
+	}
+	cvPath := filepath.Join(cvDir, "cv.md")
+	if err := os.WriteFile(cvPath, []byte("---\nbyline: worker\n---\nprecision: 2\n"), 0o644); err != nil {
+		t.Fatalf("write broken cv: %v", err)
+	}
+	agents, err := orch.LoadDenizenCVs()
+	if err != nil {
+		t.Fatalf("load cvs: %v", err)
+	}
+	if len(agents) != 0 {
+		t.Fatalf("expected the broken cv to be skipped, got %+v", agents)
+	}
+
+	info, err := os.Stat(cvPath)
+	if err != nil {
+		t.Fatalf("stat cv: %v", err)
+	}
+	fixed := "---\nname: Cass\nbyline: worker\n---\nprecision: 2\n"
+	if err := os.WriteFile(cvPath, []byte(fixed), 0o644); err != nil {
+		t.Fatalf("fix cv: %v", err)
+	}
+	// Same mtime, so only the recorded size can expose the edit.
+	if err := os.Chtimes(cvPath, time.Now(), info.ModTime()); err != nil {
+		t.Fatalf("restore cv mtime: %v", err)
+	}
+	agents, err = orch.LoadDenizenCVs()
+	if err != nil {
+		t.Fatalf("reload cvs: %v", err)
+	}
+	if len(agents) != 1 || agents[0].Name != "Cass" {
+		t.Fatalf("expected the fixed cv to be picked up, got %+v", agents)
+	}
+}
@@ -0,0 +1,178 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/eventbridge"
+)
+
+// CycleEventType names a structured up-cycle event.
+type CycleEventType string
+
+const (
+	// CycleEventCycleStarted fires once when RunUpCycle starts its sessions.
+	CycleEventCycleStarted CycleEventType = "cycle_started"
+	// CycleEventAgentDispatched fires when an agent is launched in a worktree.
+	CycleEventAgentDispatched CycleEventType = "agent_dispatched"
+	// CycleEventQuestionRaised fires when a new file appears in a worktree's
+	// outbox/questions.
+	CycleEventQuestionRaised CycleEventType = "question_raised"
+	// CycleEventAgentComplete fires when an agent_complete event is read.
+	CycleEventAgentComplete CycleEventType = "agent_complete"
+	// CycleEventOrchestratorDone fires after the orchestrator has reviewed an
+	// agent's cycle.
+	CycleEventOrchestratorDone CycleEventType = "orchestrator_done"
+	// CycleEventWorktreeLanded fires when a worktree passes the landing checks.
+	CycleEventWorktreeLanded CycleEventType = "worktree_landed"
+	// CycleEventCycleLanded fires once every worktree has landed and been
+	// removed, before the next cycle is started.
+	CycleEventCycleLanded CycleEventType = "cycle_landed"
+)
+
+// CycleEvent is the structured counterpart of a worktree LOG.md line.
+// Session-scoped fields are empty for cycle_started and cycle_landed.
+type CycleEvent struct {
+	Type CycleEventType `json:"type"`
+	Time time.Time      `json:"time"`
+	// Cycle is the global cycle number; Attempt is the session's own cycle
+	// counter, which restarts at 1 for every global cycle.
+	Cycle    int    `json:"cycle"`
+	Attempt  int    `json:"attempt,omitempty"`
+	Session  int    `json:"session,omitempty"`
+	Worktree string `json:"worktree,omitempty"`
+	Agent    string `json:"agent,omitempty"`
+	// Beads lists the bead IDs the event concerns: the assigned beads for
+	// cycle_started and agent_dispatched, the completed ones for
+	// agent_complete.
+	Beads     []string `json:"beads,omitempty"`
+	Remaining []string `json:"remaining,omitempty"`
+	// Question is the question file name for question_raised.
+	Question string `json:"question,omitempty"`
+	// session is the session the event concerns, handed to a
+	// ProgressReporter's callbacks.
+	session *WorktreeSession
+}
+
+// EventSink receives structured up-cycle events for external monitors. It is
+// the one stream the up-cycle reports milestones on; a ProgressReporter is
+// fed from it. Calls are serialized across sessions; implementations should
+// return quickly because the cycle waits on them.
+type EventSink interface {
+	Emit(event CycleEvent)
+}
+
+// NopEventSink drops every event. It is the default sink.
+type NopEventSink struct{}
+
+func (NopEventSink) Emit(CycleEvent) {}
+
+// SetEventSink registers the sink RunUpCycle emits structured events to. Nil
+// restores the no-op default.
+func (o *Orchestrator) SetEventSink(sink EventSink) {
+	if o == nil {
+		return
+	}
+	o.events = sink
+}
+
+// eventSink combines the configured sink and progress reporter into one sink
+// that concurrent sessions never call at the same time.
+func (o *Orchestrator) eventSink() EventSink {
+	var sinks []EventSink
+	if o.events != nil {
+		sinks = append(sinks, o.events)
+	}
+	if o.progress != nil {
+		sinks = append(sinks, progressEventSink{reporter: o.progress})
+	}
+	if len(sinks) == 0 {
+		return NopEventSink{}
+	}
+	return &serialEventSink{sinks: sinks}
+}
+
+type serialEventSink struct {
+	mu    sync.Mutex
+	sinks []EventSink
+}
+
+func (s *serialEventSink) Emit(event CycleEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sink := range s.sinks {
+		sink.Emit(event)
+	}
+}
+
+// BridgeEventSink routes cycle events through an event bridge router so they
+// can be read from a Subscription on moduleID like plugin events. Each event
+// carries its CycleEvent as the JSON payload, its type as the event type, and
+// the worktree name as the session ID.
+type BridgeEventSink struct {
+	router   *eventbridge.Router
+	moduleID string
+	workflow string
+	seq      atomic.Int64
+}
+
+// NewBridgeEventSink returns a sink publishing to router under moduleID.
+func NewBridgeEventSink(router *eventbridge.Router, moduleID, workflow string) *BridgeEventSink {
+	return &BridgeEventSink{router: router, moduleID: moduleID, workflow: workflow}
+}
+
+func (s *BridgeEventSink) Emit(event CycleEvent) {
+	if s == nil || s.router == nil {
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	seq := s.seq.Add(1)
+	sessionID := event.Worktree
+	if sessionID == "" {
+		sessionID = fmt.Sprintf("cycle-%d", event.Cycle)
+	}
+	s.router.Route(eventbridge.Event{
+		Version:    eventbridge.EventSchemaVersion,
+		EventID:    fmt.Sprintf("lattice-cycle-%d-%d-%d", event.Cycle, event.Time.UnixNano(), seq),
+		Sequence:   seq,
+		Type:       string(event.Type),
+		ClientTime: event.Time,
+		ServerTime: event.Time,
+		SessionID:  sessionID,
+		ModuleID:   s.moduleID,
+		Workflow:   s.workflow,
+		Payload:    payload,
+	})
+}
+
+// emit sends a session-scoped event stamped with the session's identity.
+func (m *upCycleManager) emit(cs *cycleSession, event CycleEvent) {
+	if cs != nil {
+		session := cs.WorktreeSession
+		event.session = &session
+		event.Attempt = cs.cycle
+		event.Session = cs.Number
+		event.Worktree = cs.Name
+		event.Agent = cs.Agent.Name
+	}
+	event.Cycle = m.cycleNumber
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	m.sink().Emit(event)
+}
+
+// sink returns the manager's event sink, tolerating managers built without
+// one.
+func (m *upCycleManager) sink() EventSink {
+	if m.events == nil {
+		return NopEventSink{}
+	}
+	return m.events
+}
@@ -0,0 +1,134 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+// downCycleLogHeading starts each down-cycle section in the work log; the
+// writer follows it with "(cycle N, <timestamp>)".
+const downCycleLogHeading = "## Down cycle summary"
+
+var (
+	cycleDirPattern     = regexp.MustCompile(`^cycle-([0-9]+)$`)
+	downCycleLogPattern = regexp.MustCompile(`^## Down cycle summary \(cycle ([0-9]+),([^)]*)`)
+)
+
+// CycleSummary points at the SUMMARY.md a completed cycle left behind.
+type CycleSummary struct {
+	Cycle     int
+	Path      string
+	UpdatedAt time.Time
+}
+
+// CycleSummaries lists every cycle under state/ that has written its
+// SUMMARY.md, newest cycle first.
+func (o *Orchestrator) CycleSummaries() ([]CycleSummary, error) {
+	if o == nil || o.config == nil {
+		return nil, ErrNotInitialized
+	}
+	entries, err := os.ReadDir(o.config.StateDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read state dir: %w", err)
+	}
+	var summaries []CycleSummary
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		match := cycleDirPattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		cycle, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(o.config.StateDir(), entry.Name(), "SUMMARY.md")
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue // Cycle still running or never summarized
+		}
+		summaries = append(summaries, CycleSummary{Cycle: cycle, Path: path, UpdatedAt: info.ModTime()})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Cycle > summaries[j].Cycle })
+	return summaries, nil
+}
+
+// DownCycleLogSection returns the down-cycle section the work log recorded
+// for cycle, or "" when none was written. Sections logged before headings
+// carried a cycle number cannot be matched and are skipped.
+func (o *Orchestrator) DownCycleLogSection(cycle int) (string, error) {
+	if o == nil || o.config == nil {
+		return "", ErrNotInitialized
+	}
+	data, err := os.ReadFile(o.workLogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read work log: %w", err)
+	}
+	section := downCycleSections(string(data))[cycle]
+	return strings.TrimSpace(strings.Join(section.Lines, "\n")), nil
+}
+
+// downCycleSection is one cycle's down-cycle section of the work log.
+type downCycleSection struct {
+	Cycle int
+	// FinishedAt is zero when the heading's timestamp does not parse.
+	FinishedAt time.Time
+	// Lines holds the section, heading first.
+	Lines []string
+}
+
+// downCycleSections splits the work log into its down-cycle sections by
+// cycle. A rerun down cycle supersedes earlier sections for the same cycle.
+func downCycleSections(log string) map[int]downCycleSection {
+	sections := map[int]downCycleSection{}
+	var current *downCycleSection
+	flush := func() {
+		if current != nil {
+			sections[current.Cycle] = *current
+			current = nil
+		}
+	}
+	for _, line := range strings.Split(log, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			flush()
+			match := downCycleLogPattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			number, err := strconv.Atoi(match[1])
+			if err != nil {
+				continue
+			}
+			current = &downCycleSection{Cycle: number}
+			if finished, err := time.Parse(time.RFC3339, strings.TrimSpace(match[2])); err == nil {
+				current.FinishedAt = finished
+			}
+		}
+		if current != nil {
+			current.Lines = append(current.Lines, line)
+		}
+	}
+	flush()
+	return sections
+}
+
+// workLogPath is where work-process and the down cycle append their reports.
+func (o *Orchestrator) workLogPath() string {
+	return filepath.Join(o.config.LatticeProjectDir, workflow.WorkflowDir, workflow.WorkDir, workflow.FileWorkLog)
+}
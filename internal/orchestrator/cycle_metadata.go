@@ -0,0 +1,64 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// cycleMetadataLabel prefixes the metadata line in each down-cycle section.
+const cycleMetadataLabel = "Cycle metadata:"
+
+// SetCycleMetadata attaches free-form key/value notes, such as an experiment
+// ID, to the cycles this orchestrator prepares and runs. They are stored in
+// the cycle tracker and repeated in the down-cycle log, which the release
+// notes include. Keys are trimmed and empty keys dropped; nil clears them.
+func (o *Orchestrator) SetCycleMetadata(meta map[string]string) {
+	if o == nil {
+		return
+	}
+	o.cycleMetadata = cleanCycleMetadata(meta)
+}
+
+func cleanCycleMetadata(meta map[string]string) map[string]string {
+	var out map[string]string
+	for key, value := range meta {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string, len(meta))
+		}
+		out[key] = strings.TrimSpace(value)
+	}
+	return out
+}
+
+// currentCycleMetadata returns the metadata set on the orchestrator, falling
+// back to what the cycle tracker recorded when the cycle was prepared by
+// another process.
+func (o *Orchestrator) currentCycleMetadata() map[string]string {
+	if len(o.cycleMetadata) > 0 {
+		return o.cycleMetadata
+	}
+	tracker, err := o.readCycleTracker()
+	if err != nil {
+		return nil
+	}
+	return tracker.Metadata
+}
+
+// formatCycleMetadata renders metadata as sorted key=value pairs.
+func formatCycleMetadata(meta map[string]string) string {
+	keys := make([]string, 0, len(meta))
+	for key := range meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, meta[key]))
+	}
+	return strings.Join(pairs, ", ")
+}
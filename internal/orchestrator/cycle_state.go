@@ -9,6 +9,8 @@ import (
 
 type cycleState struct {
 	Current int `json:"current"`
+	// Stalled counts consecutive cycles that finished without closing a bead.
+	Stalled int `json:"stalled,omitempty"`
 }
 
 func (o *Orchestrator) cycleStatePath() string {
@@ -24,6 +24,8 @@ type cycleTracker struct {
 	Status    string           `json:"status"`
 	UpdatedAt string           `json:"updatedAt"`
 	Sessions  []trackedSession `json:"sessions"`
+	// Metadata holds the notes attached with SetCycleMetadata.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 type trackedSession struct {
@@ -41,7 +43,7 @@ func (o *Orchestrator) cycleTrackerPath() string {
 }
 
 func (o *Orchestrator) persistCycleTracker(cycle int, sessions []WorktreeSession, status string) error {
-	tracker := cycleTracker{Cycle: cycle, Status: status, UpdatedAt: time.Now().UTC().Format(time.RFC3339)}
+	tracker := cycleTracker{Cycle: cycle, Status: status, UpdatedAt: time.Now().UTC().Format(time.RFC3339), Metadata: o.cycleMetadata}
 	tracker.Sessions = make([]trackedSession, 0, len(sessions))
 	for _, session := range sessions {
 		created := session.CreatedAt
@@ -68,6 +70,9 @@ func (o *Orchestrator) updateCycleTrackerStatus(status string) error {
 	}
 	tracker.Status = status
 	tracker.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if len(o.cycleMetadata) > 0 {
+		tracker.Metadata = o.cycleMetadata
+	}
 	return o.writeCycleTracker(tracker)
 }
 
@@ -78,6 +83,66 @@ func (o *Orchestrator) clearCycleTracker() error {
 	return nil
 }
 
+// idleTrackedSessions returns the tracked sessions that are not in running,
+// so a cycle run on a subset of its prepared sessions can leave the rest in
+// place.
+func (o *Orchestrator) idleTrackedSessions(running []WorktreeSession) []trackedSession {
+	tracker, err := o.readCycleTracker()
+	if err != nil {
+		return nil
+	}
+	names := make(map[string]struct{}, len(running))
+	for _, session := range running {
+		names[session.Name] = struct{}{}
+	}
+	var idle []trackedSession
+	for _, ts := range tracker.Sessions {
+		if _, ok := names[ts.Name]; !ok {
+			idle = append(idle, ts)
+		}
+	}
+	return idle
+}
+
+// trackIdleSessions replaces the tracker with the sessions that sat out the
+// last cycle, marked prepared for cycle so PrepareWorkCycle resumes them.
+func (o *Orchestrator) trackIdleSessions(cycle int, idle []trackedSession) error {
+	return o.writeCycleTracker(cycleTracker{
+		Cycle:     cycle,
+		Status:    "prepared",
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+		Sessions:  idle,
+	})
+}
+
+// DiscardWorktrees removes every tracked worktree session along with the
+// cycle tracker. Branches are kept so work that was already committed can be
+// recovered; failures to delete individual worktrees are collected and
+// returned after the directories are cleared.
+func (o *Orchestrator) DiscardWorktrees() error {
+	if o == nil || o.config == nil {
+		return ErrNotInitialized
+	}
+	var errs []error
+	sessions, err := o.loadTrackedSessions()
+	if err != nil && !errors.Is(err, errNoTrackedSessions) {
+		errs = append(errs, err)
+	}
+	for _, session := range sessions {
+		if err := o.invokeWorktreeDelete(session.Name, session.Path, "workflow reset"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := os.RemoveAll(o.config.WorktreeDir()); err != nil {
+		errs = append(errs, fmt.Errorf("remove %s: %w", o.config.WorktreeDir(), err))
+	}
+	_, _ = o.runProjectCommand("git", "worktree", "prune")
+	if err := o.clearCycleTracker(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
 func (o *Orchestrator) loadTrackedSessions() ([]WorktreeSession, error) {
 	tracker, err := o.readCycleTracker()
 	if err != nil {
@@ -96,6 +161,39 @@ func (o *Orchestrator) loadTrackedSessions() ([]WorktreeSession, error) {
 	return sessions, nil
 }
 
+// PreparedSessions returns the sessions tracked for the current cycle,
+// rebuilding them from WORKTREE.md files when the tracker is missing.
+func (o *Orchestrator) PreparedSessions() ([]WorktreeSession, error) {
+	return o.loadTrackedSessions()
+}
+
+// SessionFromJSON decodes synthetic session data in the cycle tracker format
+// (number, name, path, agentName, agentPath, beads). Agents that are not part
+// of the project fall back to a bare entry carrying only the name.
+func (o *Orchestrator) SessionFromJSON(data []byte) (WorktreeSession, error) {
+	var ts trackedSession
+	if err := json.Unmarshal(data, &ts); err != nil {
+		return WorktreeSession{}, fmt.Errorf("failed to parse session: %w", err)
+	}
+	if strings.TrimSpace(ts.AgentName) == "" && ts.AgentPath == "" {
+		return WorktreeSession{}, fmt.Errorf("session is missing agentName")
+	}
+	lookup, _ := o.agentLookup()
+	agent, err := o.resolveTrackedAgent(ts, lookup)
+	if err != nil {
+		agent = ProjectAgent{Name: strings.TrimSpace(ts.AgentName)}
+	}
+	created, _ := time.Parse(time.RFC3339, ts.CreatedAt)
+	return WorktreeSession{
+		Number:    ts.Number,
+		Name:      ts.Name,
+		Agent:     agent,
+		Beads:     append([]Bead(nil), ts.Beads...),
+		Path:      ts.Path,
+		CreatedAt: created,
+	}, nil
+}
+
 func (o *Orchestrator) readCycleTracker() (cycleTracker, error) {
 	path := o.cycleTrackerPath()
 	data, err := os.ReadFile(path)
@@ -159,7 +257,7 @@ func (o *Orchestrator) resolveTrackedAgent(ts trackedSession, lookup map[string]
 	if agent, ok := lookup[key]; ok {
 		return agent, nil
 	}
-	return ProjectAgent{}, fmt.Errorf("agent %s not found in project", ts.AgentName)
+	return ProjectAgent{}, fmt.Errorf("%w: %s is not in the project", ErrAgentNotFound, ts.AgentName)
 }
 
 func (o *Orchestrator) agentLookup() (map[string]ProjectAgent, error) {
@@ -244,7 +342,7 @@ func (o *Orchestrator) parseWorktreeSession(dir string, lookup map[string]Projec
 	key := strings.ToLower(strings.TrimSpace(agentName))
 	agent, ok := lookup[key]
 	if !ok {
-		return WorktreeSession{}, fmt.Errorf("agent %s not found for %s", agentName, dir)
+		return WorktreeSession{}, fmt.Errorf("%w: %s for %s", ErrAgentNotFound, agentName, dir)
 	}
 	num := parseSessionNumber(dir)
 	if createdAt.IsZero() {
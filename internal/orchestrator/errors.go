@@ -0,0 +1,68 @@
+package orchestrator
+
+import "errors"
+
+// Failure modes callers may want to handle specifically. Orchestrator errors
+// wrap these, so match them with errors.Is; Remedy maps them to a hint.
+var (
+	// ErrNotInitialized is returned when an Orchestrator has no config.
+	ErrNotInitialized = errors.New("orchestrator is not initialized")
+	// ErrWorktreeToolMissing is returned when the opencode-worktree plugin is
+	// missing, cannot be installed automatically, and plain git worktrees are
+	// unavailable as a fallback.
+	ErrWorktreeToolMissing = errors.New("opencode-worktree plugin is required but not installed")
+	// ErrWorktreeCreate and ErrWorktreeDelete are returned when no worktree
+	// backend could create or remove a session's worktree.
+	ErrWorktreeCreate = errors.New("failed to create worktree")
+	ErrWorktreeDelete = errors.New("failed to delete worktree")
+	// ErrUnknownBaseBranch is returned when the configured worktree base
+	// branch does not exist in the project repository.
+	ErrUnknownBaseBranch = errors.New("worktree base branch not found")
+	// ErrOrchestratorAgentNotFound is returned when the selected orchestrator
+	// has no generated agent file.
+	ErrOrchestratorAgentNotFound = errors.New("orchestrator agent not found")
+	// ErrAgentNotFound is returned when a rostered or tracked agent has no
+	// generated agent file.
+	ErrAgentNotFound = errors.New("agent not found")
+	// ErrNoAgents is returned when no agent files exist to schedule work on.
+	ErrNoAgents = errors.New("no agents available")
+	// ErrSparkAgents is returned when the spark policy keeps rostered SPARK
+	// placeholder agents from being scheduled and no one else can take work.
+	ErrSparkAgents = errors.New("SPARK agents cannot be scheduled")
+	// ErrSessionScaffold is returned when a worktree session is missing
+	// mailbox directories or its WORKTREE.md before an agent is dispatched.
+	ErrSessionScaffold = errors.New("worktree session is not scaffolded")
+	// ErrAgentIdle is returned when an agent window stays idle past the idle
+	// watchdog timeout on every dispatch allowed for its cycle.
+	ErrAgentIdle = errors.New("agent went idle without reporting completion")
+)
+
+// Remedy suggests what to do about err, or returns "" when err is not one of
+// the failure modes above.
+func Remedy(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrWorktreeToolMissing):
+		return "Install opencode-worktree manually with opencode install opencode-worktree (requires npm), or run inside a git repository so Lattice can fall back to git worktrees."
+	case errors.Is(err, ErrWorktreeCreate), errors.Is(err, ErrWorktreeDelete):
+		return "Check `git worktree list` in the project; remove stale entries with `git worktree prune`."
+	case errors.Is(err, ErrUnknownBaseBranch):
+		return "Fetch or create the branch named by worktree_base_branch, or remove the setting to branch from the current checkout."
+	case errors.Is(err, ErrOrchestratorAgentNotFound):
+		return "Re-run orchestrator-selection so the chosen orchestrator has an agent file under .lattice/agents."
+	case errors.Is(err, ErrAgentNotFound):
+		return "Re-run hiring so every worker in workflow/team/workers.json has an agent file under .lattice/agents."
+	case errors.Is(err, ErrNoAgents):
+		return "Run the hiring module to generate agent files before starting work."
+	case errors.Is(err, ErrSparkAgents):
+		return "Give the SPARK agents a CV and re-run hiring so they become full agents, or set work-process spark_policy to schedule to let them take beads as they are."
+	case errors.Is(err, ErrSessionScaffold):
+		return "Re-run the work-process module so the cycle's worktree sessions are prepared again, or recreate the missing items named in the error."
+	case errors.Is(err, ErrAgentIdle):
+		return "Check the session's LOG.md and opencode log for where the agent stopped; raise session.idle_watchdog.timeout or retries in .lattice/config.yaml if it was still working."
+	case errors.Is(err, ErrNotInitialized):
+		return "Open a project before running orchestrator commands."
+	}
+	return ""
+}
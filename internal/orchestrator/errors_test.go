@@ -0,0 +1,155 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestAgent(t *testing.T, orch *Orchestrator, name string) {
+	t.Helper()
+	dir := filepath.Join(orch.config.AgentsDir(), slugifyToken(name))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir agent: %v", err)
+	}
+	agent := fmt.Sprintf("---\nname: %s\nrole: Worker\n---\n\n%s builds.\n", name, name)
+	if err := os.WriteFile(filepath.Join(dir, "AGENT.md"), []byte(agent), 0644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+}
+
+func writeTestRoster(t *testing.T, orch *Orchestrator, roster string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(orch.config.WorkerListPath()), 0755); err != nil {
+		t.Fatalf("mkdir roster: %v", err)
+	}
+	if err := os.WriteFile(orch.config.WorkerListPath(), []byte(roster), 0644); err != nil {
+		t.Fatalf("write roster: %v", err)
+	}
+}
+
+func TestOrchestratorFailuresReturnTypedErrors(t *testing.T) {
+	failing := func(dir, name string, args ...string) (string, error) {
+		return "", fmt.Errorf("%s: executable file not found", name)
+	}
+	cases := []struct {
+		name string
+		want error
+		run  func(t *testing.T, orch *Orchestrator) error
+	}{
+		{
+			name: "uninitialized orchestrator",
+			want: ErrNotInitialized,
+			run: func(t *testing.T, _ *Orchestrator) error {
+				_, err := (&Orchestrator{}).CycleSummaries()
+				return err
+			},
+		},
+		{
+			name: "worktree plugin missing without git",
+			want: ErrWorktreeToolMissing,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				t.Setenv(pluginAutoInstallEnv, "0")
+				orch.runCommand = failing
+				return orch.ensureWorktreeToolInstalled()
+			},
+		},
+		{
+			name: "worktree plugin install fails",
+			want: ErrWorktreeToolMissing,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				t.Setenv(pluginAutoInstallEnv, "1")
+				orch.runCommand = failing
+				return orch.ensureWorktreeToolInstalled()
+			},
+		},
+		{
+			name: "no worktree backend can create",
+			want: ErrWorktreeCreate,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				orch.runCommand = failing
+				_, err := orch.invokeWorktreeCreate("tree-1-aster", t.TempDir())
+				return err
+			},
+		},
+		{
+			name: "git cannot create",
+			want: ErrWorktreeCreate,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				orch.runCommand = failing
+				orch.nativeWorktrees = true
+				_, err := orch.invokeWorktreeCreate("tree-1-aster", t.TempDir())
+				return err
+			},
+		},
+		{
+			name: "no worktree backend can delete",
+			want: ErrWorktreeDelete,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				orch.runCommand = failing
+				return orch.invokeWorktreeDelete("tree-1-aster", t.TempDir(), "")
+			},
+		},
+		{
+			name: "orchestrator has no agent file",
+			want: ErrOrchestratorAgentNotFound,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				writeTestAgent(t, orch, "Aster")
+				writeTestRoster(t, orch, `{"orchestrator":{"name":"Ghost"},"workers":[{"name":"Aster"}]}`)
+				_, err := (&upCycleManager{orchestrator: orch}).findOrchestratorAgent()
+				return err
+			},
+		},
+		{
+			name: "orchestrator config names unknown agent",
+			want: ErrOrchestratorAgentNotFound,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				writeTestAgent(t, orch, "Aster")
+				return orch.RefreshOpenCodeConfigFor("Ghost")
+			},
+		},
+		{
+			name: "rostered worker has no agent file",
+			want: ErrAgentNotFound,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				writeTestAgent(t, orch, "Aster")
+				writeTestRoster(t, orch, `{"workers":[{"name":"Aster","role":"worker"},{"name":"Ghost","role":"worker"}]}`)
+				_, err := orch.selectScheduledAgents()
+				return err
+			},
+		},
+		{
+			name: "tracked session agent was removed",
+			want: ErrAgentNotFound,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				writeTestAgent(t, orch, "Aster")
+				_, err := orch.resolveTrackedAgent(trackedSession{AgentName: "Ghost"}, map[string]ProjectAgent{})
+				return err
+			},
+		},
+		{
+			name: "nobody hired",
+			want: ErrNoAgents,
+			run: func(t *testing.T, orch *Orchestrator) error {
+				_, err := orch.selectScheduledAgents()
+				return err
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.run(t, newTestOrchestrator(t))
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+			if Remedy(err) == "" {
+				t.Fatalf("expected a remedy for %v", err)
+			}
+		})
+	}
+	if hint := Remedy(fmt.Errorf("unrelated")); hint != "" {
+		t.Fatalf("unexpected remedy for an unrelated error: %q", hint)
+	}
+}
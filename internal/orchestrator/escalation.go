@@ -0,0 +1,168 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Escalation notifier kinds accepted by NewEscalationNotifier.
+const (
+	EscalationNotifierNone    = "none"
+	EscalationNotifierWebhook = "webhook"
+)
+
+// escalationNotifyTimeout bounds a single notification so a slow sink cannot
+// pile up goroutines across a cycle.
+const escalationNotifyTimeout = 10 * time.Second
+
+// Escalation describes an agent question that no orchestrator answered, or a
+// '# need help' entry an agent wrote, that now needs a human.
+type Escalation struct {
+	Cycle    int    `json:"cycle"`
+	Attempt  int    `json:"attempt"`
+	Worktree string `json:"worktree"`
+	Agent    string `json:"agent"`
+	// Question is the path of the question file, or of WORKTREE.md for a
+	// '# need help' entry; Body is the question or the entry.
+	Question string    `json:"question"`
+	Body     string    `json:"body,omitempty"`
+	Reason   string    `json:"reason"`
+	Time     time.Time `json:"time"`
+}
+
+// EscalationNotifier is told about questions escalated to a human.
+// Notifications are sent off the cycle's critical path and a returned error
+// is only logged, so implementations may block on the network.
+type EscalationNotifier interface {
+	NotifyEscalation(ctx context.Context, escalation Escalation) error
+}
+
+// NopEscalationNotifier drops every escalation. It is the default.
+type NopEscalationNotifier struct{}
+
+func (NopEscalationNotifier) NotifyEscalation(context.Context, Escalation) error { return nil }
+
+// WebhookNotifier POSTs each escalation as JSON to URL.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NotifyEscalation posts the escalation and fails on any non-2xx response.
+func (w *WebhookNotifier) NotifyEscalation(ctx context.Context, escalation Escalation) error {
+	body, err := json.Marshal(escalation)
+	if err != nil {
+		return fmt.Errorf("encode escalation: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("post webhook: %s", resp.Status)
+	}
+	return nil
+}
+
+// NewEscalationNotifier builds the notifier named by kind: "none" (or empty)
+// for the no-op notifier, or "webhook", which requires url.
+func NewEscalationNotifier(kind, url string) (EscalationNotifier, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "", EscalationNotifierNone:
+		return NopEscalationNotifier{}, nil
+	case EscalationNotifierWebhook:
+		url = strings.TrimSpace(url)
+		if url == "" {
+			return nil, fmt.Errorf("webhook escalation notifier needs a url")
+		}
+		return &WebhookNotifier{URL: url}, nil
+	default:
+		return nil, fmt.Errorf("unknown escalation notifier %q (want %s or %s)", kind, EscalationNotifierNone, EscalationNotifierWebhook)
+	}
+}
+
+// SetEscalationNotifier registers the notifier told about questions left for
+// a human. Nil restores the no-op default.
+func (o *Orchestrator) SetEscalationNotifier(notifier EscalationNotifier) {
+	if o == nil {
+		return
+	}
+	o.escalations = notifier
+}
+
+// escalateQuestion notifies the configured sink that questionPath needs a
+// human. It returns immediately; a failed notification is logged to the
+// worktree LOG.md and the cycle carries on.
+func (m *upCycleManager) escalateQuestion(cs *cycleSession, questionPath, reason string) {
+	escalation := m.newEscalation(cs, questionPath, reason)
+	if data, err := os.ReadFile(questionPath); err == nil {
+		escalation.Body = strings.TrimSpace(string(data))
+	}
+	m.notifyEscalation(cs, escalation, filepath.Base(questionPath))
+}
+
+// escalateHelpRequests notifies the configured sink of each '# need help'
+// entry in the session's WORKTREE.md it has not already reported.
+func (m *upCycleManager) escalateHelpRequests(cs *cycleSession) {
+	if m.orchestrator.escalations == nil {
+		return
+	}
+	worktreePath := filepath.Join(cs.Path, "WORKTREE.md")
+	for _, entry := range worktreeEntries(worktreePath, needHelpHeading) {
+		if _, seen := cs.helpSeen[entry]; seen {
+			continue
+		}
+		if cs.helpSeen == nil {
+			cs.helpSeen = map[string]struct{}{}
+		}
+		cs.helpSeen[entry] = struct{}{}
+		escalation := m.newEscalation(cs, worktreePath, "agent asked for help under '# need help'")
+		escalation.Body = entry
+		m.notifyEscalation(cs, escalation, "need-help entry")
+	}
+}
+
+func (m *upCycleManager) newEscalation(cs *cycleSession, path, reason string) Escalation {
+	return Escalation{
+		Cycle:    m.cycleNumber,
+		Attempt:  cs.cycle,
+		Worktree: cs.Name,
+		Agent:    cs.Agent.Name,
+		Question: path,
+		Reason:   reason,
+		Time:     time.Now().UTC(),
+	}
+}
+
+// notifyEscalation sends escalation in the background; label names it in
+// the worktree LOG.md if sending fails.
+func (m *upCycleManager) notifyEscalation(cs *cycleSession, escalation Escalation, label string) {
+	notifier := m.orchestrator.escalations
+	if notifier == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), escalationNotifyTimeout)
+		defer cancel()
+		if err := notifier.NotifyEscalation(ctx, escalation); err != nil {
+			_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Escalation notification for %s failed: %v", label, err))
+		}
+	}()
+}
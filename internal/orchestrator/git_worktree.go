@@ -0,0 +1,79 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SetWorktreeBaseBranch makes new worktrees branch from branch instead of the
+// project's current checkout. It returns ErrUnknownBaseBranch when branch does
+// not resolve to a commit. An empty branch restores the default.
+func (o *Orchestrator) SetWorktreeBaseBranch(branch string) error {
+	if o == nil || o.config == nil {
+		return ErrNotInitialized
+	}
+	branch = strings.TrimSpace(branch)
+	if branch != "" {
+		if err := o.verifyBaseBranch(branch); err != nil {
+			return err
+		}
+	}
+	o.worktreeBaseBranch = branch
+	return nil
+}
+
+// verifyBaseBranch returns ErrUnknownBaseBranch when branch does not resolve
+// to a commit in the project repository.
+func (o *Orchestrator) verifyBaseBranch(branch string) error {
+	if _, err := o.runProjectCommand("git", "rev-parse", "--verify", "--quiet", branch+"^{commit}"); err != nil {
+		return fmt.Errorf("%w %s", ErrUnknownBaseBranch, branch)
+	}
+	return nil
+}
+
+// gitWorktreeAvailable reports whether the project is a git repository that
+// supports `git worktree`, which lets lattice manage worktrees without the
+// opencode-worktree plugin.
+func (o *Orchestrator) gitWorktreeAvailable() bool {
+	if o == nil || o.config == nil {
+		return false
+	}
+	_, err := o.runProjectCommand("git", "worktree", "list", "--porcelain")
+	return err == nil
+}
+
+// hasGitWorktree reports whether path is a linked git checkout, which holds
+// a .git file pointing back at the project repository.
+func hasGitWorktree(path string) bool {
+	info, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil && !info.IsDir()
+}
+
+// createGitWorktree checks out a new branch named after the session at path,
+// starting from the configured base branch when set. -B resets a stale
+// branch left behind by an aborted cycle. path must not exist yet.
+func (o *Orchestrator) createGitWorktree(name, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to prepare git worktree directory: %w", err)
+	}
+	args := []string{"worktree", "add", "-B", name, path}
+	if o.worktreeBaseBranch != "" {
+		args = append(args, o.worktreeBaseBranch)
+	}
+	if _, err := o.runProjectCommand("git", args...); err != nil {
+		return fmt.Errorf("%w %s with git: %w", ErrWorktreeCreate, name, err)
+	}
+	return nil
+}
+
+// deleteGitWorktree removes the checkout at path but keeps the branch so
+// landed work is never discarded.
+func (o *Orchestrator) deleteGitWorktree(name, path string) error {
+	if _, err := o.runProjectCommand("git", "worktree", "remove", "--force", path); err != nil {
+		return fmt.Errorf("%w %s with git: %w", ErrWorktreeDelete, name, err)
+	}
+	_, _ = o.runProjectCommand("git", "worktree", "prune")
+	return nil
+}
@@ -0,0 +1,144 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+)
+
+type fakeRunner struct {
+	calls []string
+}
+
+func (f *fakeRunner) run(dir, name string, args ...string) (string, error) {
+	call := strings.TrimSpace(name + " " + strings.Join(args, " "))
+	f.calls = append(f.calls, call)
+	if name == "git" {
+		return "", nil
+	}
+	return "", fmt.Errorf("%s: executable file not found", name)
+}
+
+func (f *fakeRunner) called(prefix string) bool {
+	for _, call := range f.calls {
+		if strings.HasPrefix(call, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestOrchestrator(t *testing.T) *Orchestrator {
+	t.Helper()
+	projectDir := t.TempDir()
+	cfg := &config.Config{
+		ProjectDir:        projectDir,
+		LatticeProjectDir: filepath.Join(projectDir, config.LatticeDir),
+		LatticeRoot:       projectDir,
+	}
+	return New(cfg)
+}
+
+func TestWorktreeFallsBackToGitWhenPluginMissing(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	runner := &fakeRunner{}
+	orch.runCommand = runner.run
+
+	slots := []AssignmentSlot{
+		{Agent: ProjectAgent{Name: "Aster"}, Beads: []Bead{{ID: "lat-1", Points: 1}}},
+		{Agent: ProjectAgent{Name: "Birch"}, Beads: []Bead{{ID: "lat-2", Points: 1}}},
+	}
+	sessions, err := orch.createWorktreeSessions(slots, 1)
+	if err != nil {
+		t.Fatalf("create sessions: %v", err)
+	}
+	if orch.nativeWorktrees {
+		t.Fatalf("a per-session fallback must not switch the orchestrator to native mode")
+	}
+	for _, session := range sessions {
+		if want := filepath.Join(orch.config.WorktreeDir(), strconv.Itoa(session.Number), session.Name); session.Path != want {
+			t.Fatalf("session path = %s, want %s", session.Path, want)
+		}
+		if !runner.called("opencode worktree_create " + session.Name) {
+			t.Fatalf("expected the plugin to be tried for %s, got %v", session.Name, runner.calls)
+		}
+		if !runner.called("git worktree add -B " + session.Name + " " + session.Path) {
+			t.Fatalf("expected the checkout at the session path, got %v", runner.calls)
+		}
+		log, err := os.ReadFile(filepath.Join(session.Path, "LOG.md"))
+		if err != nil {
+			t.Fatalf("read session log: %v", err)
+		}
+		if !strings.Contains(string(log), "checked it out with git worktree instead") {
+			t.Fatalf("session log should explain the fallback:\n%s", log)
+		}
+	}
+
+	// git writes a .git file into the checkout; the fake runner does not.
+	path := sessions[0].Path
+	if err := os.WriteFile(filepath.Join(path, ".git"), []byte("gitdir: elsewhere\n"), 0644); err != nil {
+		t.Fatalf("write .git: %v", err)
+	}
+	if err := orch.invokeWorktreeDelete(sessions[0].Name, path, ""); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if !runner.called("git worktree remove --force " + path) {
+		t.Fatalf("expected git worktree remove, got %v", runner.calls)
+	}
+}
+
+func TestWorktreeCreateFailsWithoutPluginOrGit(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		return "", fmt.Errorf("%s unavailable", name)
+	}
+	if _, err := orch.invokeWorktreeCreate("tree-2", t.TempDir()); err == nil {
+		t.Fatalf("expected error when neither plugin nor git is available")
+	}
+}
+
+func TestWorktreeCreatePassesConfiguredBaseBranch(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	runner := &fakeRunner{}
+	orch.runCommand = runner.run
+
+	if err := orch.SetWorktreeBaseBranch("main"); err != nil {
+		t.Fatalf("set base branch: %v", err)
+	}
+	if !runner.called("git rev-parse --verify --quiet main^{commit}") {
+		t.Fatalf("expected the base branch to be validated, got %v", runner.calls)
+	}
+	path := filepath.Join(t.TempDir(), "tree-3")
+	if _, err := orch.invokeWorktreeCreate("tree-3", path); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !runner.called("opencode worktree_create tree-3 --base main") {
+		t.Fatalf("expected the plugin to receive the base branch, got %v", runner.calls)
+	}
+	if !runner.called("git worktree add -B tree-3 " + path + " main") {
+		t.Fatalf("expected git worktree add from main, got %v", runner.calls)
+	}
+
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		return "", fmt.Errorf("exit status 1")
+	}
+	err := orch.SetWorktreeBaseBranch("release/9.9")
+	if !errors.Is(err, ErrUnknownBaseBranch) {
+		t.Fatalf("expected ErrUnknownBaseBranch, got %v", err)
+	}
+	if orch.worktreeBaseBranch != "main" {
+		t.Fatalf("a rejected branch must not replace the configured one, got %q", orch.worktreeBaseBranch)
+	}
+
+	// main has since been deleted: the session fails rather than starting
+	// from the current checkout.
+	if _, err := orch.invokeWorktreeCreate("tree-4", filepath.Join(t.TempDir(), "tree-4")); !errors.Is(err, ErrUnknownBaseBranch) {
+		t.Fatalf("expected a vanished base branch to fail the create, got %v", err)
+	}
+}
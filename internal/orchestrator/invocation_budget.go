@@ -0,0 +1,39 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// allowInvocation charges one opencode launch against
+// UpCycleConfig.InvocationBudget. Critical launches (first agent attempts,
+// orchestrator reviews, summaries, and landing) always run and are counted;
+// once the budget is spent, other launches are refused and recorded so the
+// down-cycle log can report what was deferred or skipped.
+func (m *upCycleManager) allowInvocation(what string, critical bool) bool {
+	m.budgetMu.Lock()
+	defer m.budgetMu.Unlock()
+	limit := m.config.InvocationBudget
+	if limit > 0 && m.invocations >= limit && !critical {
+		m.budgetSkipped = append(m.budgetSkipped, what)
+		return false
+	}
+	m.invocations++
+	return true
+}
+
+// invocationBudgetLine summarizes budget use for the down-cycle log, or
+// returns "" when no budget is configured.
+func (m *upCycleManager) invocationBudgetLine() string {
+	m.budgetMu.Lock()
+	defer m.budgetMu.Unlock()
+	limit := m.config.InvocationBudget
+	if limit <= 0 {
+		return ""
+	}
+	line := fmt.Sprintf("Invocation budget: %d of %d opencode launch(es) used", m.invocations, limit)
+	if len(m.budgetSkipped) > 0 {
+		line += fmt.Sprintf("; budget hit, deferred or skipped: %s", strings.Join(m.budgetSkipped, "; "))
+	}
+	return line
+}
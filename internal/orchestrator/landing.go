@@ -0,0 +1,90 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LandingConflictError reports a worktree that was not clean after its landing
+// session, usually because `git pull --rebase` hit conflicts the agent could
+// not resolve. The worktree is left in place so a human can finish the merge.
+type LandingConflictError struct {
+	Worktree string
+	Path     string
+	// Conflicts lists unmerged paths from `git status --porcelain`.
+	Conflicts []string
+	// Pending lists the remaining uncommitted paths.
+	Pending []string
+	// Rebasing is set when a rebase was left in progress.
+	Rebasing bool
+}
+
+func (e *LandingConflictError) Error() string {
+	switch {
+	case len(e.Conflicts) > 0:
+		msg := fmt.Sprintf("worktree %s needs a human merge: merge conflicts in %s", e.Worktree, strings.Join(e.Conflicts, ", "))
+		if e.Rebasing {
+			msg += " (rebase in progress)"
+		}
+		return msg + fmt.Sprintf("; resolve them in %s before rerunning the cycle", e.Path)
+	case e.Rebasing:
+		return fmt.Sprintf("worktree %s needs a human merge: a rebase is still in progress in %s", e.Worktree, e.Path)
+	default:
+		return fmt.Sprintf("worktree %s still has uncommitted changes after landing (%s); it was kept at %s", e.Worktree, strings.Join(e.Pending, ", "), e.Path)
+	}
+}
+
+// unmergedStatusCodes are the porcelain XY codes git uses for conflicts.
+var unmergedStatusCodes = map[string]bool{
+	"DD": true, "AU": true, "UD": true, "UA": true, "DU": true, "AA": true, "UU": true,
+}
+
+// checkLandedWorktree verifies that landing left the worktree clean. A dirty
+// worktree yields a *LandingConflictError naming any conflicted files, and
+// the details are appended to the worktree LOG.md.
+func (o *Orchestrator) checkLandedWorktree(session WorktreeSession) error {
+	output, err := o.command(session.Path, "git", "status", "--porcelain")
+	if err != nil {
+		return fmt.Errorf("git status failed in %s: %w", session.Path, err)
+	}
+	landErr := &LandingConflictError{Worktree: session.Name, Path: session.Path}
+	for _, line := range strings.Split(output, "\n") {
+		if len(strings.TrimSpace(line)) == 0 || len(line) < 4 {
+			continue
+		}
+		path := strings.TrimSpace(line[3:])
+		if unmergedStatusCodes[line[:2]] {
+			landErr.Conflicts = append(landErr.Conflicts, path)
+		} else {
+			landErr.Pending = append(landErr.Pending, path)
+		}
+	}
+	landErr.Rebasing = o.rebaseInProgress(session.Path)
+	if len(landErr.Conflicts) == 0 && len(landErr.Pending) == 0 && !landErr.Rebasing {
+		return nil
+	}
+	_ = appendWorktreeLog(session, fmt.Sprintf("Landing needs attention: %s\n\n```\n%s```", landErr.Error(), output))
+	return landErr
+}
+
+func (o *Orchestrator) rebaseInProgress(dir string) bool {
+	for _, name := range []string{"rebase-merge", "rebase-apply"} {
+		output, err := o.command(dir, "git", "rev-parse", "--git-path", name)
+		if err != nil {
+			continue
+		}
+		path := strings.TrimSpace(output)
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
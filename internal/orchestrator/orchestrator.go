@@ -20,13 +20,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/uuid"
-	"github.com/kingrea/The-Lattice/internal/community"
 	"github.com/kingrea/The-Lattice/internal/config"
 	"github.com/kingrea/The-Lattice/internal/eventbridge"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 	"github.com/kingrea/The-Lattice/internal/skills"
 )
 
@@ -52,6 +53,7 @@ type WorkerRef struct {
 // WorkerList tracks which denizens are working on a project
 type WorkerList struct {
 	Orchestrator *WorkerRef  `json:"orchestrator,omitempty"` // Selected orchestrator
+	CoConductors []WorkerRef `json:"coConductors,omitempty"` // Extra orchestrators sharing large cycles
 	Workers      []WorkerRef `json:"workers,omitempty"`      // Worker denizens
 	UpdatedAt    string      `json:"updatedAt"`              // ISO timestamp
 }
@@ -93,6 +95,94 @@ type Orchestrator struct {
 	windowName  string
 	bridgeURL   string
 	eventRouter *eventbridge.Router
+	// runCommand executes external tools; swapped out in tests.
+	runCommand commandRunner
+	// commandLog records each command run through runCommand; nil records
+	// nothing.
+	commandLog CommandLogger
+	// nativeWorktrees is set when worktrees are managed with plain git because
+	// the opencode-worktree plugin is unavailable.
+	nativeWorktrees bool
+	// worktreeBaseBranch is the branch new worktrees start from; empty uses
+	// the project's current checkout.
+	worktreeBaseBranch string
+	// maxStalledCycles overrides UpCycleConfig.MaxStalledCycles when positive.
+	maxStalledCycles int
+	// maxBlockedCycles overrides defaultMaxBlockedCycles when positive.
+	maxBlockedCycles int
+	// minAgentsPerCycle holds PrepareWorkCycle until that many agents can be
+	// scheduled; zero starts a cycle with any number of agents.
+	minAgentsPerCycle int
+	// sparkPolicy governs SPARK roster entries; empty defers to
+	// LATTICE_ASSIGN_SPARK.
+	sparkPolicy SparkPolicy
+	// solo schedules a single agent, and so a single session, per cycle.
+	solo bool
+	// strictBeadIDs fails sessions whose events name unassigned bead IDs.
+	strictBeadIDs bool
+	// beadOrder selects the primary sort key for ready beads.
+	beadOrder BeadOrder
+	// assignment distributes a cycle's beads across agents; nil is balanced.
+	assignment AssignmentStrategy
+	// roleRouting sends role:-tagged beads to matching agents first.
+	roleRouting bool
+	// progress is notified of up-cycle milestones; nil reports nothing.
+	progress ProgressReporter
+	// escalations is told about questions left for a human; nil is silent.
+	escalations EscalationNotifier
+	// events receives structured up-cycle events; nil emits nothing.
+	events EventSink
+	// beadSubscriber overrides bd watch detection in WatchReadyBeads;
+	// readyFeed holds the ready set it last pushed. readyWatchMu keeps
+	// SharedReadyBeads from starting two subscriptions at once.
+	beadSubscriber BeadSubscriber
+	readyFeedMu    sync.Mutex
+	readyFeed      *readyBeadFeed
+	readyWatchMu   sync.Mutex
+	// sessionLimits constrains the resources of launched opencode sessions.
+	sessionLimits SessionLimits
+	// repoMemory adds state/REPO_MEMORY.md to agent prompts; repoMemoryMaxBytes
+	// overrides UpCycleConfig.RepoMemoryMaxBytes when positive.
+	repoMemory         bool
+	repoMemoryMaxBytes int64
+	// maxUnrelatedBugBeads caps unrelated-bug beads per cycle; zero is no cap.
+	maxUnrelatedBugBeads int
+	// maxPromptBeads caps the beads listed inline in agent prompts; zero
+	// lists them all.
+	maxPromptBeads int
+	// cycleCooldown delays the restart into the next cycle.
+	cycleCooldown time.Duration
+	// invocationBudget caps opencode launches per cycle when positive.
+	invocationBudget int
+	// maxAutoResponses caps concurrent question auto-responses across all
+	// sessions when positive.
+	maxAutoResponses int
+	// annotateCompletedBeads comments on completed beads in bd.
+	annotateCompletedBeads bool
+	// offloadOverCapacity moves unstarted beads off over-capacity sessions.
+	offloadOverCapacity bool
+	// beadEstimatesMu guards the re-estimates recorded for later cycles.
+	beadEstimatesMu sync.Mutex
+	// reviewOnly runs cycles that collect findings instead of landing code.
+	reviewOnly bool
+	// cycleMetadata holds free-form notes recorded with each cycle.
+	cycleMetadata map[string]string
+	// archiveRetention caps the archived cycles kept per session; zero keeps
+	// them all.
+	archiveRetention int
+	// sessions tracks exit markers for opencode runs launched in tmux.
+	sessions sessionTracker
+	// beadSyncInterval spaces the `bd sync` runs between agent cycles; zero
+	// disables them. lastBeadSync is when the last one was attempted.
+	beadSyncInterval time.Duration
+	beadSyncMu       sync.Mutex
+	lastBeadSync     time.Time
+	// now is the clock used for bead sync scheduling; nil uses time.Now.
+	now func() time.Time
+	// bdFormat is the bead JSON profile picked by probing `bd --version`
+	// once, on first use.
+	bdProbe  sync.Once
+	bdFormat bdProfile
 }
 
 const (
@@ -119,6 +209,7 @@ func New(cfg *config.Config) *Orchestrator {
 	return &Orchestrator{
 		config:     cfg,
 		windowName: "opencode-worker",
+		runCommand: execCommand,
 	}
 }
 
@@ -131,6 +222,249 @@ func (o *Orchestrator) AttachEventBridge(url string, router *eventbridge.Router)
 	o.eventRouter = router
 }
 
+// SetStrictBeadIDs makes RunUpCycle fail a session with ErrUnknownBeadIDs
+// when its agent reports bead IDs it was not assigned. Otherwise those IDs
+// are only logged to the worktree LOG.md.
+func (o *Orchestrator) SetStrictBeadIDs(strict bool) {
+	if o == nil {
+		return
+	}
+	o.strictBeadIDs = strict
+}
+
+// SetRepoMemory makes agent prompts reference state/REPO_MEMORY.md so agents
+// load repo-wide learnings first. maxBytes caps the size agents are told to
+// load in full; non-positive values keep the default.
+func (o *Orchestrator) SetRepoMemory(enabled bool, maxBytes int64) {
+	if o == nil {
+		return
+	}
+	o.repoMemory = enabled
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	o.repoMemoryMaxBytes = maxBytes
+}
+
+func (o *Orchestrator) applyRepoMemory(cfg *UpCycleConfig) {
+	cfg.RepoMemory = o.repoMemory
+	if o.repoMemoryMaxBytes > 0 {
+		cfg.RepoMemoryMaxBytes = o.repoMemoryMaxBytes
+	}
+}
+
+func (o *Orchestrator) repoMemoryPath() string {
+	return filepath.Join(o.config.StateDir(), "REPO_MEMORY.md")
+}
+
+// SetMaxUnrelatedBugBeads caps how many beads post-cycle orchestrators create
+// from '# unrelated bugs' entries per cycle. Entries past the cap are folded
+// into a single "misc bugs" bead per session. Non-positive values remove the
+// cap.
+func (o *Orchestrator) SetMaxUnrelatedBugBeads(n int) {
+	if o == nil {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.maxUnrelatedBugBeads = n
+}
+
+// SetMaxPromptBeads caps how many assigned beads an agent prompt lists
+// inline. The rest are summarized with a pointer to WORKTREE.md, which keeps
+// the full list. Non-positive values list every bead.
+func (o *Orchestrator) SetMaxPromptBeads(n int) {
+	if o == nil {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.maxPromptBeads = n
+}
+
+// SetInvocationBudget caps how many opencode sessions a cycle may launch.
+// Once spent, agent retries wait for the next cycle and auto-answers and
+// dreaming are skipped. Non-positive values remove the cap.
+func (o *Orchestrator) SetInvocationBudget(n int) {
+	if o == nil {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.invocationBudget = n
+}
+
+// SetMaxConcurrentAutoResponses caps how many question auto-responses may run
+// at once across every session of a cycle. Questions past the cap wait for a
+// free slot. Non-positive values remove the cap.
+func (o *Orchestrator) SetMaxConcurrentAutoResponses(n int) {
+	if o == nil {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.maxAutoResponses = n
+}
+
+// SetAnnotateCompletedBeads makes RunUpCycle comment on each bead an agent
+// reports completed with the agent's name and the cycle number. Bead stores
+// that reject the comment are logged once and left alone for the cycle.
+func (o *Orchestrator) SetAnnotateCompletedBeads(enabled bool) {
+	if o == nil {
+		return
+	}
+	o.annotateCompletedBeads = enabled
+}
+
+// SetOffloadOverCapacity makes RunUpCycle move beads an agent has not started
+// off its session when the remaining points, after any re-estimates in the
+// agent's event, exceed the agent's capacity. The beads stay open in bd for
+// the next work cycle.
+func (o *Orchestrator) SetOffloadOverCapacity(enabled bool) {
+	if o == nil {
+		return
+	}
+	o.offloadOverCapacity = enabled
+}
+
+// SetReviewOnly makes RunUpCycle run review-only cycles: agents are told not
+// to commit, their reports under outbox/reports/ are copied into the
+// down-cycle log, and landing plus its git checks are skipped.
+func (o *Orchestrator) SetReviewOnly(enabled bool) {
+	if o == nil {
+		return
+	}
+	o.reviewOnly = enabled
+}
+
+// SetCycleCooldown makes RunUpCycle wait d after landing a cycle before it
+// restarts the orchestrator for the next one. Cancelling the run's context
+// ends the wait early. Non-positive values restart immediately.
+func (o *Orchestrator) SetCycleCooldown(d time.Duration) {
+	if o == nil {
+		return
+	}
+	if d < 0 {
+		d = 0
+	}
+	o.cycleCooldown = d
+}
+
+// SetMaxStalledCycles sets how many consecutive cycles may finish without
+// closing a bead before RunUpCycle returns ErrNoProgress. Non-positive values
+// restore the default.
+func (o *Orchestrator) SetMaxStalledCycles(n int) {
+	if o == nil {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.maxStalledCycles = n
+}
+
+// SetMaxBlockedCycles sets how many cycles a bead may stay blocked before
+// PrepareWorkCycle reports it in logs/stuck-beads.log. Non-positive values
+// restore the default.
+func (o *Orchestrator) SetMaxBlockedCycles(n int) {
+	if o == nil {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.maxBlockedCycles = n
+}
+
+// SetArchiveRetention keeps only the last n cycles of archived WORKTREE.md
+// files and events in each session's archive/. Non-positive values keep every
+// cycle.
+func (o *Orchestrator) SetArchiveRetention(n int) {
+	if o == nil {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.archiveRetention = n
+}
+
+// SetMinAgentsPerCycle makes PrepareWorkCycle return ErrTooFewAgents instead
+// of staging a cycle with fewer than n scheduled agents. Non-positive values
+// disable the guard.
+func (o *Orchestrator) SetMinAgentsPerCycle(n int) {
+	if o == nil {
+		return
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.minAgentsPerCycle = n
+}
+
+// SetSparkPolicy decides whether PrepareWorkCycle schedules SPARK placeholder
+// agents. The zero value defers to LATTICE_ASSIGN_SPARK, then SparkPolicySkip.
+func (o *Orchestrator) SetSparkPolicy(policy SparkPolicy) {
+	if o == nil {
+		return
+	}
+	o.sparkPolicy = policy
+}
+
+// SetSolo makes PrepareWorkCycle schedule only the first rostered agent, so
+// every cycle runs one session. The minimum-agent guard does not apply.
+func (o *Orchestrator) SetSolo(enabled bool) {
+	if o == nil {
+		return
+	}
+	o.solo = enabled
+}
+
+// SetBeadOrder selects the primary sort key PrepareWorkCycle uses for ready
+// beads. The zero value keeps the default points-first order.
+func (o *Orchestrator) SetBeadOrder(order BeadOrder) {
+	if o == nil {
+		return
+	}
+	o.beadOrder = order
+}
+
+// SetAssignmentStrategy selects how PrepareWorkCycle distributes beads
+// across the scheduled agents. A nil strategy restores the default balanced
+// heuristic.
+func (o *Orchestrator) SetAssignmentStrategy(strategy AssignmentStrategy) {
+	if o == nil {
+		return
+	}
+	o.assignment = strategy
+}
+
+// SetRoleRouting makes PrepareWorkCycle give beads tagged role:<name> to an
+// agent whose AGENT.md role mentions <name> while one has capacity left.
+// Other beads, and role-tagged ones no matching agent has room for, go
+// through the assignment strategy as usual.
+func (o *Orchestrator) SetRoleRouting(enabled bool) {
+	if o == nil {
+		return
+	}
+	o.roleRouting = enabled
+}
+
+func (o *Orchestrator) assignmentStrategy() AssignmentStrategy {
+	var strategy AssignmentStrategy = balancedStrategy{}
+	if o.assignment != nil {
+		strategy = o.assignment
+	}
+	if o.roleRouting {
+		return roleRoutedStrategy{next: strategy}
+	}
+	return strategy
+}
+
 // BridgeURL returns the currently attached bridge base URL.
 func (o *Orchestrator) BridgeURL() string {
 	if o == nil {
@@ -180,16 +514,16 @@ func (o *Orchestrator) createTmuxWindow(name string) error {
 	if name == "" {
 		name = o.windowName
 	}
-	cmd := exec.Command("tmux", "new-window", "-n", name)
-	return cmd.Run()
+	_, err := o.command("", "tmux", "new-window", "-n", name)
+	return err
 }
 
 func (o *Orchestrator) createTmuxWindowInDir(name, dir string) error {
 	if dir == "" {
 		return o.createTmuxWindow(name)
 	}
-	cmd := exec.Command("tmux", "new-window", "-n", name, "-c", dir)
-	return cmd.Run()
+	_, err := o.command("", "tmux", "new-window", "-n", name, "-c", dir)
+	return err
 }
 
 // killTmuxWindow closes the worker window
@@ -197,11 +531,8 @@ func (o *Orchestrator) killTmuxWindow(name string) error {
 	if name == "" {
 		name = o.windowName
 	}
-	cmd := exec.Command("tmux", "kill-window", "-t", name)
-	if err := cmd.Run(); err != nil {
-		return err
-	}
-	return nil
+	_, err := o.command("", "tmux", "kill-window", "-t", name)
+	return err
 }
 
 // runOpenCode sends the OpenCode command to the tmux window and falls back to the
@@ -219,8 +550,6 @@ func (o *Orchestrator) runOpenCodeWithOptions(prompt string, windowName string,
 	if windowName == "" {
 		windowName = o.windowName
 	}
-	escapedPrompt := strings.ReplaceAll(prompt, `"`, `\"`)
-	escapedPrompt = strings.ReplaceAll(escapedPrompt, "\n", " ")
 	args := []string{"opencode"}
 	normalizedAgent := strings.TrimSpace(agentName)
 	if normalizedAgent == "" && allowFallback {
@@ -229,14 +558,27 @@ func (o *Orchestrator) runOpenCodeWithOptions(prompt string, windowName string,
 	if normalizedAgent != "" {
 		slug := slugifyToken(normalizedAgent)
 		if slug != "" {
-			escapedAgent := strings.ReplaceAll(slug, `"`, `\"`)
-			args = append(args, fmt.Sprintf(`--agent "%s"`, escapedAgent))
+			args = append(args, "--agent "+shellQuote(slug))
 		}
 	}
-	args = append(args, fmt.Sprintf(`--prompt "%s"`, escapedPrompt))
-	opencodeCmd := strings.Join(args, " ")
-	cmd := exec.Command("tmux", "send-keys", "-t", windowName, opencodeCmd, "Enter")
-	return cmd.Run()
+	session, err := o.trackSession(windowName, normalizedAgent)
+	if err != nil {
+		return err
+	}
+	promptArg, err := opencode.PromptArg(prompt, session.PromptPath)
+	if err != nil {
+		o.forgetSession(windowName)
+		return err
+	}
+	args = append(args, promptArg)
+	opencodeCmd := limitSessionCommand(strings.Join(args, " "), o.sessionLimits)
+	// Mirror pane output into the session log; failure only loses the transcript.
+	_, _ = o.command("", "tmux", "pipe-pane", "-o", "-t", windowName, "cat >> "+shellQuote(session.LogPath))
+	if _, err := o.command("", "tmux", "send-keys", "-t", windowName, wrapSessionCommand(opencodeCmd, session), "Enter"); err != nil {
+		o.forgetSession(windowName)
+		return err
+	}
+	return nil
 }
 
 func (o *Orchestrator) restartInitialPromptWithCycle(cycle int) error {
@@ -290,55 +632,26 @@ func (o *Orchestrator) waitForCompletion() error {
 	}
 }
 
-// LoadDenizenCVs reads cv.md files from all communities in the Lattice
+// LoadDenizenCVs reads cv.md files from all communities in the Lattice. The
+// parsed denizens are cached in the project's CV index and reused until a
+// community directory, community.yaml, or cv.md changes; RefreshCVIndex forces
+// a rescan. A failure to save the index is logged to logs/cv-index.log.
 func (o *Orchestrator) LoadDenizenCVs() ([]Agent, error) {
 	communitiesDir := o.config.CommunitiesDir()
+	indexPath := CVIndexPath(o.config)
+	if index, ok := loadCVIndex(indexPath, communitiesDir); ok {
+		return index.Agents, nil
+	}
 
-	var agents []Agent
-
-	communities, err := os.ReadDir(communitiesDir)
+	index, err := o.scanDenizenCVs(communitiesDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read communities dir: %w", err)
+		return nil, err
 	}
-
-	for _, entry := range communities {
-		if !entry.IsDir() {
-			continue
-		}
-
-		communityRoot := filepath.Join(communitiesDir, entry.Name())
-		cfg, err := community.Load(communityRoot)
-		if err != nil {
-			continue
-		}
-
-		communityName := strings.TrimSpace(cfg.Config.Name)
-		if communityName == "" {
-			communityName = entry.Name()
-		}
-
-		denizensDir := cfg.CVsPath()
-		denizens, err := os.ReadDir(denizensDir)
-		if err != nil {
-			continue // Community might not have denizens yet
-		}
-
-		for _, denizen := range denizens {
-			if !denizen.IsDir() {
-				continue // Skip files like denizen-file-structure.md
-			}
-
-			cvPath := filepath.Join(denizensDir, denizen.Name(), "cv.md")
-			agent, err := o.parseCVFile(cvPath, communityName)
-			if err != nil {
-				continue // Skip denizens without CVs
-			}
-
-			agents = append(agents, agent)
-		}
+	if err := index.save(indexPath); err != nil {
+		_ = o.appendCVIndexLog(fmt.Sprintf("could not save %s (%v); the next load rescans every community", indexPath, err))
 	}
 
-	return agents, nil
+	return index.Agents, nil
 }
 
 // parseCVFile parses a cv.md file into an Agent struct
@@ -472,7 +785,7 @@ func (o *Orchestrator) CurrentWorkerList() WorkerList {
 // LoadProjectAgents returns the generated project agents available to the orchestrator.
 func (o *Orchestrator) LoadProjectAgents() ([]ProjectAgent, error) {
 	if o == nil {
-		return nil, fmt.Errorf("orchestrator not initialized")
+		return nil, ErrNotInitialized
 	}
 	return o.loadProjectAgents()
 }
@@ -481,7 +794,7 @@ func (o *Orchestrator) LoadProjectAgents() ([]ProjectAgent, error) {
 // perspective of the provided role and writes the audit markdown file.
 func (o *Orchestrator) RunStakeholderAudit(role string, agent ProjectAgent, auditPath string, projectDesc string) error {
 	if o == nil {
-		return fmt.Errorf("orchestrator not initialized")
+		return ErrNotInitialized
 	}
 	if strings.TrimSpace(role) == "" {
 		return fmt.Errorf("stakeholder role is required")
@@ -501,7 +814,7 @@ func (o *Orchestrator) RunStakeholderAudit(role string, agent ProjectAgent, audi
 	if err := o.runOpenCode(prompt, window, agent.Name); err != nil {
 		return fmt.Errorf("failed to start audit session: %w", err)
 	}
-	return o.waitForFile(auditPath, 5*time.Minute)
+	return o.waitForSessionFile(window, auditPath, 5*time.Minute)
 }
 
 // RunAuditSynthesis asks the orchestrator agent to read all audit files and
@@ -509,7 +822,7 @@ func (o *Orchestrator) RunStakeholderAudit(role string, agent ProjectAgent, audi
 // indicate completion.
 func (o *Orchestrator) RunAuditSynthesis(auditDir string, projectDesc string) (string, error) {
 	if o == nil {
-		return "", fmt.Errorf("orchestrator not initialized")
+		return "", ErrNotInitialized
 	}
 	if err := os.MkdirAll(auditDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to prepare audit dir: %w", err)
@@ -524,7 +837,7 @@ func (o *Orchestrator) RunAuditSynthesis(auditDir string, projectDesc string) (s
 	if err := o.runOpenCode(prompt, window, ""); err != nil {
 		return "", fmt.Errorf("failed to start audit synthesis: %w", err)
 	}
-	if err := o.waitForFile(summaryPath, 5*time.Minute); err != nil {
+	if err := o.waitForSessionFile(window, summaryPath, 5*time.Minute); err != nil {
 		return "", err
 	}
 	return summaryPath, nil
@@ -534,7 +847,7 @@ func (o *Orchestrator) RunAuditSynthesis(auditDir string, projectDesc string) (s
 // manual review conversation and returns the window name for later cleanup.
 func (o *Orchestrator) LaunchManualReviewSession(projectDesc string) (string, error) {
 	if o == nil {
-		return "", fmt.Errorf("orchestrator not initialized")
+		return "", ErrNotInitialized
 	}
 	window := fmt.Sprintf("manual-review-%d", time.Now().UnixNano())
 	if err := o.createTmuxWindow(window); err != nil {
@@ -657,7 +970,7 @@ func (o *Orchestrator) runAgentSkill(agent Agent, role, roleContext, sourceDir,
 		return fmt.Errorf("failed to send OpenCode prompt: %w", err)
 	}
 
-	return o.waitForFile(targetFile, 5*time.Minute)
+	return o.waitForSessionFile(windowName, targetFile, 5*time.Minute)
 }
 
 func (o *Orchestrator) buildAgentSkillPrompt(agent Agent, role, roleContext, sourceDir, targetFile, skillPath string, strict bool) string {
@@ -674,24 +987,6 @@ func (o *Orchestrator) buildAgentSkillPrompt(agent Agent, role, roleContext, sou
 	return b.String()
 }
 
-func (o *Orchestrator) waitForFile(path string, timeout time.Duration) error {
-	deadline := time.After(timeout)
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-deadline:
-			return fmt.Errorf("timed out waiting for %s", path)
-		case <-ticker.C:
-			info, err := os.Stat(path)
-			if err == nil && info.Size() > 0 {
-				return nil
-			}
-		}
-	}
-}
-
 func (o *Orchestrator) notifyTmux(message string) error {
 	cmd := exec.Command("tmux", "display-message", message)
 	return cmd.Run()
@@ -749,6 +1044,58 @@ func (o *Orchestrator) ensureOrchestratorAgentFile(agent Agent) error {
 	return err
 }
 
+// SetCoConductors records additional orchestrators that share question
+// handling and post-cycle reviews with the primary orchestrator. Passing no
+// names clears the list.
+func (o *Orchestrator) SetCoConductors(names ...string) error {
+	workerListPath := o.config.WorkerListPath()
+	workerList := o.loadWorkerList(workerListPath)
+	primary := ""
+	if workerList.Orchestrator != nil {
+		primary = strings.ToLower(strings.TrimSpace(workerList.Orchestrator.Name))
+	}
+	seen := make(map[string]struct{})
+	workerList.CoConductors = nil
+	for _, name := range names {
+		trimmed := strings.TrimSpace(name)
+		key := strings.ToLower(trimmed)
+		if trimmed == "" || key == primary {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		workerList.CoConductors = append(workerList.CoConductors, WorkerRef{Name: trimmed})
+	}
+	workerList.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	if err := os.MkdirAll(filepath.Dir(workerListPath), 0755); err != nil {
+		return fmt.Errorf("failed to prepare state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(workerList, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal worker list: %w", err)
+	}
+	return os.WriteFile(workerListPath, data, 0644)
+}
+
+// conductorNames lists the primary orchestrator followed by any co-conductors
+// recorded in the worker list.
+func (o *Orchestrator) conductorNames() []string {
+	workerList := o.loadWorkerList(o.config.WorkerListPath())
+	var names []string
+	if workerList.Orchestrator != nil && strings.TrimSpace(workerList.Orchestrator.Name) != "" {
+		names = append(names, strings.TrimSpace(workerList.Orchestrator.Name))
+	}
+	for _, ref := range workerList.CoConductors {
+		if name := strings.TrimSpace(ref.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // AddWorker adds a worker denizen to the worker list
 func (o *Orchestrator) AddWorker(agentName string) error {
 	workerListPath := o.config.WorkerListPath()
@@ -783,8 +1130,34 @@ func (o *Orchestrator) AddWorker(agentName string) error {
 // RefreshOpenCodeConfig writes opencode.jsonc describing project agents and plugins.
 func (o *Orchestrator) RefreshOpenCodeConfig() error {
 	if o == nil || o.config == nil {
-		return fmt.Errorf("orchestrator is not initialized")
+		return ErrNotInitialized
+	}
+	return o.refreshOpenCodeConfig(o.currentOrchestratorAgent())
+}
+
+// RefreshOpenCodeConfigFor rewrites opencode.jsonc with the named orchestrator
+// as the default agent. The name must match a generated project agent.
+func (o *Orchestrator) RefreshOpenCodeConfigFor(name string) error {
+	if o == nil || o.config == nil {
+		return ErrNotInitialized
+	}
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("orchestrator name is required")
+	}
+	slug := slugifyToken(name)
+	agents, err := o.loadProjectAgents()
+	if err != nil {
+		return err
+	}
+	for _, agent := range agents {
+		if slugifyToken(agent.Name) == slug {
+			return o.refreshOpenCodeConfig(name)
+		}
 	}
+	return fmt.Errorf("%w: no agent file for %s in %s", ErrOrchestratorAgentNotFound, strings.TrimSpace(name), o.config.AgentsDir())
+}
+
+func (o *Orchestrator) refreshOpenCodeConfig(orchestratorName string) error {
 	if err := o.ensureBridgePluginInstalled(); err != nil {
 		return err
 	}
@@ -793,7 +1166,7 @@ func (o *Orchestrator) RefreshOpenCodeConfig() error {
 		return err
 	}
 	if len(agents) == 0 {
-		return fmt.Errorf("no agent files found in %s", o.config.AgentsDir())
+		return fmt.Errorf("%w: no agent files found in %s", ErrNoAgents, o.config.AgentsDir())
 	}
 	plugins := make([]string, len(defaultOpencodePlugins))
 	copy(plugins, defaultOpencodePlugins)
@@ -802,7 +1175,7 @@ func (o *Orchestrator) RefreshOpenCodeConfig() error {
 		Plugin: plugins,
 		Agents: make(map[string]openCodeAgent),
 	}
-	preferred := slugifyToken(o.currentOrchestratorAgent())
+	preferred := slugifyToken(orchestratorName)
 	for _, agent := range agents {
 		slug := slugifyToken(agent.Name)
 		if slug == "" {
@@ -933,6 +1306,26 @@ func (o *Orchestrator) copyOrchestratorFiles(agent Agent) error {
 	return nil
 }
 
+// appendTimestampedLog appends each line to the log at path as
+// "- <RFC3339 UTC time> · <line>", creating the file and its directory.
+func appendTimestampedLog(path string, at time.Time, lines ...string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("prepare %s dir: %w", filepath.Base(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", filepath.Base(path), err)
+	}
+	defer f.Close()
+	timestamp := at.UTC().Format(time.RFC3339)
+	for _, line := range lines {
+		if _, err := fmt.Fprintf(f, "- %s · %s\n", timestamp, line); err != nil {
+			return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
 func copyDir(src, dst string) error {
 	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
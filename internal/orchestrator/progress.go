@@ -0,0 +1,62 @@
+package orchestrator
+
+// ProgressReporter receives the milestones of an up-cycle so a front-end
+// embedding the orchestrator can follow a run without tailing worktree logs.
+// attempt is the session's own cycle counter, which restarts at 1 for every
+// global cycle. Sessions run concurrently, but calls are serialized, so
+// implementations need no locking of their own; they should return quickly
+// because the cycle waits on them.
+type ProgressReporter interface {
+	// SessionStarted fires once an agent has been dispatched to a worktree.
+	SessionStarted(session WorktreeSession, attempt int)
+	// AgentComplete fires when the agent's agent_complete event is read.
+	AgentComplete(session WorktreeSession, attempt int, completed, remaining []string)
+	// OrchestratorDone fires after the orchestrator has reviewed the attempt.
+	OrchestratorDone(session WorktreeSession, attempt int)
+	// CycleLanded fires once every worktree has landed and been removed,
+	// before the next cycle is started.
+	CycleLanded(cycle int)
+}
+
+// NopProgressReporter ignores every milestone. It is the default reporter and
+// can be embedded to implement only the callbacks a caller cares about.
+type NopProgressReporter struct{}
+
+func (NopProgressReporter) SessionStarted(WorktreeSession, int)                    {}
+func (NopProgressReporter) AgentComplete(WorktreeSession, int, []string, []string) {}
+func (NopProgressReporter) OrchestratorDone(WorktreeSession, int)                  {}
+func (NopProgressReporter) CycleLanded(int)                                        {}
+
+// SetProgressReporter registers the reporter RunUpCycle notifies. Nil restores
+// the no-op default.
+func (o *Orchestrator) SetProgressReporter(reporter ProgressReporter) {
+	if o == nil {
+		return
+	}
+	o.progress = reporter
+}
+
+// progressEventSink delivers the cycle events a ProgressReporter has a
+// callback for, so the reporter is driven by the same event stream as the
+// EventSink and every milestone is announced once.
+type progressEventSink struct {
+	reporter ProgressReporter
+}
+
+func (s progressEventSink) Emit(event CycleEvent) {
+	if event.Type == CycleEventCycleLanded {
+		s.reporter.CycleLanded(event.Cycle)
+		return
+	}
+	if event.session == nil {
+		return
+	}
+	switch event.Type {
+	case CycleEventAgentDispatched:
+		s.reporter.SessionStarted(*event.session, event.Attempt)
+	case CycleEventAgentComplete:
+		s.reporter.AgentComplete(*event.session, event.Attempt, event.Beads, event.Remaining)
+	case CycleEventOrchestratorDone:
+		s.reporter.OrchestratorDone(*event.session, event.Attempt)
+	}
+}
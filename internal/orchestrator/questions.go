@@ -0,0 +1,74 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Agents name orchestrator questions cycle-N-<slug>.md and wait for the
+// answer at <same name>.response.md.
+var (
+	questionFilenamePattern = regexp.MustCompile(`^cycle-[0-9]+-[a-z0-9]+(-[a-z0-9]+)*\.md$`)
+	questionCyclePrefix     = regexp.MustCompile(`^cycle-[0-9]+-`)
+)
+
+// questionExtensions are stripped from question filenames before deriving
+// the response name, so cycle-1-x.md.txt routes like cycle-1-x.md.
+var questionExtensions = []string{".md", ".markdown", ".txt"}
+
+// questionStem returns the name a question's response is keyed on: the base
+// filename without any trailing question extensions, or without its last
+// extension when none of those match.
+func questionStem(questionPath string) string {
+	base := filepath.Base(questionPath)
+	stem := strings.TrimSpace(base)
+	for {
+		trimmed := false
+		for _, ext := range questionExtensions {
+			if len(stem) > len(ext) && strings.EqualFold(stem[len(stem)-len(ext):], ext) {
+				stem = strings.TrimSpace(stem[:len(stem)-len(ext)])
+				trimmed = true
+			}
+		}
+		if !trimmed {
+			break
+		}
+	}
+	if stem == base {
+		return strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return stem
+}
+
+// questionFilenameProblem describes how name deviates from cycle-N-<slug>.md,
+// or returns "" when it follows the convention.
+func questionFilenameProblem(name string) string {
+	if questionFilenamePattern.MatchString(name) {
+		return ""
+	}
+	stem := questionStem(name)
+	switch {
+	case filepath.Ext(name) != ".md":
+		return "expected a .md extension"
+	case stem+".md" != name:
+		return "extra extensions or whitespace around the name"
+	case !questionCyclePrefix.MatchString(stem):
+		return "missing the cycle-N- prefix"
+	default:
+		return "slug should use only lowercase letters, digits, and dashes"
+	}
+}
+
+// logQuestionDeviation records a misnamed question file in the session log
+// together with where its response will be routed.
+func logQuestionDeviation(cs *cycleSession, questionPath string) {
+	name := filepath.Base(questionPath)
+	problem := questionFilenameProblem(name)
+	if problem == "" {
+		return
+	}
+	responsePath := responsePathForQuestion(cs.Path, questionPath)
+	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Warning: question file %q does not follow cycle-N-<slug>.md (%s); routing its response to %s", name, problem, filepath.Base(responsePath)))
+}
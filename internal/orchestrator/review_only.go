@@ -0,0 +1,92 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// reviewOnlyLogLine marks a review-only cycle in the down-cycle log.
+const reviewOnlyLogLine = "Review-only cycle: findings collected, no code landed."
+
+// reviewFinding is one report an agent wrote during a review-only cycle.
+type reviewFinding struct {
+	Name string
+	Body string
+}
+
+// reviewReportDir is where agents drop findings during a review-only cycle.
+func reviewReportDir(cs *cycleSession) string {
+	return filepath.Join(cs.Path, "outbox", "reports")
+}
+
+// commitGuidance is the agent prompt step on committing. Review-only cycles
+// swap it for instructions to report findings without touching the code.
+func (m *upCycleManager) commitGuidance(cs *cycleSession) string {
+	if !m.config.ReviewOnly {
+		return "Follow AGENTS.md when committing: clean working tree, run tests, git pull --rebase, bd sync, git push."
+	}
+	return fmt.Sprintf(
+		"This is a review-only cycle. Do not edit code, commit, push, or land the worktree. Investigate your beads and write your findings as markdown to %s (cycle-%d.md), noting the files and lines they concern; keep a short pointer to them in WORKTREE.md.",
+		reviewReportDir(cs),
+		cs.cycle,
+	)
+}
+
+// readReviewFindings loads the markdown reports a session wrote to its
+// outbox, in name order. A session that wrote none returns no findings.
+func readReviewFindings(cs *cycleSession) ([]reviewFinding, error) {
+	dir := reviewReportDir(cs)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("session %s: read reports: %w", cs.Name, err)
+	}
+	var findings []reviewFinding
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("session %s: read report %s: %w", cs.Name, entry.Name(), err)
+		}
+		body := strings.TrimSpace(string(data))
+		if body == "" {
+			continue
+		}
+		findings = append(findings, reviewFinding{Name: entry.Name(), Body: body})
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Name < findings[j].Name })
+	return findings, nil
+}
+
+// writeReviewFindings appends a session's findings to the down-cycle log.
+// Report bodies are indented so their headings stay inside the session entry.
+func writeReviewFindings(w io.Writer, findings []reviewFinding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "- findings: none reported")
+		return
+	}
+	fmt.Fprintln(w, "- findings:")
+	for _, finding := range findings {
+		fmt.Fprintf(w, "  - %s\n", finding.Name)
+		for _, line := range strings.Split(finding.Body, "\n") {
+			fmt.Fprintf(w, "    %s\n", strings.TrimRight(line, " \t"))
+		}
+	}
+}
+
+// skipLanding records in each worktree that a review-only cycle leaves it
+// unlanded, in place of landWorktrees and its git checks.
+func (m *upCycleManager) skipLanding() {
+	for _, cs := range m.sessions {
+		_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Review-only cycle %d: landing skipped", m.cycleNumber))
+	}
+}
@@ -14,6 +14,33 @@ const (
 	fallbackMaxAgents         = 4
 )
 
+// SparkPolicy decides whether SPARK placeholder agents from hiring may be
+// scheduled for work.
+type SparkPolicy string
+
+const (
+	// SparkPolicySkip leaves SPARKs out of the cycle; a roster of only SPARKs
+	// fails with ErrSparkAgents. It is the default.
+	SparkPolicySkip SparkPolicy = "skip"
+	// SparkPolicySchedule schedules SPARKs like any other worker.
+	SparkPolicySchedule SparkPolicy = "schedule"
+	// SparkPolicyFail refuses to start a cycle while any SPARK is rostered.
+	SparkPolicyFail SparkPolicy = "fail"
+)
+
+// ParseSparkPolicy validates a spark_policy setting. An empty value selects
+// SparkPolicySkip.
+func ParseSparkPolicy(value string) (SparkPolicy, error) {
+	switch policy := SparkPolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return SparkPolicySkip, nil
+	case SparkPolicySkip, SparkPolicySchedule, SparkPolicyFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown spark policy %q (want skip, schedule, or fail)", value)
+	}
+}
+
 type scheduledAgent struct {
 	Agent    ProjectAgent
 	Role     string
@@ -28,27 +55,52 @@ func (o *Orchestrator) selectScheduledAgents() ([]scheduledAgent, error) {
 		}
 		return nil, err
 	}
-	filtered := o.filterRoster(roster)
+	policy := o.effectiveSparkPolicy()
+	filtered, sparks := o.filterRoster(roster, policy)
+	if sparks > 0 && policy == SparkPolicyFail {
+		return nil, fmt.Errorf("%w: %d SPARK agent(s) on the roster and spark_policy is %s", ErrSparkAgents, sparks, policy)
+	}
 	if len(filtered) == 0 {
+		if sparks > 0 {
+			return nil, fmt.Errorf("%w: the roster lists only %d SPARK agent(s)", ErrSparkAgents, sparks)
+		}
 		return o.fallbackScheduledAgents()
 	}
 	return o.bindRosterToAgents(filtered)
 }
 
-func (o *Orchestrator) filterRoster(entries []workflow.WorkerEntry) []workflow.WorkerEntry {
-	allowSpark := allowSparkAssignments()
+// filterRoster normalizes the roster and drops invalid entries, plus SPARKs
+// unless the policy schedules them. It also reports how many valid SPARK
+// entries the roster held.
+func (o *Orchestrator) filterRoster(entries []workflow.WorkerEntry, policy SparkPolicy) ([]workflow.WorkerEntry, int) {
 	filtered := make([]workflow.WorkerEntry, 0, len(entries))
+	sparks := 0
 	for _, entry := range entries {
 		normalized, err := entry.Normalize()
 		if err != nil {
 			continue
 		}
-		if normalized.IsSpark && !allowSpark {
-			continue
+		if normalized.IsSpark {
+			sparks++
+			if policy != SparkPolicySchedule {
+				continue
+			}
 		}
 		filtered = append(filtered, normalized)
 	}
-	return filtered
+	return filtered, sparks
+}
+
+// effectiveSparkPolicy returns the configured policy, falling back to
+// LATTICE_ASSIGN_SPARK and then SparkPolicySkip.
+func (o *Orchestrator) effectiveSparkPolicy() SparkPolicy {
+	if o.sparkPolicy != "" {
+		return o.sparkPolicy
+	}
+	if allowSparkAssignments() {
+		return SparkPolicySchedule
+	}
+	return SparkPolicySkip
 }
 
 func allowSparkAssignments() bool {
@@ -70,25 +122,25 @@ func (o *Orchestrator) bindRosterToAgents(roster []workflow.WorkerEntry) ([]sche
 		key := strings.ToLower(strings.TrimSpace(entry.Name))
 		agent, ok := index[key]
 		if !ok {
-			return nil, fmt.Errorf("agent %s is missing from .lattice/agents", entry.Name)
+			return nil, fmt.Errorf("%w: %s is missing from .lattice/agents", ErrAgentNotFound, entry.Name)
 		}
 		scheduled = append(scheduled, scheduledAgent{
 			Agent:    agent,
 			Role:     entry.Role,
-			Capacity: capacityForEntry(entry),
+			Capacity: capacityForEntry(entry, o.cycleConfig().MaxAgentPoints),
 		})
 	}
 	return scheduled, nil
 }
 
-func capacityForEntry(entry workflow.WorkerEntry) int {
+func capacityForEntry(entry workflow.WorkerEntry, defaultCapacity int) int {
 	if entry.Capacity > 0 {
 		return entry.Capacity
 	}
 	if strings.EqualFold(entry.Role, "specialist") {
 		return defaultSpecialistCapacity
 	}
-	return maxAgentStoryPoints
+	return defaultCapacity
 }
 
 func (o *Orchestrator) fallbackScheduledAgents() ([]scheduledAgent, error) {
@@ -97,7 +149,7 @@ func (o *Orchestrator) fallbackScheduledAgents() ([]scheduledAgent, error) {
 		return nil, err
 	}
 	if len(projectAgents) == 0 {
-		return nil, fmt.Errorf("no agent files available; run hiring first")
+		return nil, fmt.Errorf("%w: no agent files; run hiring first", ErrNoAgents)
 	}
 	sort.SliceStable(projectAgents, func(i, j int) bool {
 		return strings.ToLower(projectAgents[i].Name) < strings.ToLower(projectAgents[j].Name)
@@ -106,12 +158,13 @@ func (o *Orchestrator) fallbackScheduledAgents() ([]scheduledAgent, error) {
 	if limit > fallbackMaxAgents {
 		limit = fallbackMaxAgents
 	}
+	capacity := o.cycleConfig().MaxAgentPoints
 	scheduled := make([]scheduledAgent, 0, limit)
 	for i := 0; i < limit; i++ {
 		scheduled = append(scheduled, scheduledAgent{
 			Agent:    projectAgents[i],
 			Role:     projectAgents[i].Role,
-			Capacity: maxAgentStoryPoints,
+			Capacity: capacity,
 		})
 	}
 	return scheduled, nil
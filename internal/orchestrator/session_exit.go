@@ -0,0 +1,221 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// openCodeSession tracks the exit marker and output log for one opencode
+// invocation launched inside a tmux window.
+type openCodeSession struct {
+	Window   string `json:"window"`
+	Agent    string `json:"agent,omitempty"`
+	LogPath  string `json:"log"`
+	ExitPath string `json:"-"`
+	// PromptPath holds the prompt opencode reads under the file strategy.
+	PromptPath string    `json:"-"`
+	StartedAt  time.Time `json:"startedAt"`
+}
+
+// SessionExitError reports an opencode session that exited with a non-zero
+// status before producing its expected output.
+type SessionExitError struct {
+	Window   string
+	Agent    string
+	ExitCode int
+	LogPath  string
+	Output   string
+}
+
+func (e *SessionExitError) Error() string {
+	msg := fmt.Sprintf("opencode session %s exited with status %d", e.Window, e.ExitCode)
+	if e.Agent != "" {
+		msg = fmt.Sprintf("opencode session %s (%s) exited with status %d", e.Window, e.Agent, e.ExitCode)
+	}
+	if e.Output != "" {
+		msg += ": " + e.Output
+	}
+	return msg
+}
+
+type sessionRecord struct {
+	openCodeSession
+	ExitCode   int       `json:"exitCode"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+const sessionOutputTailLines = 20
+
+type sessionTracker struct {
+	mu       sync.Mutex
+	sessions map[string]openCodeSession
+}
+
+func (o *Orchestrator) sessionDir() string {
+	return filepath.Join(o.config.LogsDir(), "opencode")
+}
+
+func (o *Orchestrator) sessionLogPath() string {
+	return filepath.Join(o.sessionDir(), "sessions.jsonl")
+}
+
+// trackSession prepares exit/log paths for a window and remembers them so the
+// waiting code can detect a session that died early.
+func (o *Orchestrator) trackSession(window, agent string) (openCodeSession, error) {
+	dir := o.sessionDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return openCodeSession{}, fmt.Errorf("prepare session log dir: %w", err)
+	}
+	token := slugifyToken(window)
+	if token == "" {
+		token = "session"
+	}
+	session := openCodeSession{
+		Window:     window,
+		Agent:      agent,
+		LogPath:    filepath.Join(dir, token+".log"),
+		ExitPath:   filepath.Join(dir, token+".exit"),
+		PromptPath: filepath.Join(dir, token+".prompt"),
+		StartedAt:  time.Now().UTC(),
+	}
+	if err := os.Remove(session.ExitPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return openCodeSession{}, fmt.Errorf("reset session exit marker: %w", err)
+	}
+	o.sessions.mu.Lock()
+	if o.sessions.sessions == nil {
+		o.sessions.sessions = make(map[string]openCodeSession)
+	}
+	o.sessions.sessions[window] = session
+	o.sessions.mu.Unlock()
+	return session, nil
+}
+
+func (o *Orchestrator) lookupSession(window string) (openCodeSession, bool) {
+	o.sessions.mu.Lock()
+	defer o.sessions.mu.Unlock()
+	session, ok := o.sessions.sessions[window]
+	return session, ok
+}
+
+func (o *Orchestrator) forgetSession(window string) {
+	o.sessions.mu.Lock()
+	delete(o.sessions.sessions, window)
+	o.sessions.mu.Unlock()
+}
+
+// wrapSessionCommand appends the exit-code capture to an opencode command line.
+func wrapSessionCommand(command string, session openCodeSession) string {
+	return fmt.Sprintf("%s; echo $? > %s", command, shellQuote(session.ExitPath))
+}
+
+// checkSessionExit reports whether the session in window has finished. A
+// non-zero exit is recorded in the session log and returned as a
+// *SessionExitError. Untracked windows report as still running.
+func (o *Orchestrator) checkSessionExit(window string) (bool, error) {
+	session, ok := o.lookupSession(window)
+	if !ok {
+		return false, nil
+	}
+	data, err := os.ReadFile(session.ExitPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read session exit marker: %w", err)
+	}
+	raw := strings.TrimSpace(string(data))
+	if raw == "" {
+		return false, nil
+	}
+	code, err := strconv.Atoi(raw)
+	if err != nil {
+		return true, fmt.Errorf("parse session exit marker %s: %w", session.ExitPath, err)
+	}
+	o.forgetSession(window)
+	if err := o.recordSessionExit(session, code); err != nil {
+		return true, err
+	}
+	if code == 0 {
+		return true, nil
+	}
+	return true, &SessionExitError{
+		Window:   session.Window,
+		Agent:    session.Agent,
+		ExitCode: code,
+		LogPath:  session.LogPath,
+		Output:   tailFile(session.LogPath, sessionOutputTailLines),
+	}
+}
+
+func (o *Orchestrator) recordSessionExit(session openCodeSession, code int) error {
+	record := sessionRecord{openCodeSession: session, ExitCode: code, FinishedAt: time.Now().UTC()}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode session record: %w", err)
+	}
+	f, err := os.OpenFile(o.sessionLogPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open session log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write session log: %w", err)
+	}
+	return nil
+}
+
+// waitForSessionFile behaves like waitForFile but fails fast when the opencode
+// session running in window exits non-zero before the file appears. The file
+// is checked before each poll interval, so output that is already written is
+// picked up without waiting.
+func (o *Orchestrator) waitForSessionFile(window, path string, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if fileHasContent(path) {
+			return nil
+		}
+		select {
+		case <-deadline:
+			return fmt.Errorf("timed out waiting for %s", path)
+		case <-ticker.C:
+			exited, err := o.checkSessionExit(window)
+			if err != nil {
+				return err
+			}
+			if exited && !fileHasContent(path) {
+				return fmt.Errorf("opencode session %s exited without writing %s", window, path)
+			}
+		}
+	}
+}
+
+func fileHasContent(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Size() > 0
+}
+
+func tailFile(path string, lines int) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	all := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return strings.TrimSpace(strings.Join(all, "\n"))
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,113 @@
+package orchestrator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForSessionFileRecordsNonZeroExit(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	session, err := orch.trackSession("audit-security-1", "Aster")
+	if err != nil {
+		t.Fatalf("track session: %v", err)
+	}
+	if !strings.HasSuffix(wrapSessionCommand("opencode", session), "> '"+session.ExitPath+"'") {
+		t.Fatalf("wrapped command does not capture exit code: %s", wrapSessionCommand("opencode", session))
+	}
+	if err := os.WriteFile(session.LogPath, []byte("starting\nerror: model quota exceeded\n"), 0644); err != nil {
+		t.Fatalf("seed log: %v", err)
+	}
+	if err := os.WriteFile(session.ExitPath, []byte("2\n"), 0644); err != nil {
+		t.Fatalf("seed exit marker: %v", err)
+	}
+
+	err = orch.waitForSessionFile(session.Window, session.Window+"-never-written.md", 10*time.Second)
+	var exitErr *SessionExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected SessionExitError, got %v", err)
+	}
+	if exitErr.ExitCode != 2 || exitErr.Agent != "Aster" {
+		t.Fatalf("unexpected exit error: %+v", exitErr)
+	}
+	if !strings.Contains(exitErr.Error(), "model quota exceeded") {
+		t.Fatalf("expected output tail in error, got %q", exitErr.Error())
+	}
+	data, err := os.ReadFile(orch.sessionLogPath())
+	if err != nil {
+		t.Fatalf("read session log: %v", err)
+	}
+	if !strings.Contains(string(data), `"exitCode":2`) || !strings.Contains(string(data), `"window":"audit-security-1"`) {
+		t.Fatalf("session log missing exit record: %s", data)
+	}
+	if exited, err := orch.checkSessionExit(session.Window); exited || err != nil {
+		t.Fatalf("expected session to be forgotten after reporting, got exited=%v err=%v", exited, err)
+	}
+}
+
+func TestWaitForSessionFileReturnsWrittenFileWithoutPolling(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	session, err := orch.trackSession("summary-2", "")
+	if err != nil {
+		t.Fatalf("track session: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "done.md")
+	if err := os.WriteFile(path, []byte("done\n"), 0644); err != nil {
+		t.Fatalf("seed output: %v", err)
+	}
+	started := time.Now()
+	if err := orch.waitForSessionFile(session.Window, path, 10*time.Second); err != nil {
+		t.Fatalf("wait for written file: %v", err)
+	}
+	if waited := time.Since(started); waited >= time.Second {
+		t.Fatalf("an existing file should not wait for a poll tick, waited %s", waited)
+	}
+}
+
+func TestCheckSessionExitReportsRunningSession(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	session, err := orch.trackSession("summary-1", "")
+	if err != nil {
+		t.Fatalf("track session: %v", err)
+	}
+	if exited, err := orch.checkSessionExit(session.Window); exited || err != nil {
+		t.Fatalf("expected running session, got exited=%v err=%v", exited, err)
+	}
+	if err := os.WriteFile(session.ExitPath, []byte("0\n"), 0644); err != nil {
+		t.Fatalf("seed exit marker: %v", err)
+	}
+	if exited, err := orch.checkSessionExit(session.Window); !exited || err != nil {
+		t.Fatalf("expected clean exit, got exited=%v err=%v", exited, err)
+	}
+}
+
+func TestLimitSessionCommandWrapsOpenCode(t *testing.T) {
+	origOS, origPrlimit := sessionLimitOS, hasPrlimit
+	t.Cleanup(func() { sessionLimitOS, hasPrlimit = origOS, origPrlimit })
+	limits := SessionLimits{CPUSeconds: 600, MemoryMB: 2048, MaxProcs: 64}
+
+	sessionLimitOS = "linux"
+	hasPrlimit = func() bool { return true }
+	want := `prlimit --cpu=600 --as=2147483648 --nproc=64 -- opencode --prompt "go"`
+	if got := limitSessionCommand(`opencode --prompt "go"`, limits); got != want {
+		t.Fatalf("prlimit wrapper mismatch:\n got %s\nwant %s", got, want)
+	}
+
+	hasPrlimit = func() bool { return false }
+	want = `(ulimit -t 600 && ulimit -v 2097152 && ulimit -u 64 && opencode --prompt "go")`
+	if got := limitSessionCommand(`opencode --prompt "go"`, limits); got != want {
+		t.Fatalf("ulimit wrapper mismatch:\n got %s\nwant %s", got, want)
+	}
+
+	sessionLimitOS = "darwin"
+	if got := limitSessionCommand("opencode", limits); got != "opencode" {
+		t.Fatalf("unsupported platforms should run the command unchanged, got %s", got)
+	}
+	sessionLimitOS = "linux"
+	if got := limitSessionCommand("opencode", SessionLimits{}); got != "opencode" {
+		t.Fatalf("unconfigured limits should not wrap the command, got %s", got)
+	}
+}
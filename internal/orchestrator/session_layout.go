@@ -0,0 +1,33 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// sessionLayout lists the directories every worktree session needs, relative
+// to the session root: the question and event mailboxes agents write to, the
+// response inbox orchestrators answer in, and the per-cycle event archive.
+var sessionLayout = []string{
+	filepath.Join("outbox", "questions"),
+	filepath.Join("outbox", "events"),
+	filepath.Join("inbox", "responses"),
+	filepath.Join("archive", "events"),
+}
+
+// ValidateSessionDir checks that dir is scaffolded as a worktree session
+// before an agent is dispatched into it. It returns the missing items,
+// relative to dir, in layout order with WORKTREE.md last; nil means the
+// session is ready.
+func ValidateSessionDir(dir string) []string {
+	var missing []string
+	for _, rel := range sessionLayout {
+		if info, err := os.Stat(filepath.Join(dir, rel)); err != nil || !info.IsDir() {
+			missing = append(missing, rel)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(dir, "WORKTREE.md")); err != nil || info.IsDir() {
+		missing = append(missing, "WORKTREE.md")
+	}
+	return missing
+}
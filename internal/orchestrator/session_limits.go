@@ -0,0 +1,76 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// SessionLimits caps the resources each opencode session process may use.
+// Zero fields leave that resource unlimited.
+type SessionLimits struct {
+	// CPUSeconds limits total CPU time.
+	CPUSeconds int
+	// MemoryMB limits the address space.
+	MemoryMB int
+	// MaxProcs limits how many processes the session user may run.
+	MaxProcs int
+}
+
+// IsZero reports whether no limit is configured.
+func (l SessionLimits) IsZero() bool {
+	return l.CPUSeconds <= 0 && l.MemoryMB <= 0 && l.MaxProcs <= 0
+}
+
+// sessionLimitOS and hasPrlimit are swapped in tests.
+var (
+	sessionLimitOS = runtime.GOOS
+	hasPrlimit     = func() bool {
+		_, err := exec.LookPath("prlimit")
+		return err == nil
+	}
+)
+
+// SetSessionLimits applies resource limits to every opencode session the
+// orchestrator launches. Limits are only enforced on Linux; elsewhere sessions
+// run unconstrained.
+func (o *Orchestrator) SetSessionLimits(limits SessionLimits) {
+	if o == nil {
+		return
+	}
+	o.sessionLimits = limits
+}
+
+// limitSessionCommand wraps a session command line so the limits apply to the
+// opencode process. It uses prlimit when installed and falls back to ulimit in
+// a subshell; unsupported platforms get the command unchanged.
+func limitSessionCommand(command string, limits SessionLimits) string {
+	if limits.IsZero() || sessionLimitOS != "linux" {
+		return command
+	}
+	if hasPrlimit() {
+		var flags []string
+		if limits.CPUSeconds > 0 {
+			flags = append(flags, fmt.Sprintf("--cpu=%d", limits.CPUSeconds))
+		}
+		if limits.MemoryMB > 0 {
+			flags = append(flags, fmt.Sprintf("--as=%d", int64(limits.MemoryMB)*1024*1024))
+		}
+		if limits.MaxProcs > 0 {
+			flags = append(flags, fmt.Sprintf("--nproc=%d", limits.MaxProcs))
+		}
+		return fmt.Sprintf("prlimit %s -- %s", strings.Join(flags, " "), command)
+	}
+	var steps []string
+	if limits.CPUSeconds > 0 {
+		steps = append(steps, fmt.Sprintf("ulimit -t %d", limits.CPUSeconds))
+	}
+	if limits.MemoryMB > 0 {
+		steps = append(steps, fmt.Sprintf("ulimit -v %d", limits.MemoryMB*1024))
+	}
+	if limits.MaxProcs > 0 {
+		steps = append(steps, fmt.Sprintf("ulimit -u %d", limits.MaxProcs))
+	}
+	return fmt.Sprintf("(%s && %s)", strings.Join(steps, " && "), command)
+}
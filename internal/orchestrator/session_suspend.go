@@ -0,0 +1,90 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// sessionSuspendMarker in a session directory holds back its next agent
+// cycle until the file is removed. It may be created by hand or through
+// SuspendSession.
+const sessionSuspendMarker = "SUSPENDED"
+
+// SuspendSession pauses the named worktree session: once its current agent
+// cycle finishes, no further cycle is dispatched until ResumeSession is
+// called. Other sessions keep running and the worktree is left in place.
+func (o *Orchestrator) SuspendSession(name, reason string) error {
+	dir, err := o.findSessionDir(name)
+	if err != nil {
+		return err
+	}
+	note := fmt.Sprintf("suspended %s", time.Now().UTC().Format(time.RFC3339))
+	if reason = strings.TrimSpace(reason); reason != "" {
+		note += ": " + reason
+	}
+	return os.WriteFile(filepath.Join(dir, sessionSuspendMarker), []byte(note+"\n"), 0644)
+}
+
+// ResumeSession lets a suspended session dispatch its next agent cycle.
+// Resuming a session that is not suspended is a no-op.
+func (o *Orchestrator) ResumeSession(name string) error {
+	dir, err := o.findSessionDir(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, sessionSuspendMarker)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// findSessionDir finds the directory of the worktree session called name.
+func (o *Orchestrator) findSessionDir(name string) (string, error) {
+	if o == nil || o.config == nil {
+		return "", ErrNotInitialized
+	}
+	dirs, err := o.scanWorktreeSessions()
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range dirs {
+		if filepath.Base(dir) == name {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("worktree session %q not found", name)
+}
+
+func sessionSuspended(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, sessionSuspendMarker))
+	return err == nil
+}
+
+// waitWhileSuspended holds a session before its next dispatch for as long as
+// its suspend marker exists, polling at the event interval.
+func (m *upCycleManager) waitWhileSuspended(ctx context.Context, cs *cycleSession) error {
+	if !sessionSuspended(cs.Path) {
+		return nil
+	}
+	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Session suspended; holding cycle %d for %s", cs.cycle, cs.Agent.Name))
+	status := WorktreeStatus{Phase: "up-cycle", State: "suspended", Cycle: cs.cycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
+	_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
+	ticker := time.NewTicker(m.config.EventPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if !sessionSuspended(cs.Path) {
+				_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Session resumed; dispatching cycle %d", cs.cycle))
+				return nil
+			}
+		}
+	}
+}
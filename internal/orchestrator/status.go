@@ -8,6 +8,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/kingrea/The-Lattice/internal/workflow"
 )
 
 // SessionSnapshot captures the real-time view of a worktree session for the TUI.
@@ -21,16 +23,16 @@ type SessionSnapshot struct {
 
 // CycleStatus summarizes the currently tracked work cycle.
 type CycleStatus struct {
-	Cycle        int
-	Status       string
-	UpdatedAt    time.Time
-	SessionCount int
+	Cycle        int       `json:"cycle"`
+	Status       string    `json:"status"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	SessionCount int       `json:"session_count"`
 }
 
 // SessionSnapshots returns the current worktree sessions along with their status metadata.
 func (o *Orchestrator) SessionSnapshots() ([]SessionSnapshot, error) {
 	if o == nil || o.config == nil {
-		return nil, fmt.Errorf("orchestrator is not initialized")
+		return nil, ErrNotInitialized
 	}
 	sessions, err := o.loadTrackedSessions()
 	if err != nil {
@@ -69,7 +71,7 @@ func (o *Orchestrator) SessionSnapshots() ([]SessionSnapshot, error) {
 // CurrentCycleStatus reports metadata about the actively tracked cycle, if any.
 func (o *Orchestrator) CurrentCycleStatus() (CycleStatus, error) {
 	if o == nil || o.config == nil {
-		return CycleStatus{}, fmt.Errorf("orchestrator is not initialized")
+		return CycleStatus{}, ErrNotInitialized
 	}
 	tracker, err := o.readCycleTracker()
 	if err != nil {
@@ -86,3 +88,154 @@ func (o *Orchestrator) CurrentCycleStatus() (CycleStatus, error) {
 		SessionCount: len(tracker.Sessions),
 	}, nil
 }
+
+// StatusBoard is the data behind the TUI status board: the workflow phase,
+// the tracked cycle, and one row per worktree session.
+type StatusBoard struct {
+	Phase     workflow.Phase  `json:"-"`
+	PhaseName string          `json:"phase"`
+	Cycle     CycleStatus     `json:"cycle"`
+	HasCycle  bool            `json:"has_cycle"`
+	Sessions  []SessionStatus `json:"sessions"`
+}
+
+// SessionStatus is one worktree session row of the status board. Window is
+// the tmux window running the session's agent or orchestrator, empty when
+// the session is idle or tmux is unavailable.
+type SessionStatus struct {
+	Agent        string    `json:"agent"`
+	Worktree     string    `json:"worktree"`
+	Number       int       `json:"number"`
+	Points       int       `json:"points"`
+	Beads        int       `json:"beads"`
+	Cycle        int       `json:"cycle"`
+	Phase        string    `json:"phase"`
+	State        string    `json:"state"`
+	Questions    int       `json:"questions"`
+	Waiting      int       `json:"questions_waiting"`
+	Window       string    `json:"window,omitempty"`
+	WindowActive bool      `json:"window_active,omitempty"`
+	LastUpdated  time.Time `json:"last_updated"`
+}
+
+// StatusBoard reads the status board from .lattice state. tmuxSession names
+// the tmux session whose windows are matched to worktree sessions; when it
+// is empty or tmux cannot be queried, every session is reported without a
+// window.
+func (o *Orchestrator) StatusBoard(tmuxSession string) (StatusBoard, error) {
+	if o == nil || o.config == nil {
+		return StatusBoard{}, ErrNotInitialized
+	}
+	phase := workflow.New(o.config.LatticeProjectDir).CurrentPhase()
+	board := StatusBoard{Phase: phase, PhaseName: phase.String(), Sessions: []SessionStatus{}}
+	snapshots, err := o.SessionSnapshots()
+	if err != nil {
+		return board, err
+	}
+	windows := o.tmuxWindows(tmuxSession)
+	for _, snap := range snapshots {
+		row := SessionStatus{
+			Agent:       snap.Worktree.Agent.Name,
+			Worktree:    snap.Worktree.Name,
+			Number:      snap.Worktree.Number,
+			Points:      snap.Worktree.TotalPoints(),
+			Beads:       len(snap.Worktree.Beads),
+			Cycle:       snap.Status.Cycle,
+			Phase:       snap.Status.Phase,
+			State:       snap.Status.State,
+			Questions:   snap.QuestionsTotal,
+			Waiting:     snap.QuestionsWaiting,
+			LastUpdated: snap.LastUpdated,
+		}
+		for _, candidate := range sessionWindowNames(snap) {
+			if active, ok := windows[candidate]; ok {
+				row.Window = candidate
+				row.WindowActive = active
+				break
+			}
+		}
+		board.Sessions = append(board.Sessions, row)
+	}
+	cycle, err := o.CurrentCycleStatus()
+	switch {
+	case err == nil:
+		board.Cycle, board.HasCycle = cycle, true
+	case !errors.Is(err, errNoTrackedSessions):
+		return board, err
+	}
+	return board, nil
+}
+
+// sessionWindowNames lists the tmux windows a session's current cycle may be
+// running in.
+func sessionWindowNames(snapshot SessionSnapshot) []string {
+	num := snapshot.Worktree.Number
+	cycle := snapshot.Status.Cycle
+	if cycle <= 0 {
+		cycle = 1
+	}
+	return []string{
+		fmt.Sprintf("worktree-agent-%d-%d", num, cycle),
+		fmt.Sprintf("worktree-orchestrator-%d-%d", num, cycle),
+	}
+}
+
+// tmuxWindows maps the window names of a tmux session to whether each is
+// active. It returns an empty map when session is empty or tmux fails.
+func (o *Orchestrator) tmuxWindows(session string) map[string]bool {
+	windows := map[string]bool{}
+	if strings.TrimSpace(session) == "" {
+		return windows
+	}
+	output, err := o.runProjectCommand("tmux", "list-windows", "-t", session, "-F", "#{window_name}::#{window_active}")
+	if err != nil {
+		return windows
+	}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		name, active, ok := strings.Cut(strings.TrimSpace(line), "::")
+		if !ok || name == "" {
+			continue
+		}
+		windows[name] = strings.TrimSpace(active) == "1"
+	}
+	return windows
+}
+
+// BeadCounts summarizes the beads still outstanding in bd by status.
+type BeadCounts struct {
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Blocked    int `json:"blocked"`
+	Total      int `json:"total"`
+}
+
+// OutstandingBeads counts the beads `bd list --json` reports as not closed.
+func (o *Orchestrator) OutstandingBeads() (BeadCounts, error) {
+	if o == nil || o.config == nil {
+		return BeadCounts{}, ErrNotInitialized
+	}
+	output, err := o.runProjectCommand("bd", "list", "--json")
+	if err != nil {
+		return BeadCounts{}, err
+	}
+	records, err := parseBeadRecords([]byte(output), o.beadProfile())
+	if err != nil {
+		return BeadCounts{}, err
+	}
+	var counts BeadCounts
+	for _, record := range records {
+		status := strings.ToLower(strings.TrimSpace(record.Status))
+		switch {
+		case status == "closed":
+			continue
+		case status == "in_progress" || status == "in-progress":
+			counts.InProgress++
+		case statusIndicatesBlock(status) || containsBlockedTag(record.Tags):
+			counts.Blocked++
+		default:
+			counts.Open++
+		}
+		counts.Total++
+	}
+	return counts, nil
+}
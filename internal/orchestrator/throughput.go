@@ -0,0 +1,166 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cycleDurationLabel prefixes the duration line in each down-cycle section.
+const cycleDurationLabel = "Cycle duration:"
+
+var overallProgressPattern = regexp.MustCompile(`^Overall progress: ([0-9]+)/([0-9]+) beads .*?, ([0-9]+)/([0-9]+) points`)
+
+// CycleThroughput is what one past cycle delivered, read from its down-cycle
+// section in the work log and its state/cycle-<n>/ directory.
+type CycleThroughput struct {
+	Cycle           int `json:"cycle"`
+	Agents          int `json:"agents"`
+	AssignedBeads   int `json:"assigned_beads"`
+	CompletedBeads  int `json:"completed_beads"`
+	AssignedPoints  int `json:"assigned_points"`
+	CompletedPoints int `json:"completed_points"`
+	// Duration is zero when the work log predates duration tracking.
+	Duration   time.Duration `json:"duration_ns,omitempty"`
+	FinishedAt time.Time     `json:"finished_at"`
+	// Logged is false for cycles that only left a SUMMARY.md; their counts
+	// are unknown and they are left out of the averages.
+	Logged      bool   `json:"logged"`
+	SummaryPath string `json:"summary_path,omitempty"`
+}
+
+// ThroughputReport aggregates past cycles, oldest first, for capacity
+// planning.
+type ThroughputReport struct {
+	Cycles []CycleThroughput
+}
+
+// logged returns the cycles with work-log metrics.
+func (r ThroughputReport) logged() []CycleThroughput {
+	var cycles []CycleThroughput
+	for _, cycle := range r.Cycles {
+		if cycle.Logged {
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles
+}
+
+// BeadsPerCycle is the mean number of beads closed per logged cycle.
+func (r ThroughputReport) BeadsPerCycle() float64 {
+	cycles := r.logged()
+	if len(cycles) == 0 {
+		return 0
+	}
+	total := 0
+	for _, cycle := range cycles {
+		total += cycle.CompletedBeads
+	}
+	return float64(total) / float64(len(cycles))
+}
+
+// PointsPerCycle is the mean number of points closed per logged cycle.
+func (r ThroughputReport) PointsPerCycle() float64 {
+	cycles := r.logged()
+	if len(cycles) == 0 {
+		return 0
+	}
+	total := 0
+	for _, cycle := range cycles {
+		total += cycle.CompletedPoints
+	}
+	return float64(total) / float64(len(cycles))
+}
+
+// PointsPerAgent is the mean number of points one agent session closed per
+// cycle, the figure a hiring heuristic divides remaining points by.
+func (r ThroughputReport) PointsPerAgent() float64 {
+	points, agents := 0, 0
+	for _, cycle := range r.logged() {
+		points += cycle.CompletedPoints
+		agents += cycle.Agents
+	}
+	if agents == 0 {
+		return 0
+	}
+	return float64(points) / float64(agents)
+}
+
+// MeanDuration averages the cycles whose duration was recorded.
+func (r ThroughputReport) MeanDuration() time.Duration {
+	var total time.Duration
+	count := 0
+	for _, cycle := range r.Cycles {
+		if cycle.Duration > 0 {
+			total += cycle.Duration
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
+
+// Throughput reads every past cycle's down-cycle section from the work log
+// and every state/cycle-<n>/SUMMARY.md into a ThroughputReport.
+func (o *Orchestrator) Throughput() (ThroughputReport, error) {
+	if o == nil || o.config == nil {
+		return ThroughputReport{}, ErrNotInitialized
+	}
+	data, err := os.ReadFile(o.workLogPath())
+	if err != nil && !os.IsNotExist(err) {
+		return ThroughputReport{}, fmt.Errorf("read work log: %w", err)
+	}
+	byCycle := parseThroughput(string(data))
+	summaries, err := o.CycleSummaries()
+	if err != nil {
+		return ThroughputReport{}, err
+	}
+	for _, summary := range summaries {
+		cycle, ok := byCycle[summary.Cycle]
+		if !ok {
+			cycle = CycleThroughput{Cycle: summary.Cycle, FinishedAt: summary.UpdatedAt}
+		}
+		cycle.SummaryPath = summary.Path
+		byCycle[summary.Cycle] = cycle
+	}
+	report := ThroughputReport{}
+	for _, cycle := range byCycle {
+		report.Cycles = append(report.Cycles, cycle)
+	}
+	sort.Slice(report.Cycles, func(i, j int) bool { return report.Cycles[i].Cycle < report.Cycles[j].Cycle })
+	return report, nil
+}
+
+// parseThroughput extracts per-cycle metrics from the work log's down-cycle
+// sections. A rerun down cycle replaces the earlier section's numbers.
+func parseThroughput(log string) map[int]CycleThroughput {
+	cycles := map[int]CycleThroughput{}
+	for number, section := range downCycleSections(log) {
+		cycle := CycleThroughput{Cycle: number, FinishedAt: section.FinishedAt, Logged: true}
+		for _, line := range section.Lines[1:] {
+			switch {
+			case strings.HasPrefix(line, "### "):
+				cycle.Agents++
+			case strings.HasPrefix(line, cycleDurationLabel):
+				if d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(line, cycleDurationLabel))); err == nil {
+					cycle.Duration = d
+				}
+			default:
+				if match := overallProgressPattern.FindStringSubmatch(line); match != nil {
+					cycle.CompletedBeads, _ = strconv.Atoi(match[1])
+					cycle.AssignedBeads, _ = strconv.Atoi(match[2])
+					cycle.CompletedPoints, _ = strconv.Atoi(match[3])
+					cycle.AssignedPoints, _ = strconv.Atoi(match[4])
+				}
+			}
+		}
+		cycles[number] = cycle
+	}
+	return cycles
+}
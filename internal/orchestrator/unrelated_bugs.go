@@ -0,0 +1,106 @@
+package orchestrator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	unrelatedBugsHeading = "# unrelated bugs"
+	needHelpHeading      = "# need help"
+)
+
+// unrelatedBugPlan splits a session's unrelated-bug entries into those that
+// get their own bead and those folded into a single aggregate bead.
+type unrelatedBugPlan struct {
+	Individual []string
+	Aggregated []string
+}
+
+// planUnrelatedBugs reads the '# unrelated bugs' entries from the session's
+// WORKTREE.md and reserves bead slots for them against the per-cycle cap.
+// Entries beyond the cap are aggregated. With no cap every entry is
+// individual.
+func (m *upCycleManager) planUnrelatedBugs(cs *cycleSession) unrelatedBugPlan {
+	entries := worktreeEntries(filepath.Join(cs.Path, "WORKTREE.md"), unrelatedBugsHeading)
+	if m.config.MaxUnrelatedBugBeads <= 0 || len(entries) == 0 {
+		return unrelatedBugPlan{Individual: entries}
+	}
+	m.bugMu.Lock()
+	allowed := m.config.MaxUnrelatedBugBeads - m.bugBeads
+	if allowed < 0 {
+		allowed = 0
+	}
+	if allowed > len(entries) {
+		allowed = len(entries)
+	}
+	m.bugBeads += allowed
+	m.bugMu.Unlock()
+	plan := unrelatedBugPlan{Individual: entries[:allowed]}
+	if allowed < len(entries) {
+		plan.Aggregated = entries[allowed:]
+		_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Cycle %d: unrelated-bug bead cap of %d reached; aggregating %d more into one bead", cs.cycle, m.config.MaxUnrelatedBugBeads, len(plan.Aggregated)))
+	}
+	return plan
+}
+
+// promptStep renders the orchestrator instruction for unrelated bugs.
+func (p unrelatedBugPlan) promptStep(cs *cycleSession, globalCycle int) string {
+	if len(p.Aggregated) == 0 {
+		return "For each entry under '# unrelated bugs', create a new bead via bd with clear title and reference."
+	}
+	var b strings.Builder
+	if len(p.Individual) > 0 {
+		b.WriteString(fmt.Sprintf("Create a new bead via bd with clear title and reference for only these %d entries under '# unrelated bugs':\n", len(p.Individual)))
+		for _, entry := range p.Individual {
+			b.WriteString("   - " + entry + "\n")
+		}
+		b.WriteString("   The unrelated-bug bead cap for this cycle is then reached. For the remaining entries, ")
+	} else {
+		b.WriteString("The unrelated-bug bead cap for this cycle is already reached. Do not create a bead per entry under '# unrelated bugs'; instead ")
+	}
+	b.WriteString(fmt.Sprintf("create one bead titled \"Misc unrelated bugs (cycle %d, %s)\" whose description lists each of these entries:\n", globalCycle, cs.Name))
+	for i, entry := range p.Aggregated {
+		b.WriteString("   - " + entry)
+		if i < len(p.Aggregated)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// worktreeEntries returns the bullet entries under heading in a WORKTREE.md,
+// skipping the placeholder written with a fresh file.
+func worktreeEntries(worktreePath, heading string) []string {
+	file, err := os.Open(worktreePath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+	var entries []string
+	inSection := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "# ") {
+			inSection = strings.EqualFold(line, heading)
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		entry, ok := strings.CutPrefix(line, "- ")
+		if !ok {
+			entry, ok = strings.CutPrefix(line, "* ")
+		}
+		entry = strings.TrimSpace(entry)
+		if !ok || entry == "" || strings.EqualFold(entry, "none recorded yet") {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
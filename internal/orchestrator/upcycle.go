@@ -5,13 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kingrea/The-Lattice/internal/skills"
@@ -25,6 +26,65 @@ type UpCycleConfig struct {
 	EventPollInterval    time.Duration
 	ResponseTimeout      time.Duration
 	OrchestratorTimeout  time.Duration
+	// MaxStalledCycles bounds how many consecutive cycles may finish without
+	// closing a bead before orchestration halts. Zero disables the guard.
+	MaxStalledCycles int
+	// StrictBeadIDs fails a session whose agent event references bead IDs it
+	// was never assigned instead of only logging them.
+	StrictBeadIDs bool
+	// EventSettleWindow is how long an event file that fails to parse must
+	// stay unmodified before it is reported as malformed. Until then it is
+	// treated as a write still in progress and retried on the next poll.
+	EventSettleWindow time.Duration
+	// RepoMemory points each agent prompt at state/REPO_MEMORY.md so agents
+	// load repo-wide learnings before working.
+	RepoMemory bool
+	// RepoMemoryMaxBytes caps the repo memory agents are told to load in
+	// full; a larger file is referenced with a note to read only the
+	// relevant sections.
+	RepoMemoryMaxBytes int64
+	// MaxUnrelatedBugBeads caps how many beads post-cycle orchestrators create
+	// from '# unrelated bugs' entries per cycle, across all sessions. Entries
+	// past the cap are folded into one aggregate bead per session. Zero means
+	// no cap.
+	MaxUnrelatedBugBeads int
+	// CycleCooldown delays restarting the orchestrator for the next cycle so
+	// rate-limited model backends get a breather. Zero restarts immediately.
+	CycleCooldown time.Duration
+	// ArchiveRetention keeps only the archived WORKTREE.md files and events
+	// of a session's most recent cycles, pruned once the down-cycle report
+	// has read them. Zero keeps every cycle.
+	ArchiveRetention int
+	// InvocationBudget caps the opencode sessions a cycle launches. Once it
+	// is spent, agent retries are deferred to the next cycle and question
+	// auto-answers and local dreaming are skipped; first attempts, reviews,
+	// summaries, and landing still run. Zero means no cap.
+	InvocationBudget int
+	// MaxConcurrentAutoResponses caps the question auto-responses running at
+	// once across all sessions; further questions wait for a slot. Zero
+	// means no cap.
+	MaxConcurrentAutoResponses int
+	// AnnotateCompletedBeads comments on every bead an agent reports
+	// completed, naming the agent and the cycle, once its review finishes.
+	AnnotateCompletedBeads bool
+	// ReviewOnly runs a cycle that analyzes without changing code. Agents
+	// write findings to outbox/reports/ instead of committing, the findings
+	// are collected into the down-cycle log, and landing is skipped.
+	ReviewOnly bool
+	// AgentIdleTimeout closes an agent window that writes no event and
+	// leaves WORKTREE.md untouched for this long. Zero disables the watchdog.
+	AgentIdleTimeout time.Duration
+	// AgentIdleRetries is how many times an idle agent's cycle is
+	// re-dispatched before its session fails with ErrAgentIdle.
+	AgentIdleRetries int
+	// MaxPromptBeads caps the beads listed inline in an agent prompt; the
+	// rest are summarized with a pointer to WORKTREE.md, which lists them
+	// all. Zero lists every bead.
+	MaxPromptBeads int
+	// OffloadOverCapacity moves beads an agent has not started off a session
+	// whose remaining points, after the agent's re-estimates, exceed the
+	// agent's capacity. They are left for the next work cycle.
+	OffloadOverCapacity bool
 }
 
 var defaultUpCycleConfig = UpCycleConfig{
@@ -33,9 +93,26 @@ var defaultUpCycleConfig = UpCycleConfig{
 	EventPollInterval:    4 * time.Second,
 	ResponseTimeout:      2 * time.Minute,
 	OrchestratorTimeout:  5 * time.Minute,
+	MaxStalledCycles:     3,
+	EventSettleWindow:    10 * time.Second,
+	RepoMemoryMaxBytes:   64 * 1024,
 }
 
+// ErrNoProgress is returned when consecutive cycles finish without closing any
+// beads. Callers should escalate (e.g. request refinement) instead of
+// restarting the same work.
+var ErrNoProgress = errors.New("work cycles stalled without closing beads")
+
+// ErrUnknownBeadIDs is returned in strict mode when an agent event names beads
+// outside the session's assignment, usually because of a typo.
+var ErrUnknownBeadIDs = errors.New("agent event referenced unknown bead IDs")
+
 // RunUpCycle launches the assigned agents and manages their sessions until completion.
+// sessions may be a subset of the prepared sessions: the others are left
+// prepared and stay tracked for the next cycle, where PrepareWorkCycle
+// returns them again. Milestones are emitted to the EventSink set with
+// SetEventSink and reported to the ProgressReporter set with
+// SetProgressReporter.
 func (o *Orchestrator) RunUpCycle(ctx context.Context, sessions []WorktreeSession) error {
 	if len(sessions) == 0 {
 		return fmt.Errorf("no worktree sessions to run")
@@ -47,11 +124,41 @@ func (o *Orchestrator) RunUpCycle(ctx context.Context, sessions []WorktreeSessio
 	if err := o.updateCycleTrackerStatus("running"); err != nil && !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
+	return o.newUpCycleManager(cycleNumber, sessions).runCycle(ctx)
+}
+
+func (o *Orchestrator) newUpCycleManager(cycleNumber int, sessions []WorktreeSession) *upCycleManager {
 	mgr := &upCycleManager{
 		orchestrator: o,
 		sessions:     make([]*cycleSession, 0, len(sessions)),
 		config:       defaultUpCycleConfig,
 		cycleNumber:  cycleNumber,
+		events:       o.eventSink(),
+		metadata:     o.currentCycleMetadata(),
+		idle:         o.idleTrackedSessions(sessions),
+	}
+	if o.maxStalledCycles > 0 {
+		mgr.config.MaxStalledCycles = o.maxStalledCycles
+	}
+	mgr.config.StrictBeadIDs = o.strictBeadIDs
+	o.applyRepoMemory(&mgr.config)
+	mgr.config.MaxUnrelatedBugBeads = o.maxUnrelatedBugBeads
+	mgr.config.CycleCooldown = o.cycleCooldown
+	mgr.config.ArchiveRetention = o.archiveRetention
+	mgr.config.InvocationBudget = o.invocationBudget
+	mgr.config.MaxConcurrentAutoResponses = o.maxAutoResponses
+	if n := mgr.config.MaxConcurrentAutoResponses; n > 0 {
+		mgr.autoResponseSlots = make(chan struct{}, n)
+	}
+	mgr.config.AnnotateCompletedBeads = o.annotateCompletedBeads
+	mgr.config.ReviewOnly = o.reviewOnly
+	mgr.config.MaxPromptBeads = o.maxPromptBeads
+	mgr.config.OffloadOverCapacity = o.offloadOverCapacity
+	if o.config != nil {
+		if watchdog := o.config.IdleWatchdogSettings(); watchdog.Enabled {
+			mgr.config.AgentIdleTimeout = watchdog.Timeout
+			mgr.config.AgentIdleRetries = watchdog.Retries
+		}
 	}
 	for _, session := range sessions {
 		cs := &cycleSession{
@@ -67,10 +174,22 @@ func (o *Orchestrator) RunUpCycle(ctx context.Context, sessions []WorktreeSessio
 		cs.rebuildBeadIndex()
 		mgr.sessions = append(mgr.sessions, cs)
 	}
-	if err := mgr.run(ctx); err != nil {
+	mgr.assignConductors()
+	return mgr
+}
+
+// runCycle runs every session to completion and then the down-cycle.
+func (m *upCycleManager) runCycle(ctx context.Context) error {
+	m.started = time.Now()
+	var beads []string
+	for _, cs := range m.sessions {
+		beads = append(beads, beadIDs(cs.Beads)...)
+	}
+	m.emit(nil, CycleEvent{Type: CycleEventCycleStarted, Beads: beads})
+	if err := m.run(ctx); err != nil {
 		return err
 	}
-	return mgr.runDownCycle(ctx)
+	return m.runDownCycle(ctx)
 }
 
 type upCycleManager struct {
@@ -79,6 +198,37 @@ type upCycleManager struct {
 	sessions     []*cycleSession
 	cycleNumber  int
 	cycleSummary string
+	// started is when runCycle began; the down-cycle log records the
+	// cycle's duration from it.
+	started time.Time
+	// bugBeads counts unrelated-bug beads granted against
+	// UpCycleConfig.MaxUnrelatedBugBeads; sessions share it concurrently.
+	bugMu    sync.Mutex
+	bugBeads int
+	// startNextCycle restarts the orchestrator prompt for the given cycle;
+	// nil uses Orchestrator.restartInitialPromptWithCycle. Swapped in tests.
+	startNextCycle func(cycle int) error
+	// events receives the structured counterparts of LOG.md milestones and
+	// feeds the ProgressReporter.
+	events EventSink
+	// metadata holds the cycle's SetCycleMetadata notes for the down-cycle
+	// log.
+	metadata map[string]string
+	// idle holds prepared sessions left out of this run; they stay tracked
+	// and their worktrees survive the down-cycle.
+	idle []trackedSession
+	// invocations counts opencode launches against
+	// UpCycleConfig.InvocationBudget; budgetSkipped names the launches it
+	// refused. Sessions share them concurrently.
+	budgetMu      sync.Mutex
+	invocations   int
+	budgetSkipped []string
+	// autoResponseSlots bounds concurrent auto-responses across sessions
+	// when UpCycleConfig.MaxConcurrentAutoResponses is set; nil is unbounded.
+	autoResponseSlots chan struct{}
+	// annotationsOff is set once bd rejects a completion comment so the rest
+	// of the cycle stops trying.
+	annotationsOff atomic.Bool
 }
 
 type sessionReport struct {
@@ -86,6 +236,42 @@ type sessionReport struct {
 	Worktree   string
 	FinalCycle int
 	Cycles     []cycleReport
+	Progress   beadProgress
+	// Findings holds the reports a review-only cycle collected.
+	Findings []reviewFinding
+	// Offloaded labels the beads moved off the session for being over
+	// capacity.
+	Offloaded []string
+}
+
+// beadProgress compares the beads (and points) originally assigned to a
+// session with the ones it closed.
+type beadProgress struct {
+	AssignedBeads   int
+	CompletedBeads  int
+	AssignedPoints  int
+	CompletedPoints int
+}
+
+func (p *beadProgress) add(other beadProgress) {
+	p.AssignedBeads += other.AssignedBeads
+	p.CompletedBeads += other.CompletedBeads
+	p.AssignedPoints += other.AssignedPoints
+	p.CompletedPoints += other.CompletedPoints
+}
+
+func (p beadProgress) String() string {
+	return fmt.Sprintf("%d/%d beads (%d%%), %d/%d points (%d%%), %d bead(s) remaining",
+		p.CompletedBeads, p.AssignedBeads, percentOf(p.CompletedBeads, p.AssignedBeads),
+		p.CompletedPoints, p.AssignedPoints, percentOf(p.CompletedPoints, p.AssignedPoints),
+		p.AssignedBeads-p.CompletedBeads)
+}
+
+func percentOf(part, total int) int {
+	if total <= 0 {
+		return 0
+	}
+	return int(math.Round(float64(part) * 100 / float64(total)))
 }
 
 type dreamRequest struct {
@@ -110,6 +296,35 @@ type cycleSession struct {
 	agentWindow  string
 	beadsByID    map[string]Bead
 	allBeads     map[string]Bead
+	stalled      int
+	// conductor names the orchestrator agent handling this session's
+	// questions and reviews; empty uses the default agent.
+	conductor string
+	// idleRedispatches counts how often the current cycle was re-dispatched
+	// after its agent went idle.
+	idleRedispatches int
+	// offloaded labels the beads offloadOverCapacity moved off the session.
+	offloaded []string
+	// helpSeen holds the '# need help' entries already escalated.
+	helpSeen map[string]struct{}
+}
+
+// progress reports how many of the originally assigned beads have closed.
+func (cs *cycleSession) progress() beadProgress {
+	remaining := make(map[string]struct{}, len(cs.Beads))
+	for _, bead := range cs.Beads {
+		remaining[canonicalBeadKey(bead.ID)] = struct{}{}
+	}
+	var p beadProgress
+	for key, bead := range cs.allBeads {
+		p.AssignedBeads++
+		p.AssignedPoints += bead.Points
+		if _, open := remaining[key]; !open {
+			p.CompletedBeads++
+			p.CompletedPoints += bead.Points
+		}
+	}
+	return p
 }
 
 func (cs *cycleSession) rebuildBeadIndex() {
@@ -133,6 +348,9 @@ func (cs *cycleSession) describeBeadList(ids []string) []string {
 func (cs *cycleSession) beadLabel(id string) string {
 	key := canonicalBeadKey(id)
 	if bead, ok := cs.allBeads[key]; ok {
+		if bead.OrphanedParent != "" {
+			return fmt.Sprintf("%s · %s (orphaned: parent %s missing)", bead.ID, bead.Title, bead.OrphanedParent)
+		}
 		return fmt.Sprintf("%s · %s", bead.ID, bead.Title)
 	}
 	return strings.TrimSpace(id)
@@ -186,7 +404,9 @@ func (m *upCycleManager) runDownCycle(ctx context.Context) error {
 	if err := m.runLocalDreaming(ctx); err != nil {
 		return err
 	}
-	if err := m.landWorktrees(ctx); err != nil {
+	if m.config.ReviewOnly {
+		m.skipLanding()
+	} else if err := m.landWorktrees(ctx); err != nil {
 		return err
 	}
 	if err := m.writeDownCycleLog(reports); err != nil {
@@ -195,7 +415,8 @@ func (m *upCycleManager) runDownCycle(ctx context.Context) error {
 	if err := m.destroyWorktrees(); err != nil {
 		return err
 	}
-	return m.finalizeCycle()
+	m.emit(nil, CycleEvent{Type: CycleEventCycleLanded})
+	return m.finalizeCycle(ctx)
 }
 
 func (m *upCycleManager) runAgentSummaries(ctx context.Context) error {
@@ -210,6 +431,7 @@ func (m *upCycleManager) runAgentSummaries(ctx context.Context) error {
 		default:
 		}
 		summaryPath := filepath.Join(cs.Path, "SUMMARY.md")
+		m.allowInvocation("summary for "+cs.Name, true)
 		window := fmt.Sprintf("summary-%d-%d", cs.Number, time.Now().UnixNano())
 		if err := m.orchestrator.createTmuxWindowInDir(window, cs.Path); err != nil {
 			return err
@@ -226,7 +448,7 @@ func (m *upCycleManager) runAgentSummaries(ctx context.Context) error {
 			m.orchestrator.killTmuxWindow(window)
 			return err
 		}
-		if err := m.orchestrator.waitForFile(summaryPath, 5*time.Minute); err != nil {
+		if err := m.orchestrator.waitForSessionFile(window, summaryPath, 5*time.Minute); err != nil {
 			m.orchestrator.killTmuxWindow(window)
 			return err
 		}
@@ -245,6 +467,10 @@ func (m *upCycleManager) collectSessionReports() ([]sessionReport, error) {
 		reports = append(reports, report)
 		status := WorktreeStatus{Phase: "down-cycle", State: "archived", Cycle: report.FinalCycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
 		_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
+		// Prune only once the report has read every archived agent event.
+		if err := pruneSessionArchive(cs.Path, m.config.ArchiveRetention); err != nil {
+			_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Archive pruning failed: %v", err))
+		}
 	}
 	return reports, nil
 }
@@ -259,6 +485,7 @@ func (m *upCycleManager) runOrchestratorSummary(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	m.allowInvocation("cycle summary", true)
 	window := fmt.Sprintf("down-cycle-%d", time.Now().UnixNano())
 	if err := m.orchestrator.createTmuxWindow(window); err != nil {
 		return err
@@ -272,7 +499,7 @@ func (m *upCycleManager) runOrchestratorSummary(ctx context.Context) error {
 	m.cycleSummary = cycleSummary
 	summaryGlob := filepath.Join(m.orchestrator.config.LatticeProjectDir, "worktree", "*", "*", "SUMMARY.md")
 	planPath := filepath.Join(m.orchestrator.config.LatticeProjectDir, "workflow", "action", "PLAN.md")
-	repoMemory := filepath.Join(m.orchestrator.config.LatticeProjectDir, "state", "REPO_MEMORY.md")
+	repoMemory := m.orchestrator.repoMemoryPath()
 	prompt := fmt.Sprintf(
 		"All worktrees have produced SUMMARY.md files. Load the orchestrator skill at %s and execute it now. Read every summary matching %s, update %s to reflect actual bead status, update repo memory at %s, and write the cycle summary to %s for cycle %d. Assign special agents for stuck work, create beads for new bugs, and ensure repo learnings are captured. Do not finish until the cycle summary file exists and PLAN.md plus REPO_MEMORY.md are updated accordingly.",
 		skillPath,
@@ -285,7 +512,7 @@ func (m *upCycleManager) runOrchestratorSummary(ctx context.Context) error {
 	if err := m.orchestrator.runOpenCode(prompt, window, ""); err != nil {
 		return err
 	}
-	return m.orchestrator.waitForFile(cycleSummary, m.config.OrchestratorTimeout)
+	return m.orchestrator.waitForSessionFile(window, cycleSummary, m.config.OrchestratorTimeout)
 }
 
 func (m *upCycleManager) runLocalDreaming(ctx context.Context) error {
@@ -303,6 +530,9 @@ func (m *upCycleManager) runLocalDreaming(ctx context.Context) error {
 			return ctx.Err()
 		default:
 		}
+		if !m.allowInvocation("dreaming for "+req.agent.Name, false) {
+			continue
+		}
 		agentDir := filepath.Dir(req.agent.Path)
 		if err := os.MkdirAll(agentDir, 0755); err != nil {
 			return err
@@ -325,7 +555,7 @@ func (m *upCycleManager) runLocalDreaming(ctx context.Context) error {
 			m.orchestrator.killTmuxWindow(window)
 			return err
 		}
-		if err := m.orchestrator.waitForFile(memoryPath, m.config.ResponseTimeout); err != nil {
+		if err := m.orchestrator.waitForSessionFile(window, memoryPath, m.config.ResponseTimeout); err != nil {
 			m.orchestrator.killTmuxWindow(window)
 			return err
 		}
@@ -376,20 +606,42 @@ func (m *upCycleManager) findOrchestratorAgent() (*ProjectAgent, error) {
 			return &agent, nil
 		}
 	}
-	return nil, fmt.Errorf("orchestrator agent %s not found", workerList.Orchestrator.Name)
+	return nil, fmt.Errorf("%w: %s", ErrOrchestratorAgentNotFound, workerList.Orchestrator.Name)
 }
 
-func ensureGitClean(dir string) error {
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = dir
-	output, err := cmd.Output()
+// assignConductors shards sessions across the primary orchestrator and any
+// co-conductors by session number. Conductors without a project agent file are
+// skipped; with a single conductor every session keeps the default agent.
+func (m *upCycleManager) assignConductors() {
+	names := m.orchestrator.conductorNames()
+	if len(names) < 2 {
+		return
+	}
+	agents, err := m.orchestrator.loadProjectAgents()
 	if err != nil {
-		return fmt.Errorf("git status failed in %s: %w", dir, err)
+		return
 	}
-	if strings.TrimSpace(string(output)) != "" {
-		return fmt.Errorf("worktree %s still has pending changes after landing", dir)
+	known := make(map[string]string, len(agents))
+	for _, agent := range agents {
+		known[strings.ToLower(strings.TrimSpace(agent.Name))] = agent.Name
+	}
+	var conductors []string
+	for _, name := range names {
+		if agentName, ok := known[strings.ToLower(name)]; ok {
+			conductors = append(conductors, agentName)
+		}
+	}
+	if len(conductors) < 2 {
+		return
+	}
+	for _, cs := range m.sessions {
+		idx := cs.Number - 1
+		if idx < 0 {
+			idx = 0
+		}
+		cs.conductor = conductors[idx%len(conductors)]
+		_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Conductor %s assigned to %s", cs.conductor, cs.Name))
 	}
-	return nil
 }
 
 func (m *upCycleManager) landWorktrees(ctx context.Context) error {
@@ -400,6 +652,7 @@ func (m *upCycleManager) landWorktrees(ctx context.Context) error {
 			return ctx.Err()
 		default:
 		}
+		m.allowInvocation("landing "+cs.Name, true)
 		window := fmt.Sprintf("land-%d-%d", cs.Number, time.Now().UnixNano())
 		if err := m.orchestrator.createTmuxWindowInDir(window, cs.Path); err != nil {
 			return err
@@ -414,9 +667,12 @@ func (m *upCycleManager) landWorktrees(ctx context.Context) error {
 			return err
 		}
 		_ = m.orchestrator.killTmuxWindow(window)
-		if err := ensureGitClean(cs.Path); err != nil {
+		if err := m.orchestrator.checkLandedWorktree(cs.WorktreeSession); err != nil {
+			status := WorktreeStatus{Phase: "down-cycle", State: "conflict", Cycle: cs.cycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
+			_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
 			return err
 		}
+		m.emit(cs, CycleEvent{Type: CycleEventWorktreeLanded})
 	}
 	return nil
 }
@@ -424,7 +680,7 @@ func (m *upCycleManager) landWorktrees(ctx context.Context) error {
 func (m *upCycleManager) destroyWorktrees() error {
 	parents := make(map[string]struct{})
 	for _, cs := range m.sessions {
-		if err := m.orchestrator.invokeWorktreeDelete(cs.Name, "cycle complete"); err != nil {
+		if err := m.orchestrator.invokeWorktreeDelete(cs.Name, cs.Path, "cycle complete"); err != nil {
 			return err
 		}
 		parent := filepath.Dir(cs.Path)
@@ -441,25 +697,105 @@ func (m *upCycleManager) destroyWorktrees() error {
 	return nil
 }
 
-func (m *upCycleManager) finalizeCycle() error {
-	nextCycle, err := m.orchestrator.incrementCycleNumber()
+func (m *upCycleManager) finalizeCycle(ctx context.Context) error {
+	halt, err := m.recordCycleProgress()
 	if err != nil {
 		return err
 	}
-	worktreeBase := m.orchestrator.config.WorktreeDir()
-	if err := os.RemoveAll(worktreeBase); err != nil {
+	nextCycle, err := m.orchestrator.incrementCycleNumber()
+	if err != nil {
 		return err
 	}
-	if err := m.orchestrator.clearCycleTracker(); err != nil {
-		return err
+	if len(m.idle) > 0 {
+		if err := m.orchestrator.trackIdleSessions(nextCycle, m.idle); err != nil {
+			return err
+		}
+	} else {
+		worktreeBase := m.orchestrator.config.WorktreeDir()
+		if err := os.RemoveAll(worktreeBase); err != nil {
+			return err
+		}
+		if err := m.orchestrator.clearCycleTracker(); err != nil {
+			return err
+		}
+	}
+	if halt {
+		return fmt.Errorf("cycle %d: %d consecutive cycle(s) without progress: %w", m.cycleNumber, m.config.MaxStalledCycles, ErrNoProgress)
+	}
+	if err := waitCooldown(ctx, m.config.CycleCooldown); err != nil {
+		return fmt.Errorf("cycle %d: cooldown before cycle %d: %w", m.cycleNumber, nextCycle, err)
+	}
+	if m.startNextCycle != nil {
+		return m.startNextCycle(nextCycle)
 	}
 	return m.orchestrator.restartInitialPromptWithCycle(nextCycle)
 }
 
+// waitCooldown blocks for d, returning early with the context error when ctx
+// is cancelled.
+func waitCooldown(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// recordCycleProgress updates the consecutive no-progress counter in the cycle
+// state and reports whether the stall limit has been reached. The counter is
+// reset when the limit trips so the next run after escalation starts fresh.
+func (m *upCycleManager) recordCycleProgress() (bool, error) {
+	state, err := m.orchestrator.readCycleState()
+	if os.IsNotExist(err) {
+		state.Current = m.cycleNumber
+	} else if err != nil {
+		return false, err
+	}
+	if m.closedBeadCount() > 0 {
+		state.Stalled = 0
+	} else {
+		state.Stalled++
+	}
+	halt := m.config.MaxStalledCycles > 0 && state.Stalled >= m.config.MaxStalledCycles
+	if halt {
+		state.Stalled = 0
+	}
+	if err := os.MkdirAll(filepath.Dir(m.orchestrator.cycleStatePath()), 0755); err != nil {
+		return false, err
+	}
+	if err := m.orchestrator.writeCycleState(state); err != nil {
+		return false, err
+	}
+	return halt, nil
+}
+
+func (m *upCycleManager) closedBeadCount() int {
+	closed := 0
+	for _, cs := range m.sessions {
+		closed += len(cs.allBeads) - len(cs.Beads)
+	}
+	return closed
+}
+
 func (m *upCycleManager) buildSessionReport(cs *cycleSession) (sessionReport, error) {
 	report := sessionReport{
-		Agent:    cs.Agent.Name,
-		Worktree: cs.Name,
+		Agent:     cs.Agent.Name,
+		Worktree:  cs.Name,
+		Progress:  cs.progress(),
+		Offloaded: append([]string(nil), cs.offloaded...),
+	}
+	if m.config.ReviewOnly {
+		findings, err := readReviewFindings(cs)
+		if err != nil {
+			return sessionReport{}, err
+		}
+		report.Findings = findings
 	}
 	dir := filepath.Join(cs.Path, "archive", "events")
 	entries, err := os.ReadDir(dir)
@@ -524,10 +860,34 @@ func (m *upCycleManager) writeDownCycleLog(reports []sessionReport) error {
 	}
 	defer f.Close()
 	timestamp := time.Now().UTC().Format(time.RFC3339)
-	fmt.Fprintf(f, "\n## Down cycle summary (%s)\n\n", timestamp)
+	fmt.Fprintf(f, "\n%s (cycle %d, %s)\n\n", downCycleLogHeading, m.cycleNumber, timestamp)
+	var overall beadProgress
+	for _, report := range reports {
+		overall.add(report.Progress)
+	}
+	fmt.Fprintf(f, "Overall progress: %s\n\n", overall)
+	if !m.started.IsZero() {
+		fmt.Fprintf(f, "%s %s\n\n", cycleDurationLabel, time.Since(m.started).Round(time.Second))
+	}
+	if line := m.invocationBudgetLine(); line != "" {
+		fmt.Fprintf(f, "%s\n\n", line)
+	}
+	if m.config.ReviewOnly {
+		fmt.Fprintf(f, "%s\n\n", reviewOnlyLogLine)
+	}
+	if len(m.metadata) > 0 {
+		fmt.Fprintf(f, "%s %s\n\n", cycleMetadataLabel, formatCycleMetadata(m.metadata))
+	}
 	for _, report := range reports {
 		fmt.Fprintf(f, "### %s — %s\n", report.Worktree, report.Agent)
+		fmt.Fprintf(f, "- progress: %s\n", report.Progress)
 		fmt.Fprintf(f, "- cycles run: %d\n", len(report.Cycles))
+		if len(report.Offloaded) > 0 {
+			fmt.Fprintf(f, "- offloaded to the next cycle: %s\n", strings.Join(report.Offloaded, "; "))
+		}
+		if m.config.ReviewOnly {
+			writeReviewFindings(f, report.Findings)
+		}
 		if len(report.Cycles) == 0 {
 			fmt.Fprintln(f, "- no agent cycle data recorded")
 			continue
@@ -552,17 +912,52 @@ func (m *upCycleManager) writeDownCycleLog(reports []sessionReport) error {
 func (m *upCycleManager) runSession(ctx context.Context, cs *cycleSession) error {
 	defer cs.stopQuestionWatcher()
 	for {
+		if err := m.waitWhileSuspended(ctx, cs); err != nil {
+			return fmt.Errorf("session %s: %w", cs.Name, err)
+		}
+		if !m.allowInvocation(fmt.Sprintf("%s agent cycle %d", cs.Name, cs.cycle), cs.cycle == 1 && cs.idleRedispatches == 0) {
+			status := WorktreeStatus{Phase: "up-cycle", State: "deferred", Cycle: cs.cycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
+			_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
+			_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Invocation budget spent; deferring %d bead(s) for %s to the next cycle", len(cs.Beads), cs.Agent.Name))
+			return nil
+		}
+		if err := m.checkSessionScaffold(cs); err != nil {
+			return err
+		}
 		if err := m.startAgentCycle(ctx, cs); err != nil {
 			return err
 		}
 		agentEvent, err := m.waitForAgentEvent(ctx, cs)
+		if errors.Is(err, ErrAgentIdle) {
+			if cs.idleRedispatches >= m.config.AgentIdleRetries {
+				status := WorktreeStatus{Phase: "up-cycle", State: "failed", Cycle: cs.cycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
+				_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
+				return err
+			}
+			cs.idleRedispatches++
+			if m.dropClosedBeads(cs) {
+				return nil
+			}
+			_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Re-dispatching cycle %d to %s with %d bead(s) (idle retry %d of %d)", cs.cycle, cs.Agent.Name, len(cs.Beads), cs.idleRedispatches, m.config.AgentIdleRetries))
+			continue
+		}
 		if err != nil {
 			return err
 		}
+		if err := m.checkEventBeadIDs(cs, agentEvent); err != nil {
+			return err
+		}
 		if err := m.runPostCycleOrchestrator(ctx, cs, agentEvent); err != nil {
 			return err
 		}
 		remaining := m.filterRemainingBeads(cs, agentEvent.RemainingBeads)
+		remaining = m.applyReestimates(cs, agentEvent, remaining)
+		if len(remaining) > 0 && len(remaining) >= len(cs.Beads) {
+			cs.stalled++
+		} else {
+			cs.stalled = 0
+		}
+		remaining = m.offloadOverCapacity(cs, agentEvent, remaining)
 		cs.Beads = remaining
 		cs.WorktreeSession.Beads = remaining
 		cs.rebuildBeadIndex()
@@ -572,8 +967,29 @@ func (m *upCycleManager) runSession(ctx context.Context, cs *cycleSession) error
 			_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Cycle %d complete for %s", cs.cycle, cs.Agent.Name))
 			return nil
 		}
+		if m.config.MaxStalledCycles > 0 && cs.stalled >= m.config.MaxStalledCycles {
+			status := WorktreeStatus{Phase: "up-cycle", State: "stalled", Cycle: cs.cycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
+			_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
+			_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("No progress after %d cycle(s) for %s; leaving %d bead(s) for escalation", cs.stalled, cs.Agent.Name, len(remaining)))
+			return nil
+		}
 		cs.cycle++
+		cs.idleRedispatches = 0
+		m.orchestrator.syncBeadsIfDue(fmt.Sprintf("%s agent cycle %d", cs.Name, cs.cycle))
+	}
+}
+
+// checkSessionScaffold refuses to dispatch an agent into a session whose
+// mailboxes or WORKTREE.md are missing, since the agent would have nowhere to
+// post questions or its completion event.
+func (m *upCycleManager) checkSessionScaffold(cs *cycleSession) error {
+	missing := ValidateSessionDir(cs.Path)
+	if len(missing) == 0 {
+		return nil
 	}
+	list := strings.Join(missing, ", ")
+	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Cycle %d not dispatched; session is missing %s", cs.cycle, list))
+	return fmt.Errorf("session %s cycle %d: %w: missing %s", cs.Name, cs.cycle, ErrSessionScaffold, list)
 }
 
 func (m *upCycleManager) startAgentCycle(ctx context.Context, cs *cycleSession) error {
@@ -602,6 +1018,7 @@ func (m *upCycleManager) startAgentCycle(ctx context.Context, cs *cycleSession)
 		return fmt.Errorf("session %s: failed to launch agent: %w", cs.Name, err)
 	}
 	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Cycle %d dispatched to %s", cs.cycle, cs.Agent.Name))
+	m.emit(cs, CycleEvent{Type: CycleEventAgentDispatched, Beads: beadIDs(cs.Beads)})
 	return nil
 }
 
@@ -609,20 +1026,20 @@ func (m *upCycleManager) waitForAgentEvent(ctx context.Context, cs *cycleSession
 	dir := filepath.Join(cs.Path, "outbox", "events")
 	ticker := time.NewTicker(m.config.EventPollInterval)
 	defer ticker.Stop()
+	lastActivity := time.Now()
 	for {
 		select {
 		case <-ctx.Done():
 			return worktreeEvent{}, ctx.Err()
 		case <-ticker.C:
 			entries, err := os.ReadDir(dir)
-			if err != nil {
-				if errors.Is(err, os.ErrNotExist) {
-					continue
-				}
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
 				return worktreeEvent{}, fmt.Errorf("session %s: read events: %w", cs.Name, err)
 			}
 			if len(entries) == 0 {
-				continue
+				if err := m.checkAgentExit(cs); err != nil {
+					return worktreeEvent{}, err
+				}
 			}
 			sort.Slice(entries, func(i, j int) bool {
 				return entries[i].Name() < entries[j].Name()
@@ -635,8 +1052,11 @@ func (m *upCycleManager) waitForAgentEvent(ctx context.Context, cs *cycleSession
 				if _, ok := cs.eventSeen[path]; ok {
 					continue
 				}
+				evt, settled, err := readSettledEvent(path, m.config.EventSettleWindow, time.Now())
+				if !settled {
+					continue
+				}
 				cs.eventSeen[path] = struct{}{}
-				evt, err := readWorktreeEvent(path)
 				if err != nil {
 					_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Failed to parse %s: %v", entry.Name(), err))
 					continue
@@ -652,30 +1072,159 @@ func (m *upCycleManager) waitForAgentEvent(ctx context.Context, cs *cycleSession
 					_ = m.orchestrator.killTmuxWindow(cs.agentWindow)
 					cs.agentWindow = ""
 				}
+				m.emit(cs, CycleEvent{Type: CycleEventAgentComplete, Beads: evt.CompletedBeads, Remaining: evt.RemainingBeads})
 				return evt, nil
 			}
+			if err := m.checkAgentIdle(cs, &lastActivity, time.Now()); err != nil {
+				return worktreeEvent{}, err
+			}
+		}
+	}
+}
+
+// checkAgentIdle closes the agent window and returns ErrAgentIdle once
+// neither WORKTREE.md nor the session's event, question, or response files
+// have changed for UpCycleConfig.AgentIdleTimeout. lastActivity is advanced
+// to the newest change seen. The timer is paused while a question is waiting
+// for its response, since the agent was told to wait for it.
+func (m *upCycleManager) checkAgentIdle(cs *cycleSession, lastActivity *time.Time, now time.Time) error {
+	timeout := m.config.AgentIdleTimeout
+	if timeout <= 0 || cs.agentWindow == "" {
+		return nil
+	}
+	if awaitingResponse(cs.Path) {
+		*lastActivity = now
+		return nil
+	}
+	if latest := latestSessionActivity(cs.Path); latest.After(*lastActivity) {
+		*lastActivity = latest
+	}
+	idle := now.Sub(*lastActivity)
+	if idle < timeout {
+		return nil
+	}
+	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Cycle %d agent %s idle for %s with no event or WORKTREE.md update; closing %s", cs.cycle, cs.Agent.Name, idle.Round(time.Second), cs.agentWindow))
+	_ = m.orchestrator.killTmuxWindow(cs.agentWindow)
+	cs.agentWindow = ""
+	return fmt.Errorf("session %s cycle %d: %w after %s", cs.Name, cs.cycle, ErrAgentIdle, timeout)
+}
+
+// latestSessionActivity returns the newest modification time of the
+// session's WORKTREE.md, outbox events and questions, and inbox responses.
+func latestSessionActivity(sessionPath string) time.Time {
+	var latest time.Time
+	paths := []string{filepath.Join(sessionPath, "WORKTREE.md")}
+	for _, dir := range []string{
+		filepath.Join(sessionPath, "outbox", "events"),
+		filepath.Join(sessionPath, "outbox", "questions"),
+		filepath.Join(sessionPath, "inbox", "responses"),
+	} {
+		if entries, err := os.ReadDir(dir); err == nil {
+			for _, entry := range entries {
+				paths = append(paths, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// awaitingResponse reports whether a question in the session's outbox has
+// no response yet.
+func awaitingResponse(sessionPath string) bool {
+	_, waiting := countPendingQuestions(sessionPath)
+	return waiting > 0
+}
+
+// dropClosedBeads removes beads bd already reports closed before an idle
+// cycle is re-dispatched, so the new agent does not redo them and progress
+// counts them once. It reports whether no beads remain, in which case the
+// session is marked complete.
+func (m *upCycleManager) dropClosedBeads(cs *cycleSession) bool {
+	var remaining []Bead
+	var closed []string
+	for _, bead := range cs.Beads {
+		output, err := m.orchestrator.runProjectCommand("bd", "show", bead.ID, "--json")
+		if err == nil {
+			records, parseErr := parseBeadRecords([]byte(output), m.orchestrator.beadProfile())
+			if parseErr == nil && len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0].Status), "closed") {
+				closed = append(closed, bead.ID)
+				continue
+			}
 		}
+		remaining = append(remaining, bead)
+	}
+	if len(closed) == 0 {
+		return false
+	}
+	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Idle agent already closed %s; not re-dispatching them", strings.Join(closed, ", ")))
+	m.forgetClosedReestimates(cs, closed)
+	cs.Beads = remaining
+	cs.WorktreeSession.Beads = remaining
+	cs.rebuildBeadIndex()
+	if len(remaining) > 0 {
+		return false
 	}
+	status := WorktreeStatus{Phase: "up-cycle", State: "complete", Cycle: cs.cycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
+	_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
+	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Cycle %d complete for %s", cs.cycle, cs.Agent.Name))
+	return true
+}
+
+// forgetClosedReestimates drops recorded re-estimates for beads that closed
+// in this session, logging rather than failing when the file cannot be saved.
+func (m *upCycleManager) forgetClosedReestimates(cs *cycleSession, ids []string) {
+	if err := m.orchestrator.forgetReestimates(ids...); err != nil {
+		_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Re-estimates of closed beads not cleared: %v", err))
+	}
+}
+
+// checkAgentExit fails the session when its agent's opencode process exited
+// non-zero before writing a completion event.
+func (m *upCycleManager) checkAgentExit(cs *cycleSession) error {
+	if cs.agentWindow == "" {
+		return nil
+	}
+	_, err := m.orchestrator.checkSessionExit(cs.agentWindow)
+	var exitErr *SessionExitError
+	if errors.As(err, &exitErr) {
+		_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Cycle %d agent session exited with status %d (log: %s)", cs.cycle, exitErr.ExitCode, exitErr.LogPath))
+		status := WorktreeStatus{Phase: "up-cycle", State: "failed", Cycle: cs.cycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
+		_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
+	}
+	if err != nil {
+		return fmt.Errorf("session %s cycle %d: %w", cs.Name, cs.cycle, err)
+	}
+	return nil
 }
 
 func (m *upCycleManager) runPostCycleOrchestrator(ctx context.Context, cs *cycleSession, evt worktreeEvent) error {
+	m.escalateHelpRequests(cs)
 	status := WorktreeStatus{Phase: "up-cycle", State: "review", Cycle: cs.cycle, Global: m.cycleNumber, Updated: time.Now().UTC()}
 	_ = updateWorktreeStatusFile(cs.WorktreeSession, status)
+	m.allowInvocation(fmt.Sprintf("%s review %d", cs.Name, cs.cycle), true)
 	window := fmt.Sprintf("worktree-orchestrator-%d-%d", cs.Number, cs.cycle)
 	if err := m.orchestrator.createTmuxWindowInDir(window, cs.Path); err != nil {
 		return fmt.Errorf("session %s: orchestrator window: %w", cs.Name, err)
 	}
 	defer m.orchestrator.killTmuxWindow(window)
 	marker := filepath.Join(cs.Path, "outbox", "events", fmt.Sprintf("orchestrator-cycle-%d.json", cs.cycle))
-	prompt := m.buildOrchestratorPrompt(cs, evt, marker)
-	if err := m.orchestrator.runOpenCode(prompt, window, ""); err != nil {
+	prompt := m.buildOrchestratorPrompt(cs, evt, marker, m.planUnrelatedBugs(cs))
+	if err := m.orchestrator.runOpenCode(prompt, window, cs.conductor); err != nil {
 		return fmt.Errorf("session %s: orchestrator launch: %w", cs.Name, err)
 	}
-	if err := m.orchestrator.waitForFile(marker, m.config.OrchestratorTimeout); err != nil {
+	if err := m.orchestrator.waitForSessionFile(window, marker, m.config.OrchestratorTimeout); err != nil {
 		return fmt.Errorf("session %s: orchestrator timeout: %w", cs.Name, err)
 	}
 	_ = m.archiveEventFile(cs, marker)
 	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Orchestrator finished cycle %d", cs.cycle))
+	m.annotateCompletedBeads(cs, evt)
+	m.forgetClosedReestimates(cs, evt.CompletedBeads)
+	m.emit(cs, CycleEvent{Type: CycleEventOrchestratorDone})
 	if err := m.archiveWorktree(cs, len(evt.RemainingBeads) > 0); err != nil {
 		return fmt.Errorf("session %s: archive worktree: %w", cs.Name, err)
 	}
@@ -708,6 +1257,8 @@ func (m *upCycleManager) watchQuestions(ctx context.Context, cs *cycleSession) {
 					continue
 				}
 				cs.questionSeen[path] = struct{}{}
+				logQuestionDeviation(cs, path)
+				m.emit(cs, CycleEvent{Type: CycleEventQuestionRaised, Question: entry.Name()})
 				go m.handleQuestion(ctx, cs, path)
 			}
 		}
@@ -725,15 +1276,59 @@ func (m *upCycleManager) handleQuestion(ctx context.Context, cs *cycleSession, q
 		if fileExists(responsePath) {
 			return
 		}
+		release, ok := m.acquireAutoResponseSlot(ctx)
+		if !ok {
+			return
+		}
+		defer release()
+		if fileExists(responsePath) {
+			return
+		}
+		if !m.allowInvocation("auto-answer for "+filepath.Base(questionPath), false) {
+			_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Invocation budget spent; leaving %s for the agent to resolve", filepath.Base(questionPath)))
+			if err := writeFallbackResponse(responsePath, "the invocation budget for this cycle is spent"); err != nil {
+				_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Fallback response for %s failed: %v", filepath.Base(questionPath), err))
+			}
+			m.escalateQuestion(cs, questionPath, "invocation budget spent before the orchestrator could answer")
+			return
+		}
 		_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Auto-orchestrator responding to %s", filepath.Base(questionPath)))
 		if err := m.spawnAutoResponse(cs, questionPath, responsePath); err != nil {
 			_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Auto-response failed: %v", err))
+			m.escalateQuestion(cs, questionPath, fmt.Sprintf("auto-response failed: %v", err))
 		}
 	}
 }
 
+// acquireAutoResponseSlot waits for room under the cross-session
+// auto-response cap. It reports false when ctx ends first.
+func (m *upCycleManager) acquireAutoResponseSlot(ctx context.Context) (func(), bool) {
+	if m.autoResponseSlots == nil {
+		return func() {}, true
+	}
+	select {
+	case m.autoResponseSlots <- struct{}{}:
+		return func() { <-m.autoResponseSlots }, true
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// writeFallbackResponse answers a question the orchestrator will not
+// auto-answer, so the agent stops waiting instead of idling until the cycle
+// ends.
+func writeFallbackResponse(responsePath, reason string) error {
+	if err := os.MkdirAll(filepath.Dir(responsePath), 0755); err != nil {
+		return err
+	}
+	body := fmt.Sprintf("No automatic answer is available: %s.\n\n"+
+		"Proceed with your best judgement if you can do so safely. Otherwise record the blocker in WORKTREE.md, "+
+		"add a `# need help` entry, and move on to your next bead.\n", reason)
+	return os.WriteFile(responsePath, []byte(body), 0644)
+}
+
 func (m *upCycleManager) spawnAutoResponse(cs *cycleSession, questionPath, responsePath string) error {
-	window := fmt.Sprintf("worktree-help-%d-%d", cs.Number, time.Now().Unix())
+	window := fmt.Sprintf("worktree-help-%d-%d", cs.Number, time.Now().UnixNano())
 	if err := m.orchestrator.createTmuxWindowInDir(window, cs.Path); err != nil {
 		return err
 	}
@@ -744,10 +1339,10 @@ func (m *upCycleManager) spawnAutoResponse(cs *cycleSession, questionPath, respo
 			"Write a concise response to %s. Provide a direct answer or advise them to continue with best judgement.",
 		questionPath, worktreePath, responsePath,
 	)
-	if err := m.orchestrator.runOpenCode(prompt, window, ""); err != nil {
+	if err := m.orchestrator.runOpenCode(prompt, window, cs.conductor); err != nil {
 		return err
 	}
-	return m.orchestrator.waitForFile(responsePath, m.config.ResponseTimeout)
+	return m.orchestrator.waitForSessionFile(window, responsePath, m.config.ResponseTimeout)
 }
 
 func (m *upCycleManager) archiveWorktree(cs *cycleSession, hasRemaining bool) error {
@@ -772,6 +1367,41 @@ func (m *upCycleManager) archiveWorktree(cs *cycleSession, hasRemaining bool) er
 	return writeWorktreeState(sessionCopy, nextStatus)
 }
 
+// PreviewAgentPrompt renders the prompt the agent for session receives on the
+// given cycle, without creating tmux windows or launching opencode. The
+// prompt uses the same cycle settings a run would, so configure the
+// orchestrator the way the work-process module does first.
+func (o *Orchestrator) PreviewAgentPrompt(session WorktreeSession, cycle int) (string, error) {
+	if cycle < 1 {
+		cycle = 1
+	}
+	finalSkillPath, err := skills.Ensure(o.config.SkillsDir(), skills.FinalSession)
+	if err != nil {
+		return "", err
+	}
+	mgr := o.newUpCycleManager(cycle, []WorktreeSession{session})
+	cs := mgr.sessions[0]
+	cs.cycle = cycle
+	return mgr.buildAgentPrompt(cs, finalSkillPath), nil
+}
+
+// repoMemoryLine returns the prompt line pointing the agent at the repo
+// memory, or "" when onboarding is disabled or the file does not exist yet.
+func (m *upCycleManager) repoMemoryLine() string {
+	if !m.config.RepoMemory {
+		return ""
+	}
+	path := m.orchestrator.repoMemoryPath()
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Size() == 0 {
+		return ""
+	}
+	if limit := m.config.RepoMemoryMaxBytes; limit > 0 && info.Size() > limit {
+		return fmt.Sprintf("Repo memory: %s (%d KB, over the %d KB limit; read the sections relevant to your beads before working)\n", path, (info.Size()+1023)/1024, limit/1024)
+	}
+	return fmt.Sprintf("Repo memory: %s (load fully before working)\n", path)
+}
+
 func (m *upCycleManager) buildAgentPrompt(cs *cycleSession, finalSkillPath string) string {
 	worktreePath := filepath.Join(cs.Path, "WORKTREE.md")
 	questionDir := filepath.Join(cs.Path, "outbox", "questions")
@@ -783,9 +1413,25 @@ func (m *upCycleManager) buildAgentPrompt(cs *cycleSession, finalSkillPath strin
 	if memoryPath != "" {
 		memoryLine = fmt.Sprintf("Personal memory: %s (load fully before working)\n", memoryPath)
 	}
+	memoryLine = m.repoMemoryLine() + memoryLine
+	inline := cs.Beads
+	if limit := m.config.MaxPromptBeads; limit > 0 && len(inline) > limit {
+		inline = inline[:limit]
+	}
 	var beadLines []string
-	for _, bead := range cs.Beads {
-		beadLines = append(beadLines, fmt.Sprintf("- %s · %s (%d pt)", bead.ID, bead.Title, bead.Points))
+	for _, bead := range inline {
+		line := fmt.Sprintf("- %s · %s (%d pt)", bead.ID, bead.Title, bead.Points)
+		if deps := bead.dependencies(); len(deps) > 0 {
+			line += fmt.Sprintf(" — start after %s", strings.Join(deps, ", "))
+		}
+		beadLines = append(beadLines, line)
+	}
+	if rest := cs.Beads[len(inline):]; len(rest) > 0 {
+		points := 0
+		for _, bead := range rest {
+			points += bead.Points
+		}
+		beadLines = append(beadLines, fmt.Sprintf("- …and %d more bead(s) (%d pt); see Assigned Beads in %s for the full list", len(rest), points, worktreePath))
 	}
 	beadSection := "(no beads assigned)"
 	if len(beadLines) > 0 {
@@ -804,12 +1450,13 @@ func (m *upCycleManager) buildAgentPrompt(cs *cycleSession, finalSkillPath strin
 			"1. Work bead-by-bead. Keep WORKTREE.md updated with your current bead, status, and timestamps.\n"+
 			"2. If you discover an unrelated bug, log a single-sentence entry under '# unrelated bugs' in WORKTREE.md with the file path.\n"+
 			"3. If you attempt the same bead three times without success, add an entry under '# need help' with the bead ID, describe the problem, and unassign it via bd before moving on.\n"+
-			"4. Follow AGENTS.md when committing: clean working tree, run tests, git pull --rebase, bd sync, git push.\n"+
+			"4. %s\n"+
 			"5. For orchestrator questions, drop a markdown file into %s. Use the filename cycle-%d-<slug>.md and wait for an answer in %s (same slug + .response.md).\n"+
 			"6. If you wait too long, default to best judgement—but still log the question thread in WORKTREE.md.\n"+
 			"7. When you finish or hit context compaction, run the final-session-prompt skill at %s and paste the output into WORKTREE.md.\n"+
 			"8. After all work you can do this cycle is complete, write a JSON event to %s with:\n"+
 			"   {\n     \"type\": \"agent_complete\",\n     \"cycle\": %d,\n     \"completedBeads\": [..],\n     \"remainingBeads\": [..],\n     \"message\": \"notes for orchestrator\"\n   }\n"+
+			"   If a remaining bead is much bigger than its estimate, add \"reestimates\": {\"<bead-id>\": <points>} and list the remaining beads you have already started in \"startedBeads\".\n"+
 			"   Then exit.\n",
 		cs.Name,
 		cs.cycle,
@@ -818,6 +1465,7 @@ func (m *upCycleManager) buildAgentPrompt(cs *cycleSession, finalSkillPath strin
 		agentManual,
 		memoryLine,
 		beadSection,
+		m.commitGuidance(cs),
 		questionDir,
 		cs.cycle,
 		responseDir,
@@ -827,13 +1475,13 @@ func (m *upCycleManager) buildAgentPrompt(cs *cycleSession, finalSkillPath strin
 	)
 }
 
-func (m *upCycleManager) buildOrchestratorPrompt(cs *cycleSession, evt worktreeEvent, marker string) string {
+func (m *upCycleManager) buildOrchestratorPrompt(cs *cycleSession, evt worktreeEvent, marker string, bugs unrelatedBugPlan) string {
 	worktreePath := filepath.Join(cs.Path, "WORKTREE.md")
 	planPath := filepath.Join(m.orchestrator.config.LatticeProjectDir, "action", "PLAN.md")
 	return fmt.Sprintf(
 		"You are the orchestrator for %s (cycle %d).\n"+
 			"1. Read %s for the full session log.\n"+
-			"2. For each entry under '# unrelated bugs', create a new bead via bd with clear title and reference.\n"+
+			"2. %s\n"+
 			"3. For each remaining bead called out in the event summary, update its bead description/status with any relevant notes.\n"+
 			"4. For every '# need help' entry, append it to %s under '# cycle %d' -> '## help' with '- <worktree>/<bead>: <summary>'.\n"+
 			"5. Answer any outstanding agent questions if necessary.\n"+
@@ -842,6 +1490,7 @@ func (m *upCycleManager) buildOrchestratorPrompt(cs *cycleSession, evt worktreeE
 		cs.Name,
 		cs.cycle,
 		worktreePath,
+		bugs.promptStep(cs, m.cycleNumber),
 		planPath,
 		cs.cycle,
 		marker,
@@ -849,6 +1498,46 @@ func (m *upCycleManager) buildOrchestratorPrompt(cs *cycleSession, evt worktreeE
 	)
 }
 
+// checkEventBeadIDs logs bead IDs in an agent event that the session was never
+// assigned; filterRemainingBeads would otherwise drop them silently. In strict
+// mode the session fails with ErrUnknownBeadIDs.
+func (m *upCycleManager) checkEventBeadIDs(cs *cycleSession, event worktreeEvent) error {
+	unknown := unknownBeadIDs(cs.allBeads, event.RemainingBeads, event.CompletedBeads)
+	if len(unknown) == 0 {
+		return nil
+	}
+	list := strings.Join(unknown, ", ")
+	_ = appendWorktreeLog(cs.WorktreeSession, fmt.Sprintf("Cycle %d event from %s referenced unknown bead ID(s): %s", cs.cycle, cs.Agent.Name, list))
+	if m.config.StrictBeadIDs {
+		return fmt.Errorf("session %s cycle %d: %w: %s", cs.Name, cs.cycle, ErrUnknownBeadIDs, list)
+	}
+	return nil
+}
+
+// unknownBeadIDs returns the IDs across lists that are not keys of known,
+// deduplicated and in first-seen order.
+func unknownBeadIDs(known map[string]Bead, lists ...[]string) []string {
+	seen := make(map[string]struct{})
+	var unknown []string
+	for _, ids := range lists {
+		for _, id := range ids {
+			key := canonicalBeadKey(id)
+			if key == "" {
+				continue
+			}
+			if _, ok := known[key]; ok {
+				continue
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			unknown = append(unknown, strings.TrimSpace(id))
+		}
+	}
+	return unknown
+}
+
 func (m *upCycleManager) filterRemainingBeads(cs *cycleSession, remaining []string) []Bead {
 	if len(remaining) == 0 {
 		return nil
@@ -892,9 +1581,29 @@ func readWorktreeEvent(path string) (worktreeEvent, error) {
 	return evt, nil
 }
 
+// readSettledEvent reads an outbox event file the agent may still be writing.
+// A file that fails to parse reports settled=false until its modification time
+// is at least settle old; after that the parse error is returned as final.
+func readSettledEvent(path string, settle time.Duration, now time.Time) (worktreeEvent, bool, error) {
+	evt, err := readWorktreeEvent(path)
+	if err == nil {
+		return evt, true, nil
+	}
+	info, statErr := os.Stat(path)
+	if statErr != nil {
+		if errors.Is(statErr, os.ErrNotExist) {
+			return worktreeEvent{}, false, nil
+		}
+		return worktreeEvent{}, true, statErr
+	}
+	if now.Sub(info.ModTime()) < settle {
+		return worktreeEvent{}, false, nil
+	}
+	return worktreeEvent{}, true, err
+}
+
 func responsePathForQuestion(sessionPath, questionPath string) string {
-	base := strings.TrimSuffix(filepath.Base(questionPath), filepath.Ext(questionPath))
-	return filepath.Join(sessionPath, "inbox", "responses", base+".response.md")
+	return filepath.Join(sessionPath, "inbox", "responses", questionStem(questionPath)+".response.md")
 }
 
 func fileExists(path string) bool {
@@ -912,4 +1621,9 @@ type worktreeEvent struct {
 	Message        string   `json:"message"`
 	RemainingBeads []string `json:"remainingBeads"`
 	CompletedBeads []string `json:"completedBeads"`
+	// StartedBeads lists remaining beads the agent has begun; they are never
+	// offloaded.
+	StartedBeads []string `json:"startedBeads,omitempty"`
+	// Reestimates maps bead IDs to revised story points.
+	Reestimates map[string]int `json:"reestimates,omitempty"`
 }
@@ -0,0 +1,1945 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/eventbridge"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func newStalledCycleManager(t *testing.T, orch *Orchestrator, limit int) *upCycleManager {
+	t.Helper()
+	if _, err := orch.ensureCycleState(); err != nil {
+		t.Fatalf("ensure cycle state: %v", err)
+	}
+	bead := Bead{ID: "task-1", Title: "Stuck", Points: 2}
+	cs := &cycleSession{
+		WorktreeSession: WorktreeSession{Number: 1, Name: "tree-1-aster", Beads: []Bead{bead}},
+		allBeads:        map[string]Bead{canonicalBeadKey(bead.ID): bead},
+	}
+	cs.rebuildBeadIndex()
+	config := defaultUpCycleConfig
+	config.MaxStalledCycles = limit
+	return &upCycleManager{
+		orchestrator: orch,
+		config:       config,
+		sessions:     []*cycleSession{cs},
+		cycleNumber:  1,
+	}
+}
+
+func TestFinalizeCycleHaltsAfterConsecutiveNoProgress(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	mgr := newStalledCycleManager(t, orch, 3)
+
+	for i := 1; i < 3; i++ {
+		halt, err := mgr.recordCycleProgress()
+		if err != nil {
+			t.Fatalf("cycle %d: record progress: %v", i, err)
+		}
+		if halt {
+			t.Fatalf("cycle %d: halted before reaching the limit", i)
+		}
+	}
+	if err := os.MkdirAll(orch.config.WorktreeDir(), 0755); err != nil {
+		t.Fatalf("mkdir worktrees: %v", err)
+	}
+	err := mgr.finalizeCycle(context.Background())
+	if !errors.Is(err, ErrNoProgress) {
+		t.Fatalf("expected ErrNoProgress, got %v", err)
+	}
+	state, err := orch.readCycleState()
+	if err != nil {
+		t.Fatalf("read cycle state: %v", err)
+	}
+	if state.Current != 2 {
+		t.Fatalf("expected cycle to advance to 2, got %d", state.Current)
+	}
+	if state.Stalled != 0 {
+		t.Fatalf("expected stall counter reset after halt, got %d", state.Stalled)
+	}
+	if _, err := os.Stat(orch.config.WorktreeDir()); !os.IsNotExist(err) {
+		t.Fatalf("expected worktree dir removed, stat err=%v", err)
+	}
+}
+
+func TestFinalizeCycleWaitsForCooldown(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetCycleCooldown(80 * time.Millisecond)
+	mgr := newStalledCycleManager(t, orch, 3)
+	mgr.config.CycleCooldown = orch.cycleCooldown
+	var (
+		startedAt time.Time
+		started   int
+	)
+	mgr.startNextCycle = func(cycle int) error {
+		startedAt = time.Now()
+		started = cycle
+		return nil
+	}
+	if err := os.MkdirAll(orch.config.WorktreeDir(), 0755); err != nil {
+		t.Fatalf("mkdir worktrees: %v", err)
+	}
+	begin := time.Now()
+	if err := mgr.finalizeCycle(context.Background()); err != nil {
+		t.Fatalf("finalize: %v", err)
+	}
+	if started != 2 {
+		t.Fatalf("expected cycle 2 to start, got %d", started)
+	}
+	if waited := startedAt.Sub(begin); waited < 80*time.Millisecond {
+		t.Fatalf("next cycle started after %s, before the 80ms cooldown", waited)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	started = 0
+	orch.SetCycleCooldown(time.Hour)
+	mgr.config.CycleCooldown = orch.cycleCooldown
+	if err := mgr.finalizeCycle(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation during cooldown, got %v", err)
+	}
+	if started != 0 {
+		t.Fatalf("cancelled cooldown should not start cycle %d", started)
+	}
+}
+
+func TestRecordCycleProgressResetsOnClosedBead(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	mgr := newStalledCycleManager(t, orch, 2)
+
+	if halt, err := mgr.recordCycleProgress(); err != nil || halt {
+		t.Fatalf("first stalled cycle: halt=%v err=%v", halt, err)
+	}
+	mgr.sessions[0].Beads = nil
+	if halt, err := mgr.recordCycleProgress(); err != nil || halt {
+		t.Fatalf("progress cycle: halt=%v err=%v", halt, err)
+	}
+	state, err := orch.readCycleState()
+	if err != nil {
+		t.Fatalf("read cycle state: %v", err)
+	}
+	if state.Stalled != 0 {
+		t.Fatalf("expected stall counter reset, got %d", state.Stalled)
+	}
+}
+
+func TestWriteDownCycleLogReportsBeadProgress(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	done := []Bead{{ID: "task-1", Title: "Parse", Points: 3}, {ID: "task-2", Title: "Render", Points: 1}}
+	open := []Bead{{ID: "task-3", Title: "Persist", Points: 2}, {ID: "task-4", Title: "Ship", Points: 2}}
+	cs := &cycleSession{
+		WorktreeSession: WorktreeSession{Number: 1, Name: "tree-1-aster", Agent: ProjectAgent{Name: "Aster"}, Beads: open},
+		allBeads:        make(map[string]Bead),
+	}
+	for _, bead := range append(append([]Bead{}, done...), open...) {
+		cs.allBeads[canonicalBeadKey(bead.ID)] = bead
+	}
+	progress := cs.progress()
+	want := beadProgress{AssignedBeads: 4, CompletedBeads: 2, AssignedPoints: 8, CompletedPoints: 4}
+	if progress != want {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+	mgr := &upCycleManager{orchestrator: orch, sessions: []*cycleSession{cs}}
+	report := sessionReport{Agent: "Aster", Worktree: cs.Name, Progress: progress}
+	if err := mgr.writeDownCycleLog([]sessionReport{report}); err != nil {
+		t.Fatalf("write down-cycle log: %v", err)
+	}
+	logPath := filepath.Join(orch.config.LatticeProjectDir, workflow.WorkflowDir, workflow.WorkDir, workflow.FileWorkLog)
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read work log: %v", err)
+	}
+	content := string(data)
+	line := "2/4 beads (50%), 4/8 points (50%), 2 bead(s) remaining"
+	if !strings.Contains(content, "Overall progress: "+line) {
+		t.Fatalf("expected overall progress line, got:\n%s", content)
+	}
+	if !strings.Contains(content, "- progress: "+line) {
+		t.Fatalf("expected session progress line, got:\n%s", content)
+	}
+}
+
+func TestCycleMetadataRecordedInTrackerAndDownCycleLog(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetCycleMetadata(map[string]string{"experiment": "exp-42", " note ": "testing new prompt", " ": "dropped"})
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Agent: ProjectAgent{Name: "Aster"}, Beads: []Bead{{ID: "task-1", Points: 1}}}
+	if err := orch.persistCycleTracker(2, []WorktreeSession{session}, "prepared"); err != nil {
+		t.Fatalf("persist tracker: %v", err)
+	}
+	data, err := os.ReadFile(orch.cycleTrackerPath())
+	if err != nil {
+		t.Fatalf("read tracker: %v", err)
+	}
+	var tracker cycleTracker
+	if err := json.Unmarshal(data, &tracker); err != nil {
+		t.Fatalf("parse tracker: %v", err)
+	}
+	if len(tracker.Metadata) != 2 || tracker.Metadata["experiment"] != "exp-42" || tracker.Metadata["note"] != "testing new prompt" {
+		t.Fatalf("unexpected tracker metadata: %v", tracker.Metadata)
+	}
+
+	// A separate process running the prepared cycle reads the notes back
+	// from the tracker.
+	runner := New(orch.config)
+	runner.runCommand = orch.runCommand
+	mgr := runner.newUpCycleManager(2, []WorktreeSession{session})
+	report := sessionReport{Agent: "Aster", Worktree: session.Name}
+	if err := mgr.writeDownCycleLog([]sessionReport{report}); err != nil {
+		t.Fatalf("write down-cycle log: %v", err)
+	}
+	logPath := filepath.Join(orch.config.LatticeProjectDir, workflow.WorkflowDir, workflow.WorkDir, workflow.FileWorkLog)
+	logData, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read work log: %v", err)
+	}
+	if want := "Cycle metadata: experiment=exp-42, note=testing new prompt"; !strings.Contains(string(logData), want) {
+		t.Fatalf("expected %q in work log, got:\n%s", want, logData)
+	}
+}
+
+func TestThroughputAggregatesPastCycles(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	cycles := []struct {
+		number   int
+		duration time.Duration
+		reports  []sessionReport
+	}{
+		{1, 90 * time.Minute, []sessionReport{
+			{Agent: "Aster", Worktree: "tree-1", Progress: beadProgress{AssignedBeads: 3, CompletedBeads: 2, AssignedPoints: 5, CompletedPoints: 3}},
+			{Agent: "Lyra", Worktree: "tree-2", Progress: beadProgress{AssignedBeads: 2, CompletedBeads: 2, AssignedPoints: 4, CompletedPoints: 4}},
+		}},
+		{2, 30 * time.Minute, []sessionReport{
+			{Agent: "Aster", Worktree: "tree-3", Progress: beadProgress{AssignedBeads: 2, CompletedBeads: 1, AssignedPoints: 6, CompletedPoints: 3}},
+		}},
+	}
+	for _, cycle := range cycles {
+		mgr := &upCycleManager{orchestrator: orch, cycleNumber: cycle.number, started: time.Now().Add(-cycle.duration)}
+		if err := mgr.writeDownCycleLog(cycle.reports); err != nil {
+			t.Fatalf("write down-cycle log for cycle %d: %v", cycle.number, err)
+		}
+	}
+	summaryOnly := filepath.Join(orch.config.StateDir(), "cycle-3")
+	if err := os.MkdirAll(summaryOnly, 0755); err != nil {
+		t.Fatalf("mkdir cycle dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(summaryOnly, "SUMMARY.md"), []byte("# Cycle 3\n"), 0644); err != nil {
+		t.Fatalf("write summary: %v", err)
+	}
+
+	report, err := orch.Throughput()
+	if err != nil {
+		t.Fatalf("throughput: %v", err)
+	}
+	if len(report.Cycles) != 3 {
+		t.Fatalf("expected three cycles, got %+v", report.Cycles)
+	}
+	first := report.Cycles[0]
+	if !first.Logged || first.Agents != 2 || first.CompletedBeads != 4 || first.AssignedPoints != 9 || first.CompletedPoints != 7 {
+		t.Fatalf("unexpected cycle 1 metrics: %+v", first)
+	}
+	if first.Duration != 90*time.Minute {
+		t.Fatalf("expected the recorded cycle duration, got %s", first.Duration)
+	}
+	if third := report.Cycles[2]; third.Logged || third.SummaryPath == "" {
+		t.Fatalf("cycle 3 should come from its summary alone: %+v", third)
+	}
+	if got := report.BeadsPerCycle(); got != 2.5 {
+		t.Fatalf("expected 2.5 beads per cycle, got %v", got)
+	}
+	if got := report.PointsPerCycle(); got != 5 {
+		t.Fatalf("expected 5 points per cycle, got %v", got)
+	}
+	if got := report.PointsPerAgent(); got != 10.0/3 {
+		t.Fatalf("expected 10/3 points per agent, got %v", got)
+	}
+	if got := report.MeanDuration(); got != time.Hour {
+		t.Fatalf("expected a one hour mean duration, got %s", got)
+	}
+}
+
+func TestAssignConductorsShardsSessionsAcrossOrchestrators(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	for _, name := range []string{"Cass", "Lyra", "Aster"} {
+		dir := filepath.Join(orch.config.AgentsDir(), strings.ToLower(name))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir agent: %v", err)
+		}
+		content := "---\nname: " + name + "\nrole: Orchestrator\n---\n\n" + name + " conducts.\n"
+		if err := os.WriteFile(filepath.Join(dir, "AGENT.md"), []byte(content), 0644); err != nil {
+			t.Fatalf("write agent: %v", err)
+		}
+	}
+	roster := `{"orchestrator":{"name":"Cass"},"workers":[{"name":"Aster"}]}`
+	if err := os.MkdirAll(filepath.Dir(orch.config.WorkerListPath()), 0755); err != nil {
+		t.Fatalf("mkdir state: %v", err)
+	}
+	if err := os.WriteFile(orch.config.WorkerListPath(), []byte(roster), 0644); err != nil {
+		t.Fatalf("write roster: %v", err)
+	}
+	if err := orch.SetCoConductors("Lyra", "cass", "Ghost"); err != nil {
+		t.Fatalf("set co-conductors: %v", err)
+	}
+
+	mgr := &upCycleManager{orchestrator: orch}
+	for i := 1; i <= 4; i++ {
+		mgr.sessions = append(mgr.sessions, &cycleSession{
+			WorktreeSession: WorktreeSession{Number: i, Name: fmt.Sprintf("tree-%d", i), Path: t.TempDir()},
+		})
+	}
+	mgr.assignConductors()
+
+	want := []string{"Cass", "Lyra", "Cass", "Lyra"}
+	for i, cs := range mgr.sessions {
+		if cs.conductor != want[i] {
+			t.Fatalf("session %d: expected conductor %s, got %q", cs.Number, want[i], cs.conductor)
+		}
+	}
+}
+
+func TestAssignConductorsKeepsDefaultWithSingleOrchestrator(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	if err := os.MkdirAll(filepath.Dir(orch.config.WorkerListPath()), 0755); err != nil {
+		t.Fatalf("mkdir state: %v", err)
+	}
+	if err := os.WriteFile(orch.config.WorkerListPath(), []byte(`{"orchestrator":{"name":"Cass"}}`), 0644); err != nil {
+		t.Fatalf("write roster: %v", err)
+	}
+	mgr := &upCycleManager{orchestrator: orch, sessions: []*cycleSession{{WorktreeSession: WorktreeSession{Number: 1, Path: t.TempDir()}}}}
+	mgr.assignConductors()
+	if got := mgr.sessions[0].conductor; got != "" {
+		t.Fatalf("expected default agent, got %q", got)
+	}
+}
+
+func TestAgentPromptLoadsRepoMemoryWhenEnabled(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	repoMemory := filepath.Join(orch.config.StateDir(), "REPO_MEMORY.md")
+	if err := os.MkdirAll(filepath.Dir(repoMemory), 0o755); err != nil {
+		t.Fatalf("mkdir state: %v", err)
+	}
+	if err := os.WriteFile(repoMemory, []byte("# Repo memory\n- run make lint before pushing\n"), 0o644); err != nil {
+		t.Fatalf("write repo memory: %v", err)
+	}
+	session := WorktreeSession{Number: 1, Name: "tree-1", Path: t.TempDir()}
+	want := "Repo memory: " + repoMemory + " (load fully before working)\n"
+
+	prompt, err := orch.PreviewAgentPrompt(session, 1)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if strings.Contains(prompt, repoMemory) {
+		t.Fatalf("repo memory should be opt-in:\n%s", prompt)
+	}
+
+	orch.SetRepoMemory(true, 0)
+	if prompt, err = orch.PreviewAgentPrompt(session, 1); err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if !strings.Contains(prompt, want) {
+		t.Fatalf("expected repo memory line %q in prompt:\n%s", want, prompt)
+	}
+
+	orch.SetRepoMemory(true, 16)
+	if prompt, err = orch.PreviewAgentPrompt(session, 1); err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if strings.Contains(prompt, want) || !strings.Contains(prompt, "read the sections relevant to your beads") {
+		t.Fatalf("expected oversized repo memory to be read selectively:\n%s", prompt)
+	}
+}
+
+func TestUnrelatedBugBeadsCappedPerCycle(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetMaxUnrelatedBugBeads(3)
+	mgr := &upCycleManager{orchestrator: orch, config: defaultUpCycleConfig, cycleNumber: 4}
+	mgr.config.MaxUnrelatedBugBeads = orch.maxUnrelatedBugBeads
+	newSession := func(name string, bugs int) *cycleSession {
+		session := WorktreeSession{Number: 1, Name: name, Path: t.TempDir()}
+		var b strings.Builder
+		b.WriteString("# WORKTREE\n\n# unrelated bugs\n")
+		for i := 1; i <= bugs; i++ {
+			b.WriteString(fmt.Sprintf("- %s bug %d in pkg/file%d.go\n", name, i, i))
+		}
+		b.WriteString("\n# need help\n- none recorded yet\n")
+		if err := os.WriteFile(filepath.Join(session.Path, "WORKTREE.md"), []byte(b.String()), 0o644); err != nil {
+			t.Fatalf("write worktree: %v", err)
+		}
+		return &cycleSession{WorktreeSession: session, cycle: 1}
+	}
+
+	first := newSession("tree-1", 5)
+	plan := mgr.planUnrelatedBugs(first)
+	if len(plan.Individual) != 3 || len(plan.Aggregated) != 2 {
+		t.Fatalf("expected 3 individual and 2 aggregated bugs, got %+v", plan)
+	}
+	prompt := mgr.buildOrchestratorPrompt(first, worktreeEvent{}, "marker.json", plan)
+	for _, want := range []string{
+		"for only these 3 entries",
+		"- tree-1 bug 3 in pkg/file3.go",
+		`create one bead titled "Misc unrelated bugs (cycle 4, tree-1)"`,
+		"- tree-1 bug 5 in pkg/file5.go",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("orchestrator prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	second := newSession("tree-2", 2)
+	plan = mgr.planUnrelatedBugs(second)
+	if len(plan.Individual) != 0 || len(plan.Aggregated) != 2 {
+		t.Fatalf("expected cap exhausted for second session, got %+v", plan)
+	}
+	prompt = mgr.buildOrchestratorPrompt(second, worktreeEvent{}, "marker.json", plan)
+	if !strings.Contains(prompt, "cap for this cycle is already reached") {
+		t.Fatalf("expected exhausted cap in prompt:\n%s", prompt)
+	}
+
+	mgr.config.MaxUnrelatedBugBeads = 0
+	plan = mgr.planUnrelatedBugs(newSession("tree-3", 12))
+	if len(plan.Individual) != 12 || len(plan.Aggregated) != 0 {
+		t.Fatalf("expected no cap by default, got %+v", plan)
+	}
+}
+
+func TestAgentPromptCapsInlineBeadsButWorktreeListsAll(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetMaxPromptBeads(2)
+	worktree := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, worktree)
+	beads := []Bead{
+		{ID: "lat-1", Title: "Wire login", Points: 3},
+		{ID: "lat-2", Title: "Add logout", Points: 2},
+		{ID: "lat-3", Title: "Reset password", Points: 2},
+		{ID: "lat-4", Title: "Lock accounts", Points: 1},
+	}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: worktree, Agent: ProjectAgent{Name: "Aster"}, Beads: beads}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 1}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+
+	prompt, err := orch.PreviewAgentPrompt(session, 1)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	worktreePath := filepath.Join(worktree, "WORKTREE.md")
+	section := strings.Join([]string{
+		"Assigned beads:",
+		"- lat-1 · Wire login (3 pt)",
+		"- lat-2 · Add logout (2 pt)",
+		"- …and 2 more bead(s) (3 pt); see Assigned Beads in " + worktreePath + " for the full list",
+		"",
+	}, "\n")
+	if !strings.Contains(prompt, section) {
+		t.Fatalf("expected a capped bead list, got:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "lat-3") || strings.Contains(prompt, "lat-4") {
+		t.Fatalf("beads past the cap should not be listed inline:\n%s", prompt)
+	}
+	state, err := os.ReadFile(worktreePath)
+	if err != nil {
+		t.Fatalf("read WORKTREE.md: %v", err)
+	}
+	for _, bead := range beads {
+		if !strings.Contains(string(state), fmt.Sprintf("- %s · %s (%d pt)", bead.ID, bead.Title, bead.Points)) {
+			t.Fatalf("WORKTREE.md is missing %s:\n%s", bead.ID, state)
+		}
+	}
+}
+
+func TestPreviewAgentPromptRendersKnownSession(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	worktree := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	data := fmt.Sprintf(`{
+  "number": 1,
+  "name": "tree-1-aster",
+  "path": %q,
+  "agentName": "Aster",
+  "beads": [
+    {"id": "lat-1", "title": "Wire login", "points": 3},
+    {"id": "lat-2", "title": "Add logout", "points": 2}
+  ]
+}`, worktree)
+	session, err := orch.SessionFromJSON([]byte(data))
+	if err != nil {
+		t.Fatalf("decode session: %v", err)
+	}
+	if session.Agent.Name != "Aster" {
+		t.Fatalf("expected synthetic agent Aster, got %+v", session.Agent)
+	}
+
+	prompt, err := orch.PreviewAgentPrompt(session, 2)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	header := strings.Join([]string{
+		"Get started on the work that is assigned to you. Use bd for issue tracking. Only do the work that is assigned to you in beads (bd).",
+		"",
+		"Session: tree-1-aster (cycle 2)",
+		"Worktree root: " + worktree,
+		"Context file (load entirely): " + filepath.Join(worktree, "WORKTREE.md"),
+		"Agent instructions: " + filepath.Join(orch.config.ProjectDir, "AGENTS.md"),
+		"",
+		"Assigned beads:",
+		"- lat-1 · Wire login (3 pt)",
+		"- lat-2 · Add logout (2 pt)",
+		"",
+	}, "\n")
+	if !strings.HasPrefix(prompt, header) {
+		t.Fatalf("unexpected prompt header:\n%s", prompt)
+	}
+	skillPath := filepath.Join(orch.config.SkillsDir(), "final-session-prompt", "SKILL.md")
+	eventPath := filepath.Join(worktree, "outbox", "events", "agent-cycle-2.json")
+	for _, want := range []string{
+		"run the final-session-prompt skill at " + skillPath,
+		"write a JSON event to " + eventPath,
+		`"cycle": 2,`,
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if _, err := os.Stat(skillPath); err != nil {
+		t.Fatalf("expected final-session skill to be installed: %v", err)
+	}
+}
+
+func TestAgentEventWithUnknownBeadIDIsLogged(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	beads := []Bead{{ID: "task-1", Title: "Parse"}, {ID: "task-2", Title: "Render"}}
+	cs := &cycleSession{
+		WorktreeSession: WorktreeSession{Number: 1, Name: "tree-1-aster", Path: t.TempDir(), Agent: ProjectAgent{Name: "Aster"}, Beads: beads},
+		cycle:           2,
+		allBeads:        make(map[string]Bead),
+	}
+	for _, bead := range beads {
+		cs.allBeads[canonicalBeadKey(bead.ID)] = bead
+	}
+	cs.rebuildBeadIndex()
+	mgr := &upCycleManager{orchestrator: orch, config: defaultUpCycleConfig, sessions: []*cycleSession{cs}}
+	event := worktreeEvent{
+		Type:           "agent_complete",
+		Cycle:          2,
+		RemainingBeads: []string{"task-2", "tsak-3"},
+		CompletedBeads: []string{"TASK-1", "tsak-3"},
+	}
+
+	if err := mgr.checkEventBeadIDs(cs, event); err != nil {
+		t.Fatalf("lenient check should only log, got %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(cs.Path, "LOG.md"))
+	if err != nil {
+		t.Fatalf("read worktree log: %v", err)
+	}
+	if !strings.Contains(string(data), "Cycle 2 event from Aster referenced unknown bead ID(s): tsak-3\n") {
+		t.Fatalf("unknown bead ID not logged:\n%s", data)
+	}
+	if remaining := mgr.filterRemainingBeads(cs, event.RemainingBeads); len(remaining) != 1 || remaining[0].ID != "task-2" {
+		t.Fatalf("unexpected remaining beads: %+v", remaining)
+	}
+
+	mgr.config.StrictBeadIDs = true
+	if err := mgr.checkEventBeadIDs(cs, event); !errors.Is(err, ErrUnknownBeadIDs) {
+		t.Fatalf("expected ErrUnknownBeadIDs in strict mode, got %v", err)
+	}
+}
+
+func TestAgentEventParsedOnceWriteCompletes(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	cs := &cycleSession{
+		WorktreeSession: WorktreeSession{Number: 1, Name: "tree-1-aster", Path: t.TempDir(), Agent: ProjectAgent{Name: "Aster"}},
+		cycle:           1,
+		eventSeen:       make(map[string]struct{}),
+	}
+	config := defaultUpCycleConfig
+	config.EventPollInterval = 10 * time.Millisecond
+	config.EventSettleWindow = time.Minute
+	mgr := &upCycleManager{orchestrator: orch, config: config, sessions: []*cycleSession{cs}}
+	outbox := filepath.Join(cs.Path, "outbox", "events")
+	if err := os.MkdirAll(outbox, 0755); err != nil {
+		t.Fatalf("create outbox: %v", err)
+	}
+	path := filepath.Join(outbox, "agent-cycle-1.json")
+	full := `{"type":"agent_complete","cycle":1,"message":"done","completedBeads":["task-1"]}`
+	if err := os.WriteFile(path, []byte(full[:20]), 0644); err != nil {
+		t.Fatalf("write partial event: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	_, err := mgr.waitForAgentEvent(ctx, cs)
+	cancel()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("a partial event should not be consumed, got %v", err)
+	}
+	if _, seen := cs.eventSeen[path]; seen {
+		t.Fatalf("a partial event should be retried on the next poll")
+	}
+
+	if err := os.WriteFile(path, []byte(full), 0644); err != nil {
+		t.Fatalf("finish event: %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	evt, err := mgr.waitForAgentEvent(ctx, cs)
+	if err != nil {
+		t.Fatalf("wait for event: %v", err)
+	}
+	if evt.Message != "done" || len(evt.CompletedBeads) != 1 {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+	if data, _ := os.ReadFile(filepath.Join(cs.Path, "LOG.md")); strings.Contains(string(data), "Failed to parse") {
+		t.Fatalf("in-progress write should not be logged as malformed:\n%s", data)
+	}
+
+	stale := filepath.Join(outbox, "agent-cycle-2.json")
+	if err := os.WriteFile(stale, []byte(`{"type":`), 0644); err != nil {
+		t.Fatalf("write malformed event: %v", err)
+	}
+	old := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatalf("age malformed event: %v", err)
+	}
+	if _, settled, err := readSettledEvent(stale, config.EventSettleWindow, time.Now()); !settled || err == nil {
+		t.Fatalf("a stable unparseable file should be reported malformed, got settled=%v err=%v", settled, err)
+	}
+}
+
+func TestDirtyWorktreeAfterLandingEscalatesConflicts(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: t.TempDir()}
+	rebaseDir := filepath.Join(session.Path, ".git-rebase-merge")
+	if err := os.MkdirAll(rebaseDir, 0755); err != nil {
+		t.Fatalf("create rebase dir: %v", err)
+	}
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		switch strings.Join(append([]string{name}, args...), " ") {
+		case "git status --porcelain":
+			return "UU internal/app.go\n M README.md\n", nil
+		case "git rev-parse --git-path rebase-merge":
+			return rebaseDir + "\n", nil
+		case "git rev-parse --git-path rebase-apply":
+			return filepath.Join(session.Path, ".git-rebase-apply") + "\n", nil
+		}
+		return "", fmt.Errorf("unexpected command %s %v", name, args)
+	}
+
+	err := orch.checkLandedWorktree(session)
+	var landErr *LandingConflictError
+	if !errors.As(err, &landErr) {
+		t.Fatalf("expected LandingConflictError, got %v", err)
+	}
+	if len(landErr.Conflicts) != 1 || landErr.Conflicts[0] != "internal/app.go" || !landErr.Rebasing {
+		t.Fatalf("unexpected conflict details: %+v", landErr)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "needs a human merge: merge conflicts in internal/app.go (rebase in progress)") {
+		t.Fatalf("escalation should name the conflicts, got %q", msg)
+	}
+	if strings.Contains(msg, "pending changes") {
+		t.Fatalf("escalation should not fall back to the generic message: %q", msg)
+	}
+	data, err := os.ReadFile(filepath.Join(session.Path, "LOG.md"))
+	if err != nil {
+		t.Fatalf("read worktree log: %v", err)
+	}
+	if !strings.Contains(string(data), "UU internal/app.go") {
+		t.Fatalf("worktree log should capture the git status output:\n%s", data)
+	}
+
+	orch.runCommand = func(dir, name string, args ...string) (string, error) { return "", nil }
+	if err := orch.checkLandedWorktree(session); err != nil {
+		t.Fatalf("a clean worktree should pass, got %v", err)
+	}
+}
+
+func TestWatchQuestionsWarnsOnMisnamedQuestionFile(t *testing.T) {
+	sessionPath := t.TempDir()
+	questionDir := filepath.Join(sessionPath, "outbox", "questions")
+	if err := os.MkdirAll(questionDir, 0o755); err != nil {
+		t.Fatalf("mkdir questions: %v", err)
+	}
+	for _, name := range []string{"cycle-2-schema-owner.md", "cycle-2-Blocked API.md.txt"} {
+		if err := os.WriteFile(filepath.Join(questionDir, name), []byte("Who owns the schema?\n"), 0o644); err != nil {
+			t.Fatalf("write question: %v", err)
+		}
+	}
+	cs := &cycleSession{
+		WorktreeSession: WorktreeSession{Number: 1, Name: "tree-1-aster", Path: sessionPath},
+		cycle:           2,
+		questionSeen:    map[string]struct{}{},
+	}
+	config := defaultUpCycleConfig
+	config.QuestionPollInterval = 5 * time.Millisecond
+	config.IdleTimeout = time.Hour
+	mgr := &upCycleManager{orchestrator: newTestOrchestrator(t), config: config}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		mgr.watchQuestions(ctx, cs)
+		close(done)
+	}()
+	logPath := filepath.Join(sessionPath, "LOG.md")
+	var log string
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if data, err := os.ReadFile(logPath); err == nil && strings.Contains(string(data), "Warning: question file") {
+			log = string(data)
+			break
+		}
+	}
+	cancel()
+	<-done
+
+	if !strings.Contains(log, `"cycle-2-Blocked API.md.txt" does not follow cycle-N-<slug>.md (expected a .md extension)`) {
+		t.Fatalf("expected a warning for the misnamed question, got %q", log)
+	}
+	if !strings.Contains(log, "routing its response to cycle-2-Blocked API.response.md") {
+		t.Fatalf("expected best-effort routing in the warning, got %q", log)
+	}
+	if strings.Contains(log, "schema-owner") {
+		t.Fatalf("well-formed question should not be flagged: %q", log)
+	}
+	want := filepath.Join(sessionPath, "inbox", "responses", "cycle-2-Blocked API.response.md")
+	if got := responsePathForQuestion(sessionPath, filepath.Join(questionDir, "cycle-2-Blocked API.md.txt")); got != want {
+		t.Fatalf("response path = %s, want %s", got, want)
+	}
+}
+
+type recordingProgress struct {
+	events []string
+}
+
+func (r *recordingProgress) SessionStarted(session WorktreeSession, attempt int) {
+	r.events = append(r.events, fmt.Sprintf("started %s #%d", session.Name, attempt))
+}
+
+func (r *recordingProgress) AgentComplete(session WorktreeSession, attempt int, completed, remaining []string) {
+	r.events = append(r.events, fmt.Sprintf("agent_complete %s #%d done=%v left=%v", session.Name, attempt, completed, remaining))
+}
+
+func (r *recordingProgress) OrchestratorDone(session WorktreeSession, attempt int) {
+	r.events = append(r.events, fmt.Sprintf("orchestrator_done %s #%d", session.Name, attempt))
+}
+
+func (r *recordingProgress) CycleLanded(cycle int) {
+	r.events = append(r.events, fmt.Sprintf("landed cycle %d", cycle))
+}
+
+// scaffoldSessionDirs creates the session mailboxes PrepareWorkCycle would.
+func scaffoldSessionDirs(t *testing.T, path string) {
+	t.Helper()
+	for _, rel := range sessionLayout {
+		if err := os.MkdirAll(filepath.Join(path, rel), 0755); err != nil {
+			t.Fatalf("create worktree: %v", err)
+		}
+	}
+}
+
+// simulatedAgents stands in for tmux and opencode: each prompt sent to a
+// window writes the file its agent would have produced. Agents finish every
+// bead unless events holds the agent_complete body for that attempt.
+type simulatedAgents struct {
+	t           *testing.T
+	mu          sync.Mutex
+	windowDirs  map[string]string
+	events      map[int]string
+	cycleReport string
+	memoryPaths map[string]string
+}
+
+func (s *simulatedAgents) run(dir, name string, args ...string) (string, error) {
+	if name != "tmux" || len(args) < 3 {
+		return "", nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	window := args[2]
+	switch args[0] {
+	case "new-window":
+		if len(args) == 5 {
+			s.windowDirs[window] = args[4]
+		}
+	case "send-keys":
+		s.respond(window)
+	}
+	return "", nil
+}
+
+func (s *simulatedAgents) respond(window string) {
+	dir := s.windowDirs[window]
+	events := filepath.Join(dir, "outbox", "events")
+	var path, body string
+	var number, attempt int
+	switch {
+	case strings.HasPrefix(window, "worktree-agent-"):
+		fmt.Sscanf(window, "worktree-agent-%d-%d", &number, &attempt)
+		path = filepath.Join(events, fmt.Sprintf("agent-cycle-%d.json", attempt))
+		body = fmt.Sprintf(`{"type":"agent_complete","cycle":%d,"remainingBeads":[]}`, attempt)
+		if event, ok := s.events[attempt]; ok {
+			body = event
+		}
+	case strings.HasPrefix(window, "worktree-orchestrator-"):
+		fmt.Sscanf(window, "worktree-orchestrator-%d-%d", &number, &attempt)
+		path = filepath.Join(events, fmt.Sprintf("orchestrator-cycle-%d.json", attempt))
+		body = `{"type":"orchestrator_complete"}`
+	case strings.HasPrefix(window, "summary-"):
+		path, body = filepath.Join(dir, "SUMMARY.md"), "# Summary\n"
+	case strings.HasPrefix(window, "down-cycle-"):
+		path, body = s.cycleReport, "# Cycle summary\n"
+	case strings.HasPrefix(window, "dream-"):
+		slug := strings.TrimPrefix(window[:strings.LastIndex(window, "-")], "dream-")
+		path, body = s.memoryPaths[slug], "## Cycle memory\n"
+	default:
+		return
+	}
+	if path == "" {
+		s.t.Errorf("no simulated output for window %s", window)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		s.t.Errorf("prepare %s: %v", path, err)
+		return
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(body), 0644); err != nil {
+		s.t.Errorf("write %s: %v", path, err)
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		s.t.Errorf("publish %s: %v", path, err)
+	}
+}
+
+func TestRunUpCycleReportsProgressInOrder(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	if _, err := orch.ensureCycleState(); err != nil {
+		t.Fatalf("ensure cycle state: %v", err)
+	}
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, path)
+	agentPath := filepath.Join(orch.config.ProjectDir, "agents", "aster", "AGENT.md")
+	beads := []Bead{{ID: "task-1", Title: "First", Points: 1}, {ID: "task-2", Title: "Second", Points: 1}}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster", Path: agentPath}, Beads: beads}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 1}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	agents := &simulatedAgents{
+		t:           t,
+		windowDirs:  map[string]string{},
+		events:      map[int]string{1: `{"type":"agent_complete","cycle":1,"completedBeads":["task-1"],"remainingBeads":["task-2"]}`, 2: `{"type":"agent_complete","cycle":2,"completedBeads":["task-2"],"remainingBeads":[]}`},
+		cycleReport: filepath.Join(orch.config.StateDir(), "cycle-1", "SUMMARY.md"),
+		memoryPaths: map[string]string{"aster": filepath.Join(filepath.Dir(agentPath), "MEMORY.md")},
+	}
+	orch.runCommand = agents.run
+	progress := &recordingProgress{}
+	orch.SetProgressReporter(progress)
+	sink := &recordingEventSink{}
+	orch.SetEventSink(sink)
+
+	mgr := orch.newUpCycleManager(1, []WorktreeSession{session})
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.startNextCycle = func(int) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.runCycle(ctx); err != nil {
+		t.Fatalf("run cycle: %v", err)
+	}
+
+	want := []string{
+		"started tree-1-aster #1",
+		"agent_complete tree-1-aster #1 done=[task-1] left=[task-2]",
+		"orchestrator_done tree-1-aster #1",
+		"started tree-1-aster #2",
+		"agent_complete tree-1-aster #2 done=[task-2] left=[]",
+		"orchestrator_done tree-1-aster #2",
+		"landed cycle 1",
+	}
+	if got := strings.Join(progress.events, "\n"); got != strings.Join(want, "\n") {
+		t.Fatalf("progress callbacks out of order:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+	// The reporter is fed from the event stream, so the sink sees each
+	// milestone it reports.
+	milestones := 0
+	for _, evt := range sink.events {
+		switch evt.Type {
+		case CycleEventAgentDispatched, CycleEventAgentComplete, CycleEventOrchestratorDone, CycleEventCycleLanded:
+			milestones++
+		}
+	}
+	if milestones != len(progress.events) {
+		t.Fatalf("sink saw %d milestones, reporter %d", milestones, len(progress.events))
+	}
+}
+
+type recordingEventSink struct {
+	events []CycleEvent
+	// onEmit, when set, observes each event as it is emitted.
+	onEmit func(CycleEvent)
+}
+
+func (r *recordingEventSink) Emit(event CycleEvent) {
+	r.events = append(r.events, event)
+	if r.onEmit != nil {
+		r.onEmit(event)
+	}
+}
+
+func TestRunUpCycleEmitsStructuredEvents(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	if _, err := orch.ensureCycleState(); err != nil {
+		t.Fatalf("ensure cycle state: %v", err)
+	}
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, path)
+	if err := os.WriteFile(filepath.Join(path, "outbox", "questions", "cycle-1-schema.md"), []byte("Which schema?\n"), 0644); err != nil {
+		t.Fatalf("write question: %v", err)
+	}
+	agentPath := filepath.Join(orch.config.ProjectDir, "agents", "aster", "AGENT.md")
+	beads := []Bead{{ID: "task-1", Title: "First", Points: 1}, {ID: "task-2", Title: "Second", Points: 1}}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster", Path: agentPath}, Beads: beads}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 3}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	agents := &simulatedAgents{
+		t:           t,
+		windowDirs:  map[string]string{},
+		events:      map[int]string{1: `{"type":"agent_complete","cycle":1,"completedBeads":["task-1"],"remainingBeads":["task-2"]}`, 2: `{"type":"agent_complete","cycle":2,"completedBeads":["task-2"],"remainingBeads":[]}`},
+		cycleReport: filepath.Join(orch.config.StateDir(), "cycle-3", "SUMMARY.md"),
+		memoryPaths: map[string]string{"aster": filepath.Join(filepath.Dir(agentPath), "MEMORY.md")},
+	}
+	orch.runCommand = agents.run
+	sink := &recordingEventSink{}
+	orch.SetEventSink(sink)
+
+	mgr := orch.newUpCycleManager(3, []WorktreeSession{session})
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.config.QuestionPollInterval = 2 * time.Millisecond
+	mgr.config.IdleTimeout = time.Hour
+	mgr.startNextCycle = func(int) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.runCycle(ctx); err != nil {
+		t.Fatalf("run cycle: %v", err)
+	}
+
+	var got, questions []string
+	for _, evt := range sink.events {
+		if evt.Cycle != 3 {
+			t.Fatalf("event %s carries cycle %d, want 3", evt.Type, evt.Cycle)
+		}
+		line := fmt.Sprintf("%s #%d %s beads=%v left=%v", evt.Type, evt.Attempt, evt.Agent, evt.Beads, evt.Remaining)
+		if evt.Type == CycleEventQuestionRaised {
+			questions = append(questions, fmt.Sprintf("%s session=%d %s", evt.Question, evt.Session, evt.Worktree))
+			continue
+		}
+		got = append(got, line)
+	}
+	want := []string{
+		"cycle_started #0  beads=[task-1 task-2] left=[]",
+		"agent_dispatched #1 Aster beads=[task-1 task-2] left=[]",
+		"agent_complete #1 Aster beads=[task-1] left=[task-2]",
+		"orchestrator_done #1 Aster beads=[] left=[]",
+		"agent_dispatched #2 Aster beads=[task-2] left=[]",
+		"agent_complete #2 Aster beads=[task-2] left=[]",
+		"orchestrator_done #2 Aster beads=[] left=[]",
+		"worktree_landed #2 Aster beads=[] left=[]",
+		"cycle_landed #0  beads=[] left=[]",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("events out of order:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(questions) != 1 || questions[0] != "cycle-1-schema.md session=1 tree-1-aster" {
+		t.Fatalf("expected one question_raised event, got %v", questions)
+	}
+}
+
+func TestRunUpCycleOffloadsUnstartedBeadsWhenReestimateExceedsCapacity(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	if _, err := orch.ensureCycleState(); err != nil {
+		t.Fatalf("ensure cycle state: %v", err)
+	}
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, path)
+	agentPath := filepath.Join(orch.config.ProjectDir, "agents", "aster", "AGENT.md")
+	beads := []Bead{
+		{ID: "task-1", Title: "First", Points: 2},
+		{ID: "task-2", Title: "Second", Points: 2},
+		{ID: "task-3", Title: "Third", Points: 3},
+	}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster", Path: agentPath}, Beads: beads}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 2}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	agents := &simulatedAgents{
+		t:          t,
+		windowDirs: map[string]string{},
+		events: map[int]string{
+			1: `{"type":"agent_complete","cycle":1,"completedBeads":["task-1"],"remainingBeads":["task-2","task-3"],"startedBeads":["task-2"],"reestimates":{"task-2":7}}`,
+			2: `{"type":"agent_complete","cycle":2,"completedBeads":["task-2"],"remainingBeads":[]}`,
+		},
+		cycleReport: filepath.Join(orch.config.StateDir(), "cycle-2", "SUMMARY.md"),
+		memoryPaths: map[string]string{"aster": filepath.Join(filepath.Dir(agentPath), "MEMORY.md")},
+	}
+	orch.runCommand = agents.run
+	orch.SetOffloadOverCapacity(true)
+	var recorded beadEstimate
+	sink := &recordingEventSink{onEmit: func(evt CycleEvent) {
+		if evt.Type == CycleEventAgentDispatched && evt.Attempt == 2 {
+			recorded = orch.readBeadEstimateState().Beads[canonicalBeadKey("task-2")]
+		}
+	}}
+	orch.SetEventSink(sink)
+
+	mgr := orch.newUpCycleManager(2, []WorktreeSession{session})
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.config.IdleTimeout = time.Hour
+	mgr.startNextCycle = func(int) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.runCycle(ctx); err != nil {
+		t.Fatalf("run cycle: %v", err)
+	}
+
+	var dispatched [][]string
+	for _, evt := range sink.events {
+		if evt.Type == CycleEventAgentDispatched {
+			dispatched = append(dispatched, evt.Beads)
+		}
+	}
+	if len(dispatched) != 2 || strings.Join(dispatched[1], ",") != "task-2" {
+		t.Fatalf("expected the second agent cycle to keep only the started bead, got %v", dispatched)
+	}
+	logPath := filepath.Join(orch.config.LatticeProjectDir, workflow.WorkflowDir, workflow.WorkDir, workflow.FileWorkLog)
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read work log: %v", err)
+	}
+	log := string(data)
+	if !strings.Contains(log, "- offloaded to the next cycle: task-3 · Third") {
+		t.Fatalf("work log should name the offloaded bead:\n%s", log)
+	}
+	if !strings.Contains(log, "- progress: 2/2 beads (100%)") {
+		t.Fatalf("offloaded beads should not count toward session progress:\n%s", log)
+	}
+	if recorded.From != 2 || recorded.Points != 7 {
+		t.Fatalf("re-estimate should be recorded for later cycles, got %+v", recorded)
+	}
+	if _, ok := orch.readBeadEstimateState().Beads[canonicalBeadKey("task-2")]; ok {
+		t.Fatalf("re-estimate should be forgotten once task-2 closed")
+	}
+}
+
+func TestBridgeEventSinkRoutesJSONPayload(t *testing.T) {
+	router := eventbridge.NewRouter()
+	sink := NewBridgeEventSink(router, "monitor", "commission-work")
+	sink.Emit(CycleEvent{Type: CycleEventAgentDispatched, Time: time.Now().UTC(), Cycle: 2, Attempt: 1, Session: 4, Worktree: "tree-4-aster", Agent: "Aster", Beads: []string{"task-1"}})
+	sink.Emit(CycleEvent{Type: CycleEventCycleStarted, Time: time.Now().UTC(), Cycle: 3})
+
+	sub := router.Subscribe("monitor")
+	defer sub.Close()
+	var routed []eventbridge.Event
+	for len(routed) < 2 {
+		select {
+		case evt := <-sub.Events:
+			routed = append(routed, evt)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected two routed events, got %d", len(routed))
+		}
+	}
+	if routed[0].Type != "agent_dispatched" || routed[0].SessionID != "tree-4-aster" || routed[0].Workflow != "commission-work" {
+		t.Fatalf("unexpected routed event: %+v", routed[0])
+	}
+	if routed[1].SessionID != "cycle-3" || routed[0].EventID == routed[1].EventID {
+		t.Fatalf("expected distinct IDs and a cycle session, got %+v", routed[1])
+	}
+	var payload CycleEvent
+	if err := json.Unmarshal(routed[0].Payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.Session != 4 || payload.Agent != "Aster" || strings.Join(payload.Beads, ",") != "task-1" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestRunUpCycleAnnotatesCompletedBeads(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetAnnotateCompletedBeads(true)
+	if _, err := orch.ensureCycleState(); err != nil {
+		t.Fatalf("ensure cycle state: %v", err)
+	}
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, path)
+	agentPath := filepath.Join(orch.config.ProjectDir, "agents", "aster", "AGENT.md")
+	beads := []Bead{{ID: "task-1", Title: "First", Points: 1}, {ID: "task-2", Title: "Second", Points: 1}}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster", Path: agentPath}, Beads: beads}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 1}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	agents := &simulatedAgents{
+		t:           t,
+		windowDirs:  map[string]string{},
+		events:      map[int]string{1: `{"type":"agent_complete","cycle":1,"completedBeads":["task-1","stray-9"],"remainingBeads":["task-2"]}`, 2: `{"type":"agent_complete","cycle":2,"completedBeads":["task-2"],"remainingBeads":[]}`},
+		cycleReport: filepath.Join(orch.config.StateDir(), "cycle-1", "SUMMARY.md"),
+		memoryPaths: map[string]string{"aster": filepath.Join(filepath.Dir(agentPath), "MEMORY.md")},
+	}
+	var mu sync.Mutex
+	var notes []string
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name == "bd" && len(args) > 0 && args[0] == "comments" {
+			mu.Lock()
+			notes = append(notes, strings.Join(args, " "))
+			mu.Unlock()
+			return "", nil
+		}
+		return agents.run(dir, name, args...)
+	}
+
+	mgr := orch.newUpCycleManager(1, []WorktreeSession{session})
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.startNextCycle = func(int) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.runCycle(ctx); err != nil {
+		t.Fatalf("run cycle: %v", err)
+	}
+
+	want := []string{
+		"comments add task-1 Completed by Aster in cycle 1 (session tree-1-aster, agent cycle 1)",
+		"comments add task-2 Completed by Aster in cycle 1 (session tree-1-aster, agent cycle 2)",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(notes, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected bead annotations:\n%s\nwant:\n%s", strings.Join(notes, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRunUpCycleReviewOnlySkipsLandingAndCollectsReports(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetReviewOnly(true)
+	if _, err := orch.ensureCycleState(); err != nil {
+		t.Fatalf("ensure cycle state: %v", err)
+	}
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, path)
+	agentPath := filepath.Join(orch.config.ProjectDir, "agents", "aster", "AGENT.md")
+	beads := []Bead{{ID: "task-1", Title: "Audit auth", Points: 1}}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster", Path: agentPath}, Beads: beads}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 1}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	agents := &simulatedAgents{
+		t:           t,
+		windowDirs:  map[string]string{},
+		events:      map[int]string{1: `{"type":"agent_complete","cycle":1,"completedBeads":["task-1"],"remainingBeads":[]}`},
+		cycleReport: filepath.Join(orch.config.StateDir(), "cycle-1", "SUMMARY.md"),
+		memoryPaths: map[string]string{"aster": filepath.Join(filepath.Dir(agentPath), "MEMORY.md")},
+	}
+	var mu sync.Mutex
+	var landing []string
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		mu.Lock()
+		switch {
+		case name == "git":
+			landing = append(landing, "git "+strings.Join(args, " "))
+		case name == "tmux" && len(args) > 2 && strings.HasPrefix(args[2], "land-"):
+			landing = append(landing, args[2])
+		case name == "tmux" && len(args) > 2 && args[0] == "send-keys" && strings.HasPrefix(args[2], "worktree-agent-"):
+			reports := filepath.Join(path, "outbox", "reports")
+			if err := os.MkdirAll(reports, 0755); err != nil {
+				t.Errorf("create reports dir: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(reports, "cycle-1.md"), []byte("## Auth\nSession tokens never expire (auth/session.go:42).\n"), 0644); err != nil {
+				t.Errorf("write report: %v", err)
+			}
+		}
+		mu.Unlock()
+		return agents.run(dir, name, args...)
+	}
+
+	mgr := orch.newUpCycleManager(1, []WorktreeSession{session})
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.startNextCycle = func(int) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.runCycle(ctx); err != nil {
+		t.Fatalf("run cycle: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(landing) != 0 {
+		t.Fatalf("review-only cycle should not land worktrees: %v", landing)
+	}
+	prompt, err := os.ReadFile(filepath.Join(orch.config.LatticeProjectDir, "logs", "opencode", "worktree-agent-1-1.prompt"))
+	if err != nil {
+		t.Fatalf("read agent prompt: %v", err)
+	}
+	if !strings.Contains(string(prompt), "review-only cycle. Do not edit code, commit") {
+		t.Fatalf("agent prompt missing review-only guidance:\n%s", prompt)
+	}
+	data, err := os.ReadFile(orch.workLogPath())
+	if err != nil {
+		t.Fatalf("read work log: %v", err)
+	}
+	log := string(data)
+	for _, want := range []string{
+		reviewOnlyLogLine,
+		"- findings:\n  - cycle-1.md\n    ## Auth\n    Session tokens never expire (auth/session.go:42).\n",
+	} {
+		if !strings.Contains(log, want) {
+			t.Fatalf("work log missing %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestRunUpCycleDefersLaunchesOnceBudgetIsSpent(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetInvocationBudget(2)
+	if _, err := orch.ensureCycleState(); err != nil {
+		t.Fatalf("ensure cycle state: %v", err)
+	}
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, path)
+	agentPath := filepath.Join(orch.config.ProjectDir, "agents", "aster", "AGENT.md")
+	beads := []Bead{{ID: "task-1", Title: "First", Points: 1}, {ID: "task-2", Title: "Second", Points: 1}}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster", Path: agentPath}, Beads: beads}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 1}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	agents := &simulatedAgents{
+		t:           t,
+		windowDirs:  map[string]string{},
+		events:      map[int]string{1: `{"type":"agent_complete","cycle":1,"completedBeads":["task-1"],"remainingBeads":["task-2"]}`},
+		cycleReport: filepath.Join(orch.config.StateDir(), "cycle-1", "SUMMARY.md"),
+		memoryPaths: map[string]string{"aster": filepath.Join(filepath.Dir(agentPath), "MEMORY.md")},
+	}
+	var mu sync.Mutex
+	var windows []string
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name == "tmux" && len(args) > 2 && args[0] == "new-window" {
+			mu.Lock()
+			windows = append(windows, args[2])
+			mu.Unlock()
+		}
+		return agents.run(dir, name, args...)
+	}
+
+	mgr := orch.newUpCycleManager(1, []WorktreeSession{session})
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.config.IdleTimeout = time.Millisecond
+	mgr.startNextCycle = func(int) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.runCycle(ctx); err != nil {
+		t.Fatalf("run cycle: %v", err)
+	}
+	// The worktree has landed and been removed; recreate it so the skipped
+	// auto-answer can still be logged.
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatalf("recreate worktree: %v", err)
+	}
+	cs := mgr.sessions[0]
+	mgr.handleQuestion(ctx, cs, filepath.Join(path, "outbox", "questions", "q-1.md"))
+
+	mu.Lock()
+	launched := strings.Join(windows, "\n")
+	mu.Unlock()
+	for _, prefix := range []string{"worktree-agent-1-1", "worktree-orchestrator-1-1", "summary-1-", "down-cycle-", "land-1-"} {
+		if !strings.Contains(launched, prefix) {
+			t.Fatalf("critical launch %s should run past the budget, got:\n%s", prefix, launched)
+		}
+	}
+	for _, prefix := range []string{"worktree-agent-1-2", "dream-", "worktree-help-"} {
+		if strings.Contains(launched, prefix) {
+			t.Fatalf("%s launched after the budget was spent:\n%s", prefix, launched)
+		}
+	}
+	if got := cs.Beads; len(got) != 1 || got[0].ID != "task-2" {
+		t.Fatalf("expected task-2 deferred to the next cycle, got %v", got)
+	}
+	worktreeLog, err := os.ReadFile(filepath.Join(path, "LOG.md"))
+	if err != nil {
+		t.Fatalf("read worktree log: %v", err)
+	}
+	if want := "Invocation budget spent; leaving q-1.md"; !strings.Contains(string(worktreeLog), want) {
+		t.Fatalf("worktree log missing %q:\n%s", want, worktreeLog)
+	}
+	response, err := os.ReadFile(filepath.Join(path, "inbox", "responses", "q-1.response.md"))
+	if err != nil {
+		t.Fatalf("expected a fallback response for the skipped question: %v", err)
+	}
+	if want := "No automatic answer is available"; !strings.Contains(string(response), want) {
+		t.Fatalf("fallback response missing %q:\n%s", want, response)
+	}
+	workLog, err := os.ReadFile(filepath.Join(orch.config.LatticeProjectDir, workflow.WorkflowDir, workflow.WorkDir, workflow.FileWorkLog))
+	if err != nil {
+		t.Fatalf("read work log: %v", err)
+	}
+	if want := "Invocation budget: 5 of 2 opencode launch(es) used; budget hit, deferred or skipped: tree-1-aster agent cycle 2; dreaming for Aster"; !strings.Contains(string(workLog), want) {
+		t.Fatalf("work log missing %q:\n%s", want, workLog)
+	}
+}
+
+func TestRunUpCycleOnSubsetLeavesOtherSessionsPrepared(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	names := []string{"Aster", "Birch", "Cedar", "Dahlia", "Elm"}
+	var workers, ready []string
+	agents := &simulatedAgents{
+		t:           t,
+		windowDirs:  map[string]string{},
+		cycleReport: filepath.Join(orch.config.StateDir(), "cycle-1", "SUMMARY.md"),
+		memoryPaths: map[string]string{},
+	}
+	for i, name := range names {
+		slug := strings.ToLower(name)
+		dir := filepath.Join(orch.config.AgentsDir(), slug)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir agent: %v", err)
+		}
+		agent := fmt.Sprintf("---\nname: %s\nrole: Worker\n---\n\n%s builds.\n", name, name)
+		if err := os.WriteFile(filepath.Join(dir, "AGENT.md"), []byte(agent), 0644); err != nil {
+			t.Fatalf("write agent: %v", err)
+		}
+		agents.memoryPaths[slug] = filepath.Join(dir, "MEMORY.md")
+		workers = append(workers, fmt.Sprintf(`{"name":%q,"role":"worker"}`, name))
+		ready = append(ready, fmt.Sprintf(`{"id":"lat-%d","title":"Task %d","points":1}`, i+1, i+1))
+	}
+	if err := os.MkdirAll(filepath.Dir(orch.config.WorkerListPath()), 0755); err != nil {
+		t.Fatalf("mkdir roster: %v", err)
+	}
+	roster := `{"workers":[` + strings.Join(workers, ",") + `]}`
+	if err := os.WriteFile(orch.config.WorkerListPath(), []byte(roster), 0644); err != nil {
+		t.Fatalf("write roster: %v", err)
+	}
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name == "bd" && len(args) > 0 && args[0] == "ready" {
+			return "[" + strings.Join(ready, ",") + "]", nil
+		}
+		return agents.run(dir, name, args...)
+	}
+
+	prepared, err := orch.PrepareWorkCycle()
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	if len(prepared) != 5 {
+		t.Fatalf("expected five sessions, got %d", len(prepared))
+	}
+	mgr := orch.newUpCycleManager(1, prepared[:2])
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.startNextCycle = func(int) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.runCycle(ctx); err != nil {
+		t.Fatalf("run subset: %v", err)
+	}
+
+	tracker, err := orch.readCycleTracker()
+	if err != nil {
+		t.Fatalf("idle sessions should stay tracked: %v", err)
+	}
+	if tracker.Status != "prepared" || tracker.Cycle != 2 {
+		t.Fatalf("expected idle sessions prepared for cycle 2, got status=%q cycle=%d", tracker.Status, tracker.Cycle)
+	}
+	for _, session := range prepared[:2] {
+		if _, err := os.Stat(session.Path); !os.IsNotExist(err) {
+			t.Fatalf("ran session %s should be removed after landing: %v", session.Name, err)
+		}
+	}
+	resumed, err := orch.PrepareWorkCycle()
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if len(resumed) != 3 {
+		t.Fatalf("expected the three idle sessions to resume, got %d", len(resumed))
+	}
+	for i, session := range resumed {
+		want := prepared[i+2]
+		if session.Name != want.Name || session.Agent.Name != want.Agent.Name || len(session.Beads) != 1 || session.Beads[0].ID != want.Beads[0].ID {
+			t.Fatalf("resumed session %d = %s/%s %v, want %s/%s %v", i, session.Name, session.Agent.Name, session.Beads, want.Name, want.Agent.Name, want.Beads)
+		}
+		if _, err := os.Stat(filepath.Join(session.Path, "WORKTREE.md")); err != nil {
+			t.Fatalf("idle session %s lost its worktree: %v", session.Name, err)
+		}
+	}
+}
+
+func TestSoloCycleRunsOneSession(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetSolo(true)
+	orch.SetMinAgentsPerCycle(3)
+	agents := &simulatedAgents{
+		t:           t,
+		windowDirs:  map[string]string{},
+		cycleReport: filepath.Join(orch.config.StateDir(), "cycle-1", "SUMMARY.md"),
+		memoryPaths: map[string]string{},
+	}
+	var workers []string
+	for _, name := range []string{"Aster", "Birch", "Cedar"} {
+		slug := strings.ToLower(name)
+		dir := filepath.Join(orch.config.AgentsDir(), slug)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir agent: %v", err)
+		}
+		agent := fmt.Sprintf("---\nname: %s\nrole: Worker\n---\n\n%s builds.\n", name, name)
+		if err := os.WriteFile(filepath.Join(dir, "AGENT.md"), []byte(agent), 0644); err != nil {
+			t.Fatalf("write agent: %v", err)
+		}
+		agents.memoryPaths[slug] = filepath.Join(dir, "MEMORY.md")
+		workers = append(workers, fmt.Sprintf(`{"name":%q,"role":"worker"}`, name))
+	}
+	if err := os.MkdirAll(filepath.Dir(orch.config.WorkerListPath()), 0755); err != nil {
+		t.Fatalf("mkdir roster: %v", err)
+	}
+	roster := `{"workers":[` + strings.Join(workers, ",") + `]}`
+	if err := os.WriteFile(orch.config.WorkerListPath(), []byte(roster), 0644); err != nil {
+		t.Fatalf("write roster: %v", err)
+	}
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name == "bd" && len(args) > 0 && args[0] == "ready" {
+			return `[{"id":"lat-1","title":"Task 1","points":2},{"id":"lat-2","title":"Task 2","points":2},{"id":"lat-3","title":"Task 3","points":2}]`, nil
+		}
+		return agents.run(dir, name, args...)
+	}
+
+	sessions, err := orch.PrepareWorkCycle()
+	if err != nil {
+		t.Fatalf("prepare solo cycle: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].Agent.Name != "Aster" || len(sessions[0].Beads) != 3 {
+		t.Fatalf("expected one session for Aster holding every bead, got %+v", sessions)
+	}
+	progress := &recordingProgress{}
+	orch.SetProgressReporter(progress)
+	mgr := orch.newUpCycleManager(1, sessions)
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.startNextCycle = func(int) error { return nil }
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.runCycle(ctx); err != nil {
+		t.Fatalf("run solo cycle: %v", err)
+	}
+	var started []string
+	for _, event := range progress.events {
+		if strings.HasPrefix(event, "started ") {
+			started = append(started, event)
+		}
+	}
+	if len(started) != 1 || !strings.Contains(started[0], sessions[0].Name) {
+		t.Fatalf("expected a single session to run, got %v", progress.events)
+	}
+}
+
+func TestDownCycleReportCountsCyclesBeyondArchiveRetention(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetArchiveRetention(2)
+	path := t.TempDir()
+	events := filepath.Join(path, "archive", "events")
+	if err := os.MkdirAll(events, 0755); err != nil {
+		t.Fatalf("create archive: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "archive", "notes.md"), []byte("keep me\n"), 0644); err != nil {
+		t.Fatalf("write notes: %v", err)
+	}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster"}}
+	mgr := orch.newUpCycleManager(1, []WorktreeSession{session})
+	cs := mgr.sessions[0]
+	for cycle := 1; cycle <= 4; cycle++ {
+		cs.cycle = cycle
+		if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "running", Cycle: cycle, Global: 1}); err != nil {
+			t.Fatalf("write worktree state: %v", err)
+		}
+		for _, name := range []string{"agent-cycle-%d.json", "orchestrator-cycle-%d.json"} {
+			body := fmt.Sprintf(`{"type":"agent_complete","cycle":%d}`, cycle)
+			if err := os.WriteFile(filepath.Join(events, fmt.Sprintf(name, cycle)), []byte(body), 0644); err != nil {
+				t.Fatalf("write event: %v", err)
+			}
+		}
+		if err := mgr.archiveWorktree(cs, true); err != nil {
+			t.Fatalf("archive cycle %d: %v", cycle, err)
+		}
+	}
+	reports, err := mgr.collectSessionReports()
+	if err != nil {
+		t.Fatalf("collect reports: %v", err)
+	}
+	if got := len(reports[0].Cycles); got != 4 || reports[0].FinalCycle != 4 {
+		t.Fatalf("report should count all 4 cycles before pruning, got %d (final %d)", got, reports[0].FinalCycle)
+	}
+
+	list := func(dir string) []string {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("read %s: %v", dir, err)
+		}
+		var names []string
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				names = append(names, entry.Name())
+			}
+		}
+		return names
+	}
+	if got, want := strings.Join(list(filepath.Join(path, "archive")), " "), "CYCLE-3-WORKTREE.md CYCLE-4-WORKTREE.md notes.md"; got != want {
+		t.Fatalf("archive = %s, want %s", got, want)
+	}
+	if got, want := strings.Join(list(events), " "), "agent-cycle-3.json agent-cycle-4.json orchestrator-cycle-3.json orchestrator-cycle-4.json"; got != want {
+		t.Fatalf("archived events = %s, want %s", got, want)
+	}
+}
+
+func TestUnansweredQuestionNotifiesEscalationWebhook(t *testing.T) {
+	received := make(chan Escalation, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var escalation Escalation
+		if err := json.NewDecoder(r.Body).Decode(&escalation); err != nil {
+			t.Errorf("decode escalation: %v", err)
+		}
+		received <- escalation
+	}))
+	defer server.Close()
+	notifier, err := NewEscalationNotifier("webhook", server.URL)
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+
+	orch := newTestOrchestrator(t)
+	orch.SetEscalationNotifier(notifier)
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name == "tmux" {
+			return "", errors.New("tmux unavailable")
+		}
+		return "", nil
+	}
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	question := filepath.Join(path, "outbox", "questions", "cycle-1-schema.md")
+	if err := os.MkdirAll(filepath.Dir(question), 0755); err != nil {
+		t.Fatalf("create questions dir: %v", err)
+	}
+	if err := os.WriteFile(question, []byte("Which schema version should the migration target?\n"), 0644); err != nil {
+		t.Fatalf("write question: %v", err)
+	}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster"}}
+	mgr := orch.newUpCycleManager(2, []WorktreeSession{session})
+	mgr.config.IdleTimeout = time.Millisecond
+	mgr.handleQuestion(context.Background(), mgr.sessions[0], question)
+
+	select {
+	case escalation := <-received:
+		if escalation.Cycle != 2 || escalation.Worktree != "tree-1-aster" || escalation.Agent != "Aster" || escalation.Question != question {
+			t.Fatalf("unexpected escalation: %+v", escalation)
+		}
+		if escalation.Body != "Which schema version should the migration target?" {
+			t.Fatalf("escalation body = %q", escalation.Body)
+		}
+		if !strings.Contains(escalation.Reason, "tmux unavailable") {
+			t.Fatalf("escalation reason should carry the auto-response failure: %q", escalation.Reason)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook never received the escalation")
+	}
+}
+
+type recordingNotifier chan Escalation
+
+func (r recordingNotifier) NotifyEscalation(_ context.Context, escalation Escalation) error {
+	r <- escalation
+	return nil
+}
+
+func TestNeedHelpEntriesAreEscalatedOnce(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	received := make(recordingNotifier, 4)
+	orch.SetEscalationNotifier(received)
+	path := t.TempDir()
+	worktree := "# WORKTREE\n\n# unrelated bugs\n- none recorded yet\n\n# need help\n- task-1: migration keeps failing on the lock table\n"
+	if err := os.WriteFile(filepath.Join(path, "WORKTREE.md"), []byte(worktree), 0o644); err != nil {
+		t.Fatalf("write worktree: %v", err)
+	}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster"}}
+	mgr := orch.newUpCycleManager(3, []WorktreeSession{session})
+	cs := mgr.sessions[0]
+	mgr.escalateHelpRequests(cs)
+	mgr.escalateHelpRequests(cs)
+
+	select {
+	case escalation := <-received:
+		if escalation.Cycle != 3 || escalation.Worktree != "tree-1-aster" || escalation.Body != "task-1: migration keeps failing on the lock table" {
+			t.Fatalf("unexpected escalation: %+v", escalation)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("need-help entry was never escalated")
+	}
+	select {
+	case escalation := <-received:
+		t.Fatalf("need-help entry escalated twice: %+v", escalation)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRunSessionRefusesPartiallyScaffoldedSession(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	for _, rel := range []string{filepath.Join("outbox", "events"), filepath.Join("archive", "events")} {
+		if err := os.MkdirAll(filepath.Join(path, rel), 0755); err != nil {
+			t.Fatalf("create %s: %v", rel, err)
+		}
+	}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster"}, Beads: []Bead{{ID: "task-1", Points: 1}}}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 1}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	var launched []string
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		launched = append(launched, name+" "+strings.Join(args, " "))
+		return "", nil
+	}
+
+	if got, want := strings.Join(ValidateSessionDir(path), ", "), "outbox/questions, inbox/responses"; got != want {
+		t.Fatalf("missing = %q, want %q", got, want)
+	}
+	mgr := orch.newUpCycleManager(1, []WorktreeSession{session})
+	err := mgr.runSession(context.Background(), mgr.sessions[0])
+	if !errors.Is(err, ErrSessionScaffold) {
+		t.Fatalf("expected ErrSessionScaffold, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "missing outbox/questions, inbox/responses") {
+		t.Fatalf("error should name the missing dirs: %v", err)
+	}
+	if len(launched) != 0 {
+		t.Fatalf("agent dispatched into an unscaffolded session: %v", launched)
+	}
+	if err := os.Remove(filepath.Join(path, "WORKTREE.md")); err != nil {
+		t.Fatalf("remove WORKTREE.md: %v", err)
+	}
+	if got := ValidateSessionDir(path); got[len(got)-1] != "WORKTREE.md" {
+		t.Fatalf("missing WORKTREE.md not reported: %v", got)
+	}
+}
+
+func TestIdleAgentIsRedispatchedWithoutClosedBeads(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	if _, err := orch.ensureCycleState(); err != nil {
+		t.Fatalf("ensure cycle state: %v", err)
+	}
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, path)
+	beads := []Bead{{ID: "task-1", Title: "First", Points: 1}, {ID: "task-2", Title: "Second", Points: 2}}
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster"}, Beads: beads}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 1}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	agents := &simulatedAgents{
+		t:          t,
+		windowDirs: map[string]string{},
+		events:     map[int]string{1: `{"type":"agent_complete","cycle":1,"completedBeads":["task-2"],"remainingBeads":[]}`},
+	}
+	var dispatches int
+	var killed []string
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		call := strings.Join(append([]string{name}, args...), " ")
+		switch {
+		case call == "bd show task-1 --json":
+			// The idle agent closed task-1 before it hung.
+			return `[{"id":"task-1","status":"closed"}]`, nil
+		case call == "bd show task-2 --json":
+			return `[{"id":"task-2","status":"in_progress"}]`, nil
+		case strings.HasPrefix(call, "tmux kill-window -t worktree-agent-"):
+			killed = append(killed, args[2])
+		case strings.HasPrefix(call, "tmux send-keys -t worktree-agent-"):
+			dispatches++
+			if dispatches == 1 {
+				return "", nil
+			}
+		}
+		return agents.run(dir, name, args...)
+	}
+
+	mgr := orch.newUpCycleManager(1, []WorktreeSession{session})
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.config.AgentIdleTimeout = 50 * time.Millisecond
+	mgr.config.AgentIdleRetries = 1
+	cs := mgr.sessions[0]
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := mgr.runSession(ctx, cs); err != nil {
+		t.Fatalf("run session: %v", err)
+	}
+
+	if dispatches != 2 || cs.cycle != 1 {
+		t.Fatalf("expected cycle 1 to be dispatched twice, got %d dispatch(es) ending on cycle %d", dispatches, cs.cycle)
+	}
+	if len(killed) == 0 || killed[0] != "worktree-agent-1-1" {
+		t.Fatalf("expected the idle agent window to be killed, got %v", killed)
+	}
+	log, err := os.ReadFile(filepath.Join(path, "LOG.md"))
+	if err != nil {
+		t.Fatalf("read worktree log: %v", err)
+	}
+	for _, want := range []string{"idle for", "already closed task-1", "Re-dispatching cycle 1 to Aster with 1 bead(s) (idle retry 1 of 1)"} {
+		if !strings.Contains(string(log), want) {
+			t.Fatalf("worktree log missing %q:\n%s", want, log)
+		}
+	}
+	if got := cs.progress(); got.CompletedBeads != 2 || got.CompletedPoints != 3 || got.AssignedPoints != 3 {
+		t.Fatalf("completed beads should be counted once, got %+v", got)
+	}
+
+	mgr = orch.newUpCycleManager(1, []WorktreeSession{session})
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	mgr.config.AgentIdleTimeout = 50 * time.Millisecond
+	mgr.config.AgentIdleRetries = 0
+	dispatches = 0
+	if err := mgr.runSession(ctx, mgr.sessions[0]); !errors.Is(err, ErrAgentIdle) {
+		t.Fatalf("expected ErrAgentIdle once retries are spent, got %v", err)
+	}
+}
+
+func TestAgentIdleTimerPausesWhileQuestionAwaitsResponse(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	var killed []string
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name == "tmux" && len(args) > 2 && args[0] == "kill-window" {
+			killed = append(killed, args[2])
+		}
+		return "", nil
+	}
+	path := t.TempDir()
+	for _, dir := range []string{filepath.Join(path, "outbox", "questions"), filepath.Join(path, "inbox", "responses")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	question := filepath.Join(path, "outbox", "questions", "cycle-1-schema.md")
+	if err := os.WriteFile(question, []byte("Which schema?\n"), 0o644); err != nil {
+		t.Fatalf("write question: %v", err)
+	}
+	mgr := &upCycleManager{orchestrator: orch, config: UpCycleConfig{AgentIdleTimeout: time.Minute}}
+	cs := &cycleSession{WorktreeSession: WorktreeSession{Name: "wt-1", Path: path}, cycle: 1, agentWindow: "worktree-agent-1-1"}
+	last := time.Now().Add(-time.Hour)
+	now := time.Now().Add(time.Hour)
+	if err := mgr.checkAgentIdle(cs, &last, now); err != nil {
+		t.Fatalf("agent waiting on a response was treated as idle: %v", err)
+	}
+	if len(killed) != 0 || cs.agentWindow == "" {
+		t.Fatalf("window closed while a question was pending: %v", killed)
+	}
+
+	if err := os.WriteFile(responsePathForQuestion(path, question), []byte("Use v2.\n"), 0o644); err != nil {
+		t.Fatalf("write response: %v", err)
+	}
+	if err := mgr.checkAgentIdle(cs, &last, now.Add(30*time.Second)); err != nil {
+		t.Fatalf("idle timer should restart once the response lands: %v", err)
+	}
+	if err := mgr.checkAgentIdle(cs, &last, now.Add(2*time.Minute)); !errors.Is(err, ErrAgentIdle) {
+		t.Fatalf("expected ErrAgentIdle after the answered agent stays quiet, got %v", err)
+	}
+}
+
+func TestBeadSyncRunsAtConfiguredCadence(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	current := start
+	orch.now = func() time.Time { return current }
+	var synced []time.Duration
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name != "bd" || strings.Join(args, " ") != "sync" {
+			return "", fmt.Errorf("unexpected command %s %v", name, args)
+		}
+		synced = append(synced, current.Sub(start))
+		if current.Sub(start) == 10*time.Minute {
+			return "", errors.New("remote unreachable")
+		}
+		return "", nil
+	}
+	tick := func() {
+		for _, offset := range []time.Duration{0, time.Minute, 4 * time.Minute, 5 * time.Minute, 7 * time.Minute, 10 * time.Minute, 12 * time.Minute, 16 * time.Minute} {
+			current = start.Add(offset)
+			orch.syncBeadsIfDue("agent cycle")
+		}
+	}
+
+	tick()
+	if len(synced) != 0 {
+		t.Fatalf("bead sync should be off by default, ran at %v", synced)
+	}
+	orch.SetBeadSyncInterval(5 * time.Minute)
+	tick()
+	want := []time.Duration{0, 5 * time.Minute, 10 * time.Minute, 16 * time.Minute}
+	if fmt.Sprint(synced) != fmt.Sprint(want) {
+		t.Fatalf("synced at %v, want %v", synced, want)
+	}
+	data, err := os.ReadFile(orch.beadSyncLogPath())
+	if err != nil {
+		t.Fatalf("read bead sync log: %v", err)
+	}
+	if !strings.Contains(string(data), "bd sync before agent cycle failed: remote unreachable") || strings.Count(string(data), "\n") != 1 {
+		t.Fatalf("expected only the failed sync to be logged, got %q", data)
+	}
+}
+
+func TestSuspendedSessionHoldsWhileSiblingsRun(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	var sessions []WorktreeSession
+	for i, name := range []string{"tree-1-aster", "tree-2-bryn"} {
+		path := filepath.Join(orch.config.WorktreeDir(), fmt.Sprint(i+1), name)
+		scaffoldSessionDirs(t, path)
+		session := WorktreeSession{Number: i + 1, Name: name, Path: path, Agent: ProjectAgent{Name: name}, Beads: []Bead{{ID: fmt.Sprintf("task-%d", i+1), Points: 1}}}
+		if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "pending", Cycle: 1, Global: 1}); err != nil {
+			t.Fatalf("write worktree state: %v", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := orch.SuspendSession("tree-1-aster", "looping on task-1"); err != nil {
+		t.Fatalf("suspend: %v", err)
+	}
+	agents := &simulatedAgents{t: t, windowDirs: map[string]string{}}
+	var mu sync.Mutex
+	var windows []string
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name == "tmux" && len(args) > 2 && args[0] == "new-window" {
+			mu.Lock()
+			windows = append(windows, args[2])
+			mu.Unlock()
+		}
+		return agents.run(dir, name, args...)
+	}
+	launched := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return strings.Join(windows, "\n")
+	}
+
+	mgr := orch.newUpCycleManager(1, sessions)
+	mgr.config.EventPollInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- mgr.run(ctx) }()
+
+	siblingArchive := filepath.Join(sessions[1].Path, "archive", "CYCLE-1-WORKTREE.md")
+	for !fileExists(siblingArchive) {
+		select {
+		case err := <-done:
+			t.Fatalf("run finished while a session was suspended: %v", err)
+		case <-ctx.Done():
+			t.Fatal("sibling session never finished its cycle")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if strings.Contains(launched(), "worktree-agent-1-") {
+		t.Fatalf("suspended session was dispatched:\n%s", launched())
+	}
+	state, err := os.ReadFile(filepath.Join(sessions[0].Path, "WORKTREE.md"))
+	if err != nil {
+		t.Fatalf("read suspended state: %v", err)
+	}
+	if !strings.Contains(string(state), "- state: suspended") {
+		t.Fatalf("suspended session state not recorded:\n%s", state)
+	}
+
+	if err := orch.ResumeSession("tree-1-aster"); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(launched(), "worktree-agent-1-1") {
+		t.Fatalf("resumed session never dispatched:\n%s", launched())
+	}
+	if !fileExists(filepath.Join(sessions[0].Path, "archive", "CYCLE-1-WORKTREE.md")) {
+		t.Fatal("resumed session did not finish its cycle")
+	}
+}
+
+func TestAutoResponsesStayUnderGlobalLimit(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetMaxConcurrentAutoResponses(2)
+	var sessions []WorktreeSession
+	for i := 1; i <= 3; i++ {
+		path := filepath.Join(orch.config.WorktreeDir(), fmt.Sprint(i), fmt.Sprintf("tree-%d", i))
+		scaffoldSessionDirs(t, path)
+		sessions = append(sessions, WorktreeSession{Number: i, Name: fmt.Sprintf("tree-%d", i), Path: path})
+	}
+	promptDir := filepath.Join(orch.config.LatticeProjectDir, "logs", "opencode")
+	responseLine := regexp.MustCompile(`Write a concise response to (\S+)\. `)
+	var mu sync.Mutex
+	active, peak := 0, 0
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name != "tmux" || len(args) < 3 || !strings.HasPrefix(args[2], "worktree-help-") {
+			return "", nil
+		}
+		window := args[2]
+		switch args[0] {
+		case "new-window":
+			mu.Lock()
+			active++
+			if active > peak {
+				peak = active
+			}
+			mu.Unlock()
+		case "kill-window":
+			mu.Lock()
+			active--
+			mu.Unlock()
+		case "send-keys":
+			prompt, err := os.ReadFile(filepath.Join(promptDir, window+".prompt"))
+			if err != nil {
+				t.Errorf("read prompt for %s: %v", window, err)
+				return "", nil
+			}
+			match := responseLine.FindSubmatch(prompt)
+			if match == nil {
+				t.Errorf("no response path in prompt for %s", window)
+				return "", nil
+			}
+			time.Sleep(30 * time.Millisecond)
+			if err := os.WriteFile(string(match[1]), []byte("Proceed.\n"), 0644); err != nil {
+				t.Errorf("write response: %v", err)
+			}
+		}
+		return "", nil
+	}
+
+	mgr := orch.newUpCycleManager(1, sessions)
+	mgr.config.IdleTimeout = time.Millisecond
+	var wg sync.WaitGroup
+	var responses []string
+	for _, cs := range mgr.sessions {
+		for _, slug := range []string{"schema", "naming"} {
+			question := filepath.Join(cs.Path, "outbox", "questions", fmt.Sprintf("cycle-1-%s.md", slug))
+			if err := os.WriteFile(question, []byte("Which way?\n"), 0644); err != nil {
+				t.Fatalf("write question: %v", err)
+			}
+			responses = append(responses, responsePathForQuestion(cs.Path, question))
+			wg.Add(1)
+			go func(cs *cycleSession, question string) {
+				defer wg.Done()
+				mgr.handleQuestion(context.Background(), cs, question)
+			}(cs, question)
+		}
+	}
+	wg.Wait()
+
+	for _, response := range responses {
+		if !fileHasContent(response) {
+			t.Fatalf("question never answered: %s", response)
+		}
+	}
+	if peak > 2 {
+		t.Fatalf("%d auto-responses ran at once across sessions, limit is 2", peak)
+	}
+}
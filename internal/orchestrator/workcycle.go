@@ -2,6 +2,8 @@ package orchestrator
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +15,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/kingrea/The-Lattice/internal/config"
 )
 
 const (
@@ -25,6 +29,16 @@ const (
 
 var ErrNoReadyBeads = errors.New("no ready beads available")
 
+// ErrWorkExhausted is returned by PrepareWorkCycle when no ready beads exist
+// because every bead is closed. It wraps ErrNoReadyBeads so callers that only
+// know the latter keep working, while work-process can skip refinement.
+var ErrWorkExhausted = fmt.Errorf("%w: every bead is closed", ErrNoReadyBeads)
+
+// ErrTooFewAgents is returned by PrepareWorkCycle when fewer agents can be
+// scheduled than the configured minimum. Callers should wait for more agents
+// rather than treat it as a failure.
+var ErrTooFewAgents = errors.New("waiting for more agents")
+
 // ProjectAgent represents an agent that exists inside the project state directory.
 type ProjectAgent struct {
 	Name    string
@@ -45,6 +59,44 @@ type Bead struct {
 	Blocked   bool     `json:"blocked"`
 	BlockedBy []string `json:"blockedBy"`
 	DependsOn []string `json:"dependsOn"`
+	// OrphanedParent records a parent epic that no longer exists in bd. The
+	// dangling ParentID is cleared so nothing labels the bead with it.
+	OrphanedParent string `json:"orphanedParent,omitempty"`
+	// Priority is bd's priority (0 is most urgent). Records without one are
+	// treated as the lowest priority.
+	Priority  int       `json:"priority"`
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
+
+// BeadOrder selects the primary sort key for ready beads.
+type BeadOrder string
+
+const (
+	// BeadOrderPoints puts the largest beads first (the default).
+	BeadOrderPoints BeadOrder = "points"
+	// BeadOrderPriority puts the most urgent bd priority first.
+	BeadOrderPriority BeadOrder = "priority"
+	// BeadOrderCreated is FIFO: the oldest bead first.
+	BeadOrderCreated BeadOrder = "created"
+	// BeadOrderID sorts by bead ID alone.
+	BeadOrderID BeadOrder = "id"
+)
+
+// lowestBeadPriority is bd's least urgent priority, used for records that do
+// not report one.
+const lowestBeadPriority = 4
+
+// ParseBeadOrder validates a bead_order setting. An empty value selects
+// BeadOrderPoints.
+func ParseBeadOrder(value string) (BeadOrder, error) {
+	switch order := BeadOrder(strings.ToLower(strings.TrimSpace(value))); order {
+	case "":
+		return BeadOrderPoints, nil
+	case BeadOrderPoints, BeadOrderPriority, BeadOrderCreated, BeadOrderID:
+		return order, nil
+	default:
+		return "", fmt.Errorf("unknown bead order %q (want points, priority, created, or id)", value)
+	}
 }
 
 // WorktreeSession captures the state for a prepared worktree/agent session.
@@ -96,20 +148,26 @@ func (o *Orchestrator) PrepareWorkCycle() ([]WorktreeSession, error) {
 		return nil, err
 	}
 	if len(scheduledAgents) == 0 {
-		return nil, fmt.Errorf("no agents available to schedule")
+		return nil, fmt.Errorf("%w to schedule", ErrNoAgents)
+	}
+	if o.solo {
+		scheduledAgents = scheduledAgents[:1]
+	} else if o.minAgentsPerCycle > 0 && len(scheduledAgents) < o.minAgentsPerCycle {
+		return nil, fmt.Errorf("%w: %d of %d required agent(s) available", ErrTooFewAgents, len(scheduledAgents), o.minAgentsPerCycle)
 	}
 
-	beads, err := o.loadReadyBeads()
+	o.syncBeadsIfDue(fmt.Sprintf("preparing cycle %d", cycleNumber))
+	beads, chainable, err := o.loadCycleBeads()
 	if err != nil {
 		return nil, err
 	}
 
-	selected := selectBeadsForCycle(beads, scheduledAgents)
+	selected := selectBeadsForCycle(beads, chainable, scheduledAgents, o.cycleConfig())
 	if len(selected) == 0 {
 		return nil, fmt.Errorf("no ready beads available for assignment")
 	}
 
-	assignments, err := assignBeadsToAgents(scheduledAgents, selected)
+	assignments, err := assignBeadsToAgents(o.assignmentStrategy(), scheduledAgents, selected)
 	if err != nil {
 		return nil, err
 	}
@@ -127,13 +185,24 @@ func (o *Orchestrator) PrepareWorkCycle() ([]WorktreeSession, error) {
 
 func (o *Orchestrator) ensureWorktreeToolInstalled() error {
 	if o == nil || o.config == nil {
-		return errors.New("orchestrator is not initialized")
+		return ErrNotInitialized
 	}
 	if worktreePluginAvailable() {
 		return nil
 	}
+	if err := o.installWorktreePlugin(); err != nil {
+		if o.gitWorktreeAvailable() {
+			o.nativeWorktrees = true
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (o *Orchestrator) installWorktreePlugin() error {
 	if !pluginAutoInstallEnabled() {
-		return fmt.Errorf("opencode-worktree plugin is required but not installed. %s Enable automatic installation again by setting %s=1.", pluginManualInstallHint, pluginAutoInstallEnv)
+		return fmt.Errorf("%w. %s Enable automatic installation again by setting %s=1.", ErrWorktreeToolMissing, pluginManualInstallHint, pluginAutoInstallEnv)
 	}
 	if _, err := o.runProjectCommand("opencode", "install", "opencode-worktree"); err != nil {
 		if worktreePluginAvailable() {
@@ -142,13 +211,13 @@ func (o *Orchestrator) ensureWorktreeToolInstalled() error {
 		errStr := strings.ToLower(err.Error())
 		switch {
 		case strings.Contains(errStr, `"opencode": executable file not found`):
-			return fmt.Errorf("OpenCode CLI is not available on PATH, so Lattice cannot install opencode-worktree automatically. Install OpenCode with npm install -g opencode, then %s", pluginManualInstallHint)
+			return fmt.Errorf("%w: OpenCode CLI is not available on PATH, so Lattice cannot install it automatically. Install OpenCode with npm install -g opencode, then %s", ErrWorktreeToolMissing, pluginManualInstallHint)
 		case pluginInstallPermissionError(errStr):
-			return fmt.Errorf("Lattice does not have permission to install opencode-worktree automatically. %s Original error: %w", pluginManualInstallHint, err)
+			return fmt.Errorf("%w: Lattice does not have permission to install it automatically. %s Original error: %w", ErrWorktreeToolMissing, pluginManualInstallHint, err)
 		case strings.Contains(errStr, "already installed") || strings.Contains(errStr, "exists"):
 			return nil
 		default:
-			return fmt.Errorf("failed to install opencode-worktree automatically: %w. %s", err, pluginManualInstallHint)
+			return fmt.Errorf("%w: automatic installation failed: %w. %s", ErrWorktreeToolMissing, err, pluginManualInstallHint)
 		}
 	}
 	return nil
@@ -156,7 +225,7 @@ func (o *Orchestrator) ensureWorktreeToolInstalled() error {
 
 func (o *Orchestrator) ensureBridgePluginInstalled() error {
 	if o == nil || o.config == nil {
-		return errors.New("orchestrator is not initialized")
+		return ErrNotInitialized
 	}
 	pluginPath := filepath.Join(o.config.LatticeRoot, "plugins", bridgePluginName)
 	if _, err := os.Stat(pluginPath); err != nil {
@@ -208,7 +277,7 @@ func (o *Orchestrator) loadProjectAgents() ([]ProjectAgent, error) {
 	agentsDir := o.config.AgentsDir()
 	if _, err := os.Stat(agentsDir); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return nil, fmt.Errorf("expected agents under %s", agentsDir)
+			return nil, fmt.Errorf("%w: expected agents under %s", ErrNoAgents, agentsDir)
 		}
 		return nil, fmt.Errorf("failed to read agents directory: %w", err)
 	}
@@ -236,7 +305,7 @@ func (o *Orchestrator) loadProjectAgents() ([]ProjectAgent, error) {
 	}
 
 	if len(agents) == 0 {
-		return nil, fmt.Errorf("no agent files found in %s", agentsDir)
+		return nil, fmt.Errorf("%w: no agent files found in %s", ErrNoAgents, agentsDir)
 	}
 
 	sort.SliceStable(agents, func(i, j int) bool {
@@ -346,25 +415,191 @@ func parseFirstParagraph(content string) string {
 }
 
 func (o *Orchestrator) loadReadyBeads() ([]Bead, error) {
-	output, err := o.runProjectCommand("bd", "ready", "--json")
-	if err != nil {
-		return nil, err
+	beads, _, err := o.loadCycleBeads()
+	return beads, err
+}
+
+// loadCycleBeads returns the ready beads plus the blocked beads that wait only
+// on other beads, which a cycle may chain behind those beads when it selects
+// them too.
+func (o *Orchestrator) loadCycleBeads() (ready, chainable []Bead, err error) {
+	output, ok := o.PushedReadyBeads()
+	if !ok {
+		polled, err := o.runProjectCommand("bd", "ready", "--json")
+		if err != nil {
+			return nil, nil, err
+		}
+		output = []byte(polled)
 	}
-	records, err := parseBeadRecords([]byte(output))
+	records, err := parseBeadRecords(output, o.beadProfile())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	beads, blocked := partitionBeadRecords(records)
+	o.applyRecordedEstimates(beads)
+	o.applyRecordedEstimates(blocked)
+	if err := o.trackBlockedBeads(blocked); err != nil {
+		// The blocked-bead count only feeds the stuck-bead report; losing
+		// it for a cycle must not hold back the ready beads.
+		_ = o.appendStuckBeadLogLine(fmt.Sprintf("blocked beads not tracked: %v", err))
 	}
-	beads := convertBeadRecords(records)
 	if len(beads) == 0 {
-		return nil, ErrNoReadyBeads
+		if open, err := o.countOpenBeads(); err == nil && open == 0 {
+			return nil, nil, ErrWorkExhausted
+		}
+		return nil, nil, ErrNoReadyBeads
+	}
+	if err := o.flagOrphanedBeads(beads); err != nil {
+		return nil, nil, err
+	}
+	sortReadyBeads(beads, o.beadOrder)
+	for _, bead := range blocked {
+		if bead.waitsOnlyOnBeads() {
+			chainable = append(chainable, bead)
+		}
+	}
+	sortReadyBeads(chainable, o.beadOrder)
+	return beads, chainable, nil
+}
+
+// dependencies lists the beads this bead waits on.
+func (b Bead) dependencies() []string {
+	return dedupeStrings(append(append([]string{}, b.BlockedBy...), b.DependsOn...))
+}
+
+// waitsOnlyOnBeads reports whether the bead is blocked solely by other beads,
+// rather than by a blocked status or tag.
+func (b Bead) waitsOnlyOnBeads() bool {
+	return len(b.dependencies()) > 0 && !containsBlockedTag(b.Tags) && !statusIndicatesBlock(b.Status)
+}
+
+// countOpenBeads returns how many beads bd still lists as not closed,
+// including blocked and in-progress ones.
+func (o *Orchestrator) countOpenBeads() (int, error) {
+	output, err := o.runProjectCommand("bd", "list", "--json")
+	if err != nil {
+		return 0, err
+	}
+	records, err := parseBeadRecords([]byte(output), o.beadProfile())
+	if err != nil {
+		return 0, err
+	}
+	open := 0
+	for _, record := range records {
+		if !strings.EqualFold(strings.TrimSpace(record.Status), "closed") {
+			open++
+		}
+	}
+	return open, nil
+}
+
+// sortReadyBeads orders beads by the selected primary key. Ties fall back to
+// points (largest first) and then bead ID so the order is deterministic.
+func sortReadyBeads(beads []Bead, order BeadOrder) {
+	byPointsThenID := func(a, b Bead) bool {
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		return a.ID < b.ID
 	}
 	sort.SliceStable(beads, func(i, j int) bool {
-		if beads[i].Points == beads[j].Points {
-			return beads[i].ID < beads[j].ID
+		a, b := beads[i], beads[j]
+		switch order {
+		case BeadOrderPriority:
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority
+			}
+		case BeadOrderCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				if a.CreatedAt.IsZero() || b.CreatedAt.IsZero() {
+					return b.CreatedAt.IsZero()
+				}
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		case BeadOrderID:
+			return a.ID < b.ID
 		}
-		return beads[i].Points > beads[j].Points
+		return byPointsThenID(a, b)
 	})
-	return beads, nil
+}
+
+// flagOrphanedBeads marks beads whose parent epic is missing from bd and
+// records each one in the orphaned-beads log so the gap is visible instead of
+// the bead being filed under a parent that no longer exists. A parent bd
+// could not be asked about is logged and left in place.
+func (o *Orchestrator) flagOrphanedBeads(beads []Bead) error {
+	ready := make(map[string]struct{}, len(beads))
+	for _, bead := range beads {
+		ready[canonicalBeadKey(bead.ID)] = struct{}{}
+	}
+	exists := make(map[string]bool)
+	var lines []string
+	for i := range beads {
+		parent := beads[i].ParentID
+		if parent == "" {
+			continue
+		}
+		key := canonicalBeadKey(parent)
+		if _, ok := ready[key]; ok {
+			continue
+		}
+		found, checked := exists[key]
+		if !checked {
+			var err error
+			found, err = o.beadExists(parent)
+			if err != nil {
+				lines = append(lines, fmt.Sprintf("parent %s not checked: %v", parent, err))
+				found = true
+			}
+			exists[key] = found
+		}
+		if found {
+			continue
+		}
+		beads[i].OrphanedParent = parent
+		beads[i].ParentID = ""
+		lines = append(lines, fmt.Sprintf("%s · %s: parent %s missing from bd", beads[i].ID, beads[i].Title, parent))
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return o.appendOrphanLog(lines)
+}
+
+// beadExists reports whether bd still knows about id. Only bd's not-found
+// answer counts as missing; any other failure, such as bd being unavailable
+// or the database locked, is returned as an error.
+func (o *Orchestrator) beadExists(id string) (bool, error) {
+	output, err := o.runProjectCommand("bd", "show", id, "--json")
+	if err != nil {
+		if isBeadNotFound(err.Error()) || isBeadNotFound(output) {
+			return false, nil
+		}
+		return false, err
+	}
+	switch strings.TrimSpace(output) {
+	case "", "[]", "{}", "null":
+		return false, nil
+	}
+	return true, nil
+}
+
+// isBeadNotFound reports whether bd output says the requested issue does not
+// exist, as opposed to the bd binary itself being missing.
+func isBeadNotFound(text string) bool {
+	text = strings.ToLower(text)
+	if strings.Contains(text, "executable file not found") {
+		return false
+	}
+	return strings.Contains(text, "not found") || strings.Contains(text, "no issue found")
+}
+
+func (o *Orchestrator) orphanLogPath() string {
+	return filepath.Join(o.config.LogsDir(), "orphaned-beads.log")
+}
+
+func (o *Orchestrator) appendOrphanLog(lines []string) error {
+	return appendTimestampedLog(o.orphanLogPath(), time.Now(), lines...)
 }
 
 type beadRecord struct {
@@ -381,13 +616,30 @@ type beadRecord struct {
 	BlockedByAlt []string    `json:"blocked_by"`
 	DependsOn    []string    `json:"dependsOn"`
 	DependsOnAlt []string    `json:"depends_on"`
+	Priority     json.Number `json:"priority"`
+	CreatedAt    string      `json:"created_at"`
+	CreatedAtAlt string      `json:"createdAt"`
 }
 
-func parseBeadRecords(data []byte) ([]beadRecord, error) {
+// parseBeadRecords decodes bd JSON output, a bare array or an items
+// wrapper, and normalizes each record with the installed bd's profile.
+func parseBeadRecords(data []byte, profile bdProfile) ([]beadRecord, error) {
+	records, err := decodeBeadRecords(data)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		records[i] = profile.normalize(records[i])
+	}
+	return records, nil
+}
+
+func decodeBeadRecords(data []byte) ([]beadRecord, error) {
 	decoder := json.NewDecoder(bytes.NewReader(data))
 	decoder.UseNumber()
 	var arr []beadRecord
-	if err := decoder.Decode(&arr); err == nil && len(arr) > 0 {
+	if err := decoder.Decode(&arr); err == nil {
+		// An empty array is a valid answer: bd has nothing to list.
 		return arr, nil
 	}
 	decoder = json.NewDecoder(bytes.NewReader(data))
@@ -398,10 +650,17 @@ func parseBeadRecords(data []byte) ([]beadRecord, error) {
 	if err := decoder.Decode(&wrapper); err == nil && len(wrapper.Items) > 0 {
 		return wrapper.Items, nil
 	}
-	return nil, fmt.Errorf("unexpected bd ready output")
+	return nil, fmt.Errorf("unexpected bd output")
 }
 
 func convertBeadRecords(records []beadRecord) []Bead {
+	ready, _ := partitionBeadRecords(records)
+	return ready
+}
+
+// partitionBeadRecords converts bd records and splits them into beads that
+// can be scheduled and beads held back as blocked.
+func partitionBeadRecords(records []beadRecord) (ready, blocked []Bead) {
 	beads := make([]Bead, 0, len(records))
 	for _, rec := range records {
 		id := strings.TrimSpace(rec.ID)
@@ -425,16 +684,19 @@ func convertBeadRecords(records []beadRecord) []Bead {
 			Blocked:   blocked,
 			BlockedBy: blockedBy,
 			DependsOn: dependsOn,
+			Priority:  beadPriority(rec.Priority),
+			CreatedAt: parseBeadTime(rec.CreatedAt, rec.CreatedAtAlt),
 		})
 	}
-	unblocked := make([]Bead, 0, len(beads))
+	ready = make([]Bead, 0, len(beads))
 	for _, bead := range beads {
 		if bead.Blocked {
+			blocked = append(blocked, bead)
 			continue
 		}
-		unblocked = append(unblocked, bead)
+		ready = append(ready, bead)
 	}
-	return unblocked
+	return ready, blocked
 }
 
 func dedupeStrings(items []string) []string {
@@ -469,6 +731,22 @@ func statusIndicatesBlock(status string) bool {
 	return strings.Contains(s, "block")
 }
 
+func beadPriority(num json.Number) int {
+	if val, err := num.Int64(); err == nil && val >= 0 {
+		return int(val)
+	}
+	return lowestBeadPriority
+}
+
+func parseBeadTime(values ...string) time.Time {
+	for _, value := range values {
+		if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
 func firstNonZeroNumber(numbers ...json.Number) int {
 	for _, num := range numbers {
 		if num == "" {
@@ -484,7 +762,31 @@ func firstNonZeroNumber(numbers ...json.Number) int {
 	return 0
 }
 
-func selectBeadsForCycle(beads []Bead, agents []scheduledAgent) []Bead {
+// cycleConfig returns the project's cycle sizing with the built-in defaults
+// filled in for unset fields.
+func (o *Orchestrator) cycleConfig() config.CycleConfig {
+	var cycle config.CycleConfig
+	if o != nil && o.config != nil {
+		cycle = o.config.Project.Cycle
+	}
+	if cycle.MinPoints <= 0 {
+		cycle.MinPoints = cycleMinStoryPoints
+	}
+	if cycle.MaxAgentPoints <= 0 {
+		cycle.MaxAgentPoints = maxAgentStoryPoints
+	}
+	if cycle.MaxTotalPoints > 0 && cycle.MinPoints > cycle.MaxTotalPoints {
+		cycle.MinPoints = cycle.MaxTotalPoints
+	}
+	return cycle
+}
+
+// selectBeadsForCycle takes ready beads in order until the agents' combined
+// capacity is used. While capacity remains, chainable beads whose dependencies
+// were all selected are added after them, so a short chain can finish in one
+// cycle. When cycle.MaxTotalPoints is set, no bead past the first is taken if
+// it would push the cycle over that ceiling.
+func selectBeadsForCycle(beads, chainable []Bead, agents []scheduledAgent, cycle config.CycleConfig) []Bead {
 	if len(beads) == 0 || len(agents) == 0 {
 		return nil
 	}
@@ -492,121 +794,65 @@ func selectBeadsForCycle(beads []Bead, agents []scheduledAgent) []Bead {
 	for _, agent := range agents {
 		cap := agent.Capacity
 		if cap <= 0 {
-			cap = maxAgentStoryPoints
+			cap = cycle.MaxAgentPoints
 		}
 		target += cap
 	}
-	if target < cycleMinStoryPoints {
-		target = cycleMinStoryPoints
+	if target < cycle.MinPoints {
+		target = cycle.MinPoints
+	}
+	ceiling := cycle.MaxTotalPoints
+	if ceiling > 0 && target > ceiling {
+		target = ceiling
 	}
 	var selection []Bead
+	total := 0
+	fits := func(points int) bool {
+		return ceiling <= 0 || len(selection) == 0 || total+points <= ceiling
+	}
+	chosen := make(map[string]bool, len(beads))
 	for _, bead := range beads {
+		if !fits(bead.Points) {
+			break
+		}
 		selection = append(selection, bead)
+		chosen[canonicalBeadKey(bead.ID)] = true
+		total += bead.Points
 		target -= bead.Points
 		if target <= 0 {
 			break
 		}
 	}
-	return selection
-}
-
-type agentAssignment struct {
-	Agent    ProjectAgent
-	Beads    []Bead
-	Points   int
-	Capacity int
-}
-
-func assignBeadsToAgents(agents []scheduledAgent, beads []Bead) ([]agentAssignment, error) {
-	if len(agents) == 0 {
-		return nil, fmt.Errorf("no agents available to assign work")
-	}
-	if len(beads) == 0 {
-		return nil, fmt.Errorf("no beads to assign")
-	}
-	limit := len(agents)
-	if limit > len(beads) {
-		limit = len(beads)
-	}
-	if limit == 0 {
-		limit = len(agents)
-	}
-	assignments := make([]*agentAssignment, 0, limit)
-	for i := 0; i < limit; i++ {
-		cap := agents[i].Capacity
-		if cap <= 0 {
-			cap = maxAgentStoryPoints
-		}
-		assignments = append(assignments, &agentAssignment{Agent: agents[i].Agent, Capacity: cap})
-	}
-	for _, bead := range beads {
-		slot := pickAssignment(assignments)
-		slot.Beads = append(slot.Beads, bead)
-		slot.Points += bead.Points
-	}
-	var result []agentAssignment
-	for _, slot := range assignments {
-		if len(slot.Beads) == 0 {
-			continue
-		}
-		result = append(result, *slot)
-	}
-	if len(result) == 0 {
-		return nil, fmt.Errorf("no assignments were created")
-	}
-	return result, nil
-}
-
-func pickAssignment(assignments []*agentAssignment) *agentAssignment {
-	if len(assignments) == 0 {
-		return nil
-	}
-	best := assignments[0]
-	for _, slot := range assignments[1:] {
-		if compareAssignmentLoad(slot, best) < 0 {
-			best = slot
+	for added := true; added && target > 0; {
+		added = false
+		for _, bead := range chainable {
+			key := canonicalBeadKey(bead.ID)
+			if chosen[key] || !allChosen(bead.dependencies(), chosen) || !fits(bead.Points) {
+				continue
+			}
+			selection = append(selection, bead)
+			chosen[key] = true
+			total += bead.Points
+			target -= bead.Points
+			added = true
+			if target <= 0 {
+				break
+			}
 		}
 	}
-	return best
+	return selection
 }
 
-func compareAssignmentLoad(a, b *agentAssignment) int {
-	loadA := loadRatio(a)
-	loadB := loadRatio(b)
-	switch {
-	case loadA < loadB:
-		return -1
-	case loadA > loadB:
-		return 1
-	default:
-		if len(a.Beads) < len(b.Beads) {
-			return -1
-		}
-		if len(a.Beads) > len(b.Beads) {
-			return 1
-		}
-		if a.Agent.Name < b.Agent.Name {
-			return -1
-		}
-		if a.Agent.Name > b.Agent.Name {
-			return 1
+func allChosen(ids []string, chosen map[string]bool) bool {
+	for _, id := range ids {
+		if !chosen[canonicalBeadKey(id)] {
+			return false
 		}
 	}
-	return 0
-}
-
-func loadRatio(a *agentAssignment) float64 {
-	cap := a.Capacity
-	if cap <= 0 {
-		cap = maxAgentStoryPoints
-	}
-	if cap == 0 {
-		return 0
-	}
-	return float64(a.Points) / float64(cap)
+	return true
 }
 
-func (o *Orchestrator) createWorktreeSessions(assignments []agentAssignment, cycleNumber int) ([]WorktreeSession, error) {
+func (o *Orchestrator) createWorktreeSessions(assignments []AssignmentSlot, cycleNumber int) ([]WorktreeSession, error) {
 	if len(assignments) == 0 {
 		return nil, fmt.Errorf("no assignments to materialize")
 	}
@@ -628,26 +874,21 @@ func (o *Orchestrator) createWorktreeSessions(assignments []agentAssignment, cyc
 		}
 		name := buildWorktreeName(number, assignment.Agent, assignment.Beads)
 		sessionDir := filepath.Join(sessionRoot, name)
+		// A git checkout has to be made before the session layout fills the
+		// directory it goes into.
+		fallback, err := o.invokeWorktreeCreate(name, sessionDir)
+		if err != nil {
+			return nil, err
+		}
 		if err := os.MkdirAll(sessionDir, 0755); err != nil {
 			return nil, fmt.Errorf("failed to create branch session directory: %w", err)
 		}
-		folders := []string{
-			filepath.Join(sessionDir, "archive"),
-			filepath.Join(sessionDir, "archive", "events"),
-			filepath.Join(sessionDir, "outbox"),
-			filepath.Join(sessionDir, "outbox", "questions"),
-			filepath.Join(sessionDir, "outbox", "events"),
-			filepath.Join(sessionDir, "inbox"),
-			filepath.Join(sessionDir, "inbox", "responses"),
-		}
-		for _, folder := range folders {
+		for _, rel := range sessionLayout {
+			folder := filepath.Join(sessionDir, rel)
 			if err := os.MkdirAll(folder, 0755); err != nil {
 				return nil, fmt.Errorf("failed to create %s: %w", folder, err)
 			}
 		}
-		if err := o.invokeWorktreeCreate(name); err != nil {
-			return nil, err
-		}
 		session := WorktreeSession{
 			Number:    number,
 			Name:      name,
@@ -663,6 +904,9 @@ func (o *Orchestrator) createWorktreeSessions(assignments []agentAssignment, cyc
 		if err := writeWorktreeLog(session); err != nil {
 			return nil, err
 		}
+		if fallback != "" {
+			_ = appendWorktreeLog(session, fallback)
+		}
 		sessions = append(sessions, session)
 	}
 	return sessions, nil
@@ -689,23 +933,57 @@ func (o *Orchestrator) nextWorktreeNumber(base string) (int, error) {
 	return maxNumber + 1, nil
 }
 
-func (o *Orchestrator) invokeWorktreeCreate(name string) error {
-	if _, err := o.runProjectCommand("opencode", "worktree_create", name); err == nil {
-		return nil
+// invokeWorktreeCreate creates the worktree for session name. In native mode,
+// or when every plugin command fails and the project is a git repository,
+// the session directory path becomes a git checkout; fallback then explains
+// why for the session's LOG.md. Each session falls back on its own, so a
+// plugin that recovers is used again for the next one.
+//
+// A configured base branch is re-verified first, so a branch deleted since
+// it was set fails the session instead of starting it from another commit,
+// and is passed to the plugin as --base so a plugin without the option
+// rejects it rather than ignoring it.
+func (o *Orchestrator) invokeWorktreeCreate(name, path string) (fallback string, err error) {
+	if o.worktreeBaseBranch != "" {
+		if err := o.verifyBaseBranch(o.worktreeBaseBranch); err != nil {
+			return "", err
+		}
 	}
-	if _, err := o.runProjectCommand("opencode-worktree", "worktree_create", name); err == nil {
-		return nil
+	if o.nativeWorktrees {
+		return "", o.createGitWorktree(name, path)
+	}
+	args := []string{name}
+	if o.worktreeBaseBranch != "" {
+		args = append(args, "--base", o.worktreeBaseBranch)
+	}
+	var pluginErr error
+	for _, command := range [][]string{
+		append([]string{"opencode", "worktree_create"}, args...),
+		append([]string{"opencode-worktree", "worktree_create"}, args...),
+		append([]string{"worktree_create"}, args...),
+	} {
+		if _, pluginErr = o.runProjectCommand(command[0], command[1:]...); pluginErr == nil {
+			return "", nil
+		}
 	}
-	if _, err := o.runProjectCommand("worktree_create", name); err == nil {
-		return nil
+	if !o.gitWorktreeAvailable() {
+		return "", fmt.Errorf("%w %s", ErrWorktreeCreate, name)
+	}
+	if err := o.createGitWorktree(name, path); err != nil {
+		return "", err
 	}
-	return fmt.Errorf("failed to create worktree %s", name)
+	return fmt.Sprintf("opencode-worktree could not create %s (%v); checked it out with git worktree instead", name, pluginErr), nil
 }
 
-func (o *Orchestrator) invokeWorktreeDelete(name, reason string) error {
+// invokeWorktreeDelete removes session name's worktree. A git checkout at
+// path is removed with git whichever mode created it.
+func (o *Orchestrator) invokeWorktreeDelete(name, path, reason string) error {
 	if reason == "" {
 		reason = "cycle complete"
 	}
+	if o.nativeWorktrees || hasGitWorktree(path) {
+		return o.deleteGitWorktree(name, path)
+	}
 	if _, err := o.runProjectCommand("opencode", "worktree_delete", name, reason); err == nil {
 		return nil
 	}
@@ -715,7 +993,7 @@ func (o *Orchestrator) invokeWorktreeDelete(name, reason string) error {
 	if _, err := o.runProjectCommand("worktree_delete", name, reason); err == nil {
 		return nil
 	}
-	return fmt.Errorf("failed to delete worktree %s", name)
+	return fmt.Errorf("%w %s", ErrWorktreeDelete, name)
 }
 
 func writeWorktreeState(session WorktreeSession, status WorktreeStatus) error {
@@ -769,6 +1047,12 @@ func writeWorktreeLog(session WorktreeSession) error {
 	ids := strings.Join(beadIDs(session.Beads), ", ")
 	b.WriteString(fmt.Sprintf("- %s · Session created for %s (%s) with beads: %s\n",
 		session.CreatedAt.Format(time.RFC3339), session.Agent.Name, session.Name, ids))
+	for _, bead := range session.Beads {
+		if bead.OrphanedParent != "" {
+			b.WriteString(fmt.Sprintf("- %s · %s is orphaned: parent epic %s no longer exists in bd\n",
+				session.CreatedAt.Format(time.RFC3339), bead.ID, bead.OrphanedParent))
+		}
+	}
 	logPath := filepath.Join(session.Path, "LOG.md")
 	return os.WriteFile(logPath, []byte(b.String()), 0644)
 }
@@ -931,13 +1215,34 @@ func countPendingQuestions(sessionPath string) (int, int) {
 	return total, waiting
 }
 
+// maxWorktreeNameLength keeps worktree directory and branch names well inside
+// filesystem limits (255 bytes per component, 260 characters per path on
+// Windows) even when the project itself lives several directories deep.
+const maxWorktreeNameLength = 64
+
+// worktreeNameHashLength is the number of hex characters appended to a
+// truncated worktree name.
+const worktreeNameHashLength = 8
+
 func buildWorktreeName(number int, agent ProjectAgent, beads []Bead) string {
 	agentSlug := slugifyToken(agent.Name)
 	var beadSlugs []string
 	for _, bead := range beads {
 		beadSlugs = append(beadSlugs, slugifyToken(bead.ID))
 	}
-	return fmt.Sprintf("tree-%d-%s-%s", number, agentSlug, strings.Join(beadSlugs, "-"))
+	return shortenWorktreeName(fmt.Sprintf("tree-%d-%s-%s", number, agentSlug, strings.Join(beadSlugs, "-")))
+}
+
+// shortenWorktreeName truncates names longer than maxWorktreeNameLength and
+// appends a short hash of the full name, so assignments that share a long
+// prefix still get distinct worktrees.
+func shortenWorktreeName(name string) string {
+	if len(name) <= maxWorktreeNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	prefix := strings.TrimRight(name[:maxWorktreeNameLength-worktreeNameHashLength-1], "-")
+	return prefix + "-" + hex.EncodeToString(sum[:])[:worktreeNameHashLength]
 }
 
 func slugifyToken(value string) string {
@@ -969,9 +1274,42 @@ func slugifyToken(value string) string {
 }
 
 func (o *Orchestrator) runProjectCommand(name string, args ...string) (string, error) {
+	return o.command(o.config.ProjectDir, name, args...)
+}
+
+// command executes name inside dir and records it in the command log.
+func (o *Orchestrator) command(dir, name string, args ...string) (string, error) {
+	run := o.runCommand
+	if run == nil {
+		run = execCommand
+	}
+	out, err := run(dir, name, args...)
+	if o.commandLog != nil {
+		o.commandLog(dir, name, args, []byte(out), err)
+	}
+	return out, err
+}
+
+// commandRunner executes an external command inside dir and returns stdout.
+type commandRunner func(dir, name string, args ...string) (string, error)
+
+// CommandLogger receives every external command the orchestrator runs, with
+// its stdout and error. module.ModuleContext.LogCommand satisfies it.
+type CommandLogger func(dir, name string, args []string, output []byte, err error)
+
+// SetCommandLog routes the commands the orchestrator runs (bd, git, and the
+// tmux calls that launch opencode sessions) to log. Nil stops recording.
+func (o *Orchestrator) SetCommandLog(log CommandLogger) {
+	if o == nil {
+		return
+	}
+	o.commandLog = log
+}
+
+func execCommand(dir, name string, args ...string) (string, error) {
 	var stdout, stderr bytes.Buffer
 	cmd := exec.Command(name, args...)
-	cmd.Dir = o.config.ProjectDir
+	cmd.Dir = dir
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
@@ -0,0 +1,788 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+)
+
+func TestLoadReadyBeadsFlagsOrphanedBeads(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	var shown []string
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		call := strings.Join(append([]string{name}, args...), " ")
+		switch {
+		case call == "bd ready --json":
+			return `[
+  {"id": "lat-2", "title": "Wire login", "points": 3, "parent": "lat-1"},
+  {"id": "lat-4", "title": "Add logout", "points": 2, "parent": "lat-3"},
+  {"id": "lat-5", "title": "Fix typo", "points": 1, "parent": "lat-3"},
+  {"id": "lat-6", "title": "Docs", "points": 1}
+]`, nil
+		case strings.HasPrefix(call, "bd show "):
+			shown = append(shown, args[1])
+			if args[1] == "lat-1" {
+				return `[{"id": "lat-1", "title": "Auth epic"}]`, nil
+			}
+			return "", fmt.Errorf("bd show: issue %s not found", args[1])
+		}
+		return "", fmt.Errorf("unexpected command %q", call)
+	}
+
+	beads, err := orch.loadReadyBeads()
+	if err != nil {
+		t.Fatalf("load ready beads: %v", err)
+	}
+	byID := make(map[string]Bead, len(beads))
+	for _, bead := range beads {
+		byID[bead.ID] = bead
+	}
+	if got := byID["lat-2"]; got.ParentID != "lat-1" || got.OrphanedParent != "" {
+		t.Fatalf("bead with live parent was flagged: %+v", got)
+	}
+	for _, id := range []string{"lat-4", "lat-5"} {
+		if got := byID[id]; got.ParentID != "" || got.OrphanedParent != "lat-3" {
+			t.Fatalf("expected %s to be orphaned from lat-3, got %+v", id, got)
+		}
+	}
+	if got := byID["lat-6"]; got.OrphanedParent != "" {
+		t.Fatalf("parentless bead was flagged: %+v", got)
+	}
+	if len(shown) != 2 {
+		t.Fatalf("expected one bd show per parent, got %v", shown)
+	}
+
+	data, err := os.ReadFile(orch.orphanLogPath())
+	if err != nil {
+		t.Fatalf("read orphan log: %v", err)
+	}
+	log := string(data)
+	for _, want := range []string{
+		"lat-4 · Add logout: parent lat-3 missing from bd",
+		"lat-5 · Fix typo: parent lat-3 missing from bd",
+	} {
+		if !strings.Contains(log, want) {
+			t.Fatalf("orphan log missing %q:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, "lat-2") {
+		t.Fatalf("orphan log mentions bead with live parent:\n%s", log)
+	}
+
+	cs := &cycleSession{allBeads: map[string]Bead{canonicalBeadKey("lat-4"): byID["lat-4"]}}
+	if label := cs.beadLabel("lat-4"); label != "lat-4 · Add logout (orphaned: parent lat-3 missing)" {
+		t.Fatalf("unexpected label %q", label)
+	}
+}
+
+func TestLoadReadyBeadsKeepsParentsBdCouldNotCheck(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		call := strings.Join(append([]string{name}, args...), " ")
+		switch {
+		case call == "bd ready --json":
+			return `[{"id": "lat-2", "title": "Wire login", "points": 3, "parent": "lat-1"}]`, nil
+		case call == "bd show lat-1 --json":
+			return "", fmt.Errorf("bd show lat-1 --json failed: database is locked")
+		}
+		return "", fmt.Errorf("unexpected command %q", call)
+	}
+	beads, err := orch.loadReadyBeads()
+	if err != nil {
+		t.Fatalf("load ready beads: %v", err)
+	}
+	if len(beads) != 1 || beads[0].ParentID != "lat-1" || beads[0].OrphanedParent != "" {
+		t.Fatalf("bead was flagged although bd never said its parent is gone: %+v", beads)
+	}
+	data, err := os.ReadFile(orch.orphanLogPath())
+	if err != nil {
+		t.Fatalf("read orphan log: %v", err)
+	}
+	if !strings.Contains(string(data), "parent lat-1 not checked: bd show lat-1 --json failed: database is locked") || strings.Contains(string(data), "missing from bd") {
+		t.Fatalf("unexpected orphan log:\n%s", data)
+	}
+}
+
+func TestLoadReadyBeadsReportsExhaustedWork(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	listed := `[{"id": "lat-1", "status": "closed"}, {"id": "lat-2", "status": "blocked"}]`
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		switch call := strings.Join(append([]string{name}, args...), " "); call {
+		case "bd ready --json":
+			return "[]", nil
+		case "bd list --json":
+			return listed, nil
+		default:
+			return "", fmt.Errorf("unexpected command %q", call)
+		}
+	}
+
+	_, err := orch.loadReadyBeads()
+	if !errors.Is(err, ErrNoReadyBeads) || errors.Is(err, ErrWorkExhausted) {
+		t.Fatalf("blocked bead should leave work open, got %v", err)
+	}
+	listed = `[{"id": "lat-1", "status": "closed"}, {"id": "lat-2", "status": "closed"}]`
+	_, err = orch.loadReadyBeads()
+	if !errors.Is(err, ErrWorkExhausted) || !errors.Is(err, ErrNoReadyBeads) {
+		t.Fatalf("expected ErrWorkExhausted wrapping ErrNoReadyBeads, got %v", err)
+	}
+}
+
+func TestPrepareWorkCycleWaitsForMinimumAgents(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	runner := &fakeRunner{}
+	orch.runCommand = runner.run
+	orch.SetMinAgentsPerCycle(2)
+
+	dir := filepath.Join(orch.config.AgentsDir(), "aster")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("mkdir agent: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "AGENT.md"), []byte("---\nname: Aster\nrole: Worker\n---\n\nAster builds.\n"), 0644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(orch.config.WorkerListPath()), 0755); err != nil {
+		t.Fatalf("mkdir roster: %v", err)
+	}
+	if err := os.WriteFile(orch.config.WorkerListPath(), []byte(`{"workers":[{"name":"Aster","role":"worker"}]}`), 0644); err != nil {
+		t.Fatalf("write roster: %v", err)
+	}
+
+	sessions, err := orch.PrepareWorkCycle()
+	if !errors.Is(err, ErrTooFewAgents) {
+		t.Fatalf("expected ErrTooFewAgents, got sessions=%v err=%v", sessions, err)
+	}
+	if !strings.Contains(err.Error(), "1 of 2 required agent(s) available") {
+		t.Fatalf("unexpected error message: %v", err)
+	}
+	if runner.called("bd ready") {
+		t.Fatalf("under-staffed cycle should not query beads: %v", runner.calls)
+	}
+	if _, err := os.Stat(orch.cycleTrackerPath()); !os.IsNotExist(err) {
+		t.Fatalf("under-staffed cycle should not persist a tracker: %v", err)
+	}
+}
+
+func TestPrepareWorkCycleAppliesSparkPolicy(t *testing.T) {
+	t.Setenv("LATTICE_ASSIGN_SPARK", "")
+	cases := []struct {
+		policy SparkPolicy
+		want   error
+	}{
+		{policy: SparkPolicySkip, want: ErrSparkAgents},
+		{policy: SparkPolicyFail, want: ErrSparkAgents},
+		{policy: SparkPolicySchedule},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			orch := newTestOrchestrator(t)
+			orch.SetSparkPolicy(tc.policy)
+			writeTestAgent(t, orch, "Spark One")
+			writeTestAgent(t, orch, "Spark Two")
+			writeTestRoster(t, orch, `{"workers":[{"name":"Spark One","role":"worker","isSpark":true},{"name":"Spark Two","role":"worker","isSpark":true}]}`)
+
+			agents, err := orch.selectScheduledAgents()
+			if tc.want != nil {
+				if !errors.Is(err, tc.want) {
+					t.Fatalf("expected %v, got agents=%v err=%v", tc.want, agents, err)
+				}
+				if !strings.Contains(err.Error(), "2 SPARK agent(s)") || Remedy(err) == "" {
+					t.Fatalf("expected guidance naming the SPARKs, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("select agents: %v", err)
+			}
+			if len(agents) != 2 || agents[0].Agent.Name != "Spark One" || agents[1].Agent.Name != "Spark Two" {
+				t.Fatalf("expected both SPARKs scheduled, got %+v", agents)
+			}
+		})
+	}
+}
+
+func TestCycleChainsBeadBehindDependencySelectedSameCycle(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if call := strings.Join(append([]string{name}, args...), " "); call != "bd ready --json" {
+			return "", fmt.Errorf("unexpected command %q", call)
+		}
+		return `[
+  {"id": "lat-1", "title": "Schema", "points": 2},
+  {"id": "lat-2", "title": "Migration", "points": 2, "dependsOn": ["lat-1"]},
+  {"id": "lat-3", "title": "Docs", "points": 3},
+  {"id": "lat-4", "title": "Rollout", "points": 1, "dependsOn": ["lat-9"]},
+  {"id": "lat-5", "title": "Frozen", "points": 1, "status": "blocked", "dependsOn": ["lat-1"]}
+]`, nil
+	}
+	agents := []scheduledAgent{
+		{Agent: ProjectAgent{Name: "Ada"}, Capacity: 8},
+		{Agent: ProjectAgent{Name: "Bo"}, Capacity: 8},
+	}
+
+	ready, chainable, err := orch.loadCycleBeads()
+	if err != nil {
+		t.Fatalf("load cycle beads: %v", err)
+	}
+	if got := strings.Join(beadIDs(ready), " "); got != "lat-3 lat-1" {
+		t.Fatalf("unexpected ready beads %q", got)
+	}
+	if got := strings.Join(beadIDs(chainable), " "); got != "lat-2 lat-4" {
+		t.Fatalf("expected only bead-blocked beads to be chainable, got %q", got)
+	}
+	selected := selectBeadsForCycle(ready, chainable, agents, orch.cycleConfig())
+	if got := strings.Join(beadIDs(selected), " "); got != "lat-3 lat-1 lat-2" {
+		t.Fatalf("expected lat-2 chained behind lat-1, got %q", got)
+	}
+	for _, name := range []string{AssignmentBalanced, AssignmentRoundRobin, AssignmentBinPacking} {
+		strategy, _ := ParseAssignmentStrategy(name)
+		slots, err := assignBeadsToAgents(strategy, agents, selected)
+		if err != nil {
+			t.Fatalf("%s: assign: %v", name, err)
+		}
+		for _, slot := range slots {
+			ids := strings.Join(beadIDs(slot.Beads), " ")
+			if strings.Contains(ids, "lat-1") && !strings.Contains(ids, "lat-1 lat-2") {
+				t.Fatalf("%s: expected lat-2 after lat-1 on the same agent, got %s", name, describeSlots(slots))
+			}
+		}
+	}
+}
+
+func TestSelectBeadsForCycleHonorsCycleConfig(t *testing.T) {
+	var beads []Bead
+	for i := 1; i <= 10; i++ {
+		beads = append(beads, Bead{ID: fmt.Sprintf("lat-%d", i), Points: 3})
+	}
+	crew := make([]scheduledAgent, 5)
+	for i := range crew {
+		crew[i] = scheduledAgent{Agent: ProjectAgent{Name: fmt.Sprintf("agent-%d", i)}, Capacity: 8}
+	}
+	uncapped := []scheduledAgent{{Agent: ProjectAgent{Name: "Ada"}}}
+	cases := []struct {
+		name   string
+		cycle  config.CycleConfig
+		agents []scheduledAgent
+		want   int
+	}{
+		{name: "defaults fill the crew", agents: crew, want: 10},
+		{name: "ceiling caps the cycle", cycle: config.CycleConfig{MaxTotalPoints: 10}, agents: crew, want: 3},
+		{name: "default agent capacity", agents: uncapped, want: 3},
+		{name: "configured agent capacity", cycle: config.CycleConfig{MaxAgentPoints: 13}, agents: uncapped, want: 5},
+		{name: "minimum target", cycle: config.CycleConfig{MaxAgentPoints: 1, MinPoints: 7}, agents: uncapped, want: 3},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orch := newTestOrchestrator(t)
+			orch.config.Project.Cycle = tc.cycle
+			selected := selectBeadsForCycle(beads, nil, tc.agents, orch.cycleConfig())
+			if len(selected) != tc.want {
+				t.Fatalf("expected %d bead(s), got %d (%s)", tc.want, len(selected), strings.Join(beadIDs(selected), " "))
+			}
+		})
+	}
+}
+
+func TestBeadProfileProbeSelectsParserForBdVersion(t *testing.T) {
+	cases := []struct {
+		name    string
+		version string
+		ready   string
+		profile string
+	}{
+		{
+			name:    "camel-case",
+			version: "bd version 0.14.2 (dev)",
+			ready:   `[{"id":"lat-1","title":"Parse","size":5,"createdAt":"2024-03-01T10:00:00Z"},{"id":"lat-2","title":"Render","size":2,"blockedBy":["lat-1"]}]`,
+			profile: "camel-case",
+		},
+		{
+			name:    "snake-case",
+			version: "bd version 0.23.0",
+			ready:   `[{"id":"lat-1","title":"Parse","points":5,"size":99,"created_at":"2024-03-01T10:00:00Z"},{"id":"lat-2","title":"Render","points":2,"blocked_by":["lat-1"]}]`,
+			profile: "snake-case",
+		},
+		{
+			// The profile only ranks field names, so a bd that already emits
+			// the snake_case schema below the cutoff keeps its blockers.
+			name:    "snake-case fields under the camel-case profile",
+			version: "bd version 0.14.2 (dev)",
+			ready:   `[{"id":"lat-1","title":"Parse","points":5,"created_at":"2024-03-01T10:00:00Z"},{"id":"lat-2","title":"Render","points":2,"blocked_by":["lat-1"]}]`,
+			profile: "camel-case",
+		},
+		{
+			name:    "unreadable version",
+			version: "bd (development build)",
+			ready:   `[{"id":"lat-1","title":"Parse","points":5,"created_at":"2024-03-01T10:00:00Z"},{"id":"lat-2","title":"Render","points":2,"blockedBy":["lat-1"]}]`,
+			profile: "tolerant",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orch := newTestOrchestrator(t)
+			probes := 0
+			orch.runCommand = func(dir, name string, args ...string) (string, error) {
+				switch strings.Join(append([]string{name}, args...), " ") {
+				case "bd --version":
+					probes++
+					return tc.version, nil
+				case "bd ready --json":
+					return tc.ready, nil
+				}
+				return "", fmt.Errorf("unexpected command %s %v", name, args)
+			}
+			for i := 0; i < 2; i++ {
+				ready, chainable, err := orch.loadCycleBeads()
+				if err != nil {
+					t.Fatalf("load cycle beads: %v", err)
+				}
+				if len(ready) != 1 || ready[0].Points != 5 || ready[0].CreatedAt.IsZero() {
+					t.Fatalf("unexpected ready beads: %+v", ready)
+				}
+				if len(chainable) != 1 || strings.Join(chainable[0].BlockedBy, ",") != "lat-1" {
+					t.Fatalf("expected lat-2 to wait on lat-1, got %+v", chainable)
+				}
+			}
+			if probes != 1 {
+				t.Fatalf("expected one bd --version probe, got %d", probes)
+			}
+			if got := orch.beadProfile().Name; got != tc.profile {
+				t.Fatalf("profile = %q, want %q", got, tc.profile)
+			}
+			// Profiles only rank field names, so no version draws a warning.
+			if log, _ := os.ReadFile(orch.bdCompatLogPath()); len(log) > 0 {
+				t.Fatalf("unexpected compatibility warning: %s", log)
+			}
+		})
+	}
+}
+
+func TestSortReadyBeadsHonorsBeadOrder(t *testing.T) {
+	records, err := parseBeadRecords([]byte(`[
+  {"id": "bd-c", "points": 3, "priority": 1, "created_at": "2024-03-01T10:00:00Z"},
+  {"id": "bd-a", "points": 1, "priority": 0, "created_at": "2024-03-03T10:00:00Z"},
+  {"id": "bd-d", "points": 3, "created_at": "2024-03-02T10:00:00Z"},
+  {"id": "bd-b", "points": 5, "priority": 1}
+]`), bdProfileTolerant)
+	if err != nil {
+		t.Fatalf("parse records: %v", err)
+	}
+	cases := []struct {
+		order BeadOrder
+		want  []string
+	}{
+		{"", []string{"bd-b", "bd-c", "bd-d", "bd-a"}},
+		{BeadOrderPoints, []string{"bd-b", "bd-c", "bd-d", "bd-a"}},
+		{BeadOrderPriority, []string{"bd-a", "bd-b", "bd-c", "bd-d"}},
+		{BeadOrderCreated, []string{"bd-c", "bd-d", "bd-a", "bd-b"}},
+		{BeadOrderID, []string{"bd-a", "bd-b", "bd-c", "bd-d"}},
+	}
+	for _, tc := range cases {
+		beads := convertBeadRecords(records)
+		sortReadyBeads(beads, tc.order)
+		if got := beadIDs(beads); strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Fatalf("order %q: got %v, want %v", tc.order, got, tc.want)
+		}
+	}
+	if _, err := ParseBeadOrder("newest"); err == nil {
+		t.Fatalf("expected unknown bead order to be rejected")
+	}
+	if order, err := ParseBeadOrder(" Created "); err != nil || order != BeadOrderCreated {
+		t.Fatalf("parse created: %q, %v", order, err)
+	}
+}
+
+func TestLoadReadyBeadsReportsPersistentlyBlockedBeads(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.SetMaxBlockedCycles(3)
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if call := strings.Join(append([]string{name}, args...), " "); call != "bd ready --json" {
+			return "", fmt.Errorf("unexpected command %q", call)
+		}
+		return `[
+  {"id": "lat-1", "title": "Ship it", "points": 2},
+  {"id": "lat-9", "title": "Wire billing", "points": 3, "blocked_by": ["lat-8"]}
+]`, nil
+	}
+	stuckLog := func() string {
+		data, err := os.ReadFile(orch.stuckBeadLogPath())
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("read stuck bead log: %v", err)
+		}
+		return string(data)
+	}
+	load := func() {
+		t.Helper()
+		beads, err := orch.loadReadyBeads()
+		if err != nil {
+			t.Fatalf("load ready beads: %v", err)
+		}
+		if got := beadIDs(beads); len(got) != 1 || got[0] != "lat-1" {
+			t.Fatalf("blocked bead leaked into ready set: %v", got)
+		}
+	}
+
+	for cycle := 1; cycle <= 2; cycle++ {
+		load()
+		load() // a second load in the same cycle must not count again
+		if log := stuckLog(); log != "" {
+			t.Fatalf("cycle %d: stuck warning fired early:\n%s", cycle, log)
+		}
+		if _, err := orch.incrementCycleNumber(); err != nil {
+			t.Fatalf("increment cycle: %v", err)
+		}
+	}
+	load()
+	log := stuckLog()
+	want := "cycle 3 · lat-9 · Wire billing has been blocked for 3 cycles (since cycle 1); waiting on lat-8"
+	if !strings.Contains(log, want) {
+		t.Fatalf("stuck bead log missing %q:\n%s", want, log)
+	}
+	if strings.Contains(log, "lat-1") {
+		t.Fatalf("ready bead reported as stuck:\n%s", log)
+	}
+
+	if _, err := orch.incrementCycleNumber(); err != nil {
+		t.Fatalf("increment cycle: %v", err)
+	}
+	load()
+	if got := stuckLog(); got != log {
+		t.Fatalf("stuck bead should be reported once per blocked streak:\n%s", got)
+	}
+}
+
+func TestLoadReadyBeadsSurvivesBlockedTrackingFailure(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if call := strings.Join(append([]string{name}, args...), " "); call != "bd ready --json" {
+			return "", fmt.Errorf("unexpected command %q", call)
+		}
+		return `[
+  {"id": "lat-1", "title": "Ship it", "points": 2},
+  {"id": "lat-9", "title": "Wire billing", "points": 3, "blocked_by": ["lat-8"]}
+]`, nil
+	}
+	// A directory where the state file belongs makes the write fail.
+	if err := os.MkdirAll(filepath.Join(orch.blockedBeadsPath(), "occupied"), 0o755); err != nil {
+		t.Fatalf("block state path: %v", err)
+	}
+	beads, err := orch.loadReadyBeads()
+	if err != nil {
+		t.Fatalf("load ready beads: %v", err)
+	}
+	if got := beadIDs(beads); len(got) != 1 || got[0] != "lat-1" {
+		t.Fatalf("expected the ready bead despite the tracking failure, got %v", got)
+	}
+	data, err := os.ReadFile(orch.stuckBeadLogPath())
+	if err != nil {
+		t.Fatalf("read stuck bead log: %v", err)
+	}
+	if !strings.Contains(string(data), "blocked beads not tracked: write blocked bead state") {
+		t.Fatalf("tracking failure not logged:\n%s", data)
+	}
+}
+
+func TestLoadReadyBeadsAppliesRecordedReestimates(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	ready := `[{"id": "lat-1", "title": "Ship it", "points": 2}, {"id": "lat-2", "title": "Wire login", "points": 3}]`
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if call := strings.Join(append([]string{name}, args...), " "); call != "bd ready --json" {
+			return "", fmt.Errorf("unexpected command %q", call)
+		}
+		return ready, nil
+	}
+	if err := orch.recordReestimates([]beadEstimate{{ID: "lat-1", From: 2, Points: 5}, {ID: "lat-2", From: 3, Points: 8}}); err != nil {
+		t.Fatalf("record reestimates: %v", err)
+	}
+	if err := orch.recordReestimates([]beadEstimate{{ID: "LAT-1", From: 5, Points: 6}}); err != nil {
+		t.Fatalf("record second reestimate: %v", err)
+	}
+	points := func() string {
+		t.Helper()
+		beads, err := orch.loadReadyBeads()
+		if err != nil {
+			t.Fatalf("load ready beads: %v", err)
+		}
+		var out []string
+		for _, bead := range beads {
+			out = append(out, fmt.Sprintf("%s=%d", bead.ID, bead.Points))
+		}
+		sort.Strings(out)
+		return strings.Join(out, ",")
+	}
+	if got := points(); got != "lat-1=6,lat-2=8" {
+		t.Fatalf("expected recorded estimates to apply, got %s", got)
+	}
+
+	// Once bd carries its own new estimate for a bead, bd wins.
+	ready = `[{"id": "lat-1", "title": "Ship it", "points": 2}, {"id": "lat-2", "title": "Wire login", "points": 4}]`
+	if got := points(); got != "lat-1=6,lat-2=4" {
+		t.Fatalf("expected bd's new estimate to win, got %s", got)
+	}
+	if _, ok := orch.readBeadEstimateState().Beads[canonicalBeadKey("lat-2")]; ok {
+		t.Fatalf("superseded re-estimate should be forgotten")
+	}
+}
+
+func TestForgetReestimatesDropsClosedBeads(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	if err := orch.recordReestimates([]beadEstimate{{ID: "lat-1", From: 2, Points: 5}, {ID: "lat-2", From: 3, Points: 8}}); err != nil {
+		t.Fatalf("record reestimates: %v", err)
+	}
+	if err := orch.forgetReestimates("LAT-1", "lat-9"); err != nil {
+		t.Fatalf("forget reestimates: %v", err)
+	}
+	state := orch.readBeadEstimateState()
+	if _, ok := state.Beads[canonicalBeadKey("lat-1")]; ok || len(state.Beads) != 1 {
+		t.Fatalf("expected only lat-2 to remain, got %+v", state.Beads)
+	}
+	if err := orch.forgetReestimates("lat-2"); err != nil {
+		t.Fatalf("forget last reestimate: %v", err)
+	}
+	if _, err := os.Stat(orch.beadEstimatesPath()); !os.IsNotExist(err) {
+		t.Fatalf("expected the estimates file to be removed once empty, got %v", err)
+	}
+}
+
+func TestCommandLogRecordsOrchestratorCommands(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		return `[{"id": "lat-1", "title": "Ship it", "points": 2}]`, nil
+	}
+	var logged []string
+	orch.SetCommandLog(func(dir, name string, args []string, output []byte, err error) {
+		logged = append(logged, fmt.Sprintf("%s: %s %s -> %s", dir, name, strings.Join(args, " "), output))
+	})
+	if _, err := orch.loadReadyBeads(); err != nil {
+		t.Fatalf("load ready beads: %v", err)
+	}
+	want := orch.config.ProjectDir + `: bd ready --json -> [{"id": "lat-1", "title": "Ship it", "points": 2}]`
+	if len(logged) == 0 || logged[0] != want {
+		t.Fatalf("command log = %q, want it to start with %q", logged, want)
+	}
+	recorded := len(logged)
+	orch.SetCommandLog(nil)
+	if _, err := orch.loadReadyBeads(); err != nil {
+		t.Fatalf("load ready beads: %v", err)
+	}
+	if len(logged) != recorded {
+		t.Fatalf("cleared command log still recorded: %q", logged)
+	}
+}
+
+type fakeBeadSubscriber struct {
+	updates chan []byte
+}
+
+func (f fakeBeadSubscriber) SubscribeReady(context.Context) (<-chan []byte, error) {
+	return f.updates, nil
+}
+
+func TestWatchReadyBeadsUsesPushedReadySet(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	polled := 0
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		switch call := strings.Join(append([]string{name}, args...), " "); call {
+		case "bd ready --json":
+			polled++
+			return `[{"id": "lat-1", "title": "Polled", "points": 1}]`, nil
+		case "bd ready --help":
+			return "Usage: bd ready [--json]", nil
+		default:
+			return "", fmt.Errorf("unexpected command %q", call)
+		}
+	}
+	if _, err := orch.WatchReadyBeads(context.Background()); !errors.Is(err, ErrBeadWatchUnsupported) {
+		t.Fatalf("expected ErrBeadWatchUnsupported without a watch-capable bd, got %v", err)
+	}
+
+	updates := make(chan []byte)
+	orch.SetBeadSubscriber(fakeBeadSubscriber{updates: updates})
+	changes, err := orch.WatchReadyBeads(context.Background())
+	if err != nil {
+		t.Fatalf("watch ready beads: %v", err)
+	}
+	updates <- []byte(`[{"id": "lat-7", "title": "Pushed", "points": 2}]`)
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("pushed update was not signalled")
+	}
+	beads, err := orch.loadReadyBeads()
+	if err != nil {
+		t.Fatalf("load ready beads: %v", err)
+	}
+	if got := beadIDs(beads); len(got) != 1 || got[0] != "lat-7" {
+		t.Fatalf("expected the pushed ready set, got %v", got)
+	}
+	if polled != 0 {
+		t.Fatalf("bd ready was polled %d time(s) while the subscription was live", polled)
+	}
+
+	close(updates)
+	if _, open := <-changes; open {
+		t.Fatalf("changes channel should close with the subscription")
+	}
+	beads, err = orch.loadReadyBeads()
+	if err != nil {
+		t.Fatalf("load ready beads after subscription ended: %v", err)
+	}
+	if got := beadIDs(beads); len(got) != 1 || got[0] != "lat-1" || polled != 1 {
+		t.Fatalf("expected to fall back to polling, got %v after %d poll(s)", got, polled)
+	}
+}
+
+// countingBeadSubscriber hands every subscription the same updates channel
+// and counts how many were opened.
+type countingBeadSubscriber struct {
+	updates       chan []byte
+	subscriptions *int
+}
+
+func (f countingBeadSubscriber) SubscribeReady(context.Context) (<-chan []byte, error) {
+	*f.subscriptions++
+	return f.updates, nil
+}
+
+func TestSharedReadyBeadsReusesOneSubscription(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	subscriptions := 0
+	updates := make(chan []byte, 1)
+	orch.SetBeadSubscriber(countingBeadSubscriber{updates: updates, subscriptions: &subscriptions})
+
+	first, err := orch.SharedReadyBeads()
+	if err != nil {
+		t.Fatalf("shared ready beads: %v", err)
+	}
+	select {
+	case <-first:
+		t.Fatalf("first should stay open until a ready set arrives")
+	default:
+	}
+	updates <- []byte(`[{"id": "lat-3", "title": "Pushed", "points": 1}]`)
+	select {
+	case <-first:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("first ready set was not signalled")
+	}
+	again, err := orch.SharedReadyBeads()
+	if err != nil {
+		t.Fatalf("shared ready beads again: %v", err)
+	}
+	select {
+	case <-again:
+	default:
+		t.Fatalf("a running subscription that already delivered should not be waited on")
+	}
+	if subscriptions != 1 {
+		t.Fatalf("expected one shared subscription, got %d", subscriptions)
+	}
+
+	close(updates)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, live := orch.PushedReadyBeads(); !live {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("subscription did not end")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	ended := make(chan []byte)
+	close(ended)
+	orch.SetBeadSubscriber(countingBeadSubscriber{updates: ended, subscriptions: &subscriptions})
+	restarted, err := orch.SharedReadyBeads()
+	if err != nil {
+		t.Fatalf("shared ready beads after the subscription ended: %v", err)
+	}
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("a subscription that ends without a ready set should release waiters")
+	}
+	if subscriptions != 2 {
+		t.Fatalf("expected a new subscription once the old one ended, got %d", subscriptions)
+	}
+}
+
+func TestBuildWorktreeNameStaysUnderLengthLimit(t *testing.T) {
+	agent := ProjectAgent{Name: "Aster"}
+	if got := buildWorktreeName(3, agent, []Bead{{ID: "lat-1"}}); got != "tree-3-aster-lat-1" {
+		t.Fatalf("short names should be left alone, got %q", got)
+	}
+	var beads []Bead
+	for i := 0; i < 12; i++ {
+		beads = append(beads, Bead{ID: fmt.Sprintf("lattice-feature-%02d-with-a-very-descriptive-identifier", i)})
+	}
+	seen := map[string]bool{}
+	for i := range beads {
+		// Drop one bead at a time so every variant shares the long prefix.
+		variant := append(append([]Bead{}, beads[:i]...), beads[i+1:]...)
+		name := buildWorktreeName(12, agent, variant)
+		if len(name) > maxWorktreeNameLength {
+			t.Fatalf("name exceeds %d characters: %q (%d)", maxWorktreeNameLength, name, len(name))
+		}
+		if !strings.HasPrefix(name, "tree-12-aster-lattice-feature-00") && i != 0 {
+			t.Fatalf("expected readable prefix, got %q", name)
+		}
+		if seen[name] {
+			t.Fatalf("duplicate worktree name %q", name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestStatusBoardMatchesTmuxWindowsWhenAvailable(t *testing.T) {
+	orch := newTestOrchestrator(t)
+	writeTestAgent(t, orch, "Aster")
+	path := filepath.Join(orch.config.WorktreeDir(), "1", "tree-1-aster")
+	scaffoldSessionDirs(t, path)
+	session := WorktreeSession{Number: 1, Name: "tree-1-aster", Path: path, Agent: ProjectAgent{Name: "Aster"}, Beads: []Bead{{ID: "task-1", Points: 3}, {ID: "task-2", Points: 2}}}
+	if err := writeWorktreeState(session, WorktreeStatus{Phase: "up-cycle", State: "running", Cycle: 2, Global: 1}); err != nil {
+		t.Fatalf("write worktree state: %v", err)
+	}
+	if err := orch.persistCycleTracker(1, []WorktreeSession{session}, "running"); err != nil {
+		t.Fatalf("persist tracker: %v", err)
+	}
+
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		return "", fmt.Errorf("%s: executable file not found", name)
+	}
+	board, err := orch.StatusBoard("lattice")
+	if err != nil {
+		t.Fatalf("status board without tmux: %v", err)
+	}
+	if !board.HasCycle || board.Cycle.Cycle != 1 || board.Cycle.Status != "running" {
+		t.Fatalf("unexpected cycle status: %+v", board.Cycle)
+	}
+	if len(board.Sessions) != 1 {
+		t.Fatalf("expected one session row, got %+v", board.Sessions)
+	}
+	row := board.Sessions[0]
+	if row.Agent != "Aster" || row.Points != 5 || row.Beads != 2 || row.Cycle != 2 || row.State != "running" || row.Window != "" {
+		t.Fatalf("unexpected session row without tmux: %+v", row)
+	}
+
+	orch.runCommand = func(dir, name string, args ...string) (string, error) {
+		if name == "tmux" {
+			return "status::0\nworktree-agent-1-2::1\n", nil
+		}
+		return "", nil
+	}
+	board, err = orch.StatusBoard("lattice")
+	if err != nil {
+		t.Fatalf("status board with tmux: %v", err)
+	}
+	if row := board.Sessions[0]; row.Window != "worktree-agent-1-2" || !row.WindowActive {
+		t.Fatalf("expected the agent window to be matched, got %+v", row)
+	}
+}
@@ -13,8 +13,8 @@ package tui
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -39,6 +39,7 @@ const (
 	stateWorkflowSelect                 // Workflow picker before launching the engine
 	stateCommissionWork                 // Running a commission workflow mode
 	stateViewAgents                     // Viewing available agents (legacy)
+	stateCycleHistory                   // Browsing completed cycle summaries
 )
 
 const (
@@ -47,6 +48,12 @@ const (
 	statusReturnHotkey   = "M-s"
 	mainMenuHeading      = "⬡ THE LATTICE"
 	logPanelMaxLines     = 8
+	// compactBoardWidth is the terminal width below which the status board
+	// stacks its panels vertically instead of side by side.
+	compactBoardWidth = 80
+	// workflowChromePadding is the height the board header, phase panel, log
+	// and footer take around views rendered in the main area.
+	workflowChromePadding = 18
 )
 
 // WorkflowDefinitionLoader resolves workflow definitions for the engine-backed view.
@@ -73,7 +80,9 @@ func WithModuleRegistryFactory(factory func(*config.Config) (*module.Registry, e
 	}
 }
 
-var phaseOrder = []workflow.Phase{
+// defaultPhaseOrder is the commission-work sequence, used when the active
+// workflow definition cannot be loaded or maps to no known phases.
+var defaultPhaseOrder = []workflow.Phase{
 	workflow.PhasePlanning,
 	workflow.PhaseOrchestratorSelection,
 	workflow.PhaseHiring,
@@ -84,6 +93,28 @@ var phaseOrder = []workflow.Phase{
 	workflow.PhaseOrchestratorRelease,
 }
 
+// modulePhases maps module IDs to the marker-based phases they advance.
+// Modules that share a phase (e.g. the planning chain) collapse into one slot.
+var modulePhases = map[string][]workflow.Phase{
+	"anchor-docs":            {workflow.PhasePlanning},
+	"action-plan":            {workflow.PhasePlanning},
+	"staff-review":           {workflow.PhasePlanning},
+	"staff-incorporate":      {workflow.PhasePlanning},
+	"parallel-reviews":       {workflow.PhasePlanning},
+	"consolidation":          {workflow.PhasePlanning},
+	"bead-creation":          {workflow.PhasePlanning},
+	"orchestrator-selection": {workflow.PhaseOrchestratorSelection},
+	"hiring":                 {workflow.PhaseHiring},
+	"work-process":           {workflow.PhaseWorkProcess},
+	"solo-work":              {workflow.PhaseWorkProcess},
+	"refinement":             {workflow.PhaseRefinement},
+	"release": {
+		workflow.PhaseAgentRelease,
+		workflow.PhaseWorkCleanup,
+		workflow.PhaseOrchestratorRelease,
+	},
+}
+
 type boardFocus int
 
 const (
@@ -97,32 +128,21 @@ type statusRefreshMsg struct {
 	hasCycle bool
 	phase    workflow.Phase
 	err      error
+	// pushed marks a refresh triggered by a ready-bead change, which leaves
+	// the periodic refresh schedule alone.
+	pushed bool
 }
 
+// readyBeadsChangedMsg reports that the ready-bead subscription delivered a
+// new ready set.
+type readyBeadsChangedMsg struct{}
+
 type idleCheckMsg struct {
 	sequence int
 }
 
-type sessionItem struct {
-	Agent        string
-	Worktree     string
-	Number       int
-	Points       int
-	Beads        int
-	Cycle        int
-	Phase        string
-	State        string
-	Questions    int
-	Waiting      int
-	Window       string
-	WindowActive bool
-	LastUpdated  time.Time
-}
-
-type tmuxWindowInfo struct {
-	Name   string
-	Active bool
-}
+// sessionItem is one row of the status board.
+type sessionItem = orchestrator.SessionStatus
 
 // App is the main application model. In bubbletea, this holds ALL your state.
 type App struct {
@@ -133,6 +153,10 @@ type App struct {
 	logbook      *logbook.Logbook
 	eventBridge  *eventbridge.Server
 	eventRouter  *eventbridge.Router
+	// readyBeads signals ready-set changes from bd's watch mode; nil when bd
+	// is polled instead. stopBeadWatch ends the subscription.
+	readyBeads    <-chan struct{}
+	stopBeadWatch context.CancelFunc
 
 	workflowLoader        WorkflowDefinitionLoader
 	registryFactory       func(*config.Config) (*module.Registry, error)
@@ -142,6 +166,7 @@ type App struct {
 	selectedWorkflow      string
 	pendingWorkflowResume bool
 	workflowReturnState   appState
+	cycleHistory          *cycleHistoryView
 
 	// UI components
 	mainMenu      list.Model // The main menu list
@@ -161,6 +186,8 @@ type App struct {
 	cycleStatus      orchestrator.CycleStatus
 	hasCycleStatus   bool
 	cachedPhase      workflow.Phase
+	phaseOrder       []workflow.Phase
+	phaseOrderFor    string
 	tmuxSession      string
 	statusWindowName string
 	statusReturnKey  string
@@ -266,6 +293,13 @@ func NewApp(projectDir string, opts ...AppOption) (*App, error) {
 		statusReturnKey:     statusReturnHotkey,
 		workflowReturnState: stateMainMenu,
 	}
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	if changes, err := orch.WatchReadyBeads(watchCtx); err == nil {
+		app.readyBeads = changes
+		app.stopBeadWatch = stopWatch
+	} else {
+		stopWatch()
+	}
 	settings := cfg.IdleWatchdogSettings()
 	app.idleWatchdogEnabled = settings.Enabled
 	app.idleTimeout = settings.Timeout
@@ -286,7 +320,14 @@ func NewApp(projectDir string, opts ...AppOption) (*App, error) {
 
 // Close releases resources created by the TUI such as the HTTP event bridge server.
 func (a *App) Close() error {
-	if a == nil || a.eventBridge == nil {
+	if a == nil {
+		return nil
+	}
+	if a.stopBeadWatch != nil {
+		a.stopBeadWatch()
+	}
+	a.closeWorkflowView()
+	if a.eventBridge == nil {
 		return nil
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -317,6 +358,7 @@ func buildMainMenu(wf *workflow.Workflow) []list.Item {
 
 	items = append(items,
 		menuItem{title: "View Agents", desc: "Browse available agents"},
+		menuItem{title: "Cycle History", desc: "Read the summaries of completed cycles"},
 		menuItem{title: "Settings", desc: "Configure Lattice"},
 		menuItem{title: "Exit", desc: "Quit Lattice"},
 	)
@@ -478,7 +520,7 @@ func (a *App) logProgress(status string) {
 
 // Init is called once when the program starts.
 func (a *App) Init() tea.Cmd {
-	return tea.Batch(a.fetchStatusSnapshot(), a.armIdleWatchdog())
+	return tea.Batch(a.fetchStatusSnapshot(), a.armIdleWatchdog(), a.waitForReadyBeads())
 }
 
 // Update is called when a message is received.
@@ -496,6 +538,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if a.state == stateCommissionWork && a.workflowView != nil {
 			return a, tea.Batch(a.workflowView.Update(msg), idleActivityCmd)
 		}
+		if a.cycleHistory != nil {
+			a.cycleHistory.setSize(a.cycleHistoryWidth(), a.cycleHistoryHeight())
+		}
 		return a, idleActivityCmd
 
 	case statusRefreshMsg:
@@ -513,8 +558,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.hasCycleStatus = msg.hasCycle
 			a.cachedPhase = msg.phase
 		}
+		if msg.pushed {
+			return a, nil
+		}
 		return a, a.scheduleStatusRefresh()
 
+	case readyBeadsChangedMsg:
+		return a, tea.Batch(a.fetchPushedStatusSnapshot(), a.waitForReadyBeads())
+
 	case workflowFinishedMsg:
 		return a.handleWorkflowFinished(msg)
 
@@ -533,6 +584,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		key := msg.String()
 		idleActivityCmd = a.idleActivityCmd()
+		if key != "ctrl+c" && a.state == stateCommissionWork && a.workflowView != nil && a.workflowView.editingNote() {
+			return a, tea.Batch(a.workflowView.Update(msg), idleActivityCmd)
+		}
 		switch key {
 		case "ctrl+c":
 			return a, tea.Batch(tea.Quit, idleActivityCmd)
@@ -546,6 +600,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return model, tea.Batch(cmd, idleActivityCmd)
 			}
 		case "r":
+			if a.state == stateCycleHistory && a.cycleHistory != nil {
+				a.cycleHistory.reload()
+			}
 			a.statusMsg = "Refreshing status board..."
 			return a, tea.Batch(a.fetchStatusSnapshot(), idleActivityCmd)
 		case "tab":
@@ -619,6 +676,12 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, cmd)
 			}
 		}
+	case stateCycleHistory:
+		if a.cycleHistory != nil {
+			if cmd := a.cycleHistory.Update(msg); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
 	}
 	if idleActivityCmd != nil {
 		cmds = append(cmds, idleActivityCmd)
@@ -648,6 +711,10 @@ func (a *App) handleMainMenuSelection() (tea.Model, tea.Cmd) {
 		a.statusMsg = "Not implemented yet"
 		return a, nil
 
+	case item.title == "Cycle History":
+		a.logInfo("Menu · Cycle History selected")
+		return a.openCycleHistory()
+
 	case item.title == "Settings":
 		a.logInfo("Menu · Settings selected")
 		a.statusMsg = "Not implemented yet"
@@ -710,15 +777,36 @@ func (a *App) startWorkflowRun(resume bool) (tea.Model, tea.Cmd) {
 	a.state = stateCommissionWork
 	a.pendingWorkflowResume = false
 	a.disarmIdleWatchdog()
+	a.closeWorkflowView()
 	a.workflowView = newWorkflowView(a, a.activeWorkflowID())
 	cmd := a.workflowView.Init(resume)
 	return a, cmd
 }
 
+// closeWorkflowView stops the workflow view's pending module retries and
+// forgets it.
+func (a *App) closeWorkflowView() {
+	if a.workflowView != nil {
+		a.workflowView.close()
+		a.workflowView = nil
+	}
+}
+
+// openCycleHistory shows the completed-cycle browser.
+func (a *App) openCycleHistory() (tea.Model, tea.Cmd) {
+	a.state = stateCycleHistory
+	a.boardFocus = focusMenu
+	a.cycleHistory = newCycleHistoryView(a.orchestrator)
+	a.cycleHistory.setSize(a.cycleHistoryWidth(), a.cycleHistoryHeight())
+	a.statusMsg = "Browsing completed cycles"
+	return a, nil
+}
+
 // returnToMainMenu transitions back to the main menu
 func (a *App) returnToMainMenu() (tea.Model, tea.Cmd) {
 	a.state = stateMainMenu
-	a.workflowView = nil
+	a.closeWorkflowView()
+	a.cycleHistory = nil
 	a.pendingWorkflowResume = false
 	a.workflowReturnState = stateMainMenu
 	a.logInfo("Returned to main menu (phase: %s)", a.workflow.CurrentPhase().FriendlyName())
@@ -747,7 +835,7 @@ func (a *App) handleWorkflowFinished(msg workflowFinishedMsg) (tea.Model, tea.Cm
 		return a.returnToMainMenu()
 	default:
 		// No parent view to return to, so exit the TUI gracefully.
-		a.workflowView = nil
+		a.closeWorkflowView()
 		a.pendingWorkflowResume = false
 		a.workflowReturnState = stateMainMenu
 		return a, tea.Quit
@@ -760,6 +848,7 @@ func (a *App) View() string {
 	if width <= 0 {
 		width = 100
 	}
+	compact := width < compactBoardWidth
 	rightWidth := max(32, width/3)
 	leftWidth := width - rightWidth - 4
 	if leftWidth < 40 {
@@ -769,6 +858,10 @@ func (a *App) View() string {
 		leftWidth = width
 		rightWidth = 0
 	}
+	if compact {
+		leftWidth = max(20, width-2)
+		rightWidth = 0
+	}
 	if a.state == stateMainMenu && a.boardFocus == focusMenu {
 		a.mainMenu.SetSize(max(20, leftWidth-4), max(10, a.height-10))
 	}
@@ -789,11 +882,20 @@ func (a *App) View() string {
 		}
 	case stateViewAgents:
 		content = "Agent viewer not implemented"
+	case stateCycleHistory:
+		if a.cycleHistory != nil {
+			content = a.cycleHistory.View()
+		}
+	}
+	if compact {
+		return a.renderCompactStatusBoard(content, leftWidth)
 	}
 	return a.renderStatusBoard(content, leftWidth, rightWidth)
 }
 
-func (a *App) renderLogPanel() string {
+// renderLogPanel draws the journey log tail. A positive width wraps log lines
+// so the panel fits narrow terminals; zero keeps the natural width.
+func (a *App) renderLogPanel(width int) string {
 	if a.logbook == nil {
 		return ""
 	}
@@ -807,9 +909,12 @@ func (a *App) renderLogPanel() string {
 	}
 	visible := padLogLines(lines, logPanelMaxLines)
 	scrollbar := logScrollbarSegments(total, logPanelMaxLines)
-	content := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#AAAAAA")).
-		Render(strings.Join(visible, "\n"))
+	contentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
+	if width > 0 {
+		// Border, padding and the scrollbar column take six cells.
+		contentStyle = contentStyle.Width(max(10, width-6))
+	}
+	content := contentStyle.Render(strings.Join(visible, "\n"))
 	scroll := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#5B8DEF")).
 		PaddingLeft(1).
@@ -873,11 +978,7 @@ func logScrollbarSegments(total, height int) []string {
 }
 
 func (a *App) renderStatusBoard(mainContent string, leftWidth, rightWidth int) string {
-	header := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FF6B6B")).
-		MarginBottom(1).
-		Render(mainMenuHeading)
+	header := renderBoardHeader()
 	left := lipgloss.JoinVertical(lipgloss.Left,
 		a.renderPhasePanel(leftWidth-4),
 		"",
@@ -902,8 +1003,37 @@ func (a *App) renderStatusBoard(mainContent string, leftWidth, rightWidth int) s
 	} else {
 		body = leftBox
 	}
+	return a.joinBoardSections(header, body, 0)
+}
+
+// renderCompactStatusBoard stacks the phase/main panel above the sessions panel
+// for narrow terminals and switches session rows to their abbreviated form.
+func (a *App) renderCompactStatusBoard(mainContent string, width int) string {
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#444444")).
+		Padding(0, 1).
+		Width(max(20, width))
+	top := boxStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		a.renderPhasePanel(width-4),
+		"",
+		a.renderMainArea(mainContent, width-4),
+	))
+	bottom := boxStyle.Render(a.renderSessionsPanelWith(width-4, a.renderCompactSessionItem))
+	return a.joinBoardSections(renderBoardHeader(), lipgloss.JoinVertical(lipgloss.Left, top, bottom), width+2)
+}
+
+func renderBoardHeader() string {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF6B6B")).
+		MarginBottom(1).
+		Render(mainMenuHeading)
+}
+
+func (a *App) joinBoardSections(header, body string, logWidth int) string {
 	sections := []string{header, body}
-	if logPanel := a.renderLogPanel(); logPanel != "" {
+	if logPanel := a.renderLogPanel(logWidth); logPanel != "" {
 		sections = append(sections, logPanel)
 	}
 	footer := lipgloss.NewStyle().
@@ -919,9 +1049,13 @@ func (a *App) renderPhasePanel(width int) string {
 	if phase == 0 {
 		phase = a.workflow.CurrentPhase()
 	}
-	pos, total := phasePosition(phase)
+	order := a.activePhaseOrder()
+	pos, total := phasePosition(order, phase)
+	if pos >= total {
+		pos = total - 1
+	}
 	phaseLine := fmt.Sprintf("%s (%d/%d)", phase.FriendlyName(), pos+1, total)
-	nextPhases := upcomingPhases(phase)
+	nextPhases := upcomingPhases(order, phase)
 	nextLine := ""
 	if len(nextPhases) > 0 {
 		var names []string
@@ -997,6 +1131,10 @@ func (a *App) renderWorkflowSelection() string {
 }
 
 func (a *App) renderSessionsPanel(width int) string {
+	return a.renderSessionsPanelWith(width, a.renderSessionItem)
+}
+
+func (a *App) renderSessionsPanelWith(width int, renderItem func(sessionItem, bool, int) string) string {
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#5B8DEF")).
@@ -1008,7 +1146,7 @@ func (a *App) renderSessionsPanel(width int) string {
 	var rows []string
 	for i, item := range a.sessionItems {
 		selected := a.boardFocus == focusSessions && i == a.sessionSelection
-		rows = append(rows, a.renderSessionItem(item, selected, width))
+		rows = append(rows, renderItem(item, selected, width))
 	}
 	body := strings.Join(rows, "\n")
 	return lipgloss.JoinVertical(lipgloss.Left, title, body, a.renderSessionInstructions())
@@ -1052,12 +1190,72 @@ func (a *App) renderSessionItem(item sessionItem, selected bool, width int) stri
 	return style.Render(content)
 }
 
+// renderCompactSessionItem packs the same fields as renderSessionItem into
+// two short lines for narrow layouts.
+func (a *App) renderCompactSessionItem(item sessionItem, selected bool, width int) string {
+	marker := "  "
+	if selected {
+		marker = "▸ "
+	}
+	line1 := fmt.Sprintf("%s%s · %s", marker, item.Agent, item.Worktree)
+	parts := []string{
+		fmt.Sprintf("c%d %s/%s", item.Cycle, titleCase(item.State), titleCase(item.Phase)),
+		fmt.Sprintf("%db %dpt", item.Beads, item.Points),
+	}
+	if item.Window != "" {
+		window := "tmux " + item.Window
+		if item.WindowActive {
+			window += "*"
+		}
+		parts = append(parts, window)
+	} else {
+		parts = append(parts, "idle")
+	}
+	if item.Waiting > 0 {
+		parts = append(parts, fmt.Sprintf("⚠%d", item.Waiting))
+	}
+	if !item.LastUpdated.IsZero() {
+		parts = append(parts, humanizeDuration(time.Since(item.LastUpdated)))
+	}
+	line2 := "  " + strings.Join(parts, " · ")
+	style := lipgloss.NewStyle().Width(max(20, width))
+	if selected {
+		style = style.Bold(true).Foreground(lipgloss.Color("#5B8DEF"))
+	}
+	return style.Render(line1 + "\n" + line2)
+}
+
 func (a *App) fetchStatusSnapshot() tea.Cmd {
 	return func() tea.Msg {
 		return a.buildStatusSnapshot()
 	}
 }
 
+// fetchPushedStatusSnapshot refreshes the board after a ready-bead change
+// without starting another periodic refresh.
+func (a *App) fetchPushedStatusSnapshot() tea.Cmd {
+	return func() tea.Msg {
+		msg := a.buildStatusSnapshot()
+		msg.pushed = true
+		return msg
+	}
+}
+
+// waitForReadyBeads blocks until the ready-bead subscription reports a change.
+// Nothing is delivered once the subscription ends.
+func (a *App) waitForReadyBeads() tea.Cmd {
+	if a.readyBeads == nil {
+		return nil
+	}
+	changes := a.readyBeads
+	return func() tea.Msg {
+		if _, ok := <-changes; !ok {
+			return nil
+		}
+		return readyBeadsChangedMsg{}
+	}
+}
+
 func (a *App) scheduleStatusRefresh() tea.Cmd {
 	return tea.Tick(boardRefreshInterval, func(time.Time) tea.Msg {
 		return a.buildStatusSnapshot()
@@ -1065,50 +1263,15 @@ func (a *App) scheduleStatusRefresh() tea.Cmd {
 }
 
 func (a *App) buildStatusSnapshot() statusRefreshMsg {
-	phase := a.workflow.CurrentPhase()
-	snapshots, err := a.orchestrator.SessionSnapshots()
+	board, err := a.orchestrator.StatusBoard(a.tmuxSession)
 	if err != nil {
-		return statusRefreshMsg{phase: phase, err: err}
-	}
-	windowMap := a.tmuxWindows()
-	items := make([]sessionItem, 0, len(snapshots))
-	for _, snap := range snapshots {
-		ses := sessionItem{
-			Agent:       snap.Worktree.Agent.Name,
-			Worktree:    snap.Worktree.Name,
-			Number:      snap.Worktree.Number,
-			Points:      snap.Worktree.TotalPoints(),
-			Beads:       len(snap.Worktree.Beads),
-			Cycle:       snap.Status.Cycle,
-			Phase:       snap.Status.Phase,
-			State:       snap.Status.State,
-			Questions:   snap.QuestionsTotal,
-			Waiting:     snap.QuestionsWaiting,
-			LastUpdated: snap.LastUpdated,
-		}
-		for _, candidate := range candidateWindowNames(snap) {
-			if info, ok := windowMap[candidate]; ok {
-				ses.Window = info.Name
-				ses.WindowActive = info.Active
-				break
-			}
-		}
-		items = append(items, ses)
-	}
-	cycle, cerr := a.orchestrator.CurrentCycleStatus()
-	hasCycle := true
-	if cerr != nil {
-		if errors.Is(cerr, orchestrator.ErrNoTrackedSessions) {
-			hasCycle = false
-		} else {
-			return statusRefreshMsg{phase: phase, err: cerr}
-		}
+		return statusRefreshMsg{phase: board.Phase, err: err}
 	}
 	return statusRefreshMsg{
-		sessions: items,
-		cycle:    cycle,
-		hasCycle: hasCycle,
-		phase:    phase,
+		sessions: board.Sessions,
+		cycle:    board.Cycle,
+		hasCycle: board.HasCycle,
+		phase:    board.Phase,
 	}
 }
 
@@ -1128,31 +1291,12 @@ func (a *App) openSelectedSessionWindow() tea.Cmd {
 	}
 }
 
-func (a *App) tmuxWindows() map[string]tmuxWindowInfo {
-	if a.tmuxSession == "" {
-		return map[string]tmuxWindowInfo{}
-	}
-	cmd := exec.Command("tmux", "list-windows", "-t", a.tmuxSession, "-F", "#{window_name}::#{window_active}")
-	output, err := cmd.Output()
-	if err != nil {
-		return map[string]tmuxWindowInfo{}
-	}
-	result := make(map[string]tmuxWindowInfo)
-	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		parts := strings.Split(line, "::")
-		if len(parts) != 2 {
-			continue
-		}
-		result[parts[0]] = tmuxWindowInfo{Name: parts[0], Active: strings.TrimSpace(parts[1]) == "1"}
-	}
-	return result
-}
-
 func detectTmuxContext() (string, string, error) {
+	// Outside tmux (or under another multiplexer) there is no status window
+	// to manage, and asking tmux would only reach an unrelated server.
+	if os.Getenv("TMUX") == "" {
+		return "", "", errNotInsideTmux
+	}
 	cmd := exec.Command("tmux", "display-message", "-p", "#S::#I")
 	output, err := cmd.Output()
 	if err != nil {
@@ -1182,18 +1326,6 @@ func bindStatusReturnKey(session, windowName, hotkey string) error {
 	return cmd.Run()
 }
 
-func candidateWindowNames(snapshot orchestrator.SessionSnapshot) []string {
-	num := snapshot.Worktree.Number
-	cycle := snapshot.Status.Cycle
-	if cycle <= 0 {
-		cycle = 1
-	}
-	return []string{
-		fmt.Sprintf("worktree-agent-%d-%d", num, cycle),
-		fmt.Sprintf("worktree-orchestrator-%d-%d", num, cycle),
-	}
-}
-
 func titleCase(value string) string {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -1238,8 +1370,31 @@ func (a *App) workflowSelectionHeight() int {
 	if a.height <= 0 {
 		return 0
 	}
-	const chromePadding = 18
-	return max(8, a.height-chromePadding)
+	return max(8, a.height-workflowChromePadding)
+}
+
+// cycleHistoryWidth mirrors the main-area width View computes for the left
+// panel so the summary pane wraps inside it.
+func (a *App) cycleHistoryWidth() int {
+	width := a.width
+	if width <= 0 {
+		width = 100
+	}
+	if width < compactBoardWidth {
+		return max(20, width-2) - 4
+	}
+	leftWidth := width - max(32, width/3) - 4
+	if leftWidth < 40 {
+		leftWidth = width - 4
+	}
+	return leftWidth - 4
+}
+
+func (a *App) cycleHistoryHeight() int {
+	if a.height <= 0 {
+		return 20
+	}
+	return max(8, a.height-workflowChromePadding)
 }
 
 func humanizeWorkflowID(value string) string {
@@ -1260,21 +1415,68 @@ func humanizeWorkflowID(value string) string {
 	return strings.Join(parts, " ")
 }
 
-func phasePosition(p workflow.Phase) (int, int) {
-	for i, phase := range phaseOrder {
+// activePhaseOrder returns the phase sequence for the active workflow, cached
+// per workflow ID so rendering does not reload the definition every frame.
+func (a *App) activePhaseOrder() []workflow.Phase {
+	id := a.activeWorkflowID()
+	if a.phaseOrder != nil && a.phaseOrderFor == id {
+		return a.phaseOrder
+	}
+	order := defaultPhaseOrder
+	if a.workflowLoader != nil && a.config != nil {
+		if def, err := a.workflowLoader(a.config, id); err == nil {
+			if derived := phaseOrderFromDefinition(def); len(derived) > 0 {
+				order = derived
+			}
+		}
+	}
+	a.phaseOrder = order
+	a.phaseOrderFor = id
+	return order
+}
+
+// phaseOrderFromDefinition lists the phases a workflow passes through, in the
+// order its modules first reach them. Unknown modules are ignored.
+func phaseOrderFromDefinition(def workflow.WorkflowDefinition) []workflow.Phase {
+	seen := make(map[workflow.Phase]struct{})
+	var order []workflow.Phase
+	for _, ref := range def.Modules {
+		for _, phase := range modulePhases[strings.TrimSpace(ref.ModuleID)] {
+			if _, ok := seen[phase]; ok {
+				continue
+			}
+			seen[phase] = struct{}{}
+			order = append(order, phase)
+		}
+	}
+	return order
+}
+
+// phasePosition returns the index of p within order and the total number of
+// phases. Phases the workflow skips take the slot of the next phase it does
+// run, so the indicator never jumps past the end.
+func phasePosition(order []workflow.Phase, p workflow.Phase) (int, int) {
+	for i, phase := range order {
 		if p == phase {
-			return i, len(phaseOrder)
+			return i, len(order)
 		}
 	}
-	return len(phaseOrder), len(phaseOrder)
+	for i, phase := range order {
+		if phase > p {
+			return i, len(order)
+		}
+	}
+	return len(order), len(order)
 }
 
-func upcomingPhases(p workflow.Phase) []workflow.Phase {
-	pos, _ := phasePosition(p)
-	if pos+1 >= len(phaseOrder) {
-		return nil
+func upcomingPhases(order []workflow.Phase, p workflow.Phase) []workflow.Phase {
+	var next []workflow.Phase
+	for _, phase := range order {
+		if phase > p {
+			next = append(next, phase)
+		}
 	}
-	return phaseOrder[pos+1:]
+	return next
 }
 
 func max(a, b int) int {
@@ -8,12 +8,14 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/config"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 	"github.com/kingrea/The-Lattice/internal/workflow/engine"
+	"github.com/kingrea/The-Lattice/internal/workflow/scheduler"
 )
 
 func TestWorkflowStartAndResume(t *testing.T) {
@@ -73,6 +75,151 @@ func TestHandleModuleRunMarksCompletion(t *testing.T) {
 	}
 }
 
+func TestGateApprovalKeepsOperatorNote(t *testing.T) {
+	projectDir := t.TempDir()
+	setTestLatticeRoot(t)
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	app := newTestApp(t, projectDir)
+	model, cmd := app.startWorkflowRun(false)
+	app = runCommands(t, model, cmd)
+	view := app.workflowView
+	if view == nil {
+		t.Fatalf("workflow view missing")
+	}
+	node := view.currentNode()
+	if node == nil {
+		t.Fatalf("expected a selected module")
+	}
+	view.manualGates[node.ID] = scheduler.ManualGateState{Required: true, Note: "release window"}
+
+	if !view.toggleGateApproval() {
+		t.Fatalf("approve gate")
+	}
+	gate := view.manualGates[node.ID]
+	if !gate.Approved || gate.Note != "release window" || gate.ApprovedAt.IsZero() {
+		t.Fatalf("approval should keep the note and stamp the time, got %+v", gate)
+	}
+	if line := view.renderModuleLine(view.selection, *node); !strings.Contains(line, "Gate note: release window") {
+		t.Fatalf("module line should show the note under the gate label:\n%s", line)
+	}
+	if details := view.renderModuleDetails(*node); !strings.Contains(details, "Approved: ") {
+		t.Fatalf("details should show the approval time:\n%s", details)
+	}
+
+	if !view.toggleGateApproval() {
+		t.Fatalf("revoke gate")
+	}
+	gate = view.manualGates[node.ID]
+	if gate.Approved || gate.Note != "release window" || !gate.ApprovedAt.IsZero() {
+		t.Fatalf("revoking should keep the note and clear the time, got %+v", gate)
+	}
+}
+
+func TestGateNoteEnteredInViewSurvivesResume(t *testing.T) {
+	projectDir := t.TempDir()
+	setTestLatticeRoot(t)
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	app := newTestApp(t, projectDir)
+	model, cmd := app.startWorkflowRun(false)
+	app = runCommands(t, model, cmd)
+	view := app.workflowView
+	if view == nil {
+		t.Fatalf("workflow view missing")
+	}
+	applyCmd := func(cmd tea.Cmd) {
+		t.Helper()
+		if cmd == nil {
+			t.Fatalf("expected a runtime sync command")
+		}
+		view.Update(cmd())
+	}
+	applyCmd(view.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")}))
+	view.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if !view.editingNote() {
+		t.Fatalf("n should open the gate note input")
+	}
+	// Keys the app binds globally, like r and esc, must reach the input.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("release window")})
+	if app.state != stateCommissionWork || view.noteInput.Value() != "release window" {
+		t.Fatalf("typed note should reach the input, got %q", view.noteInput.Value())
+	}
+	applyCmd(view.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter}))
+	if view.editingNote() {
+		t.Fatalf("enter should close the note input")
+	}
+
+	app2 := newTestApp(t, projectDir)
+	model, cmd = app2.startWorkflowRun(true)
+	app2 = runCommands(t, model, cmd)
+	resumed := app2.workflowView
+	if resumed == nil {
+		t.Fatalf("resume should attach workflow view")
+	}
+	node := resumed.currentNode()
+	if gate := resumed.manualGates[node.ID]; !gate.Required || gate.Note != "release window" {
+		t.Fatalf("gate note should survive resume, got %+v", gate)
+	}
+	if line := resumed.renderModuleLine(resumed.selection, *node); !strings.Contains(line, "Gate note: release window") {
+		t.Fatalf("resumed module line should show the note:\n%s", line)
+	}
+}
+
+func TestUnskipRestoresOptionalModule(t *testing.T) {
+	projectDir := t.TempDir()
+	setTestLatticeRoot(t)
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	loader := func(cfg *config.Config, workflowID string) (workflow.WorkflowDefinition, error) {
+		return workflow.WorkflowDefinition{
+			ID:   "optional",
+			Name: "Optional",
+			Modules: []workflow.ModuleRef{
+				{ID: "alpha", ModuleID: "stub-alpha", Name: "Alpha", Optional: true},
+				{ID: "beta", ModuleID: "stub-alpha", Name: "Beta"},
+			},
+		}, nil
+	}
+	app := newTestApp(t, projectDir, WithWorkflowDefinitionLoader(loader))
+	model, cmd := app.startWorkflowRun(false)
+	app = runCommands(t, model, cmd)
+	view := app.workflowView
+	if view == nil {
+		t.Fatalf("workflow view missing")
+	}
+	view.selection = 0
+	if node := view.currentNode(); node == nil || node.ID != "alpha" {
+		t.Fatalf("expected alpha selected, got %+v", node)
+	}
+	if !view.skipSelectedModule() {
+		t.Fatalf("skip alpha")
+	}
+	app = runCommands(t, app, view.syncRuntime())
+	if got := strings.Join(app.workflowView.state.Runtime.SkippedModules, ","); got != "alpha" {
+		t.Fatalf("engine skipped modules = %q, want alpha", got)
+	}
+
+	view = app.workflowView
+	app = runCommands(t, app, view.unskipSelectedModule())
+	view = app.workflowView
+	if len(view.skipped) != 0 {
+		t.Fatalf("view should forget the skip, got %v", view.skipped)
+	}
+	if got := view.state.Runtime.SkippedModules; len(got) != 0 {
+		t.Fatalf("engine should forget the skip, got %v", got)
+	}
+	if got := strings.Join(view.targets, ","); got != "alpha,beta" {
+		t.Fatalf("targets = %q, want alpha,beta", got)
+	}
+	if cmd := view.unskipSelectedModule(); cmd != nil {
+		t.Fatalf("unskipping a module that is not skipped should be a no-op")
+	}
+}
+
 func TestWorkflowCompletionReturnsToMainMenu(t *testing.T) {
 	projectDir := t.TempDir()
 	setTestLatticeRoot(t)
@@ -304,3 +451,175 @@ func (m *stubModule) markerPath(ctx *module.ModuleContext) string {
 	}
 	return filepath.Join(ctx.Workflow.Dir(), "engine-test", m.id+".marker")
 }
+
+func TestPhasePanelFollowsWorkflowDefinition(t *testing.T) {
+	def := workflow.WorkflowDefinition{
+		ID: "custom",
+		Modules: []workflow.ModuleRef{
+			{ID: "anchor", ModuleID: "anchor-docs"},
+			{ID: "plan", ModuleID: "action-plan"},
+			{ID: "work", ModuleID: "solo-work"},
+			{ID: "ship", ModuleID: "release"},
+		},
+	}
+	order := phaseOrderFromDefinition(def)
+	want := []workflow.Phase{
+		workflow.PhasePlanning,
+		workflow.PhaseWorkProcess,
+		workflow.PhaseAgentRelease,
+		workflow.PhaseWorkCleanup,
+		workflow.PhaseOrchestratorRelease,
+	}
+	if fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Fatalf("unexpected phase order: %v", order)
+	}
+	if pos, total := phasePosition(order, workflow.PhaseWorkProcess); pos != 1 || total != 5 {
+		t.Fatalf("work phase position = %d/%d, want 1/5", pos, total)
+	}
+	next := upcomingPhases(order, workflow.PhaseWorkProcess)
+	if len(next) != 3 || next[0] != workflow.PhaseAgentRelease {
+		t.Fatalf("unexpected upcoming phases: %v", next)
+	}
+	// Phases the workflow skips take the slot of the next phase it runs.
+	if pos, _ := phasePosition(order, workflow.PhaseOrchestratorSelection); pos != 1 {
+		t.Fatalf("skipped phase position = %d, want 1", pos)
+	}
+
+	projectDir := t.TempDir()
+	setTestLatticeRoot(t)
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	app := newTestApp(t, projectDir)
+	app.workflowLoader = func(*config.Config, string) (workflow.WorkflowDefinition, error) { return def, nil }
+	app.cachedPhase = workflow.PhaseWorkProcess
+	panel := app.renderPhasePanel(80)
+	if !strings.Contains(panel, "(2/5)") {
+		t.Fatalf("expected position 2/5 in panel, got:\n%s", panel)
+	}
+	if !strings.Contains(panel, "Next: Releasing Agents → Cleanup → Final Release") {
+		t.Fatalf("expected release phases as next steps, got:\n%s", panel)
+	}
+	if strings.Contains(panel, "Hiring") || strings.Contains(panel, "Refinement") {
+		t.Fatalf("panel lists phases the workflow does not run:\n%s", panel)
+	}
+}
+
+func TestStatusBoardUsesCompactLayoutOnNarrowTerminals(t *testing.T) {
+	projectDir := t.TempDir()
+	setTestLatticeRoot(t)
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	app := newTestApp(t, projectDir)
+	app.sessionItems = []sessionItem{{
+		Agent:    "Aster",
+		Worktree: "tree-1-aster",
+		Cycle:    2,
+		State:    "running",
+		Phase:    "up-cycle",
+		Beads:    3,
+		Points:   5,
+		Window:   "worktree-agent-1-2",
+		Waiting:  1,
+	}}
+
+	app.width = 60
+	narrow := app.View()
+	if !strings.Contains(narrow, "c2 Running/Up-cycle · 3b 5pt") {
+		t.Fatalf("expected abbreviated session line, got:\n%s", narrow)
+	}
+	for _, line := range strings.Split(narrow, "\n") {
+		if w := lipgloss.Width(line); w > app.width {
+			t.Fatalf("compact line exceeds %d columns (%d): %q", app.width, w, line)
+		}
+	}
+	phaseIdx := strings.Index(narrow, "Phase:")
+	sessionsIdx := strings.Index(narrow, "Sessions (1)")
+	if phaseIdx < 0 || sessionsIdx < 0 || sessionsIdx < phaseIdx {
+		t.Fatalf("expected sessions panel stacked below phase panel, got:\n%s", narrow)
+	}
+	phaseLine := narrow[strings.LastIndex(narrow[:phaseIdx], "\n")+1:]
+	phaseLine = phaseLine[:strings.Index(phaseLine, "\n")]
+	if strings.Contains(phaseLine, "Sessions") {
+		t.Fatalf("compact layout should not place panels side by side: %q", phaseLine)
+	}
+
+	app.width = 140
+	wide := app.View()
+	if !strings.Contains(wide, "Cycle 2 · Running (Up-cycle)") {
+		t.Fatalf("expected full session layout on wide terminals, got:\n%s", wide)
+	}
+}
+
+func TestCycleHistoryListsCompletedCyclesAndShowsSummary(t *testing.T) {
+	projectDir := t.TempDir()
+	setTestLatticeRoot(t)
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	app := newTestApp(t, projectDir)
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	stateDir := app.config.StateDir()
+	writeFile(filepath.Join(stateDir, "cycle-1", "SUMMARY.md"), "# Cycle 1\nLaid the foundations.\n")
+	writeFile(filepath.Join(stateDir, "cycle-2", "SUMMARY.md"), "# Cycle 2\nShipped the parser.\n")
+	// cycle-3 is still running and has not written its summary.
+	if err := os.MkdirAll(filepath.Join(stateDir, "cycle-3"), 0o755); err != nil {
+		t.Fatalf("mkdir cycle-3: %v", err)
+	}
+	writeFile(app.workflow.WorkLogPath(), "# Work Log\n\n"+
+		"## Down cycle summary (cycle 2, 2026-01-02T00:00:00Z)\n\nOverall progress: 4/5 beads\n\n"+
+		"## Refinement requested\n\nunrelated\n")
+
+	app.width, app.height = 140, 60
+	model, _ := selectMainMenuItem(t, app, "Cycle History")
+	app = model.(*App)
+	if app.state != stateCycleHistory {
+		t.Fatalf("expected cycle history state, got %v", app.state)
+	}
+	view := app.View()
+	for _, want := range []string{"Cycle History (2)", "▸ Cycle 2 ·", "Cycle 1 ·", "Shipped the parser.", "Overall progress: 4/5 beads"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in cycle history view, got:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Cycle 3 ·") || strings.Contains(view, "unrelated") {
+		t.Fatalf("cycle history should skip unsummarized cycles and other log sections, got:\n%s", view)
+	}
+
+	model, _ = app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app = model.(*App)
+	view = app.View()
+	for _, want := range []string{"▸ Cycle 1 ·", "Laid the foundations.", "No down-cycle log section recorded for cycle 1."} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q after selecting cycle 1, got:\n%s", want, view)
+		}
+	}
+
+	model, _ = app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(*App).state != stateMainMenu {
+		t.Fatalf("esc should return to the main menu")
+	}
+}
+
+// selectMainMenuItem highlights the main menu entry titled title and
+// activates it.
+func selectMainMenuItem(t *testing.T, app *App, title string) (tea.Model, tea.Cmd) {
+	t.Helper()
+	for i, item := range app.mainMenu.Items() {
+		if entry, ok := item.(menuItem); ok && entry.title == title {
+			app.mainMenu.Select(i)
+			return app.handleMainMenuSelection()
+		}
+	}
+	t.Fatalf("main menu has no %q entry", title)
+	return nil, nil
+}
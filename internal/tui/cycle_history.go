@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
+)
+
+// cycleHistoryListRows caps how many cycles are listed above the summary
+// pane; the list scrolls to keep the selection visible.
+const cycleHistoryListRows = 6
+
+// cycleHistoryView lists completed cycles and shows the selected cycle's
+// SUMMARY.md together with its down-cycle section from the work log.
+type cycleHistoryView struct {
+	orchestrator *orchestrator.Orchestrator
+	cycles       []orchestrator.CycleSummary
+	selected     int
+	pane         viewport.Model
+	width        int
+	err          error
+}
+
+func newCycleHistoryView(orch *orchestrator.Orchestrator) *cycleHistoryView {
+	pane := viewport.New(0, 0)
+	// Up/down move the cycle selection, so the pane scrolls by page or with
+	// shift+arrows instead.
+	pane.KeyMap.Up = key.NewBinding(key.WithKeys("shift+up", "K"))
+	pane.KeyMap.Down = key.NewBinding(key.WithKeys("shift+down", "J"))
+	v := &cycleHistoryView{orchestrator: orch, pane: pane}
+	v.reload()
+	return v
+}
+
+// reload rescans state/ for completed cycles, keeping the selected cycle
+// when it is still listed.
+func (v *cycleHistoryView) reload() {
+	current := 0
+	if cycle, ok := v.selectedCycle(); ok {
+		current = cycle.Cycle
+	}
+	v.cycles, v.err = v.orchestrator.CycleSummaries()
+	v.selected = 0
+	for i, cycle := range v.cycles {
+		if cycle.Cycle == current {
+			v.selected = i
+			break
+		}
+	}
+	v.refreshPane()
+}
+
+func (v *cycleHistoryView) selectedCycle() (orchestrator.CycleSummary, bool) {
+	if v.selected < 0 || v.selected >= len(v.cycles) {
+		return orchestrator.CycleSummary{}, false
+	}
+	return v.cycles[v.selected], true
+}
+
+func (v *cycleHistoryView) setSize(width, height int) {
+	v.width = max(20, width)
+	v.pane.Width = v.width
+	v.pane.Height = max(4, height-min(len(v.cycles), cycleHistoryListRows)-3)
+	v.refreshPane()
+}
+
+func (v *cycleHistoryView) Update(msg tea.Msg) tea.Cmd {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "up", "k":
+			if v.selected > 0 {
+				v.selected--
+				v.refreshPane()
+			}
+			return nil
+		case "down", "j":
+			if v.selected < len(v.cycles)-1 {
+				v.selected++
+				v.refreshPane()
+			}
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	v.pane, cmd = v.pane.Update(msg)
+	return cmd
+}
+
+// refreshPane loads the selected cycle's summary into the scrollable pane.
+func (v *cycleHistoryView) refreshPane() {
+	v.pane.SetContent(lipgloss.NewStyle().Width(max(20, v.width)).Render(v.detail()))
+	v.pane.GotoTop()
+}
+
+func (v *cycleHistoryView) detail() string {
+	cycle, ok := v.selectedCycle()
+	if !ok {
+		return ""
+	}
+	summary := "SUMMARY.md is unreadable."
+	if data, err := os.ReadFile(cycle.Path); err == nil {
+		summary = strings.TrimSpace(string(data))
+	}
+	section, err := v.orchestrator.DownCycleLogSection(cycle.Cycle)
+	switch {
+	case err != nil:
+		section = fmt.Sprintf("Down-cycle log unavailable: %v", err)
+	case section == "":
+		section = fmt.Sprintf("No down-cycle log section recorded for cycle %d.", cycle.Cycle)
+	}
+	return summary + "\n\n" + section
+}
+
+func (v *cycleHistoryView) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#5B8DEF")).
+		Render(fmt.Sprintf("Cycle History (%d)", len(v.cycles)))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
+	if v.err != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, title, muted.Render(fmt.Sprintf("Cycle history unavailable: %v", v.err)))
+	}
+	if len(v.cycles) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, title, muted.Render("No completed cycles yet. Summaries appear here once a cycle writes state/cycle-N/SUMMARY.md."))
+	}
+	start := 0
+	if v.selected >= cycleHistoryListRows {
+		start = v.selected - cycleHistoryListRows + 1
+	}
+	end := min(len(v.cycles), start+cycleHistoryListRows)
+	var rows []string
+	for i := start; i < end; i++ {
+		cycle := v.cycles[i]
+		label := fmt.Sprintf("Cycle %d · %s", cycle.Cycle, cycle.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		if i == v.selected {
+			rows = append(rows, lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FF6B6B")).Render("▸ "+label))
+		} else {
+			rows = append(rows, "  "+label)
+		}
+	}
+	hint := muted.Render(fmt.Sprintf("↑/↓ select cycle    PgUp/PgDn scroll (%d%%)    Esc → back", int(v.pane.ScrollPercent()*100)))
+	return lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(rows, "\n"), "", v.pane.View(), hint)
+}
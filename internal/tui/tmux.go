@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTmuxRequired reports that the tmux binary could not be found. The TUI
+// runs its agents in tmux windows and cannot start without it.
+var ErrTmuxRequired = errors.New("tmux is required to run the lattice TUI")
+
+var errNotInsideTmux = errors.New("not running inside tmux")
+
+// TmuxResolver locates an executable on PATH; exec.LookPath in production.
+type TmuxResolver func(file string) (string, error)
+
+// tmuxInstallHint is shown with ErrTmuxRequired.
+const tmuxInstallHint = "install tmux (macOS: brew install tmux; Debian/Ubuntu: sudo apt install tmux; Fedora: sudo dnf install tmux) and run lattice again. " +
+	"Without tmux, `lattice status --json`, `lattice throughput`, and module-runner still work."
+
+// CheckTmux returns nil when resolve finds tmux, and otherwise an error
+// wrapping ErrTmuxRequired with install guidance.
+func CheckTmux(resolve TmuxResolver) error {
+	if resolve == nil {
+		return nil
+	}
+	if _, err := resolve("tmux"); err != nil {
+		return fmt.Errorf("%w: %s", ErrTmuxRequired, tmuxInstallHint)
+	}
+	return nil
+}
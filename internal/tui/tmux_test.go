@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestCheckTmuxExplainsMissingTmux(t *testing.T) {
+	var asked string
+	missing := func(file string) (string, error) {
+		asked = file
+		return "", exec.ErrNotFound
+	}
+	err := CheckTmux(missing)
+	if asked != "tmux" {
+		t.Fatalf("resolver asked for %q, want tmux", asked)
+	}
+	if !errors.Is(err, ErrTmuxRequired) {
+		t.Fatalf("expected ErrTmuxRequired, got %v", err)
+	}
+	for _, want := range []string{"tmux is required", "brew install tmux", "apt install tmux", "lattice status --json"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %q", want, err)
+		}
+	}
+
+	found := func(string) (string, error) { return "/usr/bin/tmux", nil }
+	if err := CheckTmux(found); err != nil {
+		t.Fatalf("tmux on PATH should pass, got %v", err)
+	}
+}
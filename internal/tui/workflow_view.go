@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/eventbridge"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/modules"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 	"github.com/kingrea/The-Lattice/internal/workflow/engine"
 	"github.com/kingrea/The-Lattice/internal/workflow/scheduler"
@@ -45,20 +48,25 @@ var (
 )
 
 type workflowView struct {
-	app             *App
-	moduleCtx       *module.ModuleContext
-	registry        *module.Registry
-	engine          *engine.Engine
-	workflowID      string
-	definition      workflow.WorkflowDefinition
-	moduleRefs      map[string]workflow.ModuleRef
-	state           engine.State
-	stateLoaded     bool
-	err             error
-	selection       int
-	running         map[string]struct{}
-	manualGates     map[string]scheduler.ManualGateState
+	app         *App
+	moduleCtx   *module.ModuleContext
+	registry    *module.Registry
+	engine      *engine.Engine
+	workflowID  string
+	definition  workflow.WorkflowDefinition
+	moduleRefs  map[string]workflow.ModuleRef
+	state       engine.State
+	stateLoaded bool
+	err         error
+	selection   int
+	running     map[string]struct{}
+	manualGates map[string]scheduler.ManualGateState
+	// noteTarget is the module whose gate note is being edited in noteInput;
+	// it is empty while the view is not capturing text.
+	noteTarget      string
+	noteInput       textinput.Model
 	targets         []string
+	skipped         []string
 	loader          WorkflowDefinitionLoader
 	registryFactory func(*config.Config) (*module.Registry, error)
 	finished        bool
@@ -67,6 +75,10 @@ type workflowView struct {
 	moduleActivity  map[string]time.Time
 	moduleSubs      map[string]eventbridge.Subscription
 	eventLogLimit   int
+	// runCtx bounds module runs and their retry backoff; stopRuns cancels it
+	// when the view is closed.
+	runCtx   context.Context
+	stopRuns context.CancelFunc
 }
 
 type moduleLabel struct {
@@ -87,9 +99,11 @@ type workflowStateMsg struct {
 type engineRefreshRequest struct{}
 
 type moduleRunFinishedMsg struct {
-	id     string
-	result module.Result
-	err    error
+	id       string
+	result   module.Result
+	err      error
+	logPath  string
+	attempts int
 }
 
 type workClaimMsg struct {
@@ -128,6 +142,10 @@ func newWorkflowView(app *App, workflowID string) *workflowView {
 		moduleSubs:     map[string]eventbridge.Subscription{},
 		eventLogLimit:  20,
 	}
+	view.noteInput = textinput.New()
+	view.noteInput.Prompt = "Gate note: "
+	view.noteInput.CharLimit = 200
+	view.runCtx, view.stopRuns = context.WithCancel(context.Background())
 	if app != nil && app.orchestrator != nil {
 		view.bridgeEnabled = strings.TrimSpace(app.orchestrator.BridgeURL()) != "" && app.orchestrator.EventRouter() != nil
 	}
@@ -153,6 +171,12 @@ func (v *workflowView) Update(msg tea.Msg) tea.Cmd {
 		}
 		v.err = nil
 		v.stateLoaded = true
+		// The view owns the gates from here on; later engine states echo what
+		// it sent and may predate the operator's latest edit.
+		v.manualGates = map[string]scheduler.ManualGateState{}
+		for id, gate := range m.state.Runtime.ManualGates {
+			v.manualGates[id] = gate
+		}
 		cmd := v.applyState(m.state)
 		v.setStatus("Workflow engine ready")
 		if v.finished {
@@ -247,9 +271,13 @@ func (v *workflowView) View() string {
 			lines = append(lines, v.renderModuleDetails(node))
 		}
 	}
+	if v.editingNote() {
+		lines = append(lines, "", v.noteInput.View(), "enter=save note  esc=cancel")
+		return strings.Join(lines, "\n")
+	}
 	lines = append(lines,
 		"",
-		"enter=run  r=refresh  s=skip optional  g=toggle gate  a=approve gate",
+		"enter=run  r=refresh  s=skip optional  u=unskip  g=toggle gate  a=approve gate  n=gate note",
 		"esc=back to menu",
 	)
 	return strings.Join(lines, "\n")
@@ -279,6 +307,11 @@ func (v *workflowView) renderModuleLine(idx int, node engine.ModuleStatus) strin
 	if badges := strings.Join(rendered, "  "); strings.TrimSpace(badges) != "" {
 		body = append(body, moduleMetaStyle.Render(badges))
 	}
+	if gate, ok := v.manualGates[node.ID]; ok && gate.Required {
+		if note := strings.TrimSpace(gate.Note); note != "" {
+			body = append(body, moduleMetaStyle.Render(fmt.Sprintf("Gate note: %s", note)))
+		}
+	}
 	stack := lipgloss.JoinVertical(lipgloss.Left, body...)
 	return container.Render(lipgloss.JoinHorizontal(lipgloss.Top, indicator, stack))
 }
@@ -291,6 +324,12 @@ func (v *workflowView) renderModuleDetails(node engine.ModuleStatus) string {
 	if len(node.BlockedBy) > 0 {
 		details = append(details, fmt.Sprintf("Blocked by: %s", strings.Join(node.BlockedBy, ", ")))
 	}
+	if len(node.SatisfiedBy) > 0 {
+		details = append(details, fmt.Sprintf("Unblocked by: %s", strings.Join(node.SatisfiedBy, ", ")))
+	}
+	if gate, ok := v.manualGates[node.ID]; ok && gate.Required && gate.Approved && !gate.ApprovedAt.IsZero() {
+		details = append(details, fmt.Sprintf("Approved: %s", gate.ApprovedAt.Local().Format("2006-01-02 15:04")))
+	}
 	if run, ok := v.state.Runs[node.ID]; ok {
 		runLine := fmt.Sprintf("Last run: %s", run.Status)
 		if run.Message != "" {
@@ -429,6 +468,9 @@ func friendlyLabel(value string) string {
 }
 
 func (v *workflowView) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
+	if v.editingNote() {
+		return v.handleNoteKey(msg)
+	}
 	switch msg.String() {
 	case "up", "k":
 		if v.selection > 0 {
@@ -446,6 +488,8 @@ func (v *workflowView) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		if v.skipSelectedModule() {
 			return v.syncRuntime()
 		}
+	case "u":
+		return v.unskipSelectedModule()
 	case "g":
 		if v.toggleGateRequirement() {
 			return v.syncRuntime()
@@ -454,10 +498,69 @@ func (v *workflowView) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		if v.toggleGateApproval() {
 			return v.syncRuntime()
 		}
+	case "n":
+		return v.beginGateNote()
 	}
 	return nil
 }
 
+// editingNote reports whether key presses are going to the gate note input.
+func (v *workflowView) editingNote() bool {
+	return v.noteTarget != ""
+}
+
+func (v *workflowView) handleNoteKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		v.endGateNote()
+		v.setStatus("Gate note unchanged")
+		return nil
+	case "enter":
+		id := v.noteTarget
+		note := strings.TrimSpace(v.noteInput.Value())
+		v.endGateNote()
+		gate, ok := v.manualGates[id]
+		if !ok {
+			return nil
+		}
+		gate.Note = note
+		v.manualGates[id] = gate
+		if note == "" {
+			v.setStatus(fmt.Sprintf("Gate note cleared for %s", id))
+		} else {
+			v.setStatus(fmt.Sprintf("Gate note saved for %s", id))
+		}
+		return v.syncRuntime()
+	}
+	var cmd tea.Cmd
+	v.noteInput, cmd = v.noteInput.Update(msg)
+	return cmd
+}
+
+// beginGateNote opens the note input for the selected module's gate,
+// prefilled with its current note.
+func (v *workflowView) beginGateNote() tea.Cmd {
+	node := v.currentNode()
+	if node == nil {
+		return nil
+	}
+	gate, ok := v.manualGates[node.ID]
+	if !ok || !gate.Required {
+		v.setStatus("Manual gate not required for this module")
+		return nil
+	}
+	v.noteTarget = node.ID
+	v.noteInput.SetValue(gate.Note)
+	v.noteInput.CursorEnd()
+	return v.noteInput.Focus()
+}
+
+func (v *workflowView) endGateNote() {
+	v.noteTarget = ""
+	v.noteInput.Blur()
+	v.noteInput.Reset()
+}
+
 func (v *workflowView) ensureRuntime() error {
 	if v.moduleCtx == nil {
 		v.moduleCtx = module.NewContext(v.app.config, v.app.workflow, v.app.orchestrator, v.app.logbook)
@@ -475,7 +578,7 @@ func (v *workflowView) ensureRuntime() error {
 		v.registry = reg
 	}
 	if v.engine == nil {
-		repo := engine.NewRepository(v.app.workflow)
+		repo := engine.NewWorkflowRepository(v.app.workflow, v.workflowID)
 		eng, err := engine.New(v.registry, repo)
 		if err != nil {
 			return err
@@ -571,7 +674,7 @@ func (v *workflowView) launchClaims(claims []engine.WorkClaim) tea.Cmd {
 			v.setStatus(fmt.Sprintf("Resolve %s: %v", claim.Name, err))
 			continue
 		}
-		cmds = append(cmds, v.executeModule(claim.ID, mod))
+		cmds = append(cmds, v.executeModule(claim.ID, mod, ref.Retry))
 	}
 	if len(cmds) == 0 {
 		return nil
@@ -579,11 +682,19 @@ func (v *workflowView) launchClaims(claims []engine.WorkClaim) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
-func (v *workflowView) executeModule(id string, mod module.Module) tea.Cmd {
+func (v *workflowView) executeModule(id string, mod module.Module, retry *workflow.RetryPolicy) tea.Cmd {
 	ctx := v.moduleCtx.WithMode("workflow-engine")
 	return func() tea.Msg {
-		result, err := mod.Run(ctx)
-		return moduleRunFinishedMsg{id: id, result: result, err: err}
+		update := engine.RunWithRetry(v.runCtx, retry, func(int) engine.ModuleStatusUpdate {
+			runCtx := ctx
+			if logged, file, err := ctx.OpenRunLog(id, time.Now()); err == nil {
+				defer file.Close()
+				runCtx = logged
+			}
+			result, err := mod.Run(runCtx)
+			return engine.ModuleStatusUpdate{Result: result, Err: err, LogPath: runCtx.RunLogPath}
+		})
+		return moduleRunFinishedMsg{id: id, result: update.Result, err: update.Err, logPath: update.LogPath, attempts: update.Attempts}
 	}
 }
 
@@ -596,6 +707,8 @@ func (v *workflowView) handleModuleRunFinished(msg moduleRunFinishedMsg) tea.Cmd
 		Result:     msg.result,
 		Err:        msg.err,
 		FinishedAt: time.Now(),
+		LogPath:    msg.logPath,
+		Attempts:   msg.attempts,
 	}
 	result := msg.result
 	if result.Status == "" {
@@ -614,15 +727,20 @@ func (v *workflowView) handleModuleRunFinished(msg moduleRunFinishedMsg) tea.Cmd
 		v.setStatus(fmt.Sprintf("Engine update failed: %v", err))
 		return nil
 	}
-	return v.applyState(state)
+	cmd := v.applyState(state)
+	if hint := orchestrator.Remedy(msg.err); hint != "" {
+		v.setStatus(fmt.Sprintf("%s failed: %s", msg.id, hint))
+	}
+	return cmd
 }
 
 func (v *workflowView) refreshEngineState() tea.Cmd {
 	if v.engine == nil || v.finished {
 		return nil
 	}
+	overrides := v.runtimeOverrides()
 	return func() tea.Msg {
-		state, err := v.engine.Update(v.moduleCtx, engine.UpdateRequest{Runtime: v.runtimeOverrides()})
+		state, err := v.engine.Update(v.moduleCtx, engine.UpdateRequest{Runtime: overrides})
 		return workflowStateMsg{state: state, err: err}
 	}
 }
@@ -649,24 +767,62 @@ func (v *workflowView) skipSelectedModule() bool {
 	if len(v.targets) == 0 {
 		return false
 	}
+	if v.isSkipped(node.ID) {
+		v.setStatus(fmt.Sprintf("%s already skipped · press u to unskip", node.Name))
+		return false
+	}
 	updated := make([]string, 0, len(v.targets))
-	removed := false
 	for _, id := range v.targets {
-		if id == node.ID {
-			removed = true
-			continue
+		if id != node.ID {
+			updated = append(updated, id)
 		}
-		updated = append(updated, id)
-	}
-	if !removed {
-		v.setStatus(fmt.Sprintf("%s already skipped", node.Name))
-		return false
 	}
 	v.targets = updated
-	v.setStatus(fmt.Sprintf("Skipped optional module %s", node.Name))
+	v.skipped = append(v.skipped, node.ID)
+	if len(node.Dependents) > 0 {
+		v.setStatus(fmt.Sprintf("Skipped optional module %s · dependents relying on it are skipped too", node.Name))
+	} else {
+		v.setStatus(fmt.Sprintf("Skipped optional module %s", node.Name))
+	}
 	return true
 }
 
+// unskipSelectedModule returns an operator-skipped module to the run through
+// the engine, which also targets it again and un-skips dependents that were
+// only skipped because of it.
+func (v *workflowView) unskipSelectedModule() tea.Cmd {
+	node := v.currentNode()
+	if node == nil {
+		return nil
+	}
+	if !v.isSkipped(node.ID) {
+		v.setStatus(fmt.Sprintf("%s is not skipped", node.Name))
+		return nil
+	}
+	v.skipped = stripString(v.skipped, node.ID)
+	if len(v.targets) > 0 {
+		v.targets = append(v.targets, node.ID)
+	}
+	v.setStatus(fmt.Sprintf("Unskipped %s", node.Name))
+	if v.engine == nil {
+		return nil
+	}
+	id := node.ID
+	return func() tea.Msg {
+		state, err := v.engine.Unskip(v.moduleCtx, engine.UnskipRequest{IDs: []string{id}, Actor: "operator"})
+		return workflowStateMsg{state: state, err: err}
+	}
+}
+
+func (v *workflowView) isSkipped(id string) bool {
+	for _, skipped := range v.skipped {
+		if skipped == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *workflowView) toggleGateRequirement() bool {
 	node := v.currentNode()
 	if node == nil {
@@ -676,7 +832,7 @@ func (v *workflowView) toggleGateRequirement() bool {
 	gate.Required = !gate.Required
 	if !gate.Required {
 		gate.Approved = false
-		gate.Note = ""
+		gate.ApprovedAt = time.Time{}
 	}
 	if v.manualGates == nil {
 		v.manualGates = map[string]scheduler.ManualGateState{}
@@ -701,6 +857,10 @@ func (v *workflowView) toggleGateApproval() bool {
 		return false
 	}
 	gate.Approved = !gate.Approved
+	gate.ApprovedAt = time.Time{}
+	if gate.Approved {
+		gate.ApprovedAt = time.Now().UTC()
+	}
 	v.manualGates[node.ID] = gate
 	if gate.Approved {
 		v.setStatus(fmt.Sprintf("Approved %s", node.Name))
@@ -772,8 +932,8 @@ func (v *workflowView) installRuntimeState(state engine.State) {
 		}
 		v.running[id] = struct{}{}
 	}
-	if len(state.Runtime.ManualGates) > 0 {
-		v.manualGates = cloneManualGates(state.Runtime.ManualGates)
+	if len(state.Runtime.SkippedModules) > 0 {
+		v.skipped = cloneStrings(state.Runtime.SkippedModules)
 	}
 	if len(state.Runtime.Targets) > 0 {
 		v.targets = cloneStrings(state.Runtime.Targets)
@@ -828,12 +988,28 @@ func (v *workflowView) applyState(state engine.State) tea.Cmd {
 	if finish := v.checkForCompletion(); finish != nil {
 		cmds = append(cmds, finish)
 	}
+	if refine := v.autoRefine(); refine != nil {
+		cmds = append(cmds, refine)
+	}
 	if len(cmds) == 0 {
 		return nil
 	}
 	return tea.Batch(cmds...)
 }
 
+// autoRefine claims refinement without operator input when the workflow sets
+// runtime.auto_refine and the refinement marker has made it runnable.
+func (v *workflowView) autoRefine() tea.Cmd {
+	if v.engine == nil || v.finished || len(engine.AutoRefineTargets(v.state)) == 0 {
+		return nil
+	}
+	overrides := v.runtimeOverrides()
+	return func() tea.Msg {
+		result, err := v.engine.ClaimAutoRefine(v.moduleCtx, overrides)
+		return workClaimMsg{result: result, err: err}
+	}
+}
+
 func (v *workflowView) checkForCompletion() tea.Cmd {
 	if v.finished {
 		return nil
@@ -867,6 +1043,14 @@ func (v *workflowView) workflowFinished(reason string) tea.Cmd {
 	return func() tea.Msg { return msg }
 }
 
+// close cancels pending retries and drops the view's bridge subscriptions.
+func (v *workflowView) close() {
+	if v.stopRuns != nil {
+		v.stopRuns()
+	}
+	v.closeBridgeSubscriptions()
+}
+
 func (v *workflowView) closeBridgeSubscriptions() {
 	for id, sub := range v.moduleSubs {
 		sub.Close()
@@ -884,6 +1068,10 @@ func (v *workflowView) runtimeOverrides() *engine.RuntimeOverrides {
 		gates := cloneManualGates(v.manualGates)
 		overrides.ManualGates = &gates
 	}
+	if len(v.skipped) > 0 {
+		skipped := cloneStrings(v.skipped)
+		overrides.SkippedModules = &skipped
+	}
 	return overrides
 }
 
@@ -891,8 +1079,9 @@ func (v *workflowView) syncRuntime() tea.Cmd {
 	if v.engine == nil {
 		return nil
 	}
+	overrides := v.runtimeOverrides()
 	return func() tea.Msg {
-		state, err := v.engine.Update(v.moduleCtx, engine.UpdateRequest{Runtime: v.runtimeOverrides()})
+		state, err := v.engine.Update(v.moduleCtx, engine.UpdateRequest{Runtime: overrides, Actor: "operator"})
 		return workflowStateMsg{state: state, err: err}
 	}
 }
@@ -926,6 +1115,17 @@ func convertModuleConfig(cfg workflow.ModuleConfig) module.Config {
 	return out
 }
 
+// stripString returns values without id.
+func stripString(values []string, id string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != id {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
 func cloneStrings(values []string) []string {
 	if len(values) == 0 {
 		return nil
@@ -3,6 +3,8 @@ package workflow
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 )
 
 // DependencyGraph maps workflow-scoped module identifiers to the module IDs they
@@ -50,6 +52,12 @@ func (def WorkflowDefinition) Clone() WorkflowDefinition {
 		Graph:       def.Graph.Clone(),
 		Runtime:     def.Runtime,
 	}
+	if len(def.Runtime.ClassLimits) > 0 {
+		clone.Runtime.ClassLimits = make(map[string]int, len(def.Runtime.ClassLimits))
+		for class, limit := range def.Runtime.ClassLimits {
+			clone.Runtime.ClassLimits[class] = limit
+		}
+	}
 	if len(def.Modules) > 0 {
 		clone.Modules = make([]ModuleRef, len(def.Modules))
 		for i, ref := range def.Modules {
@@ -67,16 +75,25 @@ func (def WorkflowDefinition) Validate() error {
 	if len(def.Modules) == 0 {
 		return fmt.Errorf("workflow %s: at least one module is required", def.ID)
 	}
-	seen := map[string]struct{}{}
+	seen := map[string]int{}
 	for idx, ref := range def.Modules {
 		if err := ref.Validate(); err != nil {
 			return fmt.Errorf("workflow %s module[%d]: %w", def.ID, idx, err)
 		}
 		instanceID := ref.InstanceID()
-		if _, exists := seen[instanceID]; exists {
-			return fmt.Errorf("workflow %s: duplicate module instance id %s", def.ID, instanceID)
+		if first, exists := seen[instanceID]; exists {
+			return fmt.Errorf("workflow %s: duplicate module instance id %q in module[%d] and module[%d]; give one a unique id", def.ID, instanceID, first, idx)
+		}
+		seen[instanceID] = idx
+	}
+	for _, ref := range def.Modules {
+		for _, group := range ref.AnyOf {
+			for _, dep := range group {
+				if _, ok := seen[dep]; !ok {
+					return fmt.Errorf("workflow %s: any_of dependency %s -> %s references unknown module", def.ID, ref.InstanceID(), dep)
+				}
+			}
 		}
-		seen[instanceID] = struct{}{}
 	}
 	for key, deps := range def.Graph {
 		if _, ok := seen[key]; !ok {
@@ -112,15 +129,42 @@ func (def WorkflowDefinition) Normalized() (WorkflowDefinition, error) {
 	return clone, nil
 }
 
+// InputFreshness governs whether a completed module reruns when the inputs
+// behind its output fingerprints change.
+type InputFreshness string
+
+const (
+	// InputFreshnessStrict reruns a module whenever an output fingerprint no
+	// longer matches its inputs. It is the default.
+	InputFreshnessStrict InputFreshness = "strict"
+	// InputFreshnessLenient keeps a module complete when only its fingerprints
+	// drifted; missing, invalid, or version-mismatched outputs still rerun.
+	InputFreshnessLenient InputFreshness = "lenient"
+)
+
 // WorkflowRuntimeConfig configures execution constraints for a workflow.
 type WorkflowRuntimeConfig struct {
-	MaxParallel int `json:"max_parallel,omitempty" yaml:"max_parallel,omitempty"`
+	MaxParallel    int            `json:"max_parallel,omitempty" yaml:"max_parallel,omitempty"`
+	InputFreshness InputFreshness `json:"input_freshness,omitempty" yaml:"input_freshness,omitempty"`
+	// AutoRefine claims the refinement module as soon as it becomes runnable
+	// (work-process left the .refinement-needed marker) instead of waiting for
+	// an operator.
+	AutoRefine bool `json:"auto_refine,omitempty" yaml:"auto_refine,omitempty"`
+	// ClassLimits caps how many running modules may share a concurrency class
+	// (module.ConcurrencyProfile.Class). Classes without a limit are unbounded.
+	ClassLimits map[string]int `json:"class_limits,omitempty" yaml:"class_limits,omitempty"`
+}
+
+// Lenient reports whether the workflow tolerates stale inputs.
+func (cfg WorkflowRuntimeConfig) Lenient() bool {
+	return cfg.InputFreshness == InputFreshnessLenient
 }
 
 func (cfg WorkflowRuntimeConfig) normalized() WorkflowRuntimeConfig {
 	if cfg.MaxParallel < 0 {
 		cfg.MaxParallel = 0
 	}
+	cfg.InputFreshness = InputFreshness(strings.ToLower(strings.TrimSpace(string(cfg.InputFreshness))))
 	return cfg
 }
 
@@ -128,6 +172,19 @@ func (cfg WorkflowRuntimeConfig) validate() error {
 	if cfg.MaxParallel < 0 {
 		return fmt.Errorf("max_parallel must be >= 0")
 	}
+	switch cfg.InputFreshness {
+	case "", InputFreshnessStrict, InputFreshnessLenient:
+	default:
+		return fmt.Errorf("input_freshness must be %q or %q, got %q", InputFreshnessStrict, InputFreshnessLenient, cfg.InputFreshness)
+	}
+	for class, limit := range cfg.ClassLimits {
+		if strings.TrimSpace(class) == "" {
+			return fmt.Errorf("class_limits has an empty class name")
+		}
+		if limit < 1 {
+			return fmt.Errorf("class_limits[%s] must be >= 1, got %d", class, limit)
+		}
+	}
 	return nil
 }
 
@@ -156,33 +213,130 @@ func (def WorkflowDefinition) Dependencies(id string) []string {
 
 // ModuleRef describes how a workflow composes and configures a module.
 type ModuleRef struct {
-	ID          string       `json:"id,omitempty" yaml:"id,omitempty"`
-	ModuleID    string       `json:"module" yaml:"module"`
-	Name        string       `json:"name,omitempty" yaml:"name,omitempty"`
-	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
-	DependsOn   []string     `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
-	Config      ModuleConfig `json:"config,omitempty" yaml:"config,omitempty"`
-	Optional    bool         `json:"optional,omitempty" yaml:"optional,omitempty"`
+	ID          string   `json:"id,omitempty" yaml:"id,omitempty"`
+	ModuleID    string   `json:"module" yaml:"module"`
+	Name        string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	DependsOn   []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	// AnyOf lists alternative dependency groups. The module waits until at
+	// least one group is fully complete, on top of everything in DependsOn.
+	AnyOf    [][]string   `json:"any_of,omitempty" yaml:"any_of,omitempty"`
+	Config   ModuleConfig `json:"config,omitempty" yaml:"config,omitempty"`
+	Optional bool         `json:"optional,omitempty" yaml:"optional,omitempty"`
+	// RequireApproval starts the module behind a manual gate, as if an
+	// operator had gated it in the TUI, until the gate is approved.
+	RequireApproval bool         `json:"require_approval,omitempty" yaml:"require_approval,omitempty"`
+	Retry           *RetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
 }
 
 // Clone returns a deep copy of the module reference.
 func (ref ModuleRef) Clone() ModuleRef {
 	clone := ModuleRef{
-		ID:          ref.ID,
-		ModuleID:    ref.ModuleID,
-		Name:        ref.Name,
-		Description: ref.Description,
-		Optional:    ref.Optional,
+		ID:              ref.ID,
+		ModuleID:        ref.ModuleID,
+		Name:            ref.Name,
+		Description:     ref.Description,
+		Optional:        ref.Optional,
+		RequireApproval: ref.RequireApproval,
 	}
 	if len(ref.DependsOn) > 0 {
 		clone.DependsOn = cloneStringSlice(ref.DependsOn)
 	}
+	if len(ref.AnyOf) > 0 {
+		clone.AnyOf = make([][]string, len(ref.AnyOf))
+		for i, group := range ref.AnyOf {
+			clone.AnyOf[i] = cloneStringSlice(group)
+		}
+	}
 	if len(ref.Config) > 0 {
 		clone.Config = ref.Config.Clone()
 	}
+	if ref.Retry != nil {
+		retry := *ref.Retry
+		retry.RetryOn = cloneStringSlice(ref.Retry.RetryOn)
+		clone.Retry = &retry
+	}
 	return clone
 }
 
+// RetryPolicy lets the engine rerun a module whose Run fails before it
+// records the failure. Only declare it for modules that are safe to repeat.
+type RetryPolicy struct {
+	// MaxAttempts counts the first run; values below 2 disable retries.
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+	// Backoff is the wait before the first retry as a Go duration ("30s").
+	// It doubles before each later retry, up to MaxRetryDelay.
+	Backoff string `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// RetryOn lists the result statuses worth retrying ("failed",
+	// "needs-input"). Empty retries failures only.
+	RetryOn []string `json:"retry_on,omitempty" yaml:"retry_on,omitempty"`
+}
+
+// retryableStatuses are the module result statuses a policy may retry.
+var retryableStatuses = map[string]bool{"failed": true, "needs-input": true}
+
+// Retries reports whether a run that ended with status should be retried.
+func (p *RetryPolicy) Retries(status string) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	if len(p.RetryOn) == 0 {
+		return status == "failed"
+	}
+	for _, candidate := range p.RetryOn {
+		if strings.EqualFold(strings.TrimSpace(candidate), status) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxRetryDelay caps the doubled backoff between retries.
+const MaxRetryDelay = time.Hour
+
+// Delay returns how long to wait before the given retry, counting from 1.
+func (p *RetryPolicy) Delay(retry int) time.Duration {
+	if p == nil || retry < 1 {
+		return 0
+	}
+	base, err := time.ParseDuration(strings.TrimSpace(p.Backoff))
+	if err != nil || base <= 0 {
+		return 0
+	}
+	delay := base
+	for i := 1; i < retry && delay < MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > MaxRetryDelay {
+		return MaxRetryDelay
+	}
+	return delay
+}
+
+func (p *RetryPolicy) validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("retry max_attempts must be >= 0")
+	}
+	if backoff := strings.TrimSpace(p.Backoff); backoff != "" {
+		d, err := time.ParseDuration(backoff)
+		if err != nil {
+			return fmt.Errorf("retry backoff: %w", err)
+		}
+		if d < 0 {
+			return fmt.Errorf("retry backoff must not be negative")
+		}
+	}
+	for _, status := range p.RetryOn {
+		if !retryableStatuses[strings.ToLower(strings.TrimSpace(status))] {
+			return fmt.Errorf("retry_on status %q must be \"failed\" or \"needs-input\"", status)
+		}
+	}
+	return nil
+}
+
 // ModuleConfig carries module-specific overrides (opaque to the runtime).
 type ModuleConfig map[string]any
 
@@ -218,6 +372,27 @@ func (ref ModuleRef) Validate() error {
 			return fmt.Errorf("workflow: module %s has duplicate dependency on %s", ref.InstanceID(), deps[i])
 		}
 	}
+	for idx, group := range ref.AnyOf {
+		if len(group) == 0 {
+			return fmt.Errorf("workflow: module %s any_of[%d] is empty", ref.InstanceID(), idx)
+		}
+		members := map[string]bool{}
+		for _, id := range group {
+			if id == "" {
+				return fmt.Errorf("workflow: module %s any_of[%d] has an empty module id", ref.InstanceID(), idx)
+			}
+			if id == ref.InstanceID() {
+				return fmt.Errorf("workflow: module %s any_of[%d] depends on itself", ref.InstanceID(), idx)
+			}
+			if members[id] {
+				return fmt.Errorf("workflow: module %s any_of[%d] lists %s twice", ref.InstanceID(), idx, id)
+			}
+			members[id] = true
+		}
+	}
+	if err := ref.Retry.validate(); err != nil {
+		return fmt.Errorf("workflow: module %s %w", ref.InstanceID(), err)
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package workflow
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseDefinitionYAMLRejectsMissingModules(t *testing.T) {
@@ -52,3 +53,37 @@ modules:
 		t.Fatalf("max_parallel should clamp to 0, got %d", def.Runtime.MaxParallel)
 	}
 }
+
+func TestParseDefinitionYAMLRejectsDuplicateInstanceIDs(t *testing.T) {
+	const payload = `
+id: duplicate-instances
+modules:
+  - module: anchor-docs
+  - id: review
+    module: staff-review
+  - id: anchor-docs
+    module: action-plan
+`
+	_, err := ParseDefinitionYAML([]byte(payload))
+	if err == nil {
+		t.Fatalf("expected error when two modules share an instance id")
+	}
+	if !strings.Contains(err.Error(), `duplicate module instance id "anchor-docs" in module[0] and module[2]`) {
+		t.Fatalf("error should name the duplicate and both modules: %v", err)
+	}
+}
+
+func TestRetryPolicyDelayDoublesUpToMax(t *testing.T) {
+	policy := &RetryPolicy{Backoff: "30s"}
+	if got := policy.Delay(1); got != 30*time.Second {
+		t.Fatalf("first retry delay = %s, want 30s", got)
+	}
+	if got := policy.Delay(3); got != 2*time.Minute {
+		t.Fatalf("third retry delay = %s, want 2m", got)
+	}
+	for _, retry := range []int{8, 64, 1000} {
+		if got := policy.Delay(retry); got != MaxRetryDelay {
+			t.Fatalf("retry %d delay = %s, want %s", retry, got, MaxRetryDelay)
+		}
+	}
+}
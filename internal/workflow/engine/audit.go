@@ -0,0 +1,252 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow/scheduler"
+)
+
+// auditFileName is the append-only audit trail kept in the project's state
+// directory.
+const auditFileName = "engine-audit.jsonl"
+
+// defaultAuditActor is recorded when a request does not name its actor.
+const defaultAuditActor = "engine"
+
+// AuditEvent enumerates the transitions recorded in the audit trail.
+type AuditEvent string
+
+const (
+	// AuditEventStart marks a new run.
+	AuditEventStart AuditEvent = "start"
+	// AuditEventClaim marks a module reserved for execution.
+	AuditEventClaim AuditEvent = "claim"
+	// AuditEventRelease marks a module leaving the running set.
+	AuditEventRelease AuditEvent = "release"
+	// AuditEventResult records a module run result reported to Update.
+	AuditEventResult AuditEvent = "result"
+	// AuditEventState records a resolver state change for a module.
+	AuditEventState AuditEvent = "state"
+	// AuditEventSkip and AuditEventUnskip record operator skip changes.
+	AuditEventSkip   AuditEvent = "skip"
+	AuditEventUnskip AuditEvent = "unskip"
+	// AuditEventGate records a manual gate being added, changed, or removed.
+	AuditEventGate AuditEvent = "gate"
+)
+
+// AuditEntry is one line of state/engine-audit.jsonl.
+type AuditEntry struct {
+	Time       time.Time  `json:"time"`
+	RunID      string     `json:"run_id"`
+	WorkflowID string     `json:"workflow_id"`
+	Operation  string     `json:"operation"`
+	Event      AuditEvent `json:"event"`
+	Module     string     `json:"module,omitempty"`
+	From       string     `json:"from,omitempty"`
+	To         string     `json:"to,omitempty"`
+	Actor      string     `json:"actor"`
+	Reason     string     `json:"reason,omitempty"`
+}
+
+// ReadAuditLog returns the audit entries recorded for the project, oldest
+// first. A project without an audit trail yields no entries.
+func ReadAuditLog(ctx *module.ModuleContext) ([]AuditEntry, error) {
+	path := auditLogPath(ctx)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entries []AuditEntry
+	for idx, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("workflow engine: audit line %d: %w", idx+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func auditLogPath(ctx *module.ModuleContext) string {
+	if ctx == nil || ctx.Config == nil || strings.TrimSpace(ctx.Config.LatticeProjectDir) == "" {
+		return ""
+	}
+	return filepath.Join(ctx.Config.StateDir(), auditFileName)
+}
+
+// recordAudit appends the audit entries for a state that has already been
+// saved. The transition stands either way, so a failed write is logged to the
+// logbook instead of failing the operation.
+func (e *Engine) recordAudit(ctx *module.ModuleContext, operation, actor string, before, after State, results []ModuleStatusUpdate) {
+	err := e.audit(ctx, operation, actor, before, after, results)
+	if err == nil || ctx == nil || ctx.Logbook == nil {
+		return
+	}
+	ctx.Logbook.Warn("%s %s: %v", operation, after.WorkflowID, err)
+}
+
+// audit appends the transitions between before and after to the project's
+// audit trail. Nothing is written when the context has no project config.
+func (e *Engine) audit(ctx *module.ModuleContext, operation, actor string, before, after State, results []ModuleStatusUpdate) error {
+	path := auditLogPath(ctx)
+	if path == "" {
+		return nil
+	}
+	if strings.TrimSpace(actor) == "" {
+		actor = defaultAuditActor
+	}
+	entries := auditTransitions(before, after, results)
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("workflow engine: audit log: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("workflow engine: audit log: %w", err)
+	}
+	defer file.Close()
+	for _, entry := range entries {
+		entry.Time = after.UpdatedAt
+		entry.RunID = after.RunID
+		entry.WorkflowID = after.WorkflowID
+		entry.Operation = operation
+		entry.Actor = actor
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(encoded, '\n')); err != nil {
+			return fmt.Errorf("workflow engine: audit log: %w", err)
+		}
+	}
+	return nil
+}
+
+// auditTransitions lists what changed between two snapshots in a stable
+// order: run start, results, releases, claims, skips, gates, then module
+// state changes in declaration order.
+func auditTransitions(before, after State, results []ModuleStatusUpdate) []AuditEntry {
+	var entries []AuditEntry
+	if before.RunID == "" {
+		entries = append(entries, AuditEntry{Event: AuditEventStart, To: string(after.Status), Reason: after.StatusReason})
+	}
+	for _, update := range results {
+		id := strings.TrimSpace(update.ID)
+		run, ok := after.Runs[id]
+		if id == "" || !ok {
+			continue
+		}
+		reason := run.Error
+		if reason == "" {
+			reason = run.Message
+		}
+		entries = append(entries, AuditEntry{Event: AuditEventResult, Module: id, To: string(run.Status), Reason: reason})
+	}
+	for _, id := range missingFrom(before.Runtime.Running, after.Runtime.Running) {
+		entries = append(entries, AuditEntry{Event: AuditEventRelease, Module: id, From: "running"})
+	}
+	for _, id := range missingFrom(after.Runtime.Running, before.Runtime.Running) {
+		entries = append(entries, AuditEntry{Event: AuditEventClaim, Module: id, To: "running"})
+	}
+	for _, id := range missingFrom(after.Runtime.SkippedModules, before.Runtime.SkippedModules) {
+		entries = append(entries, AuditEntry{Event: AuditEventSkip, Module: id, Reason: "skipped by operator"})
+	}
+	for _, id := range missingFrom(before.Runtime.SkippedModules, after.Runtime.SkippedModules) {
+		entries = append(entries, AuditEntry{Event: AuditEventUnskip, Module: id})
+	}
+	gateIDs := make([]string, 0, len(before.Runtime.ManualGates)+len(after.Runtime.ManualGates))
+	for id := range before.Runtime.ManualGates {
+		gateIDs = append(gateIDs, id)
+	}
+	for id := range after.Runtime.ManualGates {
+		if _, seen := before.Runtime.ManualGates[id]; !seen {
+			gateIDs = append(gateIDs, id)
+		}
+	}
+	sort.Strings(gateIDs)
+	for _, id := range gateIDs {
+		prev, hadPrev := before.Runtime.ManualGates[id]
+		next, hasNext := after.Runtime.ManualGates[id]
+		if hadPrev == hasNext && prev.Equal(next) {
+			continue
+		}
+		entry := AuditEntry{Event: AuditEventGate, Module: id, Reason: next.Note}
+		if hadPrev {
+			entry.From = gateLabel(prev)
+		}
+		if hasNext {
+			entry.To = gateLabel(next)
+		} else {
+			entry.Reason = prev.Note
+		}
+		entries = append(entries, entry)
+	}
+	previous := make(map[string]ModuleStatus, len(before.Nodes))
+	for _, node := range before.Nodes {
+		previous[node.ID] = node
+	}
+	for _, node := range after.Nodes {
+		prev, ok := previous[node.ID]
+		if ok && prev.State == node.State {
+			continue
+		}
+		entry := AuditEntry{Event: AuditEventState, Module: node.ID, To: string(node.State), Reason: nodeStateReason(node)}
+		if ok {
+			entry.From = string(prev.State)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func gateLabel(gate scheduler.ManualGateState) string {
+	switch {
+	case !gate.Required:
+		return "not-required"
+	case gate.Approved:
+		return "approved"
+	default:
+		return "pending"
+	}
+}
+
+func nodeStateReason(node ModuleStatus) string {
+	switch {
+	case node.Error != "":
+		return node.Error
+	case node.SkipReason != "":
+		return node.SkipReason
+	case len(node.BlockedBy) > 0:
+		return "waiting on " + strings.Join(node.BlockedBy, ", ")
+	}
+	return ""
+}
+
+// missingFrom returns the IDs in values that are absent from other, in order.
+func missingFrom(values, other []string) []string {
+	var out []string
+	for _, id := range values {
+		if !containsID(other, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
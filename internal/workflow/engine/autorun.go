@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/module"
+)
+
+// ModuleExecutor runs one claimed module and reports its outcome. AutoRun calls
+// it from a separate goroutine per claim, so implementations must be safe for
+// concurrent use.
+type ModuleExecutor func(claim WorkClaim, mod module.Module) ModuleStatusUpdate
+
+// AutoRunRequest configures an unattended run of the persisted workflow.
+type AutoRunRequest struct {
+	// Runtime applies to every claim and update. MaxParallel bounds how many
+	// modules execute at once.
+	Runtime *RuntimeOverrides
+	// Execute runs a claimed module. When nil, the module runs with the
+	// engine's module context and a per-run log.
+	Execute ModuleExecutor
+}
+
+// AutoRun repeatedly claims every runnable module and executes the claims
+// concurrently, feeding each result back through Update as soon as it
+// finishes so freed slots are reclaimed immediately. Each module is claimed at
+// most once per call; a module with a retry policy reruns within its claim
+// before its outcome is recorded. AutoRun returns when nothing is running and nothing new can
+// be claimed; on cancellation it stops claiming, waits for in-flight modules,
+// records them, and returns ctx.Err().
+func (e *Engine) AutoRun(ctx context.Context, mctx *module.ModuleContext, req AutoRunRequest) (State, error) {
+	if mctx == nil {
+		return State{}, fmt.Errorf("workflow engine: module context is required")
+	}
+	execute := req.Execute
+	if execute == nil {
+		execute = defaultExecutor(mctx)
+	}
+	current, err := e.repo.Load()
+	if err != nil {
+		return State{}, err
+	}
+	results := make(chan ModuleStatusUpdate)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	attempted := map[string]bool{}
+	inFlight := 0
+	for {
+		if ctx.Err() == nil {
+			candidates := unattempted(current.Runnable, attempted)
+			if len(candidates) > 0 {
+				claim, err := e.Claim(mctx, ClaimRequest{Runtime: req.Runtime, Modules: candidates})
+				if err != nil {
+					return e.drain(mctx, req.Runtime, results, inFlight, err)
+				}
+				current = claim.State
+				for _, work := range claim.Claims {
+					attempted[work.ID] = true
+					mod, err := e.resolveClaim(current, work)
+					inFlight++
+					wg.Add(1)
+					policy := retryPolicy(current.Definition, work.ID)
+					go func(work WorkClaim, mod module.Module, resolveErr error) {
+						defer wg.Done()
+						if resolveErr != nil {
+							results <- ModuleStatusUpdate{ID: work.ID, Err: resolveErr, FinishedAt: time.Now()}
+							return
+						}
+						update := RunWithRetry(ctx, policy, func(int) ModuleStatusUpdate {
+							return execute(work, mod)
+						})
+						update.ID = work.ID
+						results <- update
+					}(work, mod, err)
+				}
+			}
+		}
+		if inFlight == 0 {
+			if err := ctx.Err(); err != nil {
+				return current, err
+			}
+			return current, nil
+		}
+		var update ModuleStatusUpdate
+		select {
+		case update = <-results:
+		case <-ctx.Done():
+			return e.drain(mctx, req.Runtime, results, inFlight, ctx.Err())
+		}
+		inFlight--
+		current, err = e.Update(mctx, UpdateRequest{Runtime: req.Runtime, Results: []ModuleStatusUpdate{normalizeUpdate(update)}})
+		if err != nil {
+			return e.drain(mctx, req.Runtime, results, inFlight, err)
+		}
+	}
+}
+
+// drain waits for in-flight modules, records their results, and returns cause.
+func (e *Engine) drain(mctx *module.ModuleContext, runtime *RuntimeOverrides, results <-chan ModuleStatusUpdate, inFlight int, cause error) (State, error) {
+	var updates []ModuleStatusUpdate
+	for ; inFlight > 0; inFlight-- {
+		updates = append(updates, normalizeUpdate(<-results))
+	}
+	if len(updates) > 0 {
+		if state, err := e.Update(mctx, UpdateRequest{Runtime: runtime, Results: updates}); err == nil {
+			return state, cause
+		}
+	}
+	state, _ := e.repo.Load()
+	return state, cause
+}
+
+func (e *Engine) resolveClaim(state State, claim WorkClaim) (module.Module, error) {
+	for _, ref := range state.Definition.Modules {
+		if ref.InstanceID() == claim.ID {
+			return e.registry.Resolve(ref.ModuleID, module.Config(ref.Config))
+		}
+	}
+	return nil, fmt.Errorf("workflow engine: module %s is not defined", claim.ID)
+}
+
+func defaultExecutor(mctx *module.ModuleContext) ModuleExecutor {
+	ctx := mctx.WithMode("workflow-engine")
+	return func(claim WorkClaim, mod module.Module) ModuleStatusUpdate {
+		runCtx := ctx
+		if ctx.Config != nil {
+			if logged, file, err := ctx.OpenRunLog(claim.ID, time.Now()); err == nil {
+				defer file.Close()
+				runCtx = logged
+			}
+		}
+		result, err := mod.Run(runCtx)
+		return ModuleStatusUpdate{ID: claim.ID, Result: result, Err: err, FinishedAt: time.Now(), LogPath: runCtx.RunLogPath}
+	}
+}
+
+// normalizeUpdate fills in a status for executors that only report an error.
+func normalizeUpdate(update ModuleStatusUpdate) ModuleStatusUpdate {
+	if update.Result.Status == "" {
+		if update.Err != nil {
+			update.Result.Status = module.StatusFailed
+		} else {
+			update.Result.Status = module.StatusCompleted
+		}
+	}
+	return update
+}
+
+func unattempted(ids []string, attempted map[string]bool) []string {
+	var out []string
+	for _, id := range ids {
+		if !attempted[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
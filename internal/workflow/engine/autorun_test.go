@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func TestAutoRunExecutesClaimsConcurrently(t *testing.T) {
+	ctx := newTestModuleContext(t)
+	def := workflow.WorkflowDefinition{
+		ID:      "parallel-workflow",
+		Runtime: workflow.WorkflowRuntimeConfig{MaxParallel: 2},
+		Modules: []workflow.ModuleRef{
+			{ID: "anchor-plan", ModuleID: "plan"},
+			{ID: "module-build", ModuleID: "build", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-docs", ModuleID: "docs", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-lint", ModuleID: "lint", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-release", ModuleID: "release", DependsOn: []string{"module-build", "module-docs", "module-lint"}},
+		},
+	}
+	stubs := map[string]*stubModule{
+		"plan":    newStubModule("plan"),
+		"build":   newStubModule("build"),
+		"docs":    newStubModule("docs"),
+		"lint":    newStubModule("lint"),
+		"release": newStubModule("release"),
+	}
+	stubs["plan"].setComplete(true)
+	eng, _ := newCustomEngine(t, ctx, def, stubs)
+	if _, err := eng.Start(ctx, StartRequest{Definition: def}); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	var (
+		mu        sync.Mutex
+		active    int
+		maxActive int
+		order     []string
+	)
+	// The first two claims wait for each other, so a serial executor would
+	// time out instead of reaching the rendezvous.
+	rendezvous := make(chan struct{})
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	go func() {
+		arrived.Wait()
+		close(rendezvous)
+	}()
+	execute := func(claim WorkClaim, mod module.Module) ModuleStatusUpdate {
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		first := len(order) < 2
+		order = append(order, claim.ID)
+		mu.Unlock()
+		if first {
+			arrived.Done()
+			select {
+			case <-rendezvous:
+			case <-time.After(2 * time.Second):
+				return ModuleStatusUpdate{Result: module.Result{Status: module.StatusFailed, Message: "claims did not overlap"}}
+			}
+		}
+		stubs[claim.ModuleID].setComplete(true)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return ModuleStatusUpdate{Result: module.Result{Status: module.StatusCompleted}}
+	}
+
+	state, err := eng.AutoRun(context.Background(), ctx, AutoRunRequest{Execute: execute})
+	if err != nil {
+		t.Fatalf("auto-run: %v", err)
+	}
+	if maxActive != 2 {
+		t.Fatalf("expected two modules to run concurrently under max_parallel 2, peak was %d", maxActive)
+	}
+	if len(order) != 4 || order[3] != "module-release" {
+		t.Fatalf("unexpected execution order: %v", order)
+	}
+	if state.Status != EngineStatusComplete || len(state.Runtime.Running) != 0 {
+		t.Fatalf("expected a complete run with no running modules, got %s running=%v", state.Status, state.Runtime.Running)
+	}
+	for _, id := range order {
+		if run := state.Runs[id]; run.Status != module.StatusCompleted {
+			t.Fatalf("expected %s to be recorded as completed, got %+v", id, run)
+		}
+	}
+}
+
+// flakyModule fails its first failures runs, then completes.
+type flakyModule struct {
+	*stubModule
+	failures int
+	runs     int
+}
+
+func (m *flakyModule) Run(*module.ModuleContext) (module.Result, error) {
+	m.mu.Lock()
+	m.runs++
+	runs := m.runs
+	m.mu.Unlock()
+	if runs <= m.failures {
+		return module.Result{Status: module.StatusFailed}, fmt.Errorf("attempt %d failed", runs)
+	}
+	m.setComplete(true)
+	return module.Result{Status: module.StatusCompleted}, nil
+}
+
+func TestAutoRunRetriesModulesUnderRetryPolicy(t *testing.T) {
+	ctx := newTestModuleContext(t)
+	def := workflow.WorkflowDefinition{
+		ID: "retry-workflow",
+		Modules: []workflow.ModuleRef{
+			{ID: "module-write", ModuleID: "write", Retry: &workflow.RetryPolicy{MaxAttempts: 3, Backoff: "1ms"}},
+			{ID: "module-beads", ModuleID: "beads"},
+		},
+	}
+	write := &flakyModule{stubModule: newStubModule("write"), failures: 2}
+	beads := &flakyModule{stubModule: newStubModule("beads"), failures: 1}
+	reg := module.NewRegistry()
+	reg.MustRegister("write", func(module.Config) (module.Module, error) { return write, nil })
+	reg.MustRegister("beads", func(module.Config) (module.Module, error) { return beads, nil })
+	eng, err := New(reg, NewRepository(ctx.Workflow))
+	if err != nil {
+		t.Fatalf("new engine: %v", err)
+	}
+	if _, err := eng.Start(ctx, StartRequest{Definition: def}); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	state, err := eng.AutoRun(context.Background(), ctx, AutoRunRequest{})
+	if err != nil {
+		t.Fatalf("auto-run: %v", err)
+	}
+	if write.runs != 3 {
+		t.Fatalf("expected the retried module to run three times, ran %d", write.runs)
+	}
+	if run := state.Runs["module-write"]; run.Status != module.StatusCompleted || run.Attempts != 3 {
+		t.Fatalf("expected module-write to complete on its third attempt, got %+v", run)
+	}
+	if beads.runs != 1 {
+		t.Fatalf("a module without a retry policy must run once, ran %d", beads.runs)
+	}
+	if run := state.Runs["module-beads"]; run.Status != module.StatusFailed {
+		t.Fatalf("expected module-beads to be recorded as failed, got %+v", run)
+	}
+}
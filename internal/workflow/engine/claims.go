@@ -14,6 +14,8 @@ type ClaimRequest struct {
 	// Modules restricts claims to a subset of runnable module IDs. When empty,
 	// every runnable module is eligible.
 	Modules []string
+	// Actor names who claimed the modules in the audit trail.
+	Actor string
 }
 
 // WorkClaim describes a runnable module that has been reserved for execution.
@@ -78,9 +80,57 @@ func (e *Engine) Claim(ctx *module.ModuleContext, req ClaimRequest) (ClaimResult
 	if err := e.repo.Save(state); err != nil {
 		return ClaimResult{}, err
 	}
+	e.recordAudit(ctx, "claim", req.Actor, current, state, nil)
 	return ClaimResult{Claims: claims, State: state}, nil
 }
 
+// refinementModuleID is the registry ID auto_refine claims.
+const refinementModuleID = "refinement"
+
+// AutoRefineTargets returns the runnable refinement instances in state when
+// its workflow sets runtime.auto_refine. Refinement only becomes runnable
+// while the .refinement-needed marker exists.
+func AutoRefineTargets(state State) []string {
+	if !state.Definition.Runtime.AutoRefine {
+		return nil
+	}
+	var ids []string
+	for _, ref := range state.Definition.Modules {
+		if ref.ModuleID != refinementModuleID {
+			continue
+		}
+		id := ref.InstanceID()
+		if containsID(state.Runnable, id) && !containsID(state.Runtime.Running, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ClaimAutoRefine refreshes the engine and claims any refinement module that
+// auto_refine makes eligible. The result carries no claims when the flag is
+// off or refinement is not runnable.
+func (e *Engine) ClaimAutoRefine(ctx *module.ModuleContext, runtime *RuntimeOverrides) (ClaimResult, error) {
+	state, err := e.Update(ctx, UpdateRequest{Runtime: runtime, Actor: "auto_refine"})
+	if err != nil {
+		return ClaimResult{}, err
+	}
+	targets := AutoRefineTargets(state)
+	if len(targets) == 0 {
+		return ClaimResult{State: state}, nil
+	}
+	return e.Claim(ctx, ClaimRequest{Runtime: runtime, Modules: targets, Actor: "auto_refine"})
+}
+
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
 func findModuleStatus(nodes []ModuleStatus, id string) (ModuleStatus, bool) {
 	for _, node := range nodes {
 		if node.ID == id {
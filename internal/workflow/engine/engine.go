@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -53,11 +54,16 @@ func New(registry *module.Registry, repo StateStore, opts ...Option) (*Engine, e
 type StartRequest struct {
 	Definition workflow.WorkflowDefinition
 	Runtime    *RuntimeOverrides
+	// Actor names who started the run in the audit trail.
+	Actor string
 }
 
 // ResumeRequest refreshes persistent state after process restarts.
 type ResumeRequest struct {
 	Runtime *RuntimeOverrides
+	// Actor names who asked for the change in the audit trail; empty records
+	// "engine".
+	Actor string
 }
 
 // ModuleStatusUpdate informs the engine that a module finished running.
@@ -66,15 +72,30 @@ type ModuleStatusUpdate struct {
 	Result     module.Result
 	Err        error
 	FinishedAt time.Time
+	// LogPath points at the run's captured subprocess output, if any.
+	LogPath string
+	// Attempts counts the runs a retry policy made; zero without a policy.
+	Attempts int
 }
 
 // UpdateRequest applies runtime overrides and module result updates.
 type UpdateRequest struct {
 	Runtime *RuntimeOverrides
 	Results []ModuleStatusUpdate
+	// Actor names who asked for the change in the audit trail.
+	Actor string
 }
 
-// Start evaluates a workflow definition from scratch.
+// UnskipRequest returns operator-skipped modules to the run.
+type UnskipRequest struct {
+	IDs []string
+	// Actor names who asked for the change in the audit trail.
+	Actor string
+}
+
+// Start evaluates a workflow definition from scratch. A definition with a
+// dependency cycle returns an EngineStatusError state alongside an error
+// wrapping resolver.ErrCycle; nothing is persisted for it.
 func (e *Engine) Start(ctx *module.ModuleContext, req StartRequest) (State, error) {
 	if ctx == nil {
 		return State{}, fmt.Errorf("workflow engine: module context is required")
@@ -84,8 +105,21 @@ func (e *Engine) Start(ctx *module.ModuleContext, req StartRequest) (State, erro
 		return State{}, err
 	}
 	runtime := applyRuntimeOverrides(EngineRuntime{}, req.Runtime)
+	runtime = seedManualGates(normalized, runtime)
 	state, err := e.buildState(ctx, normalized, runtime, nil)
 	if err != nil {
+		if errors.Is(err, resolver.ErrCycle) {
+			// A cyclic definition can never make progress; report it as an
+			// errored run instead of persisting a state that stays blocked.
+			return State{
+				WorkflowID:   normalized.ID,
+				Definition:   normalized.Clone(),
+				Runtime:      runtime.clone(),
+				Status:       EngineStatusError,
+				StatusReason: err.Error(),
+				UpdatedAt:    e.now(),
+			}, err
+		}
 		return State{}, err
 	}
 	now := e.now()
@@ -95,6 +129,7 @@ func (e *Engine) Start(ctx *module.ModuleContext, req StartRequest) (State, erro
 	if err := e.repo.Save(state); err != nil {
 		return State{}, err
 	}
+	e.recordAudit(ctx, "start", req.Actor, State{}, state, nil)
 	return state, nil
 }
 
@@ -118,6 +153,7 @@ func (e *Engine) Resume(ctx *module.ModuleContext, req ResumeRequest) (State, er
 	if err := e.repo.Save(state); err != nil {
 		return State{}, err
 	}
+	e.recordAudit(ctx, "resume", req.Actor, current, state, nil)
 	return state, nil
 }
 
@@ -143,37 +179,85 @@ func (e *Engine) Update(ctx *module.ModuleContext, req UpdateRequest) (State, er
 	if err := e.repo.Save(state); err != nil {
 		return State{}, err
 	}
+	e.recordAudit(ctx, "update", req.Actor, current, state, req.Results)
 	return state, nil
 }
 
+// Unskip removes the requested modules from the run's skipped list and, when
+// the run has explicit targets, targets them again. Dependents that were only
+// auto-skipped because of them become schedulable on the same refresh. IDs
+// that are not skipped are ignored.
+func (e *Engine) Unskip(ctx *module.ModuleContext, req UnskipRequest) (State, error) {
+	current, err := e.repo.Load()
+	if err != nil {
+		return State{}, err
+	}
+	requested := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		requested[strings.TrimSpace(id)] = true
+	}
+	var drop []string
+	for _, id := range current.Runtime.SkippedModules {
+		if requested[id] {
+			drop = append(drop, id)
+		}
+	}
+	skipped := stripIDs(cloneStrings(current.Runtime.SkippedModules), drop)
+	if skipped == nil {
+		skipped = []string{}
+	}
+	overrides := &RuntimeOverrides{SkippedModules: &skipped}
+	if len(current.Runtime.Targets) > 0 {
+		targets := retarget(current.Definition, current.Runtime.Targets, drop)
+		overrides.Targets = &targets
+	}
+	return e.Update(ctx, UpdateRequest{Runtime: overrides, Actor: req.Actor})
+}
+
 // View returns the last persisted snapshot without recomputing resolver state.
 func (e *Engine) View() (State, error) {
 	return e.repo.Load()
 }
 
+// ResolvedDefinition returns the resolved definition of the persisted run,
+// suitable for StartRequest.Definition when reproducing its graph and limits.
+func (e *Engine) ResolvedDefinition() (workflow.WorkflowDefinition, error) {
+	state, err := e.repo.Load()
+	if err != nil {
+		return workflow.WorkflowDefinition{}, err
+	}
+	return state.Resolved.Clone(), nil
+}
+
 func (e *Engine) buildState(ctx *module.ModuleContext, def workflow.WorkflowDefinition, runtime EngineRuntime, runs map[string]ModuleRun) (State, error) {
 	runtime = applyWorkflowRuntime(def, runtime)
 	res, err := resolver.New(def, e.registry)
 	if err != nil {
 		return State{}, err
 	}
+	res.SetSkipped(runtime.SkippedModules...)
 	if err := res.Refresh(ctx); err != nil {
 		return State{}, err
 	}
+	nodes := summarizeNodes(res, runs)
+	// Prune finished modules before scheduling so a completed exclusive
+	// module no longer holds the whole workflow.
+	runtime.Running = dropCompletedRunning(runtime.Running, nodes)
 	sched, err := scheduler.New(res)
 	if err != nil {
 		return State{}, err
 	}
-	batch, err := sched.Runnable(runtime.schedulerRequest())
+	req := runtime.schedulerRequest()
+	req.ClassLimits = def.Runtime.ClassLimits
+	batch, err := sched.Runnable(req)
 	if err != nil {
 		return State{}, err
 	}
-	nodes := summarizeNodes(res, runs)
-	runtime.Running = dropCompletedRunning(runtime.Running, nodes)
 	status, reason := deriveEngineStatus(nodes, runtime, runs)
 	state := State{
 		WorkflowID:   def.ID,
 		Definition:   def.Clone(),
+		Resolved:     resolvedDefinition(def, runtime),
 		Runtime:      runtime.clone(),
 		Nodes:        nodes,
 		Runnable:     runnableIDs(batch.Nodes),
@@ -202,6 +286,11 @@ func summarizeNodes(res *resolver.Resolver, runs map[string]ModuleRun) []ModuleS
 			Dependencies: cloneStrings(node.Dependencies),
 			Dependents:   cloneStrings(node.Dependents),
 			BlockedBy:    cloneStrings(node.BlockedBy),
+			SatisfiedBy:  cloneStrings(node.SatisfiedBy),
+			SkipReason:   node.SkipReason,
+		}
+		for _, group := range node.AnyOf {
+			status.AnyOf = append(status.AnyOf, cloneStrings(group))
 		}
 		if node.Err != nil {
 			status.Error = node.Err.Error()
@@ -321,6 +410,8 @@ func mergeRuns(existing map[string]ModuleRun, updates []ModuleStatusUpdate, cloc
 			Message:    update.Result.Message,
 			Error:      errorString(update.Err),
 			FinishedAt: finished,
+			LogPath:    update.LogPath,
+			Attempts:   update.Attempts,
 		}
 		result[update.ID] = record
 	}
@@ -346,6 +437,9 @@ func applyRuntimeOverrides(base EngineRuntime, overrides *RuntimeOverrides) Engi
 	if overrides.ManualGates != nil {
 		base.ManualGates = cloneManualGates(*overrides.ManualGates)
 	}
+	if overrides.SkippedModules != nil {
+		base.SkippedModules = cloneStrings(*overrides.SkippedModules)
+	}
 	return base
 }
 
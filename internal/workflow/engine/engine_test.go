@@ -2,13 +2,17 @@ package engine
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/logbook"
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 	"github.com/kingrea/The-Lattice/internal/workflow/resolver"
@@ -37,6 +41,76 @@ func TestEngineStartPersistsState(t *testing.T) {
 	}
 }
 
+func TestEngineStartReportsDependencyCycle(t *testing.T) {
+	eng, repo, ctx, _, def := newEngineHarness(t)
+	def.Modules[0].DependsOn = []string{"module-deploy"}
+	state, err := eng.Start(ctx, StartRequest{Definition: def})
+	if !errors.Is(err, resolver.ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %v", err)
+	}
+	if state.Status != EngineStatusError {
+		t.Fatalf("expected error status, got %s", state.Status)
+	}
+	if !strings.Contains(state.StatusReason, "anchor-plan -> module-deploy -> module-build -> anchor-plan") {
+		t.Fatalf("status reason should name the cycle, got %q", state.StatusReason)
+	}
+	if _, err := repo.Load(); !errors.Is(err, ErrStateNotFound) {
+		t.Fatalf("cyclic start should not persist state, got %v", err)
+	}
+}
+
+func TestEnginePersistsResolvedDefinition(t *testing.T) {
+	eng, _, ctx, stubs, _ := newEngineHarness(t)
+	stubs["plan"].setComplete(false)
+	def, err := workflow.ParseDefinitionYAML([]byte(`
+id: test-workflow
+runtime:
+  max_parallel: 3
+modules:
+  - id: anchor-plan
+    module: plan
+    config:
+      depth: shallow
+  - id: module-build
+    module: build
+    depends_on: [anchor-plan]
+  - id: module-deploy
+    module: deploy
+    depends_on: [module-build]
+`))
+	if err != nil {
+		t.Fatalf("parse definition: %v", err)
+	}
+	maxParallel := 1
+	state, err := eng.Start(ctx, StartRequest{Definition: def, Runtime: &RuntimeOverrides{MaxParallel: &maxParallel}})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if state.Definition.Runtime.MaxParallel != 3 {
+		t.Fatalf("input definition should keep its own runtime, got %d", state.Definition.Runtime.MaxParallel)
+	}
+	resolved, err := eng.ResolvedDefinition()
+	if err != nil {
+		t.Fatalf("resolved definition: %v", err)
+	}
+	if resolved.ID != "test-workflow" || resolved.Runtime.MaxParallel != 1 {
+		t.Fatalf("expected the max_parallel override in the resolved definition, got %+v", resolved.Runtime)
+	}
+	if deps := resolved.Dependencies("module-deploy"); len(deps) != 1 || deps[0] != "module-build" {
+		t.Fatalf("expected depends_on merged into the resolved graph, got %v", resolved.Graph)
+	}
+	if got := resolved.Modules[0].Config["depth"]; got != "shallow" {
+		t.Fatalf("expected module config preserved, got %v", got)
+	}
+	replay, err := eng.Start(ctx, StartRequest{Definition: resolved})
+	if err != nil {
+		t.Fatalf("start from resolved definition: %v", err)
+	}
+	if replay.Runtime.MaxParallel != 1 || len(replay.Runnable) != 1 || replay.Runnable[0] != state.Runnable[0] {
+		t.Fatalf("replay diverged: runtime=%+v runnable=%v", replay.Runtime, replay.Runnable)
+	}
+}
+
 func TestEngineResumeRefreshesCompletion(t *testing.T) {
 	eng, _, ctx, stubs, def := newEngineHarness(t)
 	stubs["plan"].setComplete(false)
@@ -223,6 +297,137 @@ func TestEngineClaimAndReleaseRespectsParallelism(t *testing.T) {
 	}
 }
 
+func TestEngineClaimNeverCoSchedulesExclusiveModules(t *testing.T) {
+	for _, exclusiveFirst := range []bool{true, false} {
+		t.Run(fmt.Sprintf("exclusive-first=%t", exclusiveFirst), func(t *testing.T) {
+			const exclusiveID = "module-release"
+			build := workflow.ModuleRef{ID: "module-build", ModuleID: "build", DependsOn: []string{"anchor-plan"}}
+			release := workflow.ModuleRef{ID: exclusiveID, ModuleID: "release", DependsOn: []string{"anchor-plan"}}
+			modules := []workflow.ModuleRef{{ID: "anchor-plan", ModuleID: "plan"}, build, release}
+			if exclusiveFirst {
+				modules = []workflow.ModuleRef{{ID: "anchor-plan", ModuleID: "plan"}, release, build}
+			}
+			ctx := newTestModuleContext(t)
+			def := workflow.WorkflowDefinition{ID: "exclusive-workflow", Modules: modules}
+			stubs := map[string]*stubModule{
+				"plan":    newStubModule("plan"),
+				"build":   newStubModule("build"),
+				"release": newStubModule("release"),
+			}
+			stubs["plan"].setComplete(true)
+			stubs["release"].info.Concurrency = module.ConcurrencyProfile{Exclusive: true}
+			eng, _ := newCustomEngine(t, ctx, def, stubs)
+			if _, err := eng.Start(ctx, StartRequest{Definition: def}); err != nil {
+				t.Fatalf("start: %v", err)
+			}
+			first, err := eng.Claim(ctx, ClaimRequest{})
+			if err != nil {
+				t.Fatalf("claim: %v", err)
+			}
+			if len(first.Claims) != 1 {
+				t.Fatalf("expected a single claim beside an exclusive module, got %+v", first.Claims)
+			}
+			other := "module-build"
+			if first.Claims[0].ID == other {
+				other = exclusiveID
+			}
+			second, err := eng.Claim(ctx, ClaimRequest{Modules: []string{other}})
+			if err != nil {
+				t.Fatalf("claim %s: %v", other, err)
+			}
+			if len(second.Claims) != 0 {
+				t.Fatalf("co-scheduled %s with %s", other, first.Claims[0].ID)
+			}
+			if first.Claims[0].ID != exclusiveID {
+				return
+			}
+			// Once the exclusive module's outputs exist, the slot frees up
+			// even before its result is reported.
+			stubs["release"].setComplete(true)
+			third, err := eng.Claim(ctx, ClaimRequest{Modules: []string{other}})
+			if err != nil {
+				t.Fatalf("claim after exclusive finished: %v", err)
+			}
+			if len(third.Claims) != 1 {
+				t.Fatalf("expected %s to be claimable once the exclusive module finished, got %+v", other, third.Claims)
+			}
+		})
+	}
+}
+
+func TestEngineClaimHonorsConcurrencyClassLimits(t *testing.T) {
+	ctx := newTestModuleContext(t)
+	def := workflow.WorkflowDefinition{
+		ID: "class-workflow",
+		Modules: []workflow.ModuleRef{
+			{ID: "anchor-plan", ModuleID: "plan"},
+			{ID: "module-release", ModuleID: "release", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-beads", ModuleID: "beads", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-sync", ModuleID: "sync", DependsOn: []string{"anchor-plan"}},
+		},
+		Runtime: workflow.WorkflowRuntimeConfig{ClassLimits: map[string]int{"bd": 1}},
+	}
+	stubs := map[string]*stubModule{
+		"plan":    newStubModule("plan"),
+		"release": newStubModule("release"),
+		"beads":   newStubModule("beads"),
+		"sync":    newStubModule("sync"),
+	}
+	stubs["plan"].setComplete(true)
+	stubs["release"].info.Concurrency = module.ConcurrencyProfile{Exclusive: true}
+	stubs["beads"].info.Concurrency = module.ConcurrencyProfile{Class: "bd"}
+	stubs["sync"].info.Concurrency = module.ConcurrencyProfile{Class: "bd"}
+	eng, _ := newCustomEngine(t, ctx, def, stubs)
+	if _, err := eng.Start(ctx, StartRequest{Definition: def}); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	claim := func() []WorkClaim {
+		t.Helper()
+		result, err := eng.Claim(ctx, ClaimRequest{})
+		if err != nil {
+			t.Fatalf("claim: %v", err)
+		}
+		return result.Claims
+	}
+
+	first := claim()
+	if len(first) != 1 || first[0].ID != "module-release" {
+		t.Fatalf("expected the exclusive module alone, got %+v", first)
+	}
+	if blocked := claim(); len(blocked) != 0 {
+		t.Fatalf("claimed %+v beside an exclusive module", blocked)
+	}
+	stubs["release"].setComplete(true)
+
+	second := claim()
+	if len(second) != 1 || second[0].Concurrency.Class != "bd" {
+		t.Fatalf("expected one bd-class claim, got %+v", second)
+	}
+	done, other := "beads", "module-sync"
+	if second[0].ID == other {
+		done, other = "sync", "module-beads"
+	}
+	if blocked := claim(); len(blocked) != 0 {
+		t.Fatalf("claimed %+v past the bd class limit", blocked)
+	}
+	state, err := eng.View()
+	if err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	if skip := state.Skipped[other]; skip.Reason != scheduler.SkipReasonConcurrency {
+		t.Fatalf("expected %s held for concurrency, got %+v", other, skip)
+	}
+	if _, err := eng.Update(ctx, UpdateRequest{Results: []ModuleStatusUpdate{{ID: second[0].ID, Result: module.Result{Status: module.StatusCompleted}}}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	stubs[done].setComplete(true)
+
+	third := claim()
+	if len(third) != 1 || third[0].ID != other {
+		t.Fatalf("expected %s once the class slot freed, got %+v", other, third)
+	}
+}
+
 func TestEngineClaimFiltersRequestedModules(t *testing.T) {
 	ctx := newTestModuleContext(t)
 	def := workflow.WorkflowDefinition{
@@ -265,6 +470,60 @@ func TestEngineClaimFiltersRequestedModules(t *testing.T) {
 	}
 }
 
+func TestEngineAutoRefineClaimsRefinementWhenMarkerPresent(t *testing.T) {
+	for _, autoRefine := range []bool{true, false} {
+		ctx := newTestModuleContext(t)
+		def := workflow.WorkflowDefinition{
+			ID:      "refine-workflow",
+			Runtime: workflow.WorkflowRuntimeConfig{AutoRefine: autoRefine},
+			Modules: []workflow.ModuleRef{
+				{ID: "work-process", ModuleID: "work"},
+				{ID: "refinement", ModuleID: "refinement", DependsOn: []string{"work-process"}},
+			},
+		}
+		stubs := map[string]*stubModule{
+			"work":       newStubModule("work"),
+			"refinement": newStubModule("refinement"),
+		}
+		stubs["work"].setComplete(true)
+		// Without the marker refinement reports complete.
+		stubs["refinement"].setComplete(true)
+		eng, _ := newCustomEngine(t, ctx, def, stubs)
+		if _, err := eng.Start(ctx, StartRequest{Definition: def}); err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		claim, err := eng.ClaimAutoRefine(ctx, nil)
+		if err != nil {
+			t.Fatalf("claim without marker: %v", err)
+		}
+		if len(claim.Claims) != 0 {
+			t.Fatalf("auto_refine=%v: refinement claimed without the marker: %+v", autoRefine, claim.Claims)
+		}
+
+		// work-process leaves .refinement-needed behind.
+		stubs["refinement"].setComplete(false)
+		claim, err = eng.ClaimAutoRefine(ctx, nil)
+		if err != nil {
+			t.Fatalf("claim with marker: %v", err)
+		}
+		if !autoRefine {
+			if len(claim.Claims) != 0 || len(claim.State.Runtime.Running) != 0 {
+				t.Fatalf("refinement should wait for an operator, got claims %+v", claim.Claims)
+			}
+			continue
+		}
+		if len(claim.Claims) != 1 || claim.Claims[0].ID != "refinement" {
+			t.Fatalf("expected refinement to be auto-claimed, got %+v", claim.Claims)
+		}
+		if len(claim.State.Runtime.Running) != 1 || claim.State.Runtime.Running[0] != "refinement" {
+			t.Fatalf("expected refinement running, got %+v", claim.State.Runtime.Running)
+		}
+		if targets := AutoRefineTargets(claim.State); len(targets) != 0 {
+			t.Fatalf("running refinement should not be claimed again, got %v", targets)
+		}
+	}
+}
+
 func TestEngineManualGateRequiresApproval(t *testing.T) {
 	eng, _, ctx, stubs, def := newEngineHarness(t)
 	stubs["plan"].setComplete(true)
@@ -297,6 +556,145 @@ func TestEngineManualGateRequiresApproval(t *testing.T) {
 	}
 }
 
+func TestEngineStartSeedsRequireApprovalGates(t *testing.T) {
+	eng, _, ctx, stubs, _ := newEngineHarness(t)
+	stubs["plan"].setComplete(true)
+	def, err := workflow.ParseDefinitionYAML([]byte(`
+id: gated-workflow
+modules:
+  - id: anchor-plan
+    module: plan
+  - id: module-build
+    module: build
+    depends_on: [anchor-plan]
+    require_approval: true
+`))
+	if err != nil {
+		t.Fatalf("parse definition: %v", err)
+	}
+	state, err := eng.Start(ctx, StartRequest{Definition: def})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	gate, ok := state.Runtime.ManualGates["module-build"]
+	if !ok || !gate.Required || gate.Approved {
+		t.Fatalf("expected build gated from the definition, got %+v", state.Runtime.ManualGates)
+	}
+	if len(state.Runnable) != 0 {
+		t.Fatalf("expected no runnable modules while gate pending, got %+v", state.Runnable)
+	}
+	if reason, ok := state.Skipped["module-build"]; !ok || reason.Reason != scheduler.SkipReasonManualGate {
+		t.Fatalf("expected manual gate skip, got %+v", state.Skipped)
+	}
+
+	approved := map[string]scheduler.ManualGateState{
+		"module-build": {Required: true, Approved: true},
+	}
+	state, err = eng.Start(ctx, StartRequest{Definition: def, Runtime: &RuntimeOverrides{ManualGates: &approved}})
+	if err != nil {
+		t.Fatalf("restart: %v", err)
+	}
+	if len(state.Runnable) != 1 || state.Runnable[0] != "module-build" {
+		t.Fatalf("start override should take precedence over the definition, got %+v", state.Runnable)
+	}
+}
+
+func TestEngineManualGateNotePersistsAcrossResume(t *testing.T) {
+	eng, repo, ctx, stubs, def := newEngineHarness(t)
+	stubs["plan"].setComplete(true)
+	gates := map[string]scheduler.ManualGateState{
+		"module-build": {Required: true, Approved: true, Note: "approved by ops"},
+	}
+	if _, err := eng.Start(ctx, StartRequest{Definition: def, Runtime: &RuntimeOverrides{ManualGates: &gates}}); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	reg := module.NewRegistry()
+	for id, stub := range stubs {
+		stub := stub
+		reg.MustRegister(id, func(module.Config) (module.Module, error) {
+			return stub, nil
+		})
+	}
+	restarted, err := New(reg, NewRepository(ctx.Workflow))
+	if err != nil {
+		t.Fatalf("new engine: %v", err)
+	}
+	state, err := restarted.Resume(ctx, ResumeRequest{})
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if got := state.Runtime.ManualGates["module-build"]; !got.Equal(gates["module-build"]) {
+		t.Fatalf("expected gate to round-trip, got %+v", got)
+	}
+	stored, err := repo.Load()
+	if err != nil {
+		t.Fatalf("load repo: %v", err)
+	}
+	if got := stored.Runtime.ManualGates["module-build"]; got.Note != "approved by ops" {
+		t.Fatalf("persisted gate note mismatch: %+v", got)
+	}
+}
+
+func TestEngineSkipPropagatesToSoleDependents(t *testing.T) {
+	eng, _, ctx, stubs, def := newEngineHarness(t)
+	stubs["plan"].setComplete(true)
+	skipped := []string{"module-build"}
+	state, err := eng.Start(ctx, StartRequest{Definition: def, Runtime: &RuntimeOverrides{SkippedModules: &skipped}})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if len(state.Runnable) != 0 {
+		t.Fatalf("expected nothing runnable once build is skipped, got %+v", state.Runnable)
+	}
+	build := findModule(state, "module-build")
+	if build.State != resolver.NodeStateSkipped || build.SkipReason != "skipped by operator" {
+		t.Fatalf("expected build skipped by operator, got %+v", build)
+	}
+	deploy := findModule(state, "module-deploy")
+	if deploy.State != resolver.NodeStateSkipped {
+		t.Fatalf("expected deploy auto-skipped, got %s", deploy.State)
+	}
+	reason, ok := state.Skipped["module-deploy"]
+	if !ok || reason.Reason != scheduler.SkipReasonSkipped || reason.Detail != "depends on skipped module module-build" {
+		t.Fatalf("expected propagated skip reason, got %+v", state.Skipped)
+	}
+	if state.Status != EngineStatusComplete {
+		t.Fatalf("expected skipped modules not to block completion, got %s (%s)", state.Status, state.StatusReason)
+	}
+	if len(state.Runtime.SkippedModules) != 1 || state.Runtime.SkippedModules[0] != "module-build" {
+		t.Fatalf("expected skipped modules persisted, got %+v", state.Runtime.SkippedModules)
+	}
+}
+
+func TestEngineUnskipRestoresModuleAndDependents(t *testing.T) {
+	eng, _, ctx, stubs, def := newEngineHarness(t)
+	stubs["plan"].setComplete(true)
+	skipped := []string{"module-build"}
+	targets := []string{"anchor-plan", "module-deploy"}
+	if _, err := eng.Start(ctx, StartRequest{Definition: def, Runtime: &RuntimeOverrides{SkippedModules: &skipped, Targets: &targets}}); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	state, err := eng.Unskip(ctx, UnskipRequest{IDs: []string{"module-build", "anchor-plan"}, Actor: "operator"})
+	if err != nil {
+		t.Fatalf("unskip: %v", err)
+	}
+	if len(state.Runtime.SkippedModules) != 0 {
+		t.Fatalf("expected no skipped modules, got %+v", state.Runtime.SkippedModules)
+	}
+	if got := strings.Join(state.Runtime.Targets, ","); got != "anchor-plan,module-build,module-deploy" {
+		t.Fatalf("expected build targeted again in definition order, got %s", got)
+	}
+	if build := findModule(state, "module-build"); build.State == resolver.NodeStateSkipped {
+		t.Fatalf("expected build back in the run, got %+v", build)
+	}
+	if deploy := findModule(state, "module-deploy"); deploy.State == resolver.NodeStateSkipped {
+		t.Fatalf("expected deploy no longer auto-skipped, got %+v", deploy)
+	}
+	if len(state.Runnable) != 1 || state.Runnable[0] != "module-build" {
+		t.Fatalf("expected build runnable again, got %+v", state.Runnable)
+	}
+}
+
 func TestEngineResumeHonorsTargetOverrides(t *testing.T) {
 	eng, repo, ctx, stubs, def := newEngineHarness(t)
 	stubs["plan"].setComplete(true)
@@ -333,6 +731,82 @@ func TestEngineResumeHonorsTargetOverrides(t *testing.T) {
 	}
 }
 
+func TestWorkflowRepositoriesResumeIndependently(t *testing.T) {
+	ctx := newTestModuleContext(t)
+	stubs := map[string]*stubModule{
+		"plan":  newStubModule("plan"),
+		"build": newStubModule("build"),
+	}
+	defs := []workflow.WorkflowDefinition{
+		{ID: "commission-work", Modules: []workflow.ModuleRef{{ID: "anchor-plan", ModuleID: "plan"}}},
+		{ID: "custom-flow", Modules: []workflow.ModuleRef{{ID: "module-build", ModuleID: "build"}}},
+	}
+	runIDs := map[string]string{}
+	for _, def := range defs {
+		eng := newNamespacedEngine(t, ctx, def.ID, stubs)
+		state, err := eng.Start(ctx, StartRequest{Definition: def})
+		if err != nil {
+			t.Fatalf("start %s: %v", def.ID, err)
+		}
+		runIDs[def.ID] = state.RunID
+	}
+	for _, def := range defs {
+		eng := newNamespacedEngine(t, ctx, def.ID, stubs)
+		state, err := eng.Resume(ctx, ResumeRequest{})
+		if err != nil {
+			t.Fatalf("resume %s: %v", def.ID, err)
+		}
+		if state.WorkflowID != def.ID {
+			t.Fatalf("expected workflow %s, got %s", def.ID, state.WorkflowID)
+		}
+		if state.RunID != runIDs[def.ID] {
+			t.Fatalf("run id for %s changed: %s vs %s", def.ID, state.RunID, runIDs[def.ID])
+		}
+		if len(state.Nodes) != 1 || state.Nodes[0].ID != def.Modules[0].ID {
+			t.Fatalf("unexpected nodes for %s: %+v", def.ID, state.Nodes)
+		}
+		archived, err := NewWorkflowRepository(ctx.Workflow, def.ID).LoadRun(state.RunID)
+		if err != nil {
+			t.Fatalf("load archived run %s: %v", state.RunID, err)
+		}
+		if archived.WorkflowID != def.ID {
+			t.Fatalf("archived run mismatch: %+v", archived)
+		}
+	}
+}
+
+func TestWorkflowRepositoryAdoptsLegacyState(t *testing.T) {
+	ctx := newTestModuleContext(t)
+	legacy := NewRepository(ctx.Workflow)
+	if err := legacy.Save(State{RunID: "commission-work-1", WorkflowID: "commission-work"}); err != nil {
+		t.Fatalf("save legacy: %v", err)
+	}
+	state, err := NewWorkflowRepository(ctx.Workflow, "commission-work").Load()
+	if err != nil || state.RunID != "commission-work-1" {
+		t.Fatalf("expected legacy state adoption, got %+v (%v)", state, err)
+	}
+	if _, err := NewWorkflowRepository(ctx.Workflow, "solo").Load(); !errors.Is(err, ErrStateNotFound) {
+		t.Fatalf("expected other workflows to ignore legacy state, got %v", err)
+	}
+}
+
+func newNamespacedEngine(t *testing.T, ctx *module.ModuleContext, workflowID string, stubs map[string]*stubModule) *Engine {
+	t.Helper()
+	reg := module.NewRegistry()
+	for id, stub := range stubs {
+		stub := stub
+		reg.MustRegister(id, func(module.Config) (module.Module, error) {
+			return stub, nil
+		})
+	}
+	clock := &testClock{value: time.Unix(0, 0)}
+	eng, err := New(reg, NewWorkflowRepository(ctx.Workflow, workflowID), WithClock(clock.Now))
+	if err != nil {
+		t.Fatalf("new engine: %v", err)
+	}
+	return eng
+}
+
 func findModule(state State, id string) ModuleStatus {
 	for _, mod := range state.Nodes {
 		if mod.ID == id {
@@ -414,6 +888,7 @@ func newTestModuleContext(t *testing.T) *module.ModuleContext {
 }
 
 type stubModule struct {
+	mu       sync.Mutex
 	info     module.Info
 	complete bool
 	err      error
@@ -447,6 +922,8 @@ func (m *stubModule) IsComplete(*module.ModuleContext) (bool, error) {
 	if m.err != nil {
 		return false, m.err
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.complete, nil
 }
 
@@ -455,6 +932,8 @@ func (m *stubModule) Run(*module.ModuleContext) (module.Result, error) {
 }
 
 func (m *stubModule) setComplete(value bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.complete = value
 }
 
@@ -474,3 +953,93 @@ func writeArtifact(t *testing.T, ctx *module.ModuleContext, ref artifact.Artifac
 		t.Fatalf("write artifact: %v", err)
 	}
 }
+
+func TestEngineAuditTrailRecordsTransitionsInOrder(t *testing.T) {
+	eng, _, ctx, stubs, def := newEngineHarness(t)
+	gates := map[string]scheduler.ManualGateState{"module-deploy": {Required: true, Note: "release window"}}
+	if _, err := eng.Start(ctx, StartRequest{Definition: def, Runtime: &RuntimeOverrides{ManualGates: &gates}, Actor: "alice"}); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if _, err := eng.Claim(ctx, ClaimRequest{Actor: "worker-1"}); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	stubs["plan"].setComplete(true)
+	if _, err := eng.Update(ctx, UpdateRequest{Actor: "worker-1", Results: []ModuleStatusUpdate{{
+		ID:     "anchor-plan",
+		Result: module.Result{Status: module.StatusCompleted, Message: "plan written"},
+	}}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	approved := map[string]scheduler.ManualGateState{"module-deploy": {Required: true, Approved: true, Note: "approved by ops"}}
+	if _, err := eng.Resume(ctx, ResumeRequest{Runtime: &RuntimeOverrides{ManualGates: &approved}}); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+
+	entries, err := ReadAuditLog(ctx)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, fmt.Sprintf("%s/%s %s %s->%s by %s: %s", entry.Operation, entry.Event, entry.Module, entry.From, entry.To, entry.Actor, entry.Reason))
+	}
+	want := []string{
+		"start/start  ->running by alice: ",
+		"start/gate module-deploy ->pending by alice: release window",
+		"start/state anchor-plan ->ready by alice: ",
+		"start/state module-build ->blocked by alice: waiting on anchor-plan",
+		"start/state module-deploy ->blocked by alice: waiting on module-build",
+		"claim/claim anchor-plan ->running by worker-1: ",
+		"update/result anchor-plan ->completed by worker-1: plan written",
+		"update/release anchor-plan running-> by worker-1: ",
+		"update/state anchor-plan ready->complete by worker-1: ",
+		"update/state module-build blocked->ready by worker-1: ",
+		"resume/gate module-deploy pending->approved by engine: approved by ops",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected audit trail:\n%s", strings.Join(got, "\n"))
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Time.Before(entries[i-1].Time) || entries[i].RunID != entries[0].RunID {
+			t.Fatalf("audit entries out of order or mixed runs: %+v", entries)
+		}
+	}
+}
+
+func TestEngineClaimSurvivesAuditWriteFailure(t *testing.T) {
+	eng, repo, ctx, _, def := newEngineHarness(t)
+	book, err := logbook.New(filepath.Join(t.TempDir(), "lattice.log"))
+	if err != nil {
+		t.Fatalf("logbook: %v", err)
+	}
+	ctx.Logbook = book
+	if _, err := eng.Start(ctx, StartRequest{Definition: def}); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	// A directory in place of the audit file makes every append fail.
+	path := auditLogPath(ctx)
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove audit log: %v", err)
+	}
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatalf("block audit log: %v", err)
+	}
+	result, err := eng.Claim(ctx, ClaimRequest{})
+	if err != nil {
+		t.Fatalf("claim failed on an audit write error: %v", err)
+	}
+	if len(result.Claims) != 1 || result.Claims[0].ID != "anchor-plan" {
+		t.Fatalf("unexpected claims: %+v", result.Claims)
+	}
+	saved, err := repo.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(saved.Runtime.Running) != 1 || saved.Runtime.Running[0] != "anchor-plan" {
+		t.Fatalf("claim was not persisted: %+v", saved.Runtime.Running)
+	}
+	lines, _ := book.Tail(10)
+	if len(lines) == 0 || !strings.Contains(lines[len(lines)-1], "audit log") {
+		t.Fatalf("expected the audit failure in the logbook, got %v", lines)
+	}
+}
@@ -3,9 +3,12 @@ package engine
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/kingrea/The-Lattice/internal/workflow"
 )
@@ -21,17 +24,80 @@ type StateStore interface {
 
 // Repository stores engine state within the workflow directory.
 type Repository struct {
-	path string
+	path       string
+	runsDir    string
+	legacyPath string
+	workflowID string
 }
 
 // NewRepository creates a repository rooted at the workflow engine directory.
+// All workflows share a single state file; prefer NewWorkflowRepository when
+// several workflows need to keep resumable state side by side.
 func NewRepository(wf *workflow.Workflow) *Repository {
-	return &Repository{path: filepath.Join(wf.Dir(), "engine", "state.json")}
+	return &Repository{path: legacyStatePath(wf)}
+}
+
+// NewWorkflowRepository namespaces persisted state by workflow ID so multiple
+// workflows can coexist. The latest snapshot lives at
+// engine/<workflow>/state.json and every run is also archived under
+// engine/<workflow>/runs/<run-id>.json. State written by NewRepository is
+// adopted on first load when it belongs to the same workflow.
+func NewWorkflowRepository(wf *workflow.Workflow, workflowID string) *Repository {
+	namespace := sanitizeNamespace(workflowID)
+	dir := filepath.Join(wf.Dir(), "engine", namespace)
+	return &Repository{
+		path:       filepath.Join(dir, "state.json"),
+		runsDir:    filepath.Join(dir, "runs"),
+		legacyPath: legacyStatePath(wf),
+		workflowID: strings.TrimSpace(workflowID),
+	}
 }
 
 // Load reads the persisted state if present.
 func (r *Repository) Load() (State, error) {
-	data, err := os.ReadFile(r.path)
+	state, err := readState(r.path)
+	if !errors.Is(err, ErrStateNotFound) || r.legacyPath == "" {
+		return state, err
+	}
+	legacy, legacyErr := readState(r.legacyPath)
+	if legacyErr != nil {
+		if errors.Is(legacyErr, ErrStateNotFound) {
+			return State{}, ErrStateNotFound
+		}
+		return State{}, legacyErr
+	}
+	if legacy.WorkflowID != r.workflowID {
+		return State{}, ErrStateNotFound
+	}
+	return legacy, nil
+}
+
+// LoadRun reads an archived snapshot for a specific run identifier.
+func (r *Repository) LoadRun(runID string) (State, error) {
+	if r.runsDir == "" {
+		return State{}, fmt.Errorf("workflow engine: repository does not archive runs")
+	}
+	return readState(filepath.Join(r.runsDir, sanitizeNamespace(runID)+".json"))
+}
+
+// Save writes the engine state to disk with best-effort atomicity.
+func (r *Repository) Save(state State) error {
+	encoded, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	encoded = append(encoded, '\n')
+	if err := writeState(r.path, encoded); err != nil {
+		return err
+	}
+	if r.runsDir != "" && strings.TrimSpace(state.RunID) != "" {
+		return writeState(filepath.Join(r.runsDir, sanitizeNamespace(state.RunID)+".json"), encoded)
+	}
+	return nil
+}
+
+func readState(path string) (State, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return State{}, ErrStateNotFound
@@ -45,14 +111,24 @@ func (r *Repository) Load() (State, error) {
 	return state, nil
 }
 
-// Save writes the engine state to disk with best-effort atomicity.
-func (r *Repository) Save(state State) error {
-	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+func writeState(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
-	encoded, err := json.MarshalIndent(state, "", "  ")
-	if err != nil {
-		return err
+	return os.WriteFile(path, data, 0o644)
+}
+
+func legacyStatePath(wf *workflow.Workflow) string {
+	return filepath.Join(wf.Dir(), "engine", "state.json")
+}
+
+var namespacePattern = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func sanitizeNamespace(value string) string {
+	clean := namespacePattern.ReplaceAllString(strings.TrimSpace(value), "-")
+	clean = strings.Trim(clean, ".-")
+	if clean == "" {
+		return "default"
 	}
-	return os.WriteFile(r.path, append(encoded, '\n'), 0o644)
+	return clean
 }
@@ -0,0 +1,48 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+// RunWithRetry calls run until it returns a status policy does not retry or
+// policy.MaxAttempts is reached, waiting the policy's backoff between tries.
+// The claim stays held throughout, so only the final outcome reaches Update.
+// Cancelling ctx during a backoff returns the last failed attempt.
+func RunWithRetry(ctx context.Context, policy *workflow.RetryPolicy, run func(attempt int) ModuleStatusUpdate) ModuleStatusUpdate {
+	attempt := 1
+	for {
+		update := normalizeUpdate(run(attempt))
+		if policy == nil {
+			return update
+		}
+		update.Attempts = attempt
+		if attempt >= policy.MaxAttempts || !policy.Retries(string(update.Result.Status)) {
+			return update
+		}
+		if delay := policy.Delay(attempt); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return update
+			case <-timer.C:
+			}
+		} else if ctx.Err() != nil {
+			return update
+		}
+		attempt++
+	}
+}
+
+// retryPolicy returns the retry block declared for a module instance.
+func retryPolicy(def workflow.WorkflowDefinition, id string) *workflow.RetryPolicy {
+	for _, ref := range def.Modules {
+		if ref.InstanceID() == id {
+			return ref.Retry
+		}
+	}
+	return nil
+}
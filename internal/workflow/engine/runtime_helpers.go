@@ -6,6 +6,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/module"
 	"github.com/kingrea/The-Lattice/internal/workflow"
 	"github.com/kingrea/The-Lattice/internal/workflow/resolver"
+	"github.com/kingrea/The-Lattice/internal/workflow/scheduler"
 )
 
 func applyWorkflowRuntime(def workflow.WorkflowDefinition, runtime EngineRuntime) EngineRuntime {
@@ -15,6 +16,24 @@ func applyWorkflowRuntime(def workflow.WorkflowDefinition, runtime EngineRuntime
 	return runtime
 }
 
+// seedManualGates gates every module the definition marks require_approval.
+// Gates already present in the runtime, such as Start overrides, win.
+func seedManualGates(def workflow.WorkflowDefinition, runtime EngineRuntime) EngineRuntime {
+	runtime.ManualGates = scheduler.SeedManualGates(def, runtime.ManualGates)
+	return runtime
+}
+
+// resolvedDefinition folds the run's max_parallel override into the
+// normalized definition. It is the only runtime override with a definition
+// counterpart; targets, gates, and skips are persisted in EngineRuntime.
+func resolvedDefinition(def workflow.WorkflowDefinition, runtime EngineRuntime) workflow.WorkflowDefinition {
+	resolved := def.Clone()
+	if runtime.MaxParallel > 0 {
+		resolved.Runtime.MaxParallel = runtime.MaxParallel
+	}
+	return resolved
+}
+
 func releaseRunning(running []string, updates []ModuleStatusUpdate) []string {
 	if len(running) == 0 || len(updates) == 0 {
 		return running
@@ -126,6 +145,27 @@ func stripIDs(values []string, ids []string) []string {
 	return filtered
 }
 
+// retarget adds ids to targets, keeping the definition's module order.
+func retarget(def workflow.WorkflowDefinition, targets []string, ids []string) []string {
+	want := make(map[string]struct{}, len(targets)+len(ids))
+	for _, id := range append(append([]string(nil), targets...), ids...) {
+		want[id] = struct{}{}
+	}
+	out := make([]string, 0, len(want))
+	for _, id := range def.ModuleIDs() {
+		if _, ok := want[id]; ok {
+			out = append(out, id)
+			delete(want, id)
+		}
+	}
+	for _, id := range targets {
+		if _, ok := want[id]; ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
 func filterClaimable(runnable []string, requested []string) []string {
 	if len(runnable) == 0 {
 		return nil
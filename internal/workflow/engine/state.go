@@ -25,7 +25,13 @@ type State struct {
 	RunID      string                      `json:"run_id"`
 	WorkflowID string                      `json:"workflow_id"`
 	Definition workflow.WorkflowDefinition `json:"definition"`
-	Status     EngineStatus                `json:"status"`
+	// Resolved is the definition the run actually evaluated: dependencies
+	// merged into the graph and the max_parallel override applied. Targets,
+	// manual gates, and skipped modules have no definition counterpart and
+	// stay in Runtime, so starting a new run from Resolved reproduces this
+	// one's graph and limits, not those choices.
+	Resolved workflow.WorkflowDefinition `json:"resolved_definition"`
+	Status   EngineStatus                `json:"status"`
 	// StatusReason provides human readable explanation for non-running states.
 	StatusReason string                          `json:"status_reason,omitempty"`
 	Runtime      EngineRuntime                   `json:"runtime"`
@@ -43,15 +49,18 @@ type EngineRuntime struct {
 	MaxParallel int                                  `json:"max_parallel,omitempty"`
 	Running     []string                             `json:"running,omitempty"`
 	ManualGates map[string]scheduler.ManualGateState `json:"manual_gates,omitempty"`
+	// SkippedModules lists optional modules the operator chose not to run.
+	SkippedModules []string `json:"skipped_modules,omitempty"`
 }
 
 // RuntimeOverrides selectively mutates EngineRuntime fields.
 type RuntimeOverrides struct {
-	Targets     *[]string
-	BatchSize   *int
-	MaxParallel *int
-	Running     *[]string
-	ManualGates *map[string]scheduler.ManualGateState
+	Targets        *[]string
+	BatchSize      *int
+	MaxParallel    *int
+	Running        *[]string
+	ManualGates    *map[string]scheduler.ManualGateState
+	SkippedModules *[]string
 }
 
 // ModuleStatus exposes resolver metadata for a workflow node.
@@ -65,7 +74,10 @@ type ModuleStatus struct {
 	State        resolver.NodeState        `json:"state"`
 	Dependencies []string                  `json:"dependencies,omitempty"`
 	Dependents   []string                  `json:"dependents,omitempty"`
+	AnyOf        [][]string                `json:"any_of,omitempty"`
 	BlockedBy    []string                  `json:"blocked_by,omitempty"`
+	SatisfiedBy  []string                  `json:"satisfied_by,omitempty"`
+	SkipReason   string                    `json:"skip_reason,omitempty"`
 	Error        string                    `json:"error,omitempty"`
 	Artifacts    map[string]ArtifactStatus `json:"artifacts,omitempty"`
 	LastRun      *ModuleRun                `json:"last_run,omitempty"`
@@ -86,6 +98,8 @@ type ModuleRun struct {
 	Message    string        `json:"message,omitempty"`
 	Error      string        `json:"error,omitempty"`
 	FinishedAt time.Time     `json:"finished_at"`
+	LogPath    string        `json:"log_path,omitempty"`
+	Attempts   int           `json:"attempts,omitempty"`
 }
 
 // schedulerRequest converts EngineRuntime into a scheduler request payload.
@@ -121,10 +135,11 @@ func cloneManualGates(values map[string]scheduler.ManualGateState) map[string]sc
 
 func (rt EngineRuntime) clone() EngineRuntime {
 	return EngineRuntime{
-		Targets:     cloneStrings(rt.Targets),
-		BatchSize:   rt.BatchSize,
-		MaxParallel: rt.MaxParallel,
-		Running:     cloneStrings(rt.Running),
-		ManualGates: cloneManualGates(rt.ManualGates),
+		Targets:        cloneStrings(rt.Targets),
+		BatchSize:      rt.BatchSize,
+		MaxParallel:    rt.MaxParallel,
+		Running:        cloneStrings(rt.Running),
+		ManualGates:    cloneManualGates(rt.ManualGates),
+		SkippedModules: cloneStrings(rt.SkippedModules),
 	}
 }
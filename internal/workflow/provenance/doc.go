@@ -0,0 +1,8 @@
+// Package provenance repairs the lattice metadata on workflow artifacts after
+// it was stripped or mangled by a manual edit. Modules decide whether their
+// outputs are complete from that metadata, so a MODULES.md whose front matter
+// was deleted looks unfinished even when its content is fine. A repair works
+// out which module last produced each artifact from the persisted engine
+// state, then re-stamps the artifact with that module's ID and version while
+// leaving the body untouched.
+package provenance
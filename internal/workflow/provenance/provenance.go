@@ -0,0 +1,258 @@
+package provenance
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+	"github.com/kingrea/The-Lattice/internal/workflow/engine"
+	"gopkg.in/yaml.v3"
+)
+
+// Repair is one artifact whose metadata will be re-stamped.
+type Repair struct {
+	Ref  artifact.ArtifactRef
+	Path string
+	// ModuleID and Version identify the module that last produced the
+	// artifact; they are what the repair writes.
+	ModuleID string
+	Version  string
+	// Problem describes what is wrong with the metadata on disk.
+	Problem string
+
+	meta artifact.Metadata
+}
+
+func (r Repair) String() string {
+	return fmt.Sprintf("%s: %s (restamp as %s@%s)", r.Path, r.Problem, r.ModuleID, r.Version)
+}
+
+// Plan lists the repairs so callers can confirm before applying.
+type Plan struct {
+	Repairs []Repair
+
+	store *artifact.Store
+}
+
+// owner is the latest completed module instance that declares an artifact as
+// an output.
+type owner struct {
+	ref      artifact.ArtifactRef
+	module   module.Module
+	finished time.Time
+}
+
+// NewPlan inspects the outputs of every module the run in state completed and
+// collects the ones whose metadata no longer names the module that produced
+// them. When several modules write the same artifact, as the planning modules
+// do with MODULES.md and PLAN.md, the most recent completed run owns it.
+// Missing artifacts are left alone; regenerating them is the module's job.
+func NewPlan(wf *workflow.Workflow, state engine.State, reg *module.Registry) (Plan, error) {
+	if wf == nil || reg == nil {
+		return Plan{}, fmt.Errorf("provenance: workflow and registry are required")
+	}
+	def := state.Resolved
+	if len(def.Modules) == 0 {
+		def = state.Definition
+	}
+	owners := make(map[string]owner)
+	var order []string
+	for _, ref := range def.Modules {
+		run, ok := state.Runs[ref.InstanceID()]
+		if !ok || (run.Status != module.StatusCompleted && run.Status != module.StatusNoOp) {
+			continue
+		}
+		mod, err := reg.Resolve(ref.ModuleID, moduleConfig(ref.Config))
+		if err != nil {
+			return Plan{}, fmt.Errorf("provenance: resolve %s: %w", ref.InstanceID(), err)
+		}
+		for _, out := range mod.Outputs() {
+			if out.Kind == artifact.KindDirectory {
+				continue
+			}
+			current, seen := owners[out.ID]
+			if !seen {
+				order = append(order, out.ID)
+			} else if run.FinishedAt.Before(current.finished) {
+				continue
+			}
+			owners[out.ID] = owner{ref: out, module: mod, finished: run.FinishedAt}
+		}
+	}
+	plan := Plan{store: artifact.NewStore(wf)}
+	for _, id := range order {
+		repair, needed, err := inspect(plan.store, wf, owners[id])
+		if err != nil {
+			return Plan{}, err
+		}
+		if needed {
+			plan.Repairs = append(plan.Repairs, repair)
+		}
+	}
+	return plan, nil
+}
+
+// Empty reports whether every produced artifact already carries the right
+// metadata.
+func (p Plan) Empty() bool {
+	return len(p.Repairs) == 0
+}
+
+// Apply re-stamps each planned artifact, keeping its body byte for byte.
+func (p Plan) Apply() error {
+	if p.store == nil {
+		return fmt.Errorf("provenance: plan was not created with NewPlan")
+	}
+	for _, repair := range p.Repairs {
+		data, err := os.ReadFile(repair.Path)
+		if err != nil {
+			return fmt.Errorf("provenance: read %s: %w", repair.Path, err)
+		}
+		var body []byte
+		switch repair.Ref.Kind {
+		case artifact.KindDocument:
+			body = documentBody(data)
+		case artifact.KindJSON:
+			// The store replaces the _lattice block and keeps every other key.
+			body = data
+		}
+		if err := p.store.Write(repair.Ref, body, repair.meta); err != nil {
+			return fmt.Errorf("provenance: restamp %s: %w", repair.Ref.ID, err)
+		}
+	}
+	return nil
+}
+
+func inspect(store *artifact.Store, wf *workflow.Workflow, own owner) (Repair, bool, error) {
+	info := own.module.Info()
+	ref := own.ref
+	result, err := store.Check(ref)
+	repair := Repair{Ref: ref, Path: result.Path, ModuleID: info.ID, Version: info.Version}
+	switch result.State {
+	case artifact.StateMissing:
+		return repair, false, nil
+	case artifact.StateError:
+		return repair, false, fmt.Errorf("provenance: check %s: %w", ref.ID, err)
+	case artifact.StateInvalid:
+		repair.Problem = "metadata is missing or unreadable"
+	case artifact.StateReady:
+		meta := result.Metadata
+		switch {
+		case meta == nil:
+			repair.Problem = "metadata is missing"
+		case ref.Kind == artifact.KindMarker:
+			// Markers keep the metadata of the first write, so only bare
+			// markers can be re-stamped.
+			return repair, false, nil
+		case meta.ModuleID != info.ID || meta.Version != info.Version:
+			repair.Problem = fmt.Sprintf("stamped by %s@%s", meta.ModuleID, meta.Version)
+		default:
+			return repair, false, nil
+		}
+	default:
+		return repair, false, nil
+	}
+	if result.Metadata != nil {
+		repair.meta = *result.Metadata
+	} else {
+		repair.meta = artifact.Metadata{Inputs: artifactIDs(own.module.Inputs())}
+	}
+	repair.meta.ArtifactID = ref.ID
+	repair.meta.ModuleID = info.ID
+	repair.meta.Version = info.Version
+	repair.meta.Workflow = wf.Dir()
+	return repair, true, nil
+}
+
+// documentBody drops a leading front matter block that carries a lattice key
+// and returns the rest byte for byte. A block that is not valid YAML is
+// dropped when one of its lines starts with "lattice:", since that is a
+// damaged envelope rather than the author's text. WriteFrontMatter separates
+// the block from the body with a blank line, which is dropped too so repeated
+// repairs do not grow the file. Any other leading block is the author's own
+// front matter and stays part of the body.
+func documentBody(data []byte) []byte {
+	block, rest, ok := splitFrontMatter(data)
+	if !ok || !isLatticeEnvelope(block) {
+		return data
+	}
+	if bytes.HasPrefix(rest, []byte("\r\n")) {
+		return rest[2:]
+	}
+	return bytes.TrimPrefix(rest, []byte("\n"))
+}
+
+// splitFrontMatter returns the YAML between a leading pair of `---` lines and
+// everything after the closing line, accepting LF or CRLF line endings.
+func splitFrontMatter(data []byte) (block, rest []byte, ok bool) {
+	first, after, found := cutLine(data)
+	if !found || !isFence(first) {
+		return nil, nil, false
+	}
+	start := len(data) - len(after)
+	for remaining := after; len(remaining) > 0; {
+		line, next, _ := cutLine(remaining)
+		if isFence(line) {
+			end := len(data) - len(remaining)
+			return data[start:end], next, true
+		}
+		remaining = next
+	}
+	return nil, nil, false
+}
+
+// cutLine splits data after its first newline; the returned line keeps any
+// trailing carriage return.
+func cutLine(data []byte) (line, rest []byte, found bool) {
+	idx := bytes.IndexByte(data, '\n')
+	if idx < 0 {
+		return data, nil, false
+	}
+	return data[:idx], data[idx+1:], true
+}
+
+func isFence(line []byte) bool {
+	return string(bytes.TrimSuffix(line, []byte("\r"))) == "---"
+}
+
+// isLatticeEnvelope reports whether a front matter block has the top-level
+// lattice key WriteFrontMatter emits. Blocks that do not parse are matched on
+// a line starting with "lattice:".
+func isLatticeEnvelope(block []byte) bool {
+	var fields map[string]any
+	if err := yaml.Unmarshal(block, &fields); err != nil {
+		for remaining := block; len(remaining) > 0; {
+			line, next, _ := cutLine(remaining)
+			if bytes.HasPrefix(line, []byte("lattice:")) {
+				return true
+			}
+			remaining = next
+		}
+		return false
+	}
+	_, ok := fields["lattice"]
+	return ok
+}
+
+func artifactIDs(refs []artifact.ArtifactRef) []string {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.ID)
+	}
+	return ids
+}
+
+func moduleConfig(cfg workflow.ModuleConfig) module.Config {
+	if len(cfg) == 0 {
+		return nil
+	}
+	out := make(module.Config, len(cfg))
+	for key, value := range cfg {
+		out[key] = value
+	}
+	return out
+}
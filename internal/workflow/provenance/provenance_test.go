@@ -0,0 +1,148 @@
+package provenance
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/modules"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+	"github.com/kingrea/The-Lattice/internal/workflow/engine"
+)
+
+func TestRepairRestoresStrippedPlanProvenance(t *testing.T) {
+	wf := workflow.New(t.TempDir())
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	store := artifact.NewStore(wf)
+	modulesBody := "# Modules\n\n- api\n- web\n"
+	planBody := "# Plan\n\n1. api\n2. web\n"
+	stamp := artifact.Metadata{ModuleID: "consolidation", Version: "1.0.0", Workflow: wf.Dir()}
+	if err := store.Write(artifact.ModulesDoc, []byte(modulesBody), stamp); err != nil {
+		t.Fatalf("write modules: %v", err)
+	}
+	if err := store.Write(artifact.ActionPlanDoc, []byte(planBody), stamp); err != nil {
+		t.Fatalf("write plan: %v", err)
+	}
+	// A manual edit drops the front matter from MODULES.md and hand-edits the
+	// module in PLAN.md.
+	if err := os.WriteFile(wf.ModulesPath(), []byte(modulesBody), 0o644); err != nil {
+		t.Fatalf("strip modules: %v", err)
+	}
+	planData, err := os.ReadFile(wf.ActionPlanPath())
+	if err != nil {
+		t.Fatalf("read plan: %v", err)
+	}
+	edited := strings.Replace(string(planData), "module: consolidation", "module: me", 1)
+	if err := os.WriteFile(wf.ActionPlanPath(), []byte(edited), 0o644); err != nil {
+		t.Fatalf("edit plan: %v", err)
+	}
+
+	finished := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	state := engine.State{
+		Resolved: workflow.WorkflowDefinition{Modules: []workflow.ModuleRef{
+			{ID: "action-plan", ModuleID: "action-plan"},
+			{ID: "consolidation", ModuleID: "consolidation"},
+		}},
+		Runs: map[string]engine.ModuleRun{
+			"action-plan":   {Status: module.StatusCompleted, FinishedAt: finished},
+			"consolidation": {Status: module.StatusCompleted, FinishedAt: finished.Add(time.Hour)},
+		},
+	}
+	reg := module.NewRegistry()
+	modules.RegisterBuiltins(reg)
+
+	plan, err := NewPlan(wf, state, reg)
+	if err != nil {
+		t.Fatalf("new plan: %v", err)
+	}
+	if len(plan.Repairs) != 2 {
+		t.Fatalf("expected 2 repairs, got %v", plan.Repairs)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	for _, tc := range []struct {
+		ref  artifact.ArtifactRef
+		body string
+	}{{artifact.ModulesDoc, modulesBody}, {artifact.ActionPlanDoc, planBody}} {
+		ref, body := tc.ref, tc.body
+		result, err := store.Check(ref)
+		if err != nil {
+			t.Fatalf("check %s: %v", ref.ID, err)
+		}
+		if result.Metadata == nil || result.Metadata.ModuleID != "consolidation" || result.Metadata.Version != "1.0.0" {
+			t.Fatalf("%s metadata not restored: %+v", ref.ID, result.Metadata)
+		}
+		data, err := os.ReadFile(result.Path)
+		if err != nil {
+			t.Fatalf("read %s: %v", ref.ID, err)
+		}
+		if !strings.HasSuffix(string(data), "\n---\n\n"+body) || strings.Count(string(data), body) != 1 {
+			t.Fatalf("%s body changed:\n%s", ref.ID, data)
+		}
+	}
+
+	again, err := NewPlan(wf, state, reg)
+	if err != nil {
+		t.Fatalf("second plan: %v", err)
+	}
+	if !again.Empty() {
+		t.Fatalf("expected nothing left to repair, got %v", again.Repairs)
+	}
+}
+
+func TestDocumentBodyStripsOnlyLatticeFrontMatter(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "lattice envelope",
+			data: "---\nlattice:\n  artifact: action-plan\n---\n\n# Plan\n",
+			want: "# Plan\n",
+		},
+		{
+			name: "partial lattice envelope",
+			data: "---\nlattice:\n  module: \"\"\n---\n\n# Plan\n",
+			want: "# Plan\n",
+		},
+		{
+			name: "crlf body is kept byte for byte",
+			data: "---\r\nlattice:\r\n  artifact: action-plan\r\n---\r\n\r\n# Plan\r\n\r\n1. api\r\n",
+			want: "# Plan\r\n\r\n1. api\r\n",
+		},
+		{
+			name: "corrupt lattice envelope",
+			data: "---\nlattice:\n  artifact: [action-plan\n  module: \"plan\n---\n\n# Plan\n",
+			want: "# Plan\n",
+		},
+		{
+			name: "corrupt author front matter",
+			data: "---\ntitle: [Plan\n---\n# Plan\n",
+			want: "---\ntitle: [Plan\n---\n# Plan\n",
+		},
+		{
+			name: "author front matter",
+			data: "---\r\ntitle: Plan\r\n---\r\n# Plan\r\n",
+			want: "---\r\ntitle: Plan\r\n---\r\n# Plan\r\n",
+		},
+		{
+			name: "unterminated block",
+			data: "---\nlattice:\n# Plan\n",
+			want: "---\nlattice:\n# Plan\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := string(documentBody([]byte(tc.data))); got != tc.want {
+				t.Fatalf("documentBody() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package reset clears wedged workflow state without hand-editing `.lattice`.
+// A phase-scoped reset rewinds the workflow to the named phase by removing the
+// markers and state written by that phase and every later one, plus the
+// persisted engine snapshots. Planning documents survive unless an `--all`
+// reset is requested. Resets refuse to run while the orchestrator reports an
+// active work cycle.
+package reset
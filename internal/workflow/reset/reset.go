@@ -0,0 +1,203 @@
+package reset
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/orchestrator"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+// ErrCycleActive is returned when the cycle tracker reports a running work
+// cycle and the reset was not forced.
+var ErrCycleActive = errors.New("reset: a work cycle is active")
+
+// Options selects what a reset clears.
+type Options struct {
+	// Phase rewinds the workflow to this phase. Ignored when All is set.
+	Phase workflow.Phase
+	// All clears the whole workflow tree, including planning documents.
+	All bool
+	// Worktrees also discards tracked worktree sessions. Implied by All.
+	Worktrees bool
+	// Force skips the active-cycle guard.
+	Force bool
+}
+
+// Plan lists what a reset will remove so callers can confirm before applying.
+type Plan struct {
+	Scope     string
+	Paths     []string
+	Worktrees bool
+
+	cfg  *config.Config
+	opts Options
+}
+
+var phaseNames = map[string]workflow.Phase{
+	"planning":               workflow.PhasePlanning,
+	"orchestrator-selection": workflow.PhaseOrchestratorSelection,
+	"hiring":                 workflow.PhaseHiring,
+	"work":                   workflow.PhaseWorkProcess,
+	"work-process":           workflow.PhaseWorkProcess,
+	"refinement":             workflow.PhaseRefinement,
+	"agent-release":          workflow.PhaseAgentRelease,
+	"work-cleanup":           workflow.PhaseWorkCleanup,
+	"orchestrator-release":   workflow.PhaseOrchestratorRelease,
+}
+
+// ParsePhase resolves a CLI phase name such as "hiring" or "work-process".
+func ParsePhase(name string) (workflow.Phase, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if phase, ok := phaseNames[key]; ok {
+		return phase, nil
+	}
+	return workflow.PhaseNone, fmt.Errorf("reset: unknown phase %q (expected one of %s)", name, strings.Join(PhaseNames(), ", "))
+}
+
+// PhaseNames lists the accepted phase names in workflow order.
+func PhaseNames() []string {
+	names := make([]string, 0, len(phaseNames))
+	for name := range phaseNames {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if phaseNames[names[i]] == phaseNames[names[j]] {
+			return names[i] < names[j]
+		}
+		return phaseNames[names[i]] < phaseNames[names[j]]
+	})
+	return names
+}
+
+// phaseState returns the files each phase writes once it has run. Documents
+// produced during planning are deliberately absent so phase resets keep them.
+func phaseState(wf *workflow.Workflow, phase workflow.Phase) []string {
+	switch phase {
+	case workflow.PhasePlanning:
+		return []string{
+			artifact.StaffFeedbackApplied.Path(wf),
+			artifact.ReviewsAppliedMarker.Path(wf),
+			artifact.BeadsCreatedMarker.Path(wf),
+			wf.CriticalApprovedPath(),
+			wf.PlanChatReadyPath(),
+			wf.PlanChatActivePath(),
+		}
+	case workflow.PhaseOrchestratorSelection:
+		return []string{artifact.OrchestratorState.Path(wf)}
+	case workflow.PhaseHiring:
+		return []string{
+			artifact.WorkersJSON.Path(wf),
+			filepath.Join(wf.TeamDir(), workflow.MarkerHiringComplete),
+		}
+	case workflow.PhaseWorkProcess:
+		return []string{
+			artifact.WorkInProgressMarker.Path(wf),
+			artifact.WorkCompleteMarker.Path(wf),
+			artifact.WorkExhaustedMarker.Path(wf),
+		}
+	case workflow.PhaseRefinement:
+		return []string{
+			artifact.RefinementNeededMarker.Path(wf),
+			artifact.StakeholdersJSON.Path(wf),
+		}
+	case workflow.PhaseAgentRelease:
+		return []string{artifact.AgentsReleasedMarker.Path(wf)}
+	case workflow.PhaseWorkCleanup:
+		return []string{artifact.CleanupDoneMarker.Path(wf)}
+	case workflow.PhaseOrchestratorRelease:
+		return []string{artifact.OrchestratorReleasedMarker.Path(wf)}
+	default:
+		return nil
+	}
+}
+
+// NewPlan checks that no cycle is active and collects the existing paths the
+// reset would remove.
+func NewPlan(cfg *config.Config, opts Options) (Plan, error) {
+	if cfg == nil {
+		return Plan{}, fmt.Errorf("reset: config is required")
+	}
+	if !opts.All && (opts.Phase <= workflow.PhaseNone || opts.Phase >= workflow.PhaseComplete) {
+		return Plan{}, fmt.Errorf("reset: a phase or --all is required")
+	}
+	if err := checkIdle(cfg, opts.Force); err != nil {
+		return Plan{}, err
+	}
+	wf := workflow.New(cfg.LatticeProjectDir)
+	plan := Plan{cfg: cfg, opts: opts, Worktrees: opts.All || opts.Worktrees}
+	var candidates []string
+	if opts.All {
+		plan.Scope = "all workflow state"
+		candidates = []string{wf.Dir(), wf.PlanDir(), wf.ActionDir()}
+	} else {
+		plan.Scope = fmt.Sprintf("%s and later phases", opts.Phase)
+		for phase := opts.Phase; phase < workflow.PhaseComplete; phase++ {
+			candidates = append(candidates, phaseState(wf, phase)...)
+		}
+		candidates = append(candidates, filepath.Join(wf.Dir(), "engine"))
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			plan.Paths = append(plan.Paths, path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return Plan{}, fmt.Errorf("reset: stat %s: %w", path, err)
+		}
+	}
+	return plan, nil
+}
+
+// Empty reports whether applying the plan would change nothing.
+func (p Plan) Empty() bool {
+	return len(p.Paths) == 0 && !p.Worktrees
+}
+
+// Apply removes the planned paths and, when requested, discards worktrees. The
+// active-cycle guard is checked again in case a cycle started after planning.
+func (p Plan) Apply() error {
+	if p.cfg == nil {
+		return fmt.Errorf("reset: plan was not created with NewPlan")
+	}
+	if err := checkIdle(p.cfg, p.opts.Force); err != nil {
+		return err
+	}
+	if p.Worktrees {
+		if err := orchestrator.New(p.cfg).DiscardWorktrees(); err != nil {
+			return fmt.Errorf("reset: discard worktrees: %w", err)
+		}
+	}
+	for _, path := range p.Paths {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("reset: remove %s: %w", path, err)
+		}
+	}
+	if p.opts.All {
+		if err := workflow.New(p.cfg.LatticeProjectDir).Initialize(); err != nil {
+			return fmt.Errorf("reset: recreate workflow dirs: %w", err)
+		}
+	}
+	return nil
+}
+
+func checkIdle(cfg *config.Config, force bool) error {
+	if force {
+		return nil
+	}
+	status, err := orchestrator.New(cfg).CurrentCycleStatus()
+	if err != nil {
+		if errors.Is(err, orchestrator.ErrNoTrackedSessions) {
+			return nil
+		}
+		return fmt.Errorf("reset: read cycle tracker: %w", err)
+	}
+	if status.Status == "running" {
+		return fmt.Errorf("%w: cycle %d has %d session(s) running", ErrCycleActive, status.Cycle, status.SessionCount)
+	}
+	return nil
+}
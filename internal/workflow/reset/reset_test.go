@@ -0,0 +1,121 @@
+package reset
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func newResetConfig(t *testing.T) (*config.Config, *workflow.Workflow) {
+	t.Helper()
+	projectDir := t.TempDir()
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	cfg := &config.Config{
+		ProjectDir:        projectDir,
+		LatticeProjectDir: filepath.Join(projectDir, config.LatticeDir),
+		LatticeRoot:       projectDir,
+	}
+	wf := workflow.New(cfg.LatticeProjectDir)
+	if err := wf.Initialize(); err != nil {
+		t.Fatalf("initialize workflow: %v", err)
+	}
+	return cfg, wf
+}
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestPhaseResetRemovesOnlyLaterMarkers(t *testing.T) {
+	cfg, wf := newResetConfig(t)
+	kept := []string{
+		wf.CommissionPath(),
+		wf.ModulesPath(),
+		wf.ActionPlanPath(),
+		artifact.ReviewsAppliedMarker.Path(wf),
+		artifact.BeadsCreatedMarker.Path(wf),
+		artifact.OrchestratorState.Path(wf),
+		wf.WorkLogPath(),
+	}
+	removed := []string{
+		artifact.WorkersJSON.Path(wf),
+		artifact.WorkInProgressMarker.Path(wf),
+		artifact.WorkCompleteMarker.Path(wf),
+		artifact.RefinementNeededMarker.Path(wf),
+		artifact.AgentsReleasedMarker.Path(wf),
+		filepath.Join(wf.Dir(), "engine", "commission-work", "state.json"),
+	}
+	for _, path := range append(append([]string{}, kept...), removed...) {
+		touch(t, path)
+	}
+
+	plan, err := NewPlan(cfg, Options{Phase: workflow.PhaseHiring})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	if plan.Worktrees {
+		t.Fatalf("phase reset should not discard worktrees unless asked")
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	for _, path := range kept {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("expected %s to survive the reset: %v", path, err)
+		}
+	}
+	for _, path := range removed {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be removed, stat err=%v", path, err)
+		}
+	}
+	if got := wf.CurrentPhase(); got != workflow.PhaseHiring {
+		t.Fatalf("expected workflow to rewind to hiring, got %s", got)
+	}
+}
+
+func TestResetRefusesWhileCycleRunning(t *testing.T) {
+	cfg, wf := newResetConfig(t)
+	marker := artifact.WorkCompleteMarker.Path(wf)
+	touch(t, marker)
+	tracker := filepath.Join(wf.WorkDir(), "current-cycle.json")
+	if err := os.WriteFile(tracker, []byte(`{"cycle":2,"status":"running","sessions":[{"number":1,"name":"tree-1"}]}`), 0o644); err != nil {
+		t.Fatalf("write tracker: %v", err)
+	}
+
+	if _, err := NewPlan(cfg, Options{Phase: workflow.PhaseWorkProcess}); !errors.Is(err, ErrCycleActive) {
+		t.Fatalf("expected ErrCycleActive, got %v", err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Fatalf("marker should be untouched: %v", err)
+	}
+	plan, err := NewPlan(cfg, Options{Phase: workflow.PhaseWorkProcess, Force: true})
+	if err != nil {
+		t.Fatalf("forced NewPlan: %v", err)
+	}
+	if len(plan.Paths) != 1 || plan.Paths[0] != marker {
+		t.Fatalf("unexpected plan paths: %v", plan.Paths)
+	}
+}
+
+func TestParsePhase(t *testing.T) {
+	if phase, err := ParsePhase(" Work-Process "); err != nil || phase != workflow.PhaseWorkProcess {
+		t.Fatalf("ParsePhase: phase=%v err=%v", phase, err)
+	}
+	if _, err := ParsePhase("deploy"); err == nil {
+		t.Fatalf("expected unknown phase error")
+	}
+}
@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -20,19 +21,32 @@ const (
 	NodeStateBlocked  NodeState = "blocked"
 	NodeStateComplete NodeState = "complete"
 	NodeStateError    NodeState = "error"
+	NodeStateSkipped  NodeState = "skipped"
 )
 
+// ErrCycle reports a workflow whose dependencies loop back on themselves, so
+// none of the modules on the loop can ever become ready. The wrapped message
+// names the loop, e.g. "a -> b -> c -> a".
+var ErrCycle = errors.New("dependency cycle")
+
 // Node captures a workflow module instance plus its dependency metadata.
 type Node struct {
 	ID           string
 	Ref          workflow.ModuleRef
 	Module       module.Module
 	Dependencies []string
-	Dependents   []string
+	// AnyOf holds alternative dependency groups; at least one must be fully
+	// complete, in addition to Dependencies, before the node is ready.
+	AnyOf      [][]string
+	Dependents []string
 
 	State     NodeState
 	BlockedBy []string
-	Err       error
+	// SatisfiedBy names the any-of group that unblocked the node, if any.
+	SatisfiedBy []string
+	Err         error
+	// SkipReason explains why a skipped node will not run.
+	SkipReason string
 
 	Artifacts    map[string]ArtifactReport
 	fingerprints map[string]string
@@ -53,6 +67,7 @@ type Resolver struct {
 	definition workflow.WorkflowDefinition
 	nodes      map[string]*Node
 	orderedIDs []string
+	skipped    map[string]bool
 }
 
 // New constructs a resolver for the provided workflow definition. Modules are
@@ -78,6 +93,7 @@ func New(def workflow.WorkflowDefinition, registry *module.Registry) (*Resolver,
 			Ref:          ref,
 			Module:       mod,
 			Dependencies: normalized.Dependencies(id),
+			AnyOf:        ref.AnyOf,
 		}
 		nodes[id] = node
 		ordered = append(ordered, id)
@@ -90,6 +106,18 @@ func New(def workflow.WorkflowDefinition, registry *module.Registry) (*Resolver,
 			}
 			dep.Dependents = append(dep.Dependents, node.ID)
 		}
+		for _, depID := range node.anyOfMembers() {
+			dep, ok := nodes[depID]
+			if !ok {
+				return nil, fmt.Errorf("workflow %s: any_of dependency %s referenced by %s not declared", normalized.ID, depID, node.ID)
+			}
+			if !containsString(dep.Dependents, node.ID) {
+				dep.Dependents = append(dep.Dependents, node.ID)
+			}
+		}
+	}
+	if path := findCycle(nodes, ordered); len(path) > 0 {
+		return nil, fmt.Errorf("workflow %s: %w: %s", normalized.ID, ErrCycle, strings.Join(path, " -> "))
 	}
 	for _, node := range nodes {
 		if len(node.Dependents) > 1 {
@@ -103,6 +131,31 @@ func New(def workflow.WorkflowDefinition, registry *module.Registry) (*Resolver,
 	}, nil
 }
 
+// Clone returns a copy of the resolver whose nodes can be re-evaluated, or
+// have their modules swapped, without affecting the original snapshot.
+func (r *Resolver) Clone() *Resolver {
+	nodes := make(map[string]*Node, len(r.nodes))
+	for id, node := range r.nodes {
+		copied := *node
+		copied.Dependents = append([]string(nil), node.Dependents...)
+		copied.BlockedBy = append([]string(nil), node.BlockedBy...)
+		copied.SatisfiedBy = append([]string(nil), node.SatisfiedBy...)
+		copied.Artifacts = nil
+		copied.fingerprints = nil
+		nodes[id] = &copied
+	}
+	skipped := make(map[string]bool, len(r.skipped))
+	for id := range r.skipped {
+		skipped[id] = true
+	}
+	return &Resolver{
+		definition: r.definition.Clone(),
+		nodes:      nodes,
+		orderedIDs: append([]string(nil), r.orderedIDs...),
+		skipped:    skipped,
+	}
+}
+
 // Definition returns a clone of the resolver's workflow definition.
 func (r *Resolver) Definition() workflow.WorkflowDefinition {
 	return r.definition.Clone()
@@ -125,6 +178,18 @@ func (r *Resolver) Node(id string) (*Node, bool) {
 	return node, ok
 }
 
+// SetSkipped records the modules the operator chose to skip. Refresh marks them
+// skipped unless they already completed, and auto-skips any incomplete module
+// that depends on a skipped one because it could never become ready.
+func (r *Resolver) SetSkipped(ids ...string) {
+	r.skipped = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			r.skipped[id] = true
+		}
+	}
+}
+
 // Refresh re-evaluates module completion status and dependency readiness using
 // the provided module context. Callers should invoke Refresh before querying for
 // runnable modules to ensure the snapshot reflects on-disk artifacts.
@@ -135,6 +200,8 @@ func (r *Resolver) Refresh(ctx *module.ModuleContext) error {
 	for _, node := range r.nodes {
 		node.Err = nil
 		node.BlockedBy = nil
+		node.SatisfiedBy = nil
+		node.SkipReason = ""
 		node.Artifacts = nil
 		node.fingerprints = nil
 		node.State = NodeStateUnknown
@@ -166,12 +233,13 @@ func (r *Resolver) Refresh(ctx *module.ModuleContext) error {
 			continue
 		}
 		r.refreshArtifacts(ctx, node)
-		if node.State == NodeStateComplete && node.hasArtifactIssues() {
+		if node.State == NodeStateComplete && node.hasArtifactIssues(r.definition.Runtime.Lenient()) {
 			node.State = NodeStatePending
 		}
 	}
+	r.applySkips()
 	for _, node := range r.nodes {
-		if node.State == NodeStateComplete || node.State == NodeStateError {
+		if node.State == NodeStateComplete || node.State == NodeStateError || node.State == NodeStateSkipped {
 			continue
 		}
 		blockers := r.blockers(node)
@@ -199,8 +267,8 @@ func (r *Resolver) Ready() []*Node {
 
 // Queue returns modules that must run to satisfy the requested targets. If no
 // targets are provided, every incomplete module is considered. Dependencies are
-// returned before the modules that require them, and already-complete modules
-// are skipped.
+// returned before the modules that require them, and already-complete or
+// skipped modules are left out.
 func (r *Resolver) Queue(targets ...string) ([]*Node, error) {
 	if len(targets) == 0 {
 		targets = append([]string{}, r.orderedIDs...)
@@ -217,11 +285,19 @@ func (r *Resolver) Queue(targets ...string) ([]*Node, error) {
 			return fmt.Errorf("workflow: unknown module %s", id)
 		}
 		visited[id] = true
+		if node.State == NodeStateSkipped {
+			return nil
+		}
 		for _, dep := range node.Dependencies {
 			if err := visit(dep); err != nil {
 				return err
 			}
 		}
+		for _, dep := range r.anyOfQueueGroup(node) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
 		if node.State != NodeStateComplete {
 			ordered = append(ordered, node)
 		}
@@ -235,23 +311,240 @@ func (r *Resolver) Queue(targets ...string) ([]*Node, error) {
 	return ordered, nil
 }
 
+// applySkips marks operator-skipped modules and propagates the skip to every
+// incomplete dependent, repeating until no further module is affected.
+func (r *Resolver) applySkips() {
+	if len(r.skipped) == 0 {
+		return
+	}
+	for _, id := range r.orderedIDs {
+		node := r.nodes[id]
+		if r.skipped[id] && node.State != NodeStateComplete && node.State != NodeStateError {
+			node.State = NodeStateSkipped
+			node.SkipReason = "skipped by operator"
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, id := range r.orderedIDs {
+			node := r.nodes[id]
+			if node.State == NodeStateComplete || node.State == NodeStateError || node.State == NodeStateSkipped {
+				continue
+			}
+			for _, depID := range node.Dependencies {
+				if dep, ok := r.nodes[depID]; ok && dep.State == NodeStateSkipped {
+					node.State = NodeStateSkipped
+					node.SkipReason = fmt.Sprintf("depends on skipped module %s", depID)
+					changed = true
+					break
+				}
+			}
+			if node.State != NodeStateSkipped && len(node.AnyOf) > 0 && r.allAnyOfGroupsSkipped(node) {
+				node.State = NodeStateSkipped
+				node.SkipReason = "every any_of group depends on a skipped module"
+				changed = true
+			}
+		}
+	}
+}
+
+// allAnyOfGroupsSkipped reports whether every any-of group of the node
+// contains a skipped module, leaving no way to satisfy it.
+func (r *Resolver) allAnyOfGroupsSkipped(node *Node) bool {
+	for _, group := range node.AnyOf {
+		skipped := false
+		for _, depID := range group {
+			if dep, ok := r.nodes[depID]; ok && dep.State == NodeStateSkipped {
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			return false
+		}
+	}
+	return true
+}
+
+// blockers lists the incomplete modules holding the node back. When the node
+// declares any-of groups and none is complete, the incomplete members of every
+// group are reported; otherwise the first complete group is recorded in
+// SatisfiedBy.
 func (r *Resolver) blockers(node *Node) []string {
-	if len(node.Dependencies) == 0 {
+	if len(node.Dependencies) == 0 && len(node.AnyOf) == 0 {
 		return nil
 	}
 	blockers := make([]string, 0, len(node.Dependencies))
 	for _, depID := range node.Dependencies {
-		dep, ok := r.nodes[depID]
-		if !ok || dep.State != NodeStateComplete {
+		if !r.complete(depID) {
 			blockers = append(blockers, depID)
 		}
 	}
+	if len(node.AnyOf) > 0 {
+		if group := r.satisfiedGroup(node); group != nil {
+			node.SatisfiedBy = append([]string(nil), group...)
+		} else {
+			for _, depID := range node.anyOfMembers() {
+				if !r.complete(depID) && !containsString(blockers, depID) {
+					blockers = append(blockers, depID)
+				}
+			}
+		}
+	}
 	if len(blockers) == 0 {
 		return nil
 	}
 	return blockers
 }
 
+// satisfiedGroup returns the first any-of group whose modules are all complete.
+func (r *Resolver) satisfiedGroup(node *Node) []string {
+	for _, group := range node.AnyOf {
+		done := true
+		for _, depID := range group {
+			if !r.complete(depID) {
+				done = false
+				break
+			}
+		}
+		if done {
+			return group
+		}
+	}
+	return nil
+}
+
+// anyOfQueueGroup picks the any-of group Queue should schedule: nothing when a
+// group is already complete, otherwise the first group without skipped modules.
+func (r *Resolver) anyOfQueueGroup(node *Node) []string {
+	if len(node.AnyOf) == 0 || r.satisfiedGroup(node) != nil {
+		return nil
+	}
+	for _, group := range node.AnyOf {
+		usable := true
+		for _, depID := range group {
+			if dep, ok := r.nodes[depID]; ok && dep.State == NodeStateSkipped {
+				usable = false
+				break
+			}
+		}
+		if usable {
+			return group
+		}
+	}
+	return nil
+}
+
+func (r *Resolver) complete(id string) bool {
+	node, ok := r.nodes[id]
+	return ok && node.State == NodeStateComplete
+}
+
+// anyOfMembers returns every module named in the node's any-of groups, once
+// each, in declaration order.
+func (n *Node) anyOfMembers() []string {
+	var members []string
+	for _, group := range n.AnyOf {
+		for _, id := range group {
+			if !containsString(members, id) {
+				members = append(members, id)
+			}
+		}
+	}
+	return members
+}
+
+// findCycle reports the first loop that can never be satisfied, starting and
+// ending on the same node, or nil when every node can eventually run. A node
+// can run once all of its depends_on modules can and, when it has any_of
+// groups, every member of at least one group can. An any_of group that leads
+// back to the node therefore only deadlocks it when every other group does
+// too.
+func findCycle(nodes map[string]*Node, ordered []string) []string {
+	runnable := make(map[string]bool, len(nodes))
+	canRun := func(node *Node) bool {
+		for _, depID := range node.Dependencies {
+			if !runnable[depID] {
+				return false
+			}
+		}
+		if len(node.AnyOf) == 0 {
+			return true
+		}
+		return blockedMember(node.AnyOf, runnable) == ""
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, id := range ordered {
+			if !runnable[id] && canRun(nodes[id]) {
+				runnable[id] = true
+				changed = true
+			}
+		}
+	}
+	for _, id := range ordered {
+		if runnable[id] {
+			continue
+		}
+		// Every stuck node waits on another stuck node, so following the
+		// first one in declaration order must revisit a node.
+		var path []string
+		seen := map[string]int{}
+		for current := id; ; {
+			if at, ok := seen[current]; ok {
+				return append(path[at:], current)
+			}
+			seen[current] = len(path)
+			path = append(path, current)
+			current = nextBlocked(nodes[current], runnable)
+		}
+	}
+	return nil
+}
+
+// nextBlocked returns the first dependency holding a stuck node back: an
+// unrunnable depends_on module, or else the first unrunnable member of its
+// first any_of group.
+func nextBlocked(node *Node, runnable map[string]bool) string {
+	for _, depID := range node.Dependencies {
+		if !runnable[depID] {
+			return depID
+		}
+	}
+	return blockedMember(node.AnyOf[:1], runnable)
+}
+
+// blockedMember returns an unrunnable member from the first group, or "" when
+// any group is fully runnable.
+func blockedMember(groups [][]string, runnable map[string]bool) string {
+	first := ""
+	for _, group := range groups {
+		blocked := ""
+		for _, id := range group {
+			if !runnable[id] {
+				blocked = id
+				break
+			}
+		}
+		if blocked == "" {
+			return ""
+		}
+		if first == "" {
+			first = blocked
+		}
+	}
+	return first
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Resolver) refreshArtifacts(ctx *module.ModuleContext, node *Node) {
 	outputs := node.Module.Outputs()
 	if len(outputs) == 0 {
@@ -271,7 +564,9 @@ func (r *Resolver) refreshArtifacts(ctx *module.ModuleContext, node *Node) {
 	}
 }
 
-func (n *Node) hasArtifactIssues() bool {
+// hasArtifactIssues reports whether any output needs regenerating. When
+// lenient, outputs whose only problem is a fingerprint mismatch are tolerated.
+func (n *Node) hasArtifactIssues(lenient bool) bool {
 	if len(n.Artifacts) == 0 {
 		return false
 	}
@@ -280,12 +575,23 @@ func (n *Node) hasArtifactIssues() bool {
 		case module.ArtifactStatusFresh, module.ArtifactStatusReady:
 			continue
 		default:
+			if lenient && report.fingerprintDrifted() {
+				continue
+			}
 			return true
 		}
 	}
 	return false
 }
 
+// fingerprintDrifted reports whether the artifact is outdated only because its
+// inputs changed since it was written.
+func (report ArtifactReport) fingerprintDrifted() bool {
+	return report.Status == module.ArtifactStatusOutdated &&
+		strings.TrimSpace(report.StoredFingerprint) != "" &&
+		report.StoredFingerprint != report.ExpectedFingerprint
+}
+
 // CheckArtifact evaluates a single artifact and returns its resolver status.
 func (r *Resolver) CheckArtifact(ctx *module.ModuleContext, node *Node, ref artifact.ArtifactRef) ArtifactReport {
 	report := ArtifactReport{Ref: ref, Status: module.ArtifactStatusUnknown}
@@ -3,6 +3,7 @@ package resolver
 import (
 	"errors"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kingrea/The-Lattice/internal/artifact"
@@ -134,38 +135,69 @@ func TestResolverRefreshPropagatesErrors(t *testing.T) {
 	}
 }
 
-func TestResolverCyclicDependenciesRemainBlocked(t *testing.T) {
+func TestResolverRejectsDependencyCycleWithPath(t *testing.T) {
 	stubs := map[string]*stubModule{
+		"plan":  newStubModule("plan", false, nil),
 		"alpha": newStubModule("alpha", false, nil),
 		"beta":  newStubModule("beta", false, nil),
+		"gamma": newStubModule("gamma", false, nil),
 	}
 	def := workflow.WorkflowDefinition{
 		ID: "cyclic-workflow",
 		Modules: []workflow.ModuleRef{
-			{ID: "module-alpha", ModuleID: "alpha", DependsOn: []string{"module-beta"}},
+			{ID: "anchor-plan", ModuleID: "plan"},
+			{ID: "module-alpha", ModuleID: "alpha", DependsOn: []string{"anchor-plan", "module-gamma"}},
 			{ID: "module-beta", ModuleID: "beta", DependsOn: []string{"module-alpha"}},
+			{ID: "module-gamma", ModuleID: "gamma", DependsOn: []string{"module-beta"}},
 		},
 	}
-	res := buildResolverWithDefinition(t, stubs, def)
-	ctx := newTestModuleContext(t)
+	reg := module.NewRegistry()
+	for id, stub := range stubs {
+		stub := stub
+		reg.MustRegister(id, func(module.Config) (module.Module, error) {
+			return stub, nil
+		})
+	}
 
-	if err := res.Refresh(ctx); err != nil {
-		t.Fatalf("refresh: %v", err)
+	_, err := New(def, reg)
+	if !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %v", err)
+	}
+	want := "module-alpha -> module-gamma -> module-beta -> module-alpha"
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected cycle path %q in %q", want, err.Error())
 	}
+}
 
-	alpha := mustNode(t, res, "module-alpha")
-	beta := mustNode(t, res, "module-beta")
-	if alpha.State != NodeStateBlocked || beta.State != NodeStateBlocked {
-		t.Fatalf("expected both nodes blocked, got %s/%s", alpha.State, beta.State)
+func TestResolverAcceptsAnyOfLoopWithAnotherRoute(t *testing.T) {
+	reg := module.NewRegistry()
+	for _, id := range []string{"alpha", "beta", "gamma"} {
+		stub := newStubModule(id, false, nil)
+		reg.MustRegister(id, func(module.Config) (module.Module, error) {
+			return stub, nil
+		})
 	}
-	if len(alpha.BlockedBy) != 1 || alpha.BlockedBy[0] != "module-beta" {
-		t.Fatalf("alpha blockers: %+v", alpha.BlockedBy)
+	// alpha can run after gamma even though its beta route loops back.
+	def := workflow.WorkflowDefinition{
+		ID: "any-of-loop",
+		Modules: []workflow.ModuleRef{
+			{ID: "module-alpha", ModuleID: "alpha", AnyOf: [][]string{{"module-beta"}, {"module-gamma"}}},
+			{ID: "module-beta", ModuleID: "beta", DependsOn: []string{"module-alpha"}},
+			{ID: "module-gamma", ModuleID: "gamma"},
+		},
 	}
-	if len(beta.BlockedBy) != 1 || beta.BlockedBy[0] != "module-alpha" {
-		t.Fatalf("beta blockers: %+v", beta.BlockedBy)
+	if _, err := New(def, reg); err != nil {
+		t.Fatalf("expected satisfiable any_of loop to resolve, got %v", err)
 	}
-	if ready := res.Ready(); len(ready) != 0 {
-		t.Fatalf("expected no ready modules, got %+v", ready)
+
+	// Once gamma also waits on alpha, neither route can ever open.
+	def.Modules[2].DependsOn = []string{"module-alpha"}
+	_, err := New(def, reg)
+	if !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %v", err)
+	}
+	if want := "module-alpha -> module-beta -> module-alpha"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected cycle path %q in %q", want, err.Error())
 	}
 }
 
@@ -250,6 +282,65 @@ func TestResolverCheckArtifactFingerprintMismatch(t *testing.T) {
 	}
 }
 
+func TestResolverInputFreshnessPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy workflow.InputFreshness
+		rerun  bool
+	}{
+		{policy: workflow.InputFreshnessStrict, rerun: true},
+		{policy: workflow.InputFreshnessLenient, rerun: false},
+	} {
+		stubs := map[string]*stubModule{
+			"plan":   newStubModule("plan", true, nil),
+			"build":  newStubModule("build", false, nil),
+			"deploy": newStubModule("deploy", false, nil),
+		}
+		stubs["plan"].outputs = []artifact.ArtifactRef{artifact.ModulesDoc}
+		stubs["plan"].fingerprints = map[string]string{artifact.ModulesDoc.ID: "inputs-v1"}
+		def := workflow.WorkflowDefinition{
+			ID: "test-workflow",
+			Modules: []workflow.ModuleRef{
+				{ID: "anchor-plan", ModuleID: "plan"},
+				{ID: "module-build", ModuleID: "build", DependsOn: []string{"anchor-plan"}},
+				{ID: "module-deploy", ModuleID: "deploy", DependsOn: []string{"module-build"}},
+			},
+			Runtime: workflow.WorkflowRuntimeConfig{InputFreshness: tc.policy},
+		}
+		res := buildResolverWithDefinition(t, stubs, def)
+		ctx := newTestModuleContext(t)
+		meta := artifact.Metadata{
+			ArtifactID: artifact.ModulesDoc.ID,
+			ModuleID:   stubs["plan"].info.ID,
+			Version:    stubs["plan"].info.Version,
+			Workflow:   ctx.Workflow.Dir(),
+			Notes: map[string]string{
+				module.FingerprintNoteKey(artifact.ModulesDoc.ID): "inputs-v1",
+			},
+		}
+		if err := ctx.Artifacts.Write(artifact.ModulesDoc, []byte("body"), meta); err != nil {
+			t.Fatalf("write artifact: %v", err)
+		}
+		if err := res.Refresh(ctx); err != nil {
+			t.Fatalf("refresh: %v", err)
+		}
+		if plan := mustNode(t, res, "anchor-plan"); plan.State != NodeStateComplete {
+			t.Fatalf("%s: expected plan complete before inputs change, got %s", tc.policy, plan.State)
+		}
+
+		stubs["plan"].fingerprints[artifact.ModulesDoc.ID] = "inputs-v2"
+		if err := res.Refresh(ctx); err != nil {
+			t.Fatalf("refresh: %v", err)
+		}
+		plan := mustNode(t, res, "anchor-plan")
+		if got := plan.State != NodeStateComplete; got != tc.rerun {
+			t.Fatalf("%s: expected rerun=%v after inputs changed, got state %s", tc.policy, tc.rerun, plan.State)
+		}
+		if status := plan.Artifacts[artifact.ModulesDoc.ID].Status; status != module.ArtifactStatusOutdated {
+			t.Fatalf("%s: expected outdated report, got %s", tc.policy, status)
+		}
+	}
+}
+
 func TestResolverRefreshPropagatesFingerprintErrors(t *testing.T) {
 	stubs := map[string]*stubModule{
 		"plan":   newStubModule("plan", true, nil),
@@ -276,6 +367,104 @@ func TestResolverRefreshPropagatesFingerprintErrors(t *testing.T) {
 	}
 }
 
+func TestResolverSkipPropagatesOnlyFromIncompleteModules(t *testing.T) {
+	stubs := map[string]*stubModule{
+		"plan":   newStubModule("plan", true, nil),
+		"build":  newStubModule("build", false, nil),
+		"deploy": newStubModule("deploy", false, nil),
+	}
+	resolver := buildResolver(t, stubs)
+	ctx := newTestModuleContext(t)
+
+	resolver.SetSkipped("anchor-plan")
+	if err := resolver.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if build := mustNode(t, resolver, "module-build"); build.State != NodeStateReady {
+		t.Fatalf("completed skipped dependency should not cascade, got %s", build.State)
+	}
+
+	resolver.SetSkipped("module-build")
+	if err := resolver.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	deploy := mustNode(t, resolver, "module-deploy")
+	if deploy.State != NodeStateSkipped || deploy.SkipReason != "depends on skipped module module-build" {
+		t.Fatalf("expected deploy auto-skipped, got %s (%s)", deploy.State, deploy.SkipReason)
+	}
+	queue, err := resolver.Queue("module-deploy")
+	if err != nil {
+		t.Fatalf("queue: %v", err)
+	}
+	if len(queue) != 0 {
+		t.Fatalf("skipped modules should not be queued, got %d", len(queue))
+	}
+}
+
+func TestResolverAnyOfCombinesWithDependsOn(t *testing.T) {
+	stubs := map[string]*stubModule{
+		"plan":   newStubModule("plan", false, nil),
+		"refine": newStubModule("refine", false, nil),
+		"marker": newStubModule("marker", false, nil),
+		"work":   newStubModule("work", false, nil),
+	}
+	def := workflow.WorkflowDefinition{
+		ID: "any-of-workflow",
+		Modules: []workflow.ModuleRef{
+			{ID: "plan", ModuleID: "plan"},
+			{ID: "refinement", ModuleID: "refine"},
+			{ID: "skip-marker", ModuleID: "marker"},
+			{
+				ID:        "work-process",
+				ModuleID:  "work",
+				DependsOn: []string{"plan"},
+				AnyOf:     [][]string{{"refinement"}, {"skip-marker"}},
+			},
+		},
+	}
+	res := buildResolverWithDefinition(t, stubs, def)
+	ctx := newTestModuleContext(t)
+
+	if err := res.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	work := mustNode(t, res, "work-process")
+	if work.State != NodeStateBlocked {
+		t.Fatalf("expected work blocked, got %s", work.State)
+	}
+	if got := strings.Join(work.BlockedBy, ","); got != "plan,refinement,skip-marker" {
+		t.Fatalf("unexpected blockers %q", got)
+	}
+
+	stubs["marker"].complete = true
+	if err := res.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if work.State != NodeStateBlocked || strings.Join(work.BlockedBy, ",") != "plan" {
+		t.Fatalf("AND dependency should still block, got %s %v", work.State, work.BlockedBy)
+	}
+
+	stubs["plan"].complete = true
+	if err := res.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if work.State != NodeStateReady || len(work.BlockedBy) != 0 {
+		t.Fatalf("expected work ready, got %s %v", work.State, work.BlockedBy)
+	}
+	if strings.Join(work.SatisfiedBy, ",") != "skip-marker" {
+		t.Fatalf("expected skip-marker to unblock work, got %v", work.SatisfiedBy)
+	}
+
+	res.SetSkipped("refinement", "skip-marker")
+	stubs["marker"].complete = false
+	if err := res.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if work.State != NodeStateSkipped {
+		t.Fatalf("expected work skipped when every group is skipped, got %s", work.State)
+	}
+}
+
 func buildResolver(t *testing.T, stubs map[string]*stubModule) *Resolver {
 	def := workflow.WorkflowDefinition{
 		ID: "test-workflow",
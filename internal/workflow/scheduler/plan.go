@@ -0,0 +1,208 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+	"github.com/kingrea/The-Lattice/internal/workflow/resolver"
+)
+
+// Plan is the execution order DescribeBatches derives from a resolver
+// snapshot. Its fields are ordered deterministically so plans can be diffed.
+type Plan struct {
+	// Complete lists modules already finished in the snapshot.
+	Complete []string       `json:"complete,omitempty"`
+	Batches  []PlannedBatch `json:"batches"`
+	// Skipped maps modules the operator skipped to the reason.
+	Skipped map[string]string `json:"skipped,omitempty"`
+	// AwaitingApproval maps modules held by an unapproved manual gate to the
+	// gate's note. Their dependents are listed as unreachable.
+	AwaitingApproval map[string]string `json:"awaiting_approval,omitempty"`
+	// Unreachable maps modules that can never run to what stops them.
+	Unreachable map[string]string `json:"unreachable,omitempty"`
+}
+
+// PlannedBatch is one step of the plan; its modules may run together once
+// every earlier batch has finished.
+type PlannedBatch struct {
+	Number  int             `json:"number"`
+	Modules []PlannedModule `json:"modules"`
+}
+
+// PlannedModule explains why a module lands in its batch.
+type PlannedModule struct {
+	ID string `json:"id"`
+	// WaitsOn lists the dependencies planned in earlier batches.
+	WaitsOn []string `json:"waits_on,omitempty"`
+	// Deferred names the scheduling constraint that pushed the module past
+	// the first batch in which its dependencies were done.
+	Deferred string `json:"deferred,omitempty"`
+}
+
+// DescribeBatches plans the rest of a workflow from a refreshed resolver
+// snapshot. Each batch is what the scheduler would dispatch under runtime's
+// MaxParallel and ClassLimits once the previous batches finished, so modules
+// are grouped topologically. gates holds the run's manual gates; modules the
+// definition marks require_approval are gated too unless gates already
+// decides them, as when the engine starts a run. A module held by a gate is
+// never planned and is reported in AwaitingApproval. The snapshot itself is
+// left untouched.
+func DescribeBatches(snapshot *resolver.Resolver, runtime workflow.WorkflowRuntimeConfig, gates map[string]ManualGateState) (Plan, error) {
+	if snapshot == nil {
+		return Plan{}, fmt.Errorf("workflow: describing batches requires a resolver")
+	}
+	res := snapshot.Clone()
+	gates = SeedManualGates(res.Definition(), gates)
+	plan := Plan{}
+	done := map[string]bool{}
+	failed := map[string]error{}
+	for _, node := range res.Nodes() {
+		switch node.State {
+		case resolver.NodeStateComplete:
+			done[node.ID] = true
+			plan.Complete = append(plan.Complete, node.ID)
+		case resolver.NodeStateError:
+			failed[node.ID] = node.Err
+			if node.Err == nil {
+				failed[node.ID] = fmt.Errorf("workflow: %s is in an error state", node.ID)
+			}
+		}
+	}
+	planned := map[string]bool{}
+	deferred := map[string]string{}
+	var last RunnableBatch
+	for {
+		for _, node := range res.Nodes() {
+			node.Module = simulatedModule{info: node.Module.Info(), complete: done[node.ID], err: failed[node.ID]}
+		}
+		if err := res.Refresh(&module.ModuleContext{}); err != nil {
+			return Plan{}, err
+		}
+		sched, err := New(res)
+		if err != nil {
+			return Plan{}, err
+		}
+		last, err = sched.Runnable(RunnableRequest{MaxParallel: runtime.MaxParallel, ClassLimits: runtime.ClassLimits, ManualGates: gates})
+		if err != nil {
+			return Plan{}, err
+		}
+		if len(last.Nodes) == 0 {
+			break
+		}
+		step := PlannedBatch{Number: len(plan.Batches) + 1}
+		inBatch := make(map[string]bool, len(last.Nodes))
+		for _, node := range last.Nodes {
+			step.Modules = append(step.Modules, PlannedModule{
+				ID:       node.ID,
+				WaitsOn:  plannedDependencies(node, planned),
+				Deferred: deferred[node.ID],
+			})
+			inBatch[node.ID] = true
+		}
+		for _, node := range res.Ready() {
+			if inBatch[node.ID] || deferred[node.ID] != "" {
+				continue
+			}
+			skip, ok := last.Skipped[node.ID]
+			switch {
+			case ok && skip.Reason == SkipReasonManualGate:
+			case ok && skip.Reason == SkipReasonConcurrency:
+				deferred[node.ID] = skip.Detail
+			default:
+				deferred[node.ID] = "batch closed by an exclusive module"
+			}
+		}
+		for _, node := range last.Nodes {
+			done[node.ID] = true
+			planned[node.ID] = true
+		}
+		plan.Batches = append(plan.Batches, step)
+	}
+	for _, node := range res.Nodes() {
+		switch node.State {
+		case resolver.NodeStateComplete:
+		case resolver.NodeStateSkipped:
+			if plan.Skipped == nil {
+				plan.Skipped = map[string]string{}
+			}
+			plan.Skipped[node.ID] = node.SkipReason
+		case resolver.NodeStateReady:
+			if skip := last.Skipped[node.ID]; skip.Reason == SkipReasonManualGate {
+				if plan.AwaitingApproval == nil {
+					plan.AwaitingApproval = map[string]string{}
+				}
+				plan.AwaitingApproval[node.ID] = skip.Detail
+				continue
+			}
+			fallthrough
+		default:
+			if plan.Unreachable == nil {
+				plan.Unreachable = map[string]string{}
+			}
+			plan.Unreachable[node.ID] = unreachableReason(node, last.Skipped[node.ID])
+		}
+	}
+	return plan, nil
+}
+
+// SeedManualGates copies gates and adds a required gate for every module def
+// marks require_approval that gates does not mention, so existing decisions
+// such as approvals or Start overrides win. It returns nil when no module is
+// gated.
+func SeedManualGates(def workflow.WorkflowDefinition, gates map[string]ManualGateState) map[string]ManualGateState {
+	var out map[string]ManualGateState
+	set := func(id string, gate ManualGateState) {
+		if out == nil {
+			out = make(map[string]ManualGateState, len(gates))
+		}
+		out[id] = gate
+	}
+	for id, gate := range gates {
+		set(id, gate)
+	}
+	for _, ref := range def.Modules {
+		if !ref.RequireApproval {
+			continue
+		}
+		if _, ok := out[ref.InstanceID()]; !ok {
+			set(ref.InstanceID(), ManualGateState{Required: true})
+		}
+	}
+	return out
+}
+
+// plannedDependencies returns the node's dependencies, including the any-of
+// group that unblocked it, that were planned in earlier batches.
+func plannedDependencies(node *resolver.Node, planned map[string]bool) []string {
+	var deps []string
+	for _, id := range append(append([]string(nil), node.Dependencies...), node.SatisfiedBy...) {
+		if planned[id] && !containsID(deps, id) {
+			deps = append(deps, id)
+		}
+	}
+	return deps
+}
+
+func unreachableReason(node *resolver.Node, skip SkipReason) string {
+	switch {
+	case node.State == resolver.NodeStateError && node.Err != nil:
+		return fmt.Sprintf("error: %v", node.Err)
+	case len(node.BlockedBy) > 0:
+		return fmt.Sprintf("waiting on %s", strings.Join(node.BlockedBy, ", "))
+	case skip.Detail != "":
+		return skip.Detail
+	default:
+		return string(node.State)
+	}
+}
+
+func containsID(ids []string, target string) bool {
+	for _, id := range ids {
+		if id == target {
+			return true
+		}
+	}
+	return false
+}
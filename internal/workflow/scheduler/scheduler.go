@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/kingrea/The-Lattice/internal/workflow/resolver"
 )
@@ -45,14 +47,38 @@ type RunnableRequest struct {
 	// ManualGates describes whether a module requires manual approval and the
 	// approval status.
 	ManualGates map[string]ManualGateState
+	// Priority optionally weights runnable modules. When set, candidates are
+	// considered highest weight first (ties by instance ID) before BatchSize
+	// and MaxParallel truncate the batch, so scarce capacity goes to the
+	// modules that matter most. Manual gates still apply after sorting.
+	Priority PriorityFunc
+	// ClassLimits caps how many modules sharing a concurrency class may be
+	// active at once, counting Running modules and the batch being built.
+	ClassLimits map[string]int
 }
 
+// PriorityFunc weights a module instance for batch ordering; higher runs
+// first. moduleID is the instance ID the scheduler reports in batches.
+type PriorityFunc func(moduleID string) int
+
 // ManualGateState records whether a manual approval is required before a module
-// may run.
+// may run. Note is the operator's free text, such as why the gate is pending;
+// it doubles as the skip detail while unapproved. ApprovedAt records when the
+// gate was last approved and is zero while it is not.
 type ManualGateState struct {
-	Required bool
-	Approved bool
-	Note     string
+	Required   bool      `json:"required"`
+	Approved   bool      `json:"approved"`
+	Note       string    `json:"note,omitempty"`
+	ApprovedAt time.Time `json:"approved_at,omitempty"`
+}
+
+// Equal reports whether two gate states match, comparing ApprovedAt as an
+// instant so a state read back from disk equals the one that was saved.
+func (g ManualGateState) Equal(other ManualGateState) bool {
+	return g.Required == other.Required &&
+		g.Approved == other.Approved &&
+		g.Note == other.Note &&
+		g.ApprovedAt.Equal(other.ApprovedAt)
 }
 
 // RunnableBatch describes the scheduler's decision.
@@ -75,6 +101,7 @@ const (
 	SkipReasonManualGate  SkipReasonCode = "manual-gate"
 	SkipReasonConcurrency SkipReasonCode = "concurrency"
 	SkipReasonActive      SkipReasonCode = "already-running"
+	SkipReasonSkipped     SkipReasonCode = "skipped"
 )
 
 // Runnable returns a batch of runnable nodes constrained by the request.
@@ -84,10 +111,16 @@ func (s *Scheduler) Runnable(req RunnableRequest) (RunnableBatch, error) {
 		return RunnableBatch{}, err
 	}
 	rq := newRunnableQueue(queue)
+	rq.prioritize(req.Priority)
 	running := req.runningSet()
 	manual := req.manualGateSet()
 	inventory := s.concurrencyInventory(running)
 	result := RunnableBatch{}
+	for _, node := range s.resolver.Nodes() {
+		if node.State == resolver.NodeStateSkipped {
+			result.addSkip(node.ID, SkipReason{Reason: SkipReasonSkipped, Detail: node.SkipReason})
+		}
+	}
 	if req.MaxParallel > 0 && inventory.slots >= req.MaxParallel {
 		s.recordConcurrencySkip(&result, fmt.Sprintf("max parallel %d reached", req.MaxParallel))
 		return result, nil
@@ -129,8 +162,16 @@ func (s *Scheduler) Runnable(req RunnableRequest) (RunnableBatch, error) {
 			result.addSkip(node.ID, SkipReason{Reason: SkipReasonConcurrency, Detail: fmt.Sprintf("max parallel %d reached", req.MaxParallel)})
 			continue
 		}
+		class := nodeConcurrencyClass(node)
+		if limit, ok := req.ClassLimits[class]; ok && class != "" && inventory.classes[class] >= limit {
+			result.addSkip(node.ID, SkipReason{Reason: SkipReasonConcurrency, Detail: fmt.Sprintf("class %s limit %d reached", class, limit)})
+			continue
+		}
 		result.Nodes = append(result.Nodes, node)
 		batchSlots += nodeSlots
+		if class != "" {
+			inventory.classes[class]++
+		}
 		if nodeExclusive {
 			break
 		}
@@ -202,6 +243,25 @@ func newRunnableQueue(nodes []*resolver.Node) *runnableQueue {
 	return &runnableQueue{nodes: copyNodes}
 }
 
+// prioritize reorders the queue by descending weight with ties broken by
+// instance ID. A nil priority keeps the resolver's order.
+func (q *runnableQueue) prioritize(priority PriorityFunc) {
+	if priority == nil || len(q.nodes) < 2 {
+		return
+	}
+	weights := make(map[string]int, len(q.nodes))
+	for _, node := range q.nodes {
+		weights[node.ID] = priority(node.ID)
+	}
+	sort.SliceStable(q.nodes, func(i, j int) bool {
+		a, b := q.nodes[i], q.nodes[j]
+		if weights[a.ID] != weights[b.ID] {
+			return weights[a.ID] > weights[b.ID]
+		}
+		return a.ID < b.ID
+	})
+}
+
 func (q *runnableQueue) Len() int {
 	return len(q.nodes)
 }
@@ -218,10 +278,13 @@ func (q *runnableQueue) Pop() *resolver.Node {
 type runningInventory struct {
 	slots       int
 	exclusiveID string
+	// classes counts active modules per concurrency class; the scheduler adds
+	// batch members as it picks them.
+	classes map[string]int
 }
 
 func (s *Scheduler) concurrencyInventory(running map[string]struct{}) runningInventory {
-	inv := runningInventory{}
+	inv := runningInventory{classes: map[string]int{}}
 	if len(running) == 0 {
 		return inv
 	}
@@ -232,6 +295,9 @@ func (s *Scheduler) concurrencyInventory(running map[string]struct{}) runningInv
 		if inv.exclusiveID == "" && nodeRequiresExclusive(node) {
 			inv.exclusiveID = id
 		}
+		if class := nodeConcurrencyClass(node); class != "" {
+			inv.classes[class]++
+		}
 	}
 	return inv
 }
@@ -243,6 +309,13 @@ func nodeSlotCost(node *resolver.Node) int {
 	return node.Module.Info().SlotCost()
 }
 
+func nodeConcurrencyClass(node *resolver.Node) string {
+	if node == nil {
+		return ""
+	}
+	return node.Module.Info().ConcurrencyClass()
+}
+
 func nodeRequiresExclusive(node *resolver.Node) bool {
 	if node == nil {
 		return false
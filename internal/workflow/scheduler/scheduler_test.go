@@ -1,7 +1,9 @@
 package scheduler
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/kingrea/The-Lattice/internal/artifact"
@@ -165,6 +167,49 @@ func TestSchedulerEnforcesParallelLimit(t *testing.T) {
 	}
 }
 
+func TestSchedulerOrdersBatchByPriority(t *testing.T) {
+	stubs := map[string]*stubModule{
+		"plan":   newStubModule("plan", true, nil),
+		"build":  newStubModule("build", false, nil),
+		"docs":   newStubModule("docs", false, nil),
+		"hiring": newStubModule("hiring", false, nil),
+		"deploy": newStubModule("deploy", false, nil),
+	}
+	def := workflow.WorkflowDefinition{
+		ID: "test",
+		Modules: []workflow.ModuleRef{
+			{ID: "anchor-plan", ModuleID: "plan"},
+			{ID: "module-docs", ModuleID: "docs", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-build", ModuleID: "build", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-hiring", ModuleID: "hiring", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-deploy", ModuleID: "deploy", DependsOn: []string{"anchor-plan"}},
+		},
+	}
+	sched := buildScheduler(t, stubs, def)
+	weights := map[string]int{"module-deploy": 10, "module-hiring": 5}
+	batch, err := sched.Runnable(RunnableRequest{
+		MaxParallel: 2,
+		Priority:    func(id string) int { return weights[id] },
+		ManualGates: map[string]ManualGateState{"module-deploy": {Required: true}},
+	})
+	if err != nil {
+		t.Fatalf("runnable: %v", err)
+	}
+	var ids []string
+	for _, node := range batch.Nodes {
+		ids = append(ids, node.ID)
+	}
+	if got, want := strings.Join(ids, ","), "module-hiring,module-build"; got != want {
+		t.Fatalf("batch = %s, want %s", got, want)
+	}
+	if reason := batch.Skipped["module-deploy"]; reason.Reason != SkipReasonManualGate {
+		t.Fatalf("gated module should stay out of the batch despite its weight, got %+v", reason)
+	}
+	if reason := batch.Skipped["module-docs"]; reason.Reason != SkipReasonConcurrency {
+		t.Fatalf("lowest-weight module should lose the last slot, got %+v", reason)
+	}
+}
+
 func TestSchedulerRespectsModuleSlotCost(t *testing.T) {
 	stubs := map[string]*stubModule{
 		"plan":  newStubModule("plan", true, nil),
@@ -332,3 +377,139 @@ func (m *stubModule) ArtifactFingerprints(*module.ModuleContext) (map[string]str
 	}
 	return out, nil
 }
+
+func TestSimulateReportsRunnableForCompletionSet(t *testing.T) {
+	reg := module.NewRegistry()
+	for _, id := range []string{"plan", "build", "docs", "release"} {
+		stub := newStubModule(id, false, nil)
+		reg.MustRegister(id, func(module.Config) (module.Module, error) {
+			return stub, nil
+		})
+	}
+	def := workflow.WorkflowDefinition{
+		ID: "test",
+		Modules: []workflow.ModuleRef{
+			{ID: "anchor-plan", ModuleID: "plan"},
+			{ID: "module-build", ModuleID: "build", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-docs", ModuleID: "docs", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-release", ModuleID: "release", DependsOn: []string{"module-build", "module-docs"}},
+		},
+	}
+	sim, err := Simulate(def, reg, []string{"anchor-plan", "module-docs"})
+	if err != nil {
+		t.Fatalf("simulate: %v", err)
+	}
+	if len(sim.Runnable) != 1 || sim.Runnable[0] != "module-build" {
+		t.Fatalf("expected only module-build to be runnable, got %v", sim.Runnable)
+	}
+	if len(sim.Complete) != 2 {
+		t.Fatalf("expected two complete modules, got %v", sim.Complete)
+	}
+	blockers := sim.Blocked["module-release"]
+	if len(blockers) != 1 || blockers[0] != "module-build" {
+		t.Fatalf("expected module-release blocked on module-build, got %v", sim.Blocked)
+	}
+	if reason := sim.Skipped["module-release"].Reason; reason != SkipReasonNotReady {
+		t.Fatalf("expected module-release skipped as not ready, got %q", reason)
+	}
+	if _, err := Simulate(def, reg, []string{"missing"}); err == nil {
+		t.Fatalf("expected an unknown completed module to be rejected")
+	}
+}
+
+func TestDescribeBatchesGroupsModulesTopologically(t *testing.T) {
+	stubs := map[string]*stubModule{
+		"plan":    newStubModule("plan", true, nil),
+		"build":   newStubModule("build", false, nil),
+		"docs":    newStubModule("docs", false, nil),
+		"lint":    newStubModule("lint", false, nil),
+		"release": newStubModule("release", false, nil),
+	}
+	def := workflow.WorkflowDefinition{
+		ID: "test",
+		Modules: []workflow.ModuleRef{
+			{ID: "anchor-plan", ModuleID: "plan"},
+			{ID: "module-build", ModuleID: "build", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-docs", ModuleID: "docs", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-lint", ModuleID: "lint", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-release", ModuleID: "release", DependsOn: []string{"module-build", "module-docs"}},
+		},
+	}
+	res, ctx := buildResolverForTest(t, stubs, def)
+	if err := res.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	plan, err := DescribeBatches(res, workflow.WorkflowRuntimeConfig{MaxParallel: 2}, nil)
+	if err != nil {
+		t.Fatalf("describe batches: %v", err)
+	}
+	var got []string
+	for _, batch := range plan.Batches {
+		var ids []string
+		for _, mod := range batch.Modules {
+			ids = append(ids, mod.ID)
+		}
+		got = append(got, fmt.Sprintf("%d:%s", batch.Number, strings.Join(ids, ",")))
+	}
+	if want := "1:module-build,module-docs 2:module-lint,module-release"; strings.Join(got, " ") != want {
+		t.Fatalf("expected batches %q, got %q", want, strings.Join(got, " "))
+	}
+	lint, release := plan.Batches[1].Modules[0], plan.Batches[1].Modules[1]
+	if lint.Deferred != "max parallel 2 reached" || len(lint.WaitsOn) != 0 {
+		t.Fatalf("expected lint deferred by the parallel limit, got %+v", lint)
+	}
+	if strings.Join(release.WaitsOn, ",") != "module-build,module-docs" || release.Deferred != "" {
+		t.Fatalf("expected release to wait on build and docs, got %+v", release)
+	}
+	if len(plan.Complete) != 1 || plan.Complete[0] != "anchor-plan" || len(plan.Unreachable) != 0 {
+		t.Fatalf("unexpected plan summary: %+v", plan)
+	}
+	if build, _ := res.Node("module-build"); build.State != resolver.NodeStateReady {
+		t.Fatalf("describing batches should not touch the snapshot, build is %s", build.State)
+	}
+}
+
+func TestDescribeBatchesHoldsGatedModules(t *testing.T) {
+	stubs := map[string]*stubModule{
+		"plan":    newStubModule("plan", true, nil),
+		"build":   newStubModule("build", false, nil),
+		"docs":    newStubModule("docs", false, nil),
+		"release": newStubModule("release", false, nil),
+	}
+	def := workflow.WorkflowDefinition{
+		ID: "test",
+		Modules: []workflow.ModuleRef{
+			{ID: "anchor-plan", ModuleID: "plan"},
+			{ID: "module-build", ModuleID: "build", DependsOn: []string{"anchor-plan"}, RequireApproval: true},
+			{ID: "module-docs", ModuleID: "docs", DependsOn: []string{"anchor-plan"}},
+			{ID: "module-release", ModuleID: "release", DependsOn: []string{"module-docs"}},
+		},
+	}
+	res, ctx := buildResolverForTest(t, stubs, def)
+	if err := res.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	gates := map[string]ManualGateState{"module-release": {Required: true, Note: "release window"}}
+	plan, err := DescribeBatches(res, workflow.WorkflowRuntimeConfig{}, gates)
+	if err != nil {
+		t.Fatalf("describe batches: %v", err)
+	}
+	if len(plan.Batches) != 1 || len(plan.Batches[0].Modules) != 1 || plan.Batches[0].Modules[0].ID != "module-docs" {
+		t.Fatalf("expected only docs to be planned, got %+v", plan.Batches)
+	}
+	if plan.AwaitingApproval["module-build"] != "awaiting manual approval" || plan.AwaitingApproval["module-release"] != "release window" {
+		t.Fatalf("expected gated modules to await approval, got %+v", plan.AwaitingApproval)
+	}
+	if len(plan.Unreachable) != 0 {
+		t.Fatalf("gated modules should not be reported unreachable, got %+v", plan.Unreachable)
+	}
+
+	approved := map[string]ManualGateState{"module-build": {Required: true, Approved: true}}
+	plan, err = DescribeBatches(res, workflow.WorkflowRuntimeConfig{}, approved)
+	if err != nil {
+		t.Fatalf("describe approved batches: %v", err)
+	}
+	if len(plan.AwaitingApproval) != 0 || len(plan.Batches) != 2 {
+		t.Fatalf("an approved gate should let the module be planned, got %+v", plan)
+	}
+}
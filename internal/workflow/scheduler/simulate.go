@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kingrea/The-Lattice/internal/artifact"
+	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+	"github.com/kingrea/The-Lattice/internal/workflow/resolver"
+)
+
+// Simulation is the scheduler decision for a definition under a hypothetical
+// completion set.
+type Simulation struct {
+	Complete []string
+	Runnable []string
+	Skipped  map[string]SkipReason
+	// Blocked maps each blocked module to the dependencies it still waits on.
+	Blocked map[string][]string
+}
+
+// Simulate builds a resolver snapshot for def in which exactly the modules in
+// completed count as finished, then asks the scheduler for the next batch.
+// Modules are resolved through the registry so unknown IDs and bad configs
+// surface, but nothing runs and no artifacts are read.
+func Simulate(def workflow.WorkflowDefinition, registry *module.Registry, completed []string) (Simulation, error) {
+	res, err := resolver.New(def, registry)
+	if err != nil {
+		return Simulation{}, err
+	}
+	done := make(map[string]bool, len(completed))
+	for _, id := range completed {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := res.Node(id); !ok {
+			return Simulation{}, fmt.Errorf("workflow %s: completed module %s not declared", def.ID, id)
+		}
+		done[id] = true
+	}
+	for _, node := range res.Nodes() {
+		node.Module = simulatedModule{info: node.Module.Info(), complete: done[node.ID]}
+	}
+	if err := res.Refresh(&module.ModuleContext{}); err != nil {
+		return Simulation{}, err
+	}
+	sched, err := New(res)
+	if err != nil {
+		return Simulation{}, err
+	}
+	batch, err := sched.Runnable(RunnableRequest{MaxParallel: def.Runtime.MaxParallel})
+	if err != nil {
+		return Simulation{}, err
+	}
+	sim := Simulation{Skipped: batch.Skipped, Blocked: map[string][]string{}}
+	for _, node := range batch.Nodes {
+		sim.Runnable = append(sim.Runnable, node.ID)
+	}
+	for _, node := range res.Nodes() {
+		switch node.State {
+		case resolver.NodeStateComplete:
+			sim.Complete = append(sim.Complete, node.ID)
+		case resolver.NodeStateBlocked:
+			blockers := append([]string(nil), node.BlockedBy...)
+			sort.Strings(blockers)
+			sim.Blocked[node.ID] = blockers
+		}
+	}
+	return sim, nil
+}
+
+// simulatedModule stands in for a real module so completion comes from the
+// simulated set rather than the project on disk.
+type simulatedModule struct {
+	info     module.Info
+	complete bool
+	err      error
+}
+
+func (m simulatedModule) Info() module.Info { return m.info }
+
+func (m simulatedModule) Inputs() []artifact.ArtifactRef { return nil }
+
+func (m simulatedModule) Outputs() []artifact.ArtifactRef { return nil }
+
+func (m simulatedModule) IsComplete(*module.ModuleContext) (bool, error) {
+	return m.complete, m.err
+}
+
+func (m simulatedModule) Run(*module.ModuleContext) (module.Result, error) {
+	return module.Result{}, fmt.Errorf("workflow: simulated module %s cannot run", m.info.ID)
+}
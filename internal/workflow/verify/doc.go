@@ -0,0 +1,7 @@
+// Package verify checks a project's `.lattice` directory after manual edits
+// or interrupted runs. It reports directories that `config.InitLatticeDir`
+// would create but are missing, paths of the wrong type, and work markers
+// that contradict each other, such as a stale `.in-progress` left next to
+// `.complete`. Issues with an unambiguous repair can be fixed in place; the
+// rest are reported with the step that resolves them.
+package verify
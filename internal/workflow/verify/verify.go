@@ -0,0 +1,121 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+// Issue is one inconsistency found under .lattice.
+type Issue struct {
+	// Path is the file or directory at fault, relative to the project.
+	Path    string
+	Problem string
+	// Fixable is set when Report.Fix can resolve the issue without guessing.
+	Fixable bool
+
+	fix func() error
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Problem)
+}
+
+// Report lists the issues found in a project's .lattice directory.
+type Report struct {
+	ProjectDir string
+	Issues     []Issue
+}
+
+// OK reports whether no issues were found.
+func (r Report) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Check inspects the .lattice directory under projectDir.
+func Check(projectDir string) (Report, error) {
+	report := Report{ProjectDir: projectDir}
+	latticeDir := filepath.Join(projectDir, config.LatticeDir)
+	info, err := os.Stat(latticeDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return report, fmt.Errorf("verify: %w", err)
+	}
+	if err == nil && !info.IsDir() {
+		report.add(latticeDir, "is not a directory", nil)
+		return report, nil
+	}
+	initDir := func() error { return config.InitLatticeDir(projectDir) }
+	for _, dir := range config.LatticeSubdirs() {
+		path := filepath.Join(latticeDir, dir)
+		info, err := os.Stat(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			report.add(path, "directory is missing", initDir)
+		case err != nil:
+			return report, fmt.Errorf("verify: %w", err)
+		case !info.IsDir():
+			report.add(path, "should be a directory", nil)
+		}
+	}
+	configPath := filepath.Join(latticeDir, "config.yaml")
+	if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+		report.add(configPath, "project config is missing", initDir)
+	}
+	report.checkWorkMarkers(filepath.Join(latticeDir, workflow.WorkflowDir, workflow.WorkDir))
+	return report, nil
+}
+
+// checkWorkMarkers flags work-process markers that the module never leaves
+// together.
+func (r *Report) checkWorkMarkers(workDir string) {
+	has := func(marker string) bool {
+		_, err := os.Stat(filepath.Join(workDir, marker))
+		return err == nil
+	}
+	complete := has(workflow.MarkerWorkComplete)
+	if complete && has(workflow.MarkerWorkInProgress) {
+		stale := filepath.Join(workDir, workflow.MarkerWorkInProgress)
+		r.add(stale, "stale marker: work is already marked .complete", func() error {
+			if err := os.Remove(stale); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			return nil
+		})
+	}
+	if complete && has(workflow.MarkerRefinementNeeded) {
+		r.add(filepath.Join(workDir, workflow.MarkerRefinementNeeded), "conflicts with .complete; remove whichever is wrong or reset the work-process phase", nil)
+	}
+	if !complete && has(workflow.MarkerWorkExhausted) {
+		r.add(filepath.Join(workDir, workflow.MarkerWorkExhausted), "work is exhausted but not marked .complete; re-run work-process", nil)
+	}
+}
+
+func (r *Report) add(path, problem string, fix func() error) {
+	if rel, err := filepath.Rel(r.ProjectDir, path); err == nil {
+		path = rel
+	}
+	r.Issues = append(r.Issues, Issue{Path: path, Problem: problem, Fixable: fix != nil, fix: fix})
+}
+
+// Fix repairs every fixable issue and returns the issues left for a human.
+// Repairs are idempotent, so issues sharing one (such as several missing
+// directories) are safe to fix together.
+func (r Report) Fix() ([]Issue, error) {
+	var remaining []Issue
+	var errs []error
+	for _, issue := range r.Issues {
+		if !issue.Fixable {
+			remaining = append(remaining, issue)
+			continue
+		}
+		if err := issue.fix(); err != nil {
+			errs = append(errs, fmt.Errorf("fix %s: %w", issue.Path, err))
+			remaining = append(remaining, issue)
+		}
+	}
+	return remaining, errors.Join(errs...)
+}
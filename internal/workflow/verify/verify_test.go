@@ -0,0 +1,76 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kingrea/The-Lattice/internal/config"
+	"github.com/kingrea/The-Lattice/internal/workflow"
+)
+
+func TestCheckReportsAndFixesMissingDirectory(t *testing.T) {
+	projectDir := t.TempDir()
+	if err := config.InitLatticeDir(projectDir); err != nil {
+		t.Fatalf("init lattice dir: %v", err)
+	}
+	report, err := Check(projectDir)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("fresh .lattice should verify cleanly, got %v", report.Issues)
+	}
+
+	team := filepath.Join(projectDir, config.LatticeDir, "workflow", "team")
+	if err := os.RemoveAll(team); err != nil {
+		t.Fatalf("remove team dir: %v", err)
+	}
+	workDir := filepath.Join(projectDir, config.LatticeDir, workflow.WorkflowDir, workflow.WorkDir)
+	for _, marker := range []string{workflow.MarkerWorkComplete, workflow.MarkerWorkInProgress, workflow.MarkerRefinementNeeded} {
+		if err := os.WriteFile(filepath.Join(workDir, marker), nil, 0o644); err != nil {
+			t.Fatalf("write %s: %v", marker, err)
+		}
+	}
+
+	report, err = Check(projectDir)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	var lines []string
+	for _, issue := range report.Issues {
+		lines = append(lines, issue.String())
+	}
+	got := strings.Join(lines, "\n")
+	for _, want := range []string{
+		filepath.Join(".lattice", "workflow", "team") + ": directory is missing",
+		filepath.Join(".lattice", "workflow", "work", ".in-progress") + ": stale marker",
+		filepath.Join(".lattice", "workflow", "work", ".refinement-needed") + ": conflicts with .complete",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected issue %q, got:\n%s", want, got)
+		}
+	}
+
+	remaining, err := report.Fix()
+	if err != nil {
+		t.Fatalf("fix: %v", err)
+	}
+	if len(remaining) != 1 || !strings.Contains(remaining[0].Path, workflow.MarkerRefinementNeeded) {
+		t.Fatalf("only the ambiguous marker conflict should remain, got %v", remaining)
+	}
+	if info, err := os.Stat(team); err != nil || !info.IsDir() {
+		t.Fatalf("missing directory was not recreated: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, workflow.MarkerWorkInProgress)); !os.IsNotExist(err) {
+		t.Fatalf("stale .in-progress was not removed: %v", err)
+	}
+	report, err = Check(projectDir)
+	if err != nil {
+		t.Fatalf("check after fix: %v", err)
+	}
+	if len(report.Issues) != 1 {
+		t.Fatalf("expected only the unfixable issue after fixing, got %v", report.Issues)
+	}
+}
@@ -48,10 +48,12 @@ const (
 	FileReviewSimplifier     = "REVIEW_SIMPLIFIER.md"
 	FileReviewAdvocate       = "REVIEW_USER_ADVOCATE.md"
 	FileReviewSkeptic        = "REVIEW_SKEPTIC.md"
+	FileReviewMeta           = "REVIEW_META.md"
 	FileStaffFeedbackApplied = ".staff-feedback-applied" // Marker that staff feedback has been applied to the plan
 	FilePlanChatReady        = ".plan-chat-ready"        // Marker that the planning chat concluded and the user is ready
 	FilePlanChatActive       = ".plan-chat-active"       // Marker that a planning chat session is active
 	FileReviewsApplied       = ".reviews-applied"        // Marker that orchestrator applied feedback
+	FileCriticalApproved     = ".critical-approved"      // Marker that a human approved consolidating flagged reviews
 )
 
 // Beads tracking (in .lattice/action/)
@@ -65,6 +67,7 @@ const (
 	MarkerWorkInProgress       = ".in-progress"
 	MarkerWorkComplete         = ".complete"
 	MarkerRefinementNeeded     = ".refinement-needed"
+	MarkerWorkExhausted        = ".work-exhausted"
 	MarkerAgentsReleased       = ".agents-released"
 	MarkerCleanupDone          = ".cleanup-done"
 	MarkerOrchestratorReleased = ".orchestrator-released"
@@ -83,6 +86,11 @@ func New(latticeDir string) *Workflow {
 	}
 }
 
+// ProjectDir returns the project root that holds the .lattice directory.
+func (w *Workflow) ProjectDir() string {
+	return filepath.Dir(w.latticeDir)
+}
+
 // Dir returns the base workflow directory path
 func (w *Workflow) Dir() string {
 	return filepath.Join(w.latticeDir, WorkflowDir)
@@ -153,6 +161,11 @@ func (w *Workflow) ReviewSkepticPath() string {
 	return filepath.Join(w.ActionDir(), FileReviewSkeptic)
 }
 
+// ReviewMetaPath returns the path to REVIEW_META.md
+func (w *Workflow) ReviewMetaPath() string {
+	return filepath.Join(w.ActionDir(), FileReviewMeta)
+}
+
 // StaffFeedbackAppliedPath returns the marker path after staff feedback is incorporated
 func (w *Workflow) StaffFeedbackAppliedPath() string {
 	return filepath.Join(w.ActionDir(), FileStaffFeedbackApplied)
@@ -163,6 +176,12 @@ func (w *Workflow) ReviewsAppliedPath() string {
 	return filepath.Join(w.ActionDir(), FileReviewsApplied)
 }
 
+// CriticalApprovedPath returns the marker path that approves consolidation
+// after reviewers flagged critical issues
+func (w *Workflow) CriticalApprovedPath() string {
+	return filepath.Join(w.ActionDir(), FileCriticalApproved)
+}
+
 // PlanChatReadyPath returns the marker path for concluding a plan chat session
 func (w *Workflow) PlanChatReadyPath() string {
 	return filepath.Join(w.ActionDir(), FilePlanChatReady)
@@ -18,6 +18,7 @@ import (
 	"github.com/kingrea/The-Lattice/internal/artifact"
 	"github.com/kingrea/The-Lattice/internal/eventbridge"
 	"github.com/kingrea/The-Lattice/internal/module"
+	"github.com/kingrea/The-Lattice/internal/opencode"
 	"github.com/kingrea/The-Lattice/internal/skills"
 )
 
@@ -473,9 +474,10 @@ func (tm tmuxTerminal) SendOpenCode(window, prompt string, env map[string]string
 	if strings.TrimSpace(window) == "" {
 		return errors.New("tmux window is required")
 	}
-	escapedPrompt := strings.ReplaceAll(prompt, "\"", `\"`)
-	escapedPrompt = strings.ReplaceAll(escapedPrompt, "\n", " ")
-	command := fmt.Sprintf("opencode --prompt \"%s\"", strings.TrimSpace(escapedPrompt))
+	command, err := opencode.Command(strings.TrimSpace(prompt), "")
+	if err != nil {
+		return err
+	}
 	if prefix := formatEnvPrefix(env); prefix != "" {
 		command = fmt.Sprintf("%s %s", prefix, command)
 	}